@@ -3,6 +3,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +12,7 @@ import (
 	"github.com/GophKeeper/internal/client"
 	"github.com/GophKeeper/internal/config"
 	"github.com/GophKeeper/internal/logger"
+	"github.com/GophKeeper/internal/passgen"
 	"github.com/GophKeeper/internal/templates"
 	"github.com/GophKeeper/internal/version"
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,6 +23,13 @@ func main() {
 	if shouldShowVersionOrHelp() {
 		return
 	}
+	// Генерация паролей выполняется локально: без конфигурации, логов и подключения
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(os.Args[2:]); err != nil {
+			log.Fatalf("Generate failed: %v", err)
+		}
+		return
+	}
 	if err := runApplication(); err != nil {
 		log.Fatalf("Application failed: %v", err)
 	}
@@ -69,14 +79,126 @@ func runApplication() error {
 	}
 	defer gkClient.Close()
 
+	// Команды выполняются без запуска TUI
+	if args := flag.Args(); len(args) > 0 {
+		return runCommand(ctx, gkClient, args)
+	}
+
 	// Запускаем TUI приложение
-	if err := runTUI(ctx, gkClient, zapLogger); err != nil {
+	if err := runTUI(ctx, gkClient, cfg, zapLogger); err != nil {
 		return fmt.Errorf("TUI error: %w", err)
 	}
 
 	return nil
 }
 
+// runCommand выполняет команду командной строки.
+func runCommand(ctx context.Context, gkClient *client.Client, args []string) error {
+	switch args[0] {
+	case "otp-import":
+		return runOTPImport(ctx, gkClient, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, see '%s help'", args[0], os.Args[0])
+	}
+}
+
+// runOTPImport распознает QR код на снимке экрана и сохраняет секрет
+// одноразовых паролей в хранилище. Для аутентификации используется
+// персональный токен доступа из переменной окружения GOPHKEEPER_TOKEN.
+func runOTPImport(ctx context.Context, gkClient *client.Client, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: %s otp-import <image> [name]", os.Args[0])
+	}
+
+	data, err := client.DecodeOTPQRCodeFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	token := os.Getenv("GOPHKEEPER_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GOPHKEEPER_TOKEN must contain a personal access token")
+	}
+	if err := gkClient.UseAccessToken(token); err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) == 2 {
+		name = args[1]
+	}
+	entry, err := gkClient.ImportOTPEntry(ctx, data, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported OTP entry %q (%s)\n", entry.Name, entry.Id)
+	return nil
+}
+
+// runGenerate печатает сгенерированные пароли или парольные фразы.
+// Генерация выполняется локально и не требует конфигурации и подключения к серверу,
+// поэтому команда обрабатывается до загрузки конфигурации и глобальные флаги не принимает.
+func runGenerate(args []string) error {
+	opts := client.DefaultGeneratorOptions(client.GeneratorRandom)
+
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.IntVar(&opts.Password.Length, "length", opts.Password.Length, "Password length")
+	noLower := fs.Bool("no-lower", false, "Exclude lowercase letters")
+	noUpper := fs.Bool("no-upper", false, "Exclude uppercase letters")
+	noDigits := fs.Bool("no-digits", false, "Exclude digits")
+	noSymbols := fs.Bool("no-symbols", false, "Exclude symbols")
+	fs.BoolVar(&opts.Password.ExcludeAmbiguous, "exclude-ambiguous", false, "Exclude look-alike characters (Il1|O0o)")
+	pronounceable := fs.Bool("pronounceable", false, "Generate a pronounceable password")
+	passphrase := fs.Bool("passphrase", false, "Generate a diceware passphrase")
+	fs.IntVar(&opts.Passphrase.Words, "words", opts.Passphrase.Words, "Number of passphrase words")
+	fs.StringVar(&opts.Passphrase.Separator, "separator", opts.Passphrase.Separator, "Passphrase word separator")
+	fs.BoolVar(&opts.Passphrase.Capitalize, "capitalize", false, "Capitalize passphrase words")
+	fs.BoolVar(&opts.Passphrase.Number, "number", false, "Add a digit to a random passphrase word")
+	wordlist := fs.String("wordlist", "", "Wordlist file (one word per line, EFF dice format accepted)")
+	count := fs.Int("count", 1, "Number of passwords to generate")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("usage: %s generate [flags]", os.Args[0])
+	}
+
+	opts.Password.Lower = !*noLower
+	opts.Password.Upper = !*noUpper
+	opts.Password.Digits = !*noDigits
+	opts.Password.Symbols = !*noSymbols
+	switch {
+	case *passphrase:
+		opts.Mode = client.GeneratorPassphrase
+	case *pronounceable:
+		opts.Mode = client.GeneratorPronounceable
+	}
+
+	if *wordlist != "" {
+		file, err := os.Open(*wordlist)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if opts.Passphrase.Wordlist, err = passgen.ParseWordlist(file); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < *count; i++ {
+		password, err := client.GeneratePassword(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Println(password)
+	}
+	return nil
+}
+
 // loadConfiguration загружает конфигурацию клиента
 func loadConfiguration() (*config.ClientConfig, error) {
 	return config.LoadClientConfig()
@@ -93,14 +215,20 @@ func createClient(cfg *config.ClientConfig, logger *zap.Logger) (*client.Client,
 }
 
 // runTUI запускает терминальный интерфейс пользователя.
-func runTUI(ctx context.Context, gkClient *client.Client, logger *zap.Logger) error {
+func runTUI(ctx context.Context, gkClient *client.Client, cfg *config.ClientConfig, logger *zap.Logger) error {
+	checker, err := client.NewBreachChecker(cfg)
+	if err != nil {
+		return err
+	}
+
 	model := client.NewTUIModel(gkClient, logger)
+	model.SetBreachChecker(checker)
 
 	// Создаем программу Bubble Tea
 	program := tea.NewProgram(model, tea.WithAltScreen())
 
 	// Запускаем программу
-	_, err := program.Run()
+	_, err = program.Run()
 	return err
 }
 
@@ -3,19 +3,23 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/go-chi/chi/v5"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/GophKeeper/internal/auth"
+	"github.com/GophKeeper/internal/breach"
 	"github.com/GophKeeper/internal/config"
 	"github.com/GophKeeper/internal/crypto"
 	grpcServer "github.com/GophKeeper/internal/grpc"
@@ -24,6 +28,7 @@ import (
 	"github.com/GophKeeper/internal/migrations"
 	"github.com/GophKeeper/internal/otp"
 	"github.com/GophKeeper/internal/storage"
+	"github.com/GophKeeper/internal/tlsutil"
 	"github.com/GophKeeper/internal/version"
 	"go.uber.org/zap"
 )
@@ -35,6 +40,8 @@ type ServerComponents struct {
 	GRPCServer   *grpc.Server
 	GRPCListener net.Listener
 	Logger       *zap.Logger
+	// TLSEnabled включает HTTPS для HTTP сервера
+	TLSEnabled bool
 }
 
 func main() {
@@ -91,7 +98,10 @@ func runServer(ctx context.Context, cfg *config.ServerConfig, logger *zap.Logger
 	}
 
 	// Инициализация сервисов
-	authService := auth.NewService(cfg.JWTSecret)
+	authService, err := newAuthService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create auth service: %w", err)
+	}
 	cryptoService, err := crypto.NewService(privateKeyData, publicKeyData)
 	if err != nil {
 		return fmt.Errorf("failed to create crypto service: %w", err)
@@ -100,9 +110,13 @@ func runServer(ctx context.Context, cfg *config.ServerConfig, logger *zap.Logger
 
 	// Создание gRPC сервера
 	gkServer := grpcServer.NewServer(dbStorage, authService, cryptoService, otpService, logger)
+	gkServer.SetTypedPayloads(cfg.TypedPayloads)
+	if cfg.TypedPayloads {
+		logger.Warn("Typed payload mode enabled: entry payloads are stored without end-to-end encryption")
+	}
 
 	// Создание компонентов сервера
-	components, err := setupServerComponents(cfg, gkServer, authService, logger)
+	components, err := setupServerComponents(ctx, cfg, gkServer, authService, dbStorage, logger)
 	if err != nil {
 		return fmt.Errorf("failed to setup server components: %w", err)
 	}
@@ -110,6 +124,12 @@ func runServer(ctx context.Context, cfg *config.ServerConfig, logger *zap.Logger
 	// Запуск серверов
 	startServers(components)
 
+	// Фоновое удаление истекших ссылок на секреты
+	go gkServer.PurgeExpiredSecretLinks(ctx, grpcServer.SecretLinkPurgeInterval)
+
+	// Фоновое предоставление экстренного доступа по истечении периода ожидания
+	go gkServer.GrantElapsedEmergencyAccess(ctx, grpcServer.EmergencyAccessCheckInterval)
+
 	logger.Info("All servers started successfully")
 
 	// Ожидание сигнала завершения
@@ -129,8 +149,64 @@ func runServer(ctx context.Context, cfg *config.ServerConfig, logger *zap.Logger
 	return nil
 }
 
+// newAuthService создает сервис аутентификации. При заданном файле ключа токены
+// подписываются асимметричным ключом, а JWT секрет и предыдущие ключи остаются
+// только для проверки ранее выданных токенов.
+func newAuthService(cfg *config.ServerConfig) (*auth.Service, error) {
+	if cfg.JWTKeyFile == "" {
+		return auth.NewService(cfg.JWTSecret), nil
+	}
+
+	current, err := loadSigningKey(cfg.JWTKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous []*auth.SigningKey
+	for _, path := range strings.Split(cfg.JWTPreviousKeyFiles, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		key, err := loadSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		previous = append(previous, key)
+	}
+	if cfg.JWTSecret != "" {
+		legacy, err := auth.NewHMACKey(auth.LegacyKeyID, []byte(cfg.JWTSecret))
+		if err != nil {
+			return nil, err
+		}
+		previous = append(previous, legacy)
+	}
+
+	keyring, err := auth.NewKeyring(current, previous...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWT keyring: %w", err)
+	}
+
+	return auth.NewServiceWithKeyring(keyring), nil
+}
+
+// loadSigningKey загружает ключ подписи JWT из PEM файла.
+func loadSigningKey(path string) (*auth.SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT signing key: %w", err)
+	}
+
+	key, err := auth.ParseSigningKeyPEM("", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT signing key %s: %w", path, err)
+	}
+
+	return key, nil
+}
+
 // setupServerComponents создает все компоненты сервера.
-func setupServerComponents(cfg *config.ServerConfig, gkServer *grpcServer.Server, authService *auth.Service, logger *zap.Logger) (*ServerComponents, error) {
+func setupServerComponents(ctx context.Context, cfg *config.ServerConfig, gkServer *grpcServer.Server, authService *auth.Service, dbStorage storage.Storage, logger *zap.Logger) (*ServerComponents, error) {
 	// Создаем HTTP сервер
 	httpServer := &http.Server{
 		Addr:         cfg.ServerAddress,
@@ -140,9 +216,20 @@ func setupServerComponents(cfg *config.ServerConfig, gkServer *grpcServer.Server
 	}
 
 	// Настраиваем HTTP роуты
-	router := setupHTTPRoutes(gkServer, authService, logger)
+	router := setupHTTPRoutes(gkServer, authService, dbStorage, cfg.BreachCorpusDir, logger)
 	httpServer.Handler = router
 
+	// Настраиваем TLS для обоих серверов
+	var grpcOpts []grpc.ServerOption
+	if cfg.EnableTLS {
+		tlsConfig, err := setupTLS(ctx, cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		httpServer.TLSConfig = tlsConfig
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
 	// Создаем gRPC listener
 	grpcListener, err := net.Listen("tcp", cfg.GRPCAddress)
 	if err != nil {
@@ -150,7 +237,7 @@ func setupServerComponents(cfg *config.ServerConfig, gkServer *grpcServer.Server
 	}
 
 	// Создаем gRPC сервер
-	grpcSrv := grpcServer.NewGRPCServer(gkServer)
+	grpcSrv := grpcServer.NewGRPCServer(gkServer, grpcOpts...)
 	reflection.Register(grpcSrv)
 
 	return &ServerComponents{
@@ -159,15 +246,48 @@ func setupServerComponents(cfg *config.ServerConfig, gkServer *grpcServer.Server
 		GRPCServer:   grpcSrv,
 		GRPCListener: grpcListener,
 		Logger:       logger,
+		TLSEnabled:   cfg.EnableTLS,
 	}, nil
 }
 
+// setupTLS загружает сертификаты и запускает их перезагрузку при изменении файлов.
+func setupTLS(ctx context.Context, cfg *config.ServerConfig, logger *zap.Logger) (*tls.Config, error) {
+	reloader, err := tlsutil.NewReloader(tlsutil.Options{
+		CertFile:          cfg.CertFile,
+		KeyFile:           cfg.KeyFile,
+		ClientCAFile:      cfg.ClientCAFile,
+		RequireClientCert: cfg.RequireClientCert,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificates: %w", err)
+	}
+
+	go reloader.Watch(ctx, tlsutil.DefaultReloadInterval)
+
+	logger.Info("TLS enabled",
+		zap.String("cert_file", cfg.CertFile),
+		zap.Bool("client_certificates", cfg.ClientCAFile != ""),
+		zap.Bool("client_certificates_required", cfg.RequireClientCert))
+
+	return reloader.TLSConfig(), nil
+}
+
 // startServers запускает HTTP и gRPC серверы в горутинах.
 func startServers(components *ServerComponents) {
 	// Запускаем HTTP сервер в горутине
 	go func() {
-		components.Logger.Info("Starting HTTP server", zap.String("address", components.HTTPServer.Addr))
-		if err := components.HTTPServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		components.Logger.Info("Starting HTTP server",
+			zap.String("address", components.HTTPServer.Addr),
+			zap.Bool("tls", components.TLSEnabled))
+
+		var err error
+		if components.TLSEnabled {
+			// Сертификаты берутся из HTTPServer.TLSConfig
+			err = components.HTTPServer.ListenAndServeTLS("", "")
+		} else {
+			err = components.HTTPServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			components.Logger.Error("HTTP server failed", zap.Error(err))
 		}
 	}()
@@ -182,7 +302,8 @@ func startServers(components *ServerComponents) {
 }
 
 // setupHTTPRoutes настраивает HTTP роуты для REST API.
-func setupHTTPRoutes(gkServer *grpcServer.Server, authService *auth.Service, logger *zap.Logger) http.Handler {
+// Если задан breachCorpusDir, сервер работает зеркалом базы утечек.
+func setupHTTPRoutes(gkServer *grpcServer.Server, authService *auth.Service, credentials storage.CredentialRepository, breachCorpusDir string, logger *zap.Logger) http.Handler {
 	// Создаем роутер
 	router := chi.NewRouter()
 
@@ -193,19 +314,78 @@ func setupHTTPRoutes(gkServer *grpcServer.Server, authService *auth.Service, log
 	// Публичные роуты
 	router.Post("/auth/register", gkServer.HandleRegister)
 	router.Post("/auth/login", gkServer.HandleLogin)
+	router.Post("/auth/srp/begin", gkServer.HandleBeginSRPLogin)
+	router.Post("/auth/srp/finish", gkServer.HandleFinishSRPLogin)
 	router.Post("/auth/refresh", gkServer.HandleRefreshToken)
+	router.Get("/.well-known/jwks.json", gkServer.HandleJWKS)
 	router.Post("/otp/generate", gkServer.HandleGenerateOTP)
 	router.Post("/otp/secret", gkServer.HandleCreateOTPSecret)
 
+	// Публичная одноразовая ссылка на секрет: страница не расходует просмотры,
+	// секрет выдается только POST запросом
+	router.Get("/s/{id}", gkServer.HandleSecretLinkPage)
+	router.Post("/s/{id}", gkServer.HandleOpenSecretLink)
+
+	// Зеркало базы утечек в формате API Pwned Passwords. Запрос содержит только
+	// префикс хеша пароля, поэтому аутентификация не требуется, как и у HIBP
+	if breachCorpusDir != "" {
+		router.Get("/breach/range/{prefix}", breach.RangeHandler(breach.NewDirSource(breachCorpusDir)).ServeHTTP)
+		logger.Info("Breached passwords mirror enabled", zap.String("dir", breachCorpusDir))
+	}
+
 	// Защищенные роуты
 	router.Group(func(r chi.Router) {
-		r.Use(middleware.AuthMiddleware(authService, logger))
+		r.Use(middleware.AuthMiddleware(authService, credentials, logger))
+		r.Post("/auth/password", gkServer.HandleChangePassword)
+		r.Put("/account/vault-key", gkServer.HandleSetVaultKey)
+		r.Post("/account/delete", gkServer.HandleDeleteAccount)
+		r.Post("/account/export", gkServer.HandleExportAccount)
+		r.Post("/tokens", gkServer.HandleCreateAccessToken)
+		r.Get("/tokens", gkServer.HandleListAccessTokens)
+		r.Delete("/tokens/{id}", gkServer.HandleRevokeAccessToken)
 		r.Get("/data", gkServer.HandleListData)
+		r.Get("/data/rotation-due", gkServer.HandleListDueForRotation)
 		r.Post("/data", gkServer.HandleCreateData)
 		r.Get("/data/{id}", gkServer.HandleGetData)
 		r.Put("/data/{id}", gkServer.HandleUpdateData)
 		r.Delete("/data/{id}", gkServer.HandleDeleteData)
 		r.Post("/sync", gkServer.HandleSyncData)
+		r.Put("/keys", gkServer.HandleSetPublicKey)
+		r.Get("/keys/{username}", gkServer.HandleGetPublicKey)
+		r.Post("/data/{id}/shares", gkServer.HandleShareEntry)
+		r.Get("/data/{id}/shares", gkServer.HandleListEntryShares)
+		r.Post("/data/{id}/attachments", gkServer.HandleAddAttachment)
+		r.Get("/data/{id}/attachments", gkServer.HandleListAttachments)
+		r.Get("/data/{id}/attachments/{attachmentID}", gkServer.HandleGetAttachment)
+		r.Delete("/data/{id}/attachments/{attachmentID}", gkServer.HandleRemoveAttachment)
+		r.Delete("/shares/{id}", gkServer.HandleRevokeShare)
+		r.Get("/shared", gkServer.HandleListSharedWithMe)
+		r.Post("/secret-links", gkServer.HandleCreateSecretLink)
+		r.Post("/emergency-access", gkServer.HandleAddEmergencyContact)
+		r.Get("/emergency-access", gkServer.HandleListEmergencyAccess)
+		r.Delete("/emergency-access/{id}", gkServer.HandleRemoveEmergencyContact)
+		r.Post("/emergency-access/{id}/request", gkServer.HandleRequestEmergencyAccess)
+		r.Post("/emergency-access/{id}/approve", gkServer.HandleApproveEmergencyAccess)
+		r.Post("/emergency-access/{id}/deny", gkServer.HandleDenyEmergencyAccess)
+		r.Get("/emergency-access/{id}/vault", gkServer.HandleGetEmergencyVault)
+		r.Get("/emergency-access/{id}/events", gkServer.HandleListEmergencyAccessEvents)
+		r.Get("/notifications", gkServer.HandleListNotifications)
+		r.Post("/orgs", gkServer.HandleCreateOrganization)
+		r.Get("/orgs", gkServer.HandleListOrganizations)
+		r.Get("/orgs/{id}/members", gkServer.HandleListOrganizationMembers)
+		r.Put("/orgs/{id}/members/{user_id}", gkServer.HandleUpdateMemberRole)
+		r.Delete("/orgs/{id}/members/{user_id}", gkServer.HandleRemoveMember)
+		r.Post("/orgs/{id}/invitations", gkServer.HandleInviteMember)
+		r.Post("/orgs/{id}/collections", gkServer.HandleCreateCollection)
+		r.Get("/orgs/{id}/collections", gkServer.HandleListCollections)
+		r.Get("/invitations", gkServer.HandleListInvitations)
+		r.Post("/invitations/{id}/accept", gkServer.HandleAcceptInvitation)
+		r.Delete("/invitations/{id}", gkServer.HandleDeclineInvitation)
+		r.Put("/collections/{id}/keys/{user_id}", gkServer.HandleGrantCollectionKey)
+		r.Post("/collections/{id}/rotate", gkServer.HandleRotateCollectionKey)
+		r.Put("/collections/{id}/rotation", gkServer.HandleSetCollectionRotationPolicy)
+		r.Post("/collections/{id}/entries", gkServer.HandleCreateCollectionEntry)
+		r.Get("/collections/{id}/entries", gkServer.HandleListCollectionEntries)
 	})
 
 	return router
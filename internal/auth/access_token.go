@@ -0,0 +1,40 @@
+// Package auth предоставляет функциональность аутентификации и авторизации.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// AccessTokenPrefix префикс персональных токенов доступа, отличающий их от JWT.
+const AccessTokenPrefix = "gkp_"
+
+// accessTokenSize количество случайных байт в персональном токене.
+const accessTokenSize = 32
+
+// GenerateAccessToken создает новый персональный токен доступа и его хеш.
+// Сам токен показывается пользователю один раз, в хранилище попадает только хеш.
+func GenerateAccessToken() (string, []byte, error) {
+	raw := make([]byte, accessTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	token := AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashAccessToken(token), nil
+}
+
+// HashAccessToken вычисляет хеш персонального токена для поиска в хранилище.
+// Токен содержит 256 бит энтропии, поэтому медленный хеш не требуется.
+func HashAccessToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+// IsAccessToken проверяет, является ли строка персональным токеном доступа.
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, AccessTokenPrefix)
+}
@@ -13,23 +13,49 @@ import (
 
 // Service предоставляет методы для аутентификации пользователей.
 type Service struct {
-	jwtSecret []byte
+	keyring *Keyring
 }
 
 // Claims представляет claims JWT токена.
 type Claims struct {
-	UserID   uuid.UUID `json:"user_id"`
-	Username string    `json:"username"`
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	SessionID uuid.UUID `json:"sid,omitempty"`
+	// AuthTime время проверки пароля, сохраняется при обновлении токена
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// NewService создает новый сервис аутентификации.
+// NewService создает новый сервис аутентификации с HS256 подписью общим секретом.
 func NewService(jwtSecret string) *Service {
+	key := &SigningKey{
+		ID:        LegacyKeyID,
+		Method:    jwt.SigningMethodHS256,
+		signKey:   []byte(jwtSecret),
+		verifyKey: []byte(jwtSecret),
+	}
 	return &Service{
-		jwtSecret: []byte(jwtSecret),
+		keyring: &Keyring{
+			current: key,
+			ordered: []*SigningKey{key},
+			keys:    map[string]*SigningKey{key.ID: key},
+			legacy:  key,
+		},
 	}
 }
 
+// NewServiceWithKeyring создает сервис аутентификации с набором ключей подписи.
+func NewServiceWithKeyring(keyring *Keyring) *Service {
+	return &Service{
+		keyring: keyring,
+	}
+}
+
+// JWKS возвращает открытые ключи для проверки выданных токенов.
+func (s *Service) JWKS() JWKSet {
+	return s.keyring.JWKS()
+}
+
 // HashPassword хеширует пароль с помощью bcrypt.
 func (s *Service) HashPassword(password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -45,13 +71,25 @@ func (s *Service) CheckPassword(password, hash string) bool {
 	return err == nil
 }
 
-// GenerateToken генерирует JWT токен для пользователя.
+// GenerateToken генерирует JWT токен для пользователя без привязки к сессии.
 func (s *Service) GenerateToken(userID uuid.UUID, username string) (string, time.Time, error) {
+	return s.GenerateSessionToken(userID, username, uuid.Nil)
+}
+
+// GenerateSessionToken генерирует JWT токен, привязанный к сессии пользователя.
+func (s *Service) GenerateSessionToken(userID uuid.UUID, username string, sessionID uuid.UUID) (string, time.Time, error) {
+	return s.issueToken(userID, username, sessionID, time.Now())
+}
+
+// issueToken подписывает токен с указанным временем проверки пароля.
+func (s *Service) issueToken(userID uuid.UUID, username string, sessionID uuid.UUID, authTime time.Time) (string, time.Time, error) {
 	expirationTime := time.Now().Add(24 * time.Hour)
 
 	claims := &Claims{
-		UserID:   userID,
-		Username: username,
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+		AuthTime:  jwt.NewNumericDate(authTime),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -61,8 +99,7 @@ func (s *Service) GenerateToken(userID uuid.UUID, username string) (string, time
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtSecret)
+	tokenString, err := s.keyring.sign(claims)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -71,13 +108,10 @@ func (s *Service) GenerateToken(userID uuid.UUID, username string) (string, time
 }
 
 // ValidateToken проверяет и парсит JWT токен.
+// Ключ проверки выбирается по заголовку kid среди текущего и предыдущих ключей.
 func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keyring.keyFunc,
+		jwt.WithValidMethods(s.keyring.validMethods()))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -103,5 +137,12 @@ func (s *Service) RefreshToken(oldTokenString string) (string, time.Time, error)
 		return "", time.Time{}, errors.New("token too old to refresh")
 	}
 
-	return s.GenerateToken(claims.UserID, claims.Username)
+	// Обновленный токен остается в рамках той же сессии и сохраняет время входа
+	authTime := time.Now()
+	if claims.AuthTime != nil {
+		authTime = claims.AuthTime.Time
+	} else if claims.IssuedAt != nil {
+		authTime = claims.IssuedAt.Time
+	}
+	return s.issueToken(claims.UserID, claims.Username, claims.SessionID, authTime)
 }
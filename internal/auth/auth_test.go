@@ -52,3 +52,37 @@ func TestRefreshToken(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, userID, claims.UserID)
 }
+
+func TestRefreshTokenKeepsSession(t *testing.T) {
+	s := NewService("test-secret")
+	userID := uuid.New()
+	sessionID := uuid.New()
+	token, _, err := s.GenerateSessionToken(userID, "testuser", sessionID)
+	require.NoError(t, err)
+
+	claims, err := s.ValidateToken(token)
+	require.NoError(t, err)
+	require.Equal(t, sessionID, claims.SessionID)
+	require.NotNil(t, claims.AuthTime)
+	authTime := claims.AuthTime.Time
+
+	newToken, _, err := s.RefreshToken(token)
+	require.NoError(t, err)
+
+	claims, err = s.ValidateToken(newToken)
+	require.NoError(t, err)
+	require.Equal(t, sessionID, claims.SessionID)
+	require.Equal(t, authTime, claims.AuthTime.Time)
+}
+
+func TestGenerateAccessToken(t *testing.T) {
+	token, hash, err := GenerateAccessToken()
+	require.NoError(t, err)
+	require.True(t, IsAccessToken(token))
+	require.Equal(t, hash, HashAccessToken(token))
+
+	other, _, err := GenerateAccessToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+	require.False(t, IsAccessToken("eyJhbGciOiJIUzI1NiJ9.payload.signature"))
+}
@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// LegacyKeyID идентификатор HMAC ключа, созданного из общего секрета.
+// Токены без заголовка kid проверяются этим ключом.
+const LegacyKeyID = "hs256"
+
+// SigningKey представляет ключ подписи JWT токенов.
+type SigningKey struct {
+	// ID передается в заголовке kid
+	ID     string
+	Method jwt.SigningMethod
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewHMACKey создает симметричный HS256 ключ.
+func NewHMACKey(id string, secret []byte) (*SigningKey, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("HMAC secret is empty")
+	}
+	return &SigningKey{
+		ID:        id,
+		Method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}, nil
+}
+
+// NewEd25519Key создает EdDSA ключ. Пустой id заменяется отпечатком ключа.
+func NewEd25519Key(id string, privateKey ed25519.PrivateKey) (*SigningKey, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid Ed25519 private key")
+	}
+	return newAsymmetricKey(id, jwt.SigningMethodEdDSA, privateKey, privateKey.Public())
+}
+
+// NewECDSAKey создает ES256 ключ на кривой P-256. Пустой id заменяется отпечатком ключа.
+func NewECDSAKey(id string, privateKey *ecdsa.PrivateKey) (*SigningKey, error) {
+	if privateKey == nil || privateKey.Curve != elliptic.P256() {
+		return nil, errors.New("ES256 requires a P-256 private key")
+	}
+	return newAsymmetricKey(id, jwt.SigningMethodES256, privateKey, &privateKey.PublicKey)
+}
+
+// ParseSigningKeyPEM загружает Ed25519 или ECDSA P-256 ключ в формате PKCS#8 PEM.
+// Пустой id заменяется отпечатком ключа.
+func ParseSigningKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM block")
+	}
+
+	var privateKey interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	switch key := privateKey.(type) {
+	case ed25519.PrivateKey:
+		return NewEd25519Key(id, key)
+	case *ecdsa.PrivateKey:
+		return NewECDSAKey(id, key)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", privateKey)
+	}
+}
+
+// newAsymmetricKey создает асимметричный ключ и вычисляет kid при необходимости.
+func newAsymmetricKey(id string, method jwt.SigningMethod, privateKey crypto.Signer, publicKey crypto.PublicKey) (*SigningKey, error) {
+	key := &SigningKey{
+		ID:        id,
+		Method:    method,
+		signKey:   privateKey,
+		verifyKey: publicKey,
+	}
+	if key.ID == "" {
+		thumbprint, err := key.thumbprint()
+		if err != nil {
+			return nil, err
+		}
+		key.ID = thumbprint
+	}
+	return key, nil
+}
+
+// JWK представляет открытый ключ в формате JSON Web Key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKSet представляет набор открытых ключей (JWKS).
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK возвращает открытую часть ключа. Для HMAC ключей публикация невозможна.
+func (k *SigningKey) JWK() (JWK, bool) {
+	jwk := JWK{
+		KeyID:     k.ID,
+		Use:       "sig",
+		Algorithm: k.Method.Alg(),
+	}
+
+	switch key := k.verifyKey.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	case *ecdsa.PublicKey:
+		// Координаты P-256 дополняются до 32 байт согласно RFC 7518
+		jwk.KeyType = "EC"
+		jwk.Curve = "P-256"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32)))
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
+
+// thumbprint вычисляет отпечаток открытого ключа по RFC 7638.
+func (k *SigningKey) thumbprint() (string, error) {
+	jwk, ok := k.JWK()
+	if !ok {
+		return "", errors.New("thumbprint is only defined for public keys")
+	}
+
+	// Обязательные поля в лексикографическом порядке
+	var members interface{}
+	switch jwk.KeyType {
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Curve, jwk.KeyType, jwk.X, jwk.Y}
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWK: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// Keyring хранит текущий ключ подписи и предыдущие ключи, которыми
+// еще можно проверять выданные до ротации токены.
+type Keyring struct {
+	current *SigningKey
+	ordered []*SigningKey
+	keys    map[string]*SigningKey
+	legacy  *SigningKey
+}
+
+// NewKeyring создает набор ключей. Новые токены подписываются current,
+// previous используются только для проверки.
+func NewKeyring(current *SigningKey, previous ...*SigningKey) (*Keyring, error) {
+	if current == nil {
+		return nil, errors.New("current signing key is required")
+	}
+
+	kr := &Keyring{
+		current: current,
+		ordered: append([]*SigningKey{current}, previous...),
+		keys:    make(map[string]*SigningKey, len(previous)+1),
+	}
+	for _, key := range kr.ordered {
+		if key.ID == "" {
+			return nil, errors.New("signing key ID is required")
+		}
+		if _, exists := kr.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate signing key ID %q", key.ID)
+		}
+		kr.keys[key.ID] = key
+		if key.ID == LegacyKeyID {
+			kr.legacy = key
+		}
+	}
+
+	return kr, nil
+}
+
+// Current возвращает текущий ключ подписи.
+func (kr *Keyring) Current() *SigningKey {
+	return kr.current
+}
+
+// sign подписывает claims текущим ключом и добавляет заголовок kid.
+func (kr *Keyring) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(kr.current.Method, claims)
+	token.Header["kid"] = kr.current.ID
+	return token.SignedString(kr.current.signKey)
+}
+
+// keyFunc выбирает ключ проверки по kid и запрещает подмену алгоритма.
+func (kr *Keyring) keyFunc(token *jwt.Token) (interface{}, error) {
+	key := kr.legacy
+	if kid, ok := token.Header["kid"].(string); ok {
+		key = kr.keys[kid]
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key: %v", token.Header["kid"])
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.verifyKey, nil
+}
+
+// validMethods возвращает алгоритмы всех ключей набора.
+func (kr *Keyring) validMethods() []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, key := range kr.ordered {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// JWKS возвращает открытые ключи набора. Текущий ключ идет первым,
+// HMAC ключи не публикуются.
+func (kr *Keyring) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range kr.ordered {
+		if jwk, ok := key.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
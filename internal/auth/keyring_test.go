@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	oldKey, err := NewEd25519Key("", edKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, oldKey.ID)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := NewECDSAKey("", ecKey)
+	require.NoError(t, err)
+
+	oldRing, err := NewKeyring(oldKey)
+	require.NoError(t, err)
+	oldService := NewServiceWithKeyring(oldRing)
+
+	oldToken, _, err := oldService.GenerateToken(uuid.New(), "testuser")
+	require.NoError(t, err)
+
+	// После ротации старые токены проверяются предыдущим ключом
+	rotated, err := NewKeyring(newKey, oldKey)
+	require.NoError(t, err)
+	service := NewServiceWithKeyring(rotated)
+
+	_, err = service.ValidateToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := service.GenerateToken(uuid.New(), "testuser")
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	require.Equal(t, newKey.ID, parsed.Header["kid"])
+	require.Equal(t, "ES256", parsed.Header["alg"])
+
+	// Токен нового ключа не принимается сервисом, который о нем не знает
+	_, err = oldService.ValidateToken(newToken)
+	require.Error(t, err)
+
+	// После удаления предыдущего ключа старые токены отклоняются
+	current, err := NewKeyring(newKey)
+	require.NoError(t, err)
+	_, err = NewServiceWithKeyring(current).ValidateToken(oldToken)
+	require.Error(t, err)
+}
+
+func TestKeyringRejectsAlgorithmConfusion(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := NewECDSAKey("ec", ecKey)
+	require.NoError(t, err)
+	kr, err := NewKeyring(key)
+	require.NoError(t, err)
+	service := NewServiceWithKeyring(kr)
+
+	// HS256 токен с kid асимметричного ключа
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: uuid.New()})
+	forged.Header["kid"] = "ec"
+	tokenString, err := forged.SignedString([]byte("guess"))
+	require.NoError(t, err)
+
+	_, err = service.ValidateToken(tokenString)
+	require.Error(t, err)
+}
+
+func TestLegacyTokensWithoutKid(t *testing.T) {
+	legacyToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: uuid.New()}).
+		SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	_, err = NewService("test-secret").ValidateToken(legacyToken)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	current, err := NewEd25519Key("", edKey)
+	require.NoError(t, err)
+	legacy, err := NewHMACKey(LegacyKeyID, []byte("test-secret"))
+	require.NoError(t, err)
+	kr, err := NewKeyring(current, legacy)
+	require.NoError(t, err)
+
+	_, err = NewServiceWithKeyring(kr).ValidateToken(legacyToken)
+	require.NoError(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	edSigningKey, err := ParseSigningKeyPEM("", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecSigningKey, err := NewECDSAKey("", ecKey)
+	require.NoError(t, err)
+
+	hmacKey, err := NewHMACKey(LegacyKeyID, []byte("test-secret"))
+	require.NoError(t, err)
+
+	kr, err := NewKeyring(edSigningKey, ecSigningKey, hmacKey)
+	require.NoError(t, err)
+
+	set := kr.JWKS()
+	require.Len(t, set.Keys, 2)
+	require.Equal(t, edSigningKey.ID, set.Keys[0].KeyID)
+	require.Equal(t, "OKP", set.Keys[0].KeyType)
+	require.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+	require.Equal(t, "EC", set.Keys[1].KeyType)
+	require.NotEmpty(t, set.Keys[1].Y)
+
+	_, err = NewKeyring(edSigningKey, edSigningKey)
+	require.Error(t, err)
+}
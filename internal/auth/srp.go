@@ -0,0 +1,265 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Реализация SRP-6a (RFC 5054) с группой 2048 бит и SHA-256.
+// Сервер хранит только соль и верификатор v = g^x mod N и никогда не получает пароль.
+
+// srpPrimeHex 2048-битное простое число из приложения A RFC 5054.
+const srpPrimeHex = "AC6BDB41324A9A9BF166DE5E1389582FAF72B6651987EE07FC3192943DB56050" +
+	"A37329CBB4A099ED8193E0757767A13DD52312AB4B03310DCD7F48A9DA04FD50" +
+	"E8083969EDB767B0CF6095179A163AB3661A05FBD5FAAAE82918A9962F0B93B8" +
+	"55F97993EC975EEAA80D740ADBF4FF747359D041D5C33EA71D281E446B14773B" +
+	"CA97B43A23FB801676BD207A436C6481F1D2B9078717461A5B9D32E688F87748" +
+	"544523B524B0D57D5EA77A2775D2ECFA032CFBDBF52FB3786160279004E57AE6" +
+	"AF874E7303CE53299CCC041C7BC308D82A5698F3A8D0C38271AE35F8E9DBFBB6" +
+	"94B5C803D89F7AE435DE236D525F54759B65E372FCD68EF20FA7111F9E4AFF73"
+
+// SRPSaltSize размер соли верификатора в байтах.
+const SRPSaltSize = 16
+
+var (
+	srpN = mustParseHex(srpPrimeHex)
+	srpG = big.NewInt(2)
+	// srpK множитель k = H(N | PAD(g))
+	srpK = new(big.Int).SetBytes(srpHash(srpN.Bytes(), srpPad(srpG)))
+
+	// ErrSRPInvalidProof возвращается при неверном доказательстве знания пароля.
+	ErrSRPInvalidProof = errors.New("invalid SRP proof")
+)
+
+// mustParseHex разбирает шестнадцатеричную константу.
+func mustParseHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid SRP constant")
+	}
+	return n
+}
+
+// NewSRPVerifier создает соль и верификатор для регистрации или смены пароля.
+// Вычисляется на клиенте, на сервер передаются только соль и верификатор.
+func NewSRPVerifier(username, password string) (salt, verifier []byte, err error) {
+	salt = make([]byte, SRPSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	x := srpPrivateKey(username, password, salt)
+	v := new(big.Int).Exp(srpG, x, srpN)
+	return salt, srpPad(v), nil
+}
+
+// SRPClient хранит состояние клиентской стороны рукопожатия SRP.
+type SRPClient struct {
+	username string
+	password string
+	a        *big.Int
+	bigA     *big.Int
+
+	proof []byte
+	key   []byte
+}
+
+// NewSRPClient начинает рукопожатие SRP на стороне клиента.
+func NewSRPClient(username, password string) (*SRPClient, error) {
+	a, err := srpRandomExponent()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SRPClient{
+		username: username,
+		password: password,
+		a:        a,
+		bigA:     new(big.Int).Exp(srpG, a, srpN),
+	}, nil
+}
+
+// PublicKey возвращает эфемерный открытый ключ клиента A.
+func (c *SRPClient) PublicKey() []byte {
+	return srpPad(c.bigA)
+}
+
+// ProcessChallenge вычисляет доказательство клиента M1 по соли и открытому ключу сервера B.
+func (c *SRPClient) ProcessChallenge(salt, serverPublicKey []byte) ([]byte, error) {
+	bigB := new(big.Int).SetBytes(serverPublicKey)
+	if new(big.Int).Mod(bigB, srpN).Sign() == 0 {
+		return nil, errors.New("invalid server public key")
+	}
+
+	u := srpScrambler(c.bigA, bigB)
+	if u.Sign() == 0 {
+		return nil, errors.New("invalid server public key")
+	}
+
+	// S = (B - k*g^x) ^ (a + u*x) mod N
+	x := srpPrivateKey(c.username, c.password, salt)
+	gx := new(big.Int).Exp(srpG, x, srpN)
+	base := new(big.Int).Sub(bigB, new(big.Int).Mul(srpK, gx))
+	base.Mod(base, srpN)
+	exp := new(big.Int).Add(c.a, new(big.Int).Mul(u, x))
+	s := new(big.Int).Exp(base, exp, srpN)
+
+	c.key = srpHash(srpPad(s))
+	c.proof = srpClientProof(c.username, salt, c.bigA, bigB, c.key)
+	return c.proof, nil
+}
+
+// VerifyServer проверяет доказательство сервера M2, подтверждающее знание верификатора.
+func (c *SRPClient) VerifyServer(serverProof []byte) error {
+	if c.key == nil {
+		return errors.New("challenge was not processed")
+	}
+	expected := srpHash(srpPad(c.bigA), c.proof, c.key)
+	if subtle.ConstantTimeCompare(expected, serverProof) != 1 {
+		return ErrSRPInvalidProof
+	}
+	return nil
+}
+
+// SessionKey возвращает общий сеансовый ключ K.
+func (c *SRPClient) SessionKey() []byte {
+	return c.key
+}
+
+// SRPServer хранит состояние серверной стороны рукопожатия SRP.
+type SRPServer struct {
+	username string
+	salt     []byte
+	v        *big.Int
+	b        *big.Int
+	bigA     *big.Int
+	bigB     *big.Int
+}
+
+// NewSRPServer начинает рукопожатие SRP на стороне сервера по открытому ключу клиента A.
+func NewSRPServer(username string, salt, verifier, clientPublicKey []byte) (*SRPServer, error) {
+	bigA := new(big.Int).SetBytes(clientPublicKey)
+	if new(big.Int).Mod(bigA, srpN).Sign() == 0 {
+		return nil, errors.New("invalid client public key")
+	}
+
+	b, err := srpRandomExponent()
+	if err != nil {
+		return nil, err
+	}
+
+	// B = k*v + g^b mod N
+	v := new(big.Int).SetBytes(verifier)
+	bigB := new(big.Int).Mul(srpK, v)
+	bigB.Add(bigB, new(big.Int).Exp(srpG, b, srpN))
+	bigB.Mod(bigB, srpN)
+
+	return &SRPServer{
+		username: username,
+		salt:     salt,
+		v:        v,
+		b:        b,
+		bigA:     bigA,
+		bigB:     bigB,
+	}, nil
+}
+
+// NewSRPDecoyServer начинает рукопожатие для неизвестного пользователя или аккаунта
+// без SRP, чтобы ответ нельзя было отличить от настоящего: соль постоянна для имени
+// пользователя (HMAC имени на секрете сервера), верификатор случаен, поэтому
+// доказательство клиента никогда не сходится.
+func NewSRPDecoyServer(secret []byte, username string, clientPublicKey []byte) (*SRPServer, error) {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(username))
+	salt := mac.Sum(nil)[:SRPSaltSize]
+
+	exponent, err := srpRandomExponent()
+	if err != nil {
+		return nil, err
+	}
+	verifier := srpPad(new(big.Int).Exp(srpG, exponent, srpN))
+
+	return NewSRPServer(username, salt, verifier, clientPublicKey)
+}
+
+// Salt возвращает соль верификатора, которую сервер передает клиенту.
+func (s *SRPServer) Salt() []byte {
+	return s.salt
+}
+
+// PublicKey возвращает эфемерный открытый ключ сервера B.
+func (s *SRPServer) PublicKey() []byte {
+	return srpPad(s.bigB)
+}
+
+// VerifyClient проверяет доказательство клиента M1 и возвращает доказательство сервера M2.
+func (s *SRPServer) VerifyClient(clientProof []byte) ([]byte, error) {
+	u := srpScrambler(s.bigA, s.bigB)
+	if u.Sign() == 0 {
+		return nil, ErrSRPInvalidProof
+	}
+
+	// S = (A * v^u) ^ b mod N
+	base := new(big.Int).Mul(s.bigA, new(big.Int).Exp(s.v, u, srpN))
+	base.Mod(base, srpN)
+	key := srpHash(srpPad(new(big.Int).Exp(base, s.b, srpN)))
+
+	expected := srpClientProof(s.username, s.salt, s.bigA, s.bigB, key)
+	if subtle.ConstantTimeCompare(expected, clientProof) != 1 {
+		return nil, ErrSRPInvalidProof
+	}
+
+	return srpHash(srpPad(s.bigA), clientProof, key), nil
+}
+
+// srpPrivateKey вычисляет x = H(salt | argon2id(username:password, salt)).
+// Argon2id замедляет перебор паролей по утекшему верификатору.
+func srpPrivateKey(username, password string, salt []byte) *big.Int {
+	stretched := argon2.IDKey([]byte(username+":"+password), salt, 1, 64*1024, 4, 32)
+	return new(big.Int).SetBytes(srpHash(salt, stretched))
+}
+
+// srpClientProof вычисляет M1 = H(H(N) xor H(g) | H(I) | s | A | B | K).
+func srpClientProof(username string, salt []byte, bigA, bigB *big.Int, key []byte) []byte {
+	hn := srpHash(srpN.Bytes())
+	hg := srpHash(srpPad(srpG))
+	for i := range hn {
+		hn[i] ^= hg[i]
+	}
+	return srpHash(hn, srpHash([]byte(username)), salt, srpPad(bigA), srpPad(bigB), key)
+}
+
+// srpScrambler вычисляет u = H(PAD(A) | PAD(B)).
+func srpScrambler(bigA, bigB *big.Int) *big.Int {
+	return new(big.Int).SetBytes(srpHash(srpPad(bigA), srpPad(bigB)))
+}
+
+// srpRandomExponent генерирует случайный секретный показатель длиной 256 бит.
+func srpRandomExponent() (*big.Int, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate SRP exponent: %w", err)
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
+
+// srpPad дополняет число нулями слева до длины N.
+func srpPad(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, (srpN.BitLen()+7)/8))
+}
+
+// srpHash вычисляет SHA-256 от конкатенации аргументов.
+func srpHash(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
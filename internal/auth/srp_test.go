@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRPHandshake(t *testing.T) {
+	salt, verifier, err := NewSRPVerifier("testuser", "supersecret")
+	require.NoError(t, err)
+
+	client, err := NewSRPClient("testuser", "supersecret")
+	require.NoError(t, err)
+
+	server, err := NewSRPServer("testuser", salt, verifier, client.PublicKey())
+	require.NoError(t, err)
+
+	clientProof, err := client.ProcessChallenge(salt, server.PublicKey())
+	require.NoError(t, err)
+
+	serverProof, err := server.VerifyClient(clientProof)
+	require.NoError(t, err)
+	require.NoError(t, client.VerifyServer(serverProof))
+	require.Len(t, client.SessionKey(), 32)
+}
+
+func TestSRPWrongPassword(t *testing.T) {
+	salt, verifier, err := NewSRPVerifier("testuser", "supersecret")
+	require.NoError(t, err)
+
+	client, err := NewSRPClient("testuser", "wrongpassword")
+	require.NoError(t, err)
+
+	server, err := NewSRPServer("testuser", salt, verifier, client.PublicKey())
+	require.NoError(t, err)
+
+	clientProof, err := client.ProcessChallenge(salt, server.PublicKey())
+	require.NoError(t, err)
+
+	_, err = server.VerifyClient(clientProof)
+	require.ErrorIs(t, err, ErrSRPInvalidProof)
+}
+
+func TestSRPRejectsZeroPublicKey(t *testing.T) {
+	salt, verifier, err := NewSRPVerifier("testuser", "supersecret")
+	require.NoError(t, err)
+
+	// A = 0 и A = N позволили бы войти без пароля
+	_, err = NewSRPServer("testuser", salt, verifier, []byte{0})
+	require.Error(t, err)
+	_, err = NewSRPServer("testuser", salt, verifier, srpN.Bytes())
+	require.Error(t, err)
+
+	client, err := NewSRPClient("testuser", "supersecret")
+	require.NoError(t, err)
+	_, err = client.ProcessChallenge(salt, srpPad(srpN))
+	require.Error(t, err)
+}
+
+func TestSRPDecoyServer(t *testing.T) {
+	client, err := NewSRPClient("nobody", "supersecret")
+	require.NoError(t, err)
+
+	server, err := NewSRPDecoyServer([]byte("server-secret"), "nobody", client.PublicKey())
+	require.NoError(t, err)
+	require.Len(t, server.Salt(), SRPSaltSize)
+
+	// Соль постоянна для имени пользователя и секрета сервера
+	again, err := NewSRPDecoyServer([]byte("server-secret"), "nobody", client.PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, server.Salt(), again.Salt())
+	require.NotEqual(t, server.PublicKey(), again.PublicKey())
+
+	other, err := NewSRPDecoyServer([]byte("other-secret"), "nobody", client.PublicKey())
+	require.NoError(t, err)
+	require.NotEqual(t, server.Salt(), other.Salt())
+
+	clientProof, err := client.ProcessChallenge(server.Salt(), server.PublicKey())
+	require.NoError(t, err)
+	_, err = server.VerifyClient(clientProof)
+	require.ErrorIs(t, err, ErrSRPInvalidProof)
+}
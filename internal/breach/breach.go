@@ -0,0 +1,169 @@
+// Package breach проверяет пароли по базе утечек, разбитой на диапазоны
+// по префиксу SHA-1, как в API Pwned Passwords (HIBP). Проверка использует
+// k-анонимность: источнику передаются только первые пять символов хеша,
+// сравнение с остатком хеша выполняется локально.
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PrefixLength — число шестнадцатеричных символов хеша, которые передаются источнику.
+const PrefixLength = 5
+
+// suffixLength — длина остатка хеша SHA-1 в строке диапазона.
+const suffixLength = sha1.Size*2 - PrefixLength
+
+// ErrRangeNotFound возвращается, если в базе нет файла диапазона: база загружена не полностью.
+var ErrRangeNotFound = errors.New("breach range not found")
+
+// Source выдает диапазон базы утечек: строки вида "SUFFIX:COUNT", где SUFFIX —
+// остаток хеша SHA-1 в верхнем регистре после префикса из PrefixLength символов.
+type Source interface {
+	Range(ctx context.Context, prefix string) (io.ReadCloser, error)
+}
+
+// HashPassword возвращает префикс и остаток хеша SHA-1 пароля в верхнем регистре.
+// SHA-1 задан форматом базы утечек и не используется для защиты данных.
+func HashPassword(password string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return hash[:PrefixLength], hash[PrefixLength:]
+}
+
+// ValidPrefix проверяет, что префикс состоит из PrefixLength шестнадцатеричных символов.
+func ValidPrefix(prefix string) bool {
+	if len(prefix) != PrefixLength {
+		return false
+	}
+	_, err := hex.DecodeString(prefix + "0")
+	return err == nil
+}
+
+// ParseRange ищет остатки хешей suffixes в диапазоне и возвращает число
+// появлений каждого найденного в утечках. Строки с нулевым счетчиком
+// (дополнение, которое HIBP добавляет для сокрытия размера ответа) пропускаются.
+func ParseRange(r io.Reader, suffixes map[string]struct{}) (map[string]int, error) {
+	found := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		suffix, countText, ok := strings.Cut(text, ":")
+		if !ok || len(suffix) != suffixLength {
+			return nil, fmt.Errorf("breach range line %d: expected SUFFIX:COUNT", line)
+		}
+		suffix = strings.ToUpper(suffix)
+		if _, ok := suffixes[suffix]; !ok {
+			continue
+		}
+		count, err := strconv.Atoi(countText)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("breach range line %d: invalid count %q", line, countText)
+		}
+		if count > 0 {
+			found[suffix] += count
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breach range: %w", err)
+	}
+	return found, nil
+}
+
+// Checker проверяет пароли по источнику диапазонов. Результаты запоминаются
+// по хешу пароля, так как база утечек не меняется во время работы клиента.
+type Checker struct {
+	source Source
+
+	mu    sync.Mutex
+	cache map[string]int // полный хеш -> число появлений
+}
+
+// NewChecker создает проверку паролей по источнику.
+func NewChecker(source Source) *Checker {
+	return &Checker{source: source, cache: make(map[string]int)}
+}
+
+// Check возвращает, сколько раз пароль встречается в утечках; 0 — не найден.
+func (c *Checker) Check(ctx context.Context, password string) (int, error) {
+	counts, err := c.CheckMany(ctx, []string{password})
+	if err != nil {
+		return 0, err
+	}
+	return counts[password], nil
+}
+
+// CheckMany проверяет несколько паролей. Каждый диапазон запрашивается один
+// раз, даже если в нем несколько паролей. В результате только найденные пароли.
+func (c *Checker) CheckMany(ctx context.Context, passwords []string) (map[string]int, error) {
+	result := make(map[string]int)
+	byPrefix := make(map[string]map[string][]string) // префикс -> остаток -> пароли
+
+	c.mu.Lock()
+	for _, password := range passwords {
+		prefix, suffix := HashPassword(password)
+		if count, ok := c.cache[prefix+suffix]; ok {
+			if count > 0 {
+				result[password] = count
+			}
+			continue
+		}
+		if byPrefix[prefix] == nil {
+			byPrefix[prefix] = make(map[string][]string)
+		}
+		byPrefix[prefix][suffix] = append(byPrefix[prefix][suffix], password)
+	}
+	c.mu.Unlock()
+
+	for prefix, bySuffix := range byPrefix {
+		suffixes := make(map[string]struct{}, len(bySuffix))
+		for suffix := range bySuffix {
+			suffixes[suffix] = struct{}{}
+		}
+
+		found, err := c.fetchRange(ctx, prefix, suffixes)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		for suffix, same := range bySuffix {
+			count := found[suffix]
+			c.cache[prefix+suffix] = count
+			if count > 0 {
+				for _, password := range same {
+					result[password] = count
+				}
+			}
+		}
+		c.mu.Unlock()
+	}
+	return result, nil
+}
+
+// fetchRange запрашивает диапазон и ищет в нем остатки хешей.
+func (c *Checker) fetchRange(ctx context.Context, prefix string, suffixes map[string]struct{}) (map[string]int, error) {
+	body, err := c.source.Range(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get breach range %s: %w", prefix, err)
+	}
+	defer body.Close()
+
+	found, err := ParseRange(body, suffixes)
+	if err != nil {
+		return nil, fmt.Errorf("breach range %s: %w", prefix, err)
+	}
+	return found, nil
+}
@@ -0,0 +1,136 @@
+package breach
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 от "password": 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+// writeCorpus создает локальную базу с диапазоном пароля "password"
+func writeCorpus(t *testing.T) string {
+	dir := t.TempDir()
+	content := "003D68EB55068C33ACE09247EE4C639306B:3\r\n" +
+		passwordSuffix + ":9545824\r\n" +
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD9:0\r\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(content), 0o600))
+	return dir
+}
+
+// countingSource считает запросы диапазонов
+type countingSource struct {
+	Source
+	calls int
+}
+
+func (s *countingSource) Range(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	s.calls++
+	return s.Source.Range(ctx, prefix)
+}
+
+func TestHashPassword(t *testing.T) {
+	prefix, suffix := HashPassword("password")
+	assert.Equal(t, "5BAA6", prefix)
+	assert.Equal(t, passwordSuffix, suffix)
+
+	assert.True(t, ValidPrefix("5baa6"))
+	assert.False(t, ValidPrefix("5BAA"))
+	assert.False(t, ValidPrefix("5BAAZ"))
+	assert.False(t, ValidPrefix("../.."))
+}
+
+func TestParseRange(t *testing.T) {
+	suffixes := map[string]struct{}{passwordSuffix: {}, "1E4C9B93F3F0682250B6CF8331B7EE68FD9": {}}
+	found, err := ParseRange(strings.NewReader(strings.ToLower(passwordSuffix)+":12\n\n"+
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD9:0\n"), suffixes)
+	require.NoError(t, err)
+	// Строки дополнения с нулевым счетчиком не считаются утечкой
+	assert.Equal(t, map[string]int{passwordSuffix: 12}, found)
+
+	_, err = ParseRange(strings.NewReader("not a range line\n"), suffixes)
+	require.Error(t, err)
+	_, err = ParseRange(strings.NewReader(passwordSuffix+":many\n"), suffixes)
+	require.Error(t, err)
+}
+
+func TestChecker_DirSource(t *testing.T) {
+	source := &countingSource{Source: NewDirSource(writeCorpus(t))}
+	checker := NewChecker(source)
+	ctx := context.Background()
+
+	count, err := checker.Check(ctx, "password")
+	require.NoError(t, err)
+	assert.Equal(t, 9545824, count)
+
+	// Результат запоминается, диапазон повторно не читается
+	counts, err := checker.CheckMany(ctx, []string{"password", "password"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"password": 9545824}, counts)
+	assert.Equal(t, 1, source.calls)
+
+	// Диапазона нет в базе
+	_, err = checker.Check(ctx, "correct-horse-battery-staple")
+	require.ErrorIs(t, err, ErrRangeNotFound)
+}
+
+func TestChecker_HTTPMirror(t *testing.T) {
+	mirror := httptest.NewServer(http.StripPrefix("/breach", RangeHandler(NewDirSource(writeCorpus(t)))))
+	defer mirror.Close()
+
+	checker := NewChecker(NewHTTPSource(mirror.URL+"/breach/", nil))
+	count, err := checker.Check(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, 9545824, count)
+
+	_, err = checker.Check(context.Background(), "correct-horse-battery-staple")
+	require.ErrorIs(t, err, ErrRangeNotFound)
+
+	resp, err := http.Get(mirror.URL + "/breach/range/xyz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(mirror.URL + "/breach/range/5baa6")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), passwordSuffix)
+}
+
+func TestHTTPSource_RangeTooLarge(t *testing.T) {
+	line := passwordSuffix + ":1\r\n"
+	body := strings.Repeat(line, maxRangeSize/len(line)+1)
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, body)
+	}))
+	defer mirror.Close()
+
+	_, err := NewChecker(NewHTTPSource(mirror.URL, nil)).Check(context.Background(), "password")
+	require.ErrorIs(t, err, ErrRangeTooLarge)
+
+	// Ответ ровно предельного размера принимается
+	exact := strings.Repeat("0", maxRangeSize-len(line)-2) + "\r\n" + line
+	exactMirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, exact)
+	}))
+	defer exactMirror.Close()
+
+	rangeBody, err := NewHTTPSource(exactMirror.URL, nil).Range(context.Background(), "5BAA6")
+	require.NoError(t, err)
+	defer rangeBody.Close()
+	data, err := io.ReadAll(rangeBody)
+	require.NoError(t, err)
+	assert.Len(t, data, maxRangeSize)
+}
@@ -0,0 +1,159 @@
+package breach
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxRangeSize ограничивает размер ответа зеркала: диапазоны HIBP занимают
+// десятки килобайт.
+const maxRangeSize = 4 << 20
+
+// ErrRangeTooLarge возвращается, если ответ зеркала превышает maxRangeSize:
+// усеченный диапазон мог бы скрыть пароль из утечки.
+var ErrRangeTooLarge = fmt.Errorf("breach range exceeds %d bytes", maxRangeSize)
+
+// defaultHTTPTimeout — время ожидания ответа зеркала по умолчанию.
+const defaultHTTPTimeout = 10 * time.Second
+
+// DirSource читает диапазоны из локальной копии базы: каталога с файлами
+// "<PREFIX>.txt" или "<PREFIX>", как их сохраняют загрузчики HIBP.
+type DirSource struct {
+	dir string
+}
+
+// NewDirSource создает источник диапазонов из каталога.
+func NewDirSource(dir string) *DirSource {
+	return &DirSource{dir: dir}
+}
+
+// Range открывает файл диапазона. Имя файла может быть в любом регистре.
+func (s *DirSource) Range(_ context.Context, prefix string) (io.ReadCloser, error) {
+	if !ValidPrefix(prefix) {
+		return nil, fmt.Errorf("invalid range prefix %q", prefix)
+	}
+
+	for _, name := range []string{
+		strings.ToUpper(prefix) + ".txt",
+		strings.ToUpper(prefix),
+		strings.ToLower(prefix) + ".txt",
+		strings.ToLower(prefix),
+	} {
+		file, err := os.Open(filepath.Join(s.dir, name))
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, ErrRangeNotFound
+}
+
+// HTTPSource запрашивает диапазоны у зеркала с API Pwned Passwords:
+// GET <baseURL>/range/<PREFIX>.
+type HTTPSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPSource создает источник диапазонов зеркала. Если client равен nil,
+// используется NewHTTPClient(nil).
+func NewHTTPSource(baseURL string, client *http.Client) *HTTPSource {
+	if client == nil {
+		client = NewHTTPClient(nil)
+	}
+	return &HTTPSource{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// NewHTTPClient создает клиент зеркала с таймаутом по умолчанию. Если tlsConfig
+// не nil, соединения с зеркалом по HTTPS используют его вместо системных настроек.
+func NewHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &http.Client{Timeout: defaultHTTPTimeout, Transport: transport}
+}
+
+// Range запрашивает диапазон у зеркала.
+func (s *HTTPSource) Range(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	if !ValidPrefix(prefix) {
+		return nil, fmt.Errorf("invalid range prefix %q", prefix)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/range/"+strings.ToUpper(prefix), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return readRange(resp.Body)
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrRangeNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("breach mirror returned %s", resp.Status)
+	}
+}
+
+// readRange читает ответ зеркала целиком, чтобы превышение maxRangeSize
+// обнаружилось до разбора диапазона, а не обрезало его.
+func readRange(body io.ReadCloser) (io.ReadCloser, error) {
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxRangeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRangeSize {
+		return nil, ErrRangeTooLarge
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// RangeHandler отдает диапазоны источника по адресу ".../range/<PREFIX>",
+// чтобы сервер мог работать зеркалом базы утечек для клиентов без доступа
+// к внешней сети. Префикс берется из последнего сегмента пути.
+func RangeHandler(source Source) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := path.Base(r.URL.Path)
+		if !ValidPrefix(prefix) {
+			http.Error(w, "range prefix must be 5 hexadecimal characters", http.StatusBadRequest)
+			return
+		}
+
+		body, err := source.Range(r.Context(), strings.ToUpper(prefix))
+		if errors.Is(err, ErrRangeNotFound) {
+			http.Error(w, "range not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to read range", http.StatusInternalServerError)
+			return
+		}
+		defer body.Close()
+
+		w.Header().Set("Content-Type", "text/plain")
+		// База утечек обновляется редко, ответы можно кэшировать
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		_, _ = io.Copy(w, body)
+	})
+}
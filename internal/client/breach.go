@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+
+	"github.com/GophKeeper/internal/breach"
+	"github.com/GophKeeper/internal/config"
+	"github.com/GophKeeper/internal/tlsutil"
+	pb "github.com/GophKeeper/proto/gen/proto"
+)
+
+// NewBreachChecker создает проверку паролей по базе утечек из конфигурации
+// клиента: по локальному каталогу диапазонов или по зеркалу. Если не задано
+// ни то, ни другое, возвращает nil — проверка отключена. Зеркало по HTTPS
+// проверяется теми же CA, пинами SPKI и клиентским сертификатом, что и gRPC;
+// имя сервера берется из адреса зеркала.
+func NewBreachChecker(cfg *config.ClientConfig) (*breach.Checker, error) {
+	switch {
+	case cfg.BreachDir != "":
+		return breach.NewChecker(breach.NewDirSource(cfg.BreachDir)), nil
+	case cfg.BreachURL != "":
+		mirror, err := url.Parse(cfg.BreachURL)
+		if err != nil || mirror.Host == "" {
+			return nil, fmt.Errorf("invalid breach mirror URL %q", cfg.BreachURL)
+		}
+
+		var tlsConfig *tls.Config
+		if mirror.Scheme == "https" {
+			tlsOpts := clientTLSOptions(cfg)
+			tlsOpts.Address = mirror.Host
+			if tlsConfig, err = tlsutil.NewClientTLSConfig(tlsOpts); err != nil {
+				return nil, fmt.Errorf("failed to configure breach mirror TLS: %w", err)
+			}
+		}
+		return breach.NewChecker(breach.NewHTTPSource(cfg.BreachURL, breach.NewHTTPClient(tlsConfig))), nil
+	default:
+		return nil, nil
+	}
+}
+
+// CheckBreachedEntries проверяет пароли расшифрованных записей по базе утечек
+// и возвращает число появлений найденных паролей по ID записи. Источнику
+// передаются только префиксы хешей SHA-1.
+func CheckBreachedEntries(ctx context.Context, checker *breach.Checker, entries []*pb.DataEntry) (map[string]int, error) {
+	passwords := vaultPasswords(entries)
+	values := make([]string, len(passwords))
+	for i, p := range passwords {
+		values[i] = p.password
+	}
+
+	counts, err := checker.CheckMany(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	breached := make(map[string]int)
+	for _, p := range passwords {
+		if count := counts[p.password]; count > 0 {
+			breached[p.entry.Id] = count
+		}
+	}
+	return breached, nil
+}
@@ -3,20 +3,44 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/gif"  // декодер снимков экрана с QR кодами
+	_ "image/jpeg" // декодер снимков экрана с QR кодами
+	_ "image/png"  // декодер снимков экрана с QR кодами
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/GophKeeper/internal/auth"
 	"github.com/GophKeeper/internal/config"
+	"github.com/GophKeeper/internal/crypto"
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/otp"
+	"github.com/GophKeeper/internal/tlsutil"
 	pb "github.com/GophKeeper/proto/gen/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxExportSize ограничивает размер ответа с экспортом аккаунта: архив содержит
+// вложения и намного больше обычных ответов, для которых хватает 4 МБ.
+const maxExportSize = 512 << 20
+
+// ErrNoAttachmentKey возвращается, если у клиента нет ключа, которым шифруются
+// ключи вложений: сервер не должен получать ключ вложения в открытом виде.
+var ErrNoAttachmentKey = errors.New("attachment key requires a vault or entry key")
+
 // Client представляет клиент для взаимодействия с сервером GophKeeper.
 type Client struct {
 	config     *config.ClientConfig
@@ -25,6 +49,16 @@ type Client struct {
 	grpcClient pb.GophKeeperClient
 	token      string
 	expiresAt  time.Time
+
+	// Ключ хранилища, зашифрованный мастер-паролем, и он же расшифрованный при входе
+	wrappedVaultKey []byte
+	vaultKey        []byte
+	// Закрытый ключ для общих записей, зашифрованный ключом хранилища
+	wrappedPrivateKey []byte
+
+	// Имя пользователя и способ входа для повторного подтверждения пароля
+	username string
+	usesSRP  bool
 }
 
 // NewClient создает новый клиент GophKeeper.
@@ -32,18 +66,14 @@ func NewClient(cfg *config.ClientConfig, logger *zap.Logger) (*Client, error) {
 	var opts []grpc.DialOption
 
 	if cfg.EnableTLS {
-		var creds credentials.TransportCredentials
-		if cfg.CertFile != "" {
-			// Загружаем сертификат из файла
-			tlsConfig := &tls.Config{
-				ServerName: "localhost",
-			}
-			creds = credentials.NewTLS(tlsConfig)
-		} else {
-			// Используем системные сертификаты
-			creds = credentials.NewTLS(&tls.Config{})
+		tlsOpts := clientTLSOptions(cfg)
+		tlsOpts.Address = cfg.GRPCAddress
+		tlsOpts.ServerName = cfg.ServerName
+		tlsConfig, err := tlsutil.NewClientTLSConfig(tlsOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %w", err)
 		}
-		opts = append(opts, grpc.WithTransportCredentials(creds))
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
@@ -64,6 +94,17 @@ func NewClient(cfg *config.ClientConfig, logger *zap.Logger) (*Client, error) {
 	}, nil
 }
 
+// clientTLSOptions возвращает параметры TLS из конфигурации клиента без адреса
+// сервера: CA, пины SPKI и клиентский сертификат для mTLS.
+func clientTLSOptions(cfg *config.ClientConfig) tlsutil.ClientOptions {
+	return tlsutil.ClientOptions{
+		CAFile:     cfg.CertFile,
+		PinnedSPKI: strings.Split(cfg.PinnedSPKI, ","),
+		CertFile:   cfg.ClientCert,
+		KeyFile:    cfg.ClientKey,
+	}
+}
+
 // Close закрывает соединение с сервером.
 func (c *Client) Close() error {
 	if c.conn != nil {
@@ -72,11 +113,23 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// Register регистрирует нового пользователя.
+// Register регистрирует нового пользователя. Пароль на сервер не передается:
+// клиент вычисляет соль и верификатор SRP-6a.
 func (c *Client) Register(ctx context.Context, username, password string) error {
+	salt, verifier, err := auth.NewSRPVerifier(username, password)
+	if err != nil {
+		return fmt.Errorf("registration failed: %w", err)
+	}
+	vaultKey, wrappedVaultKey, err := newVaultKey(password)
+	if err != nil {
+		return fmt.Errorf("registration failed: %w", err)
+	}
+
 	req := &pb.RegisterRequest{
-		Username: username,
-		Password: password,
+		Username:        username,
+		WrappedVaultKey: wrappedVaultKey,
+		SrpSalt:         salt,
+		SrpVerifier:     verifier,
 	}
 
 	resp, err := c.grpcClient.Register(ctx, req)
@@ -84,9 +137,10 @@ func (c *Client) Register(ctx context.Context, username, password string) error
 		return fmt.Errorf("registration failed: %w", err)
 	}
 
-	// Сохраняем токен
-	c.token = resp.Token
-	c.expiresAt = resp.ExpiresAt.AsTime()
+	c.setAuth(resp)
+	c.usesSRP = true
+	c.wrappedVaultKey = wrappedVaultKey
+	c.vaultKey = vaultKey
 
 	c.logger.Info("Successfully registered and logged in",
 		zap.String("username", username))
@@ -94,33 +148,388 @@ func (c *Client) Register(ctx context.Context, username, password string) error
 	return nil
 }
 
-// Login выполняет аутентификацию пользователя.
+// Login выполняет аутентификацию пользователя по протоколу SRP-6a.
+// Сервер не сообщает, какие аккаунты еще не перешли на SRP, поэтому после
+// неудачного рукопожатия клиент пробует вход по паролю: аккаунты, созданные
+// до перехода на SRP, входят так один раз и сразу переводятся на SRP.
 func (c *Client) Login(ctx context.Context, username, password string) error {
-	req := &pb.LoginRequest{
-		Username: username,
-		Password: password,
+	resp, err := c.srpLogin(ctx, username, password)
+	if status.Code(err) == codes.Unauthenticated {
+		if err := c.legacyLogin(ctx, username, password); err != nil {
+			return err
+		}
+		c.unlockVault(ctx, password)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("login failed: %w", err)
 	}
 
-	resp, err := c.grpcClient.Login(ctx, req)
+	c.setAuth(resp)
+	c.usesSRP = true
+	c.unlockVault(ctx, password)
+
+	c.logger.Info("Successfully logged in",
+		zap.String("username", username))
+
+	return nil
+}
+
+// srpLogin выполняет рукопожатие SRP-6a и проверяет, что сервер знает верификатор.
+func (c *Client) srpLogin(ctx context.Context, username, password string) (*pb.AuthResponse, error) {
+	srpClient, err := auth.NewSRPClient(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	beginResp, err := c.grpcClient.BeginSRPLogin(ctx, &pb.BeginSRPLoginRequest{
+		Username:        username,
+		ClientPublicKey: srpClient.PublicKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := srpClient.ProcessChallenge(beginResp.Salt, beginResp.ServerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	finishResp, err := c.grpcClient.FinishSRPLogin(ctx, &pb.FinishSRPLoginRequest{
+		LoginId:     beginResp.LoginId,
+		ClientProof: proof,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srpClient.VerifyServer(finishResp.ServerProof); err != nil {
+		return nil, fmt.Errorf("server authentication failed: %w", err)
+	}
+
+	return finishResp.Auth, nil
+}
+
+// legacyLogin входит по паролю и переводит аккаунт на SRP, если сервер
+// сообщил об этом после проверки пароля.
+func (c *Client) legacyLogin(ctx context.Context, username, password string) error {
+	resp, err := c.grpcClient.Login(ctx, &pb.LoginRequest{
+		Username: username,
+		Password: password,
+	})
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
 
-	// Сохраняем токен
-	c.token = resp.Token
-	c.expiresAt = resp.ExpiresAt.AsTime()
+	c.setAuth(resp)
 
 	c.logger.Info("Successfully logged in",
 		zap.String("username", username))
 
+	if !resp.SrpUpgradeRequired {
+		return nil
+	}
+
+	// Ошибка перехода не мешает работе: попытка повторится при следующем входе
+	salt, verifier, err := auth.NewSRPVerifier(username, password)
+	if err == nil {
+		resp, err = c.grpcClient.ChangePassword(c.addAuthToContext(ctx), &pb.ChangePasswordRequest{
+			OldPassword: password,
+			SrpSalt:     salt,
+			SrpVerifier: verifier,
+		})
+	}
+	if err != nil {
+		c.logger.Warn("Failed to upgrade account to SRP login", zap.Error(err))
+		return nil
+	}
+
+	c.setAuth(resp)
+	c.usesSRP = true
+
+	c.logger.Info("Account upgraded to SRP login")
+	return nil
+}
+
+// newVaultKey создает ключ хранилища и шифрует его ключом из пароля.
+func newVaultKey(password string) ([]byte, []byte, error) {
+	vaultKey, err := crypto.GenerateAESKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	wrappedVaultKey, err := crypto.EncryptWithPassword(vaultKey, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap vault key: %w", err)
+	}
+	return vaultKey, wrappedVaultKey, nil
+}
+
+// unlockVault расшифровывает ключ хранилища паролем после входа. Аккаунту,
+// созданному без ключа хранилища, ключ создается и сохраняется на сервере.
+// Ошибка не мешает входу: без ключа хранилища недоступны только вложения.
+func (c *Client) unlockVault(ctx context.Context, password string) {
+	c.vaultKey = nil
+	if len(c.wrappedVaultKey) > 0 {
+		vaultKey, err := crypto.DecryptWithPassword(c.wrappedVaultKey, password)
+		if err != nil {
+			c.logger.Warn("Failed to unlock vault key", zap.Error(err))
+			return
+		}
+		c.vaultKey = vaultKey
+		return
+	}
+
+	vaultKey, wrappedVaultKey, err := newVaultKey(password)
+	if err == nil {
+		_, err = c.grpcClient.SetVaultKey(c.addAuthToContext(ctx), &pb.SetVaultKeyRequest{
+			WrappedVaultKey: wrappedVaultKey,
+		})
+	}
+	if err != nil {
+		c.logger.Warn("Failed to create vault key", zap.Error(err))
+		return
+	}
+	c.wrappedVaultKey = wrappedVaultKey
+	c.vaultKey = vaultKey
+}
+
+// srpConfirmation подтверждает пароль аккаунта с SRP для необратимой операции:
+// начинает рукопожатие и возвращает его идентификатор и доказательство клиента,
+// которые сервер проверяет вместе с запросом. Пароль на сервер не передается.
+func (c *Client) srpConfirmation(ctx context.Context, password string) (string, []byte, error) {
+	srpClient, err := auth.NewSRPClient(c.username, password)
+	if err != nil {
+		return "", nil, fmt.Errorf("password confirmation failed: %w", err)
+	}
+
+	beginResp, err := c.grpcClient.BeginSRPLogin(ctx, &pb.BeginSRPLoginRequest{
+		Username:        c.username,
+		ClientPublicKey: srpClient.PublicKey(),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("password confirmation failed: %w", err)
+	}
+
+	proof, err := srpClient.ProcessChallenge(beginResp.Salt, beginResp.ServerPublicKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("password confirmation failed: %w", err)
+	}
+
+	return beginResp.LoginId, proof, nil
+}
+
+// ChangePassword меняет пароль пользователя. Все остальные сессии пользователя
+// отзываются сервером. wrappedVaultKey - ключ хранилища, перешифрованный новым
+// паролем; если он пуст, клиент перешифровывает расшифрованный при входе ключ,
+// а без него ключ на сервере не меняется.
+func (c *Client) ChangePassword(ctx context.Context, oldPassword, newPassword string, wrappedVaultKey []byte) error {
+	if !c.IsAuthenticated() {
+		return fmt.Errorf("not authenticated")
+	}
+	if len(wrappedVaultKey) == 0 && c.vaultKey != nil {
+		var err error
+		if wrappedVaultKey, err = crypto.EncryptWithPassword(c.vaultKey, newPassword); err != nil {
+			return fmt.Errorf("password change failed: %w", err)
+		}
+	}
+
+	req := &pb.ChangePasswordRequest{
+		OldPassword:     oldPassword,
+		NewPassword:     newPassword,
+		WrappedVaultKey: wrappedVaultKey,
+	}
+
+	if c.usesSRP {
+		loginID, proof, err := c.srpConfirmation(ctx, oldPassword)
+		if err != nil {
+			return err
+		}
+
+		salt, verifier, err := auth.NewSRPVerifier(c.username, newPassword)
+		if err != nil {
+			return fmt.Errorf("password change failed: %w", err)
+		}
+		req = &pb.ChangePasswordRequest{
+			WrappedVaultKey: wrappedVaultKey,
+			SrpSalt:         salt,
+			SrpVerifier:     verifier,
+			SrpLoginId:      loginID,
+			SrpClientProof:  proof,
+		}
+	}
+	ctx = c.addAuthToContext(ctx)
+
+	resp, err := c.grpcClient.ChangePassword(ctx, req)
+	if err != nil {
+		return fmt.Errorf("password change failed: %w", err)
+	}
+
+	c.setAuth(resp)
+
+	c.logger.Info("Password changed successfully")
+	return nil
+}
+
+// DeleteAccount удаляет аккаунт пользователя со всеми данными.
+// После успешного удаления клиент сбрасывает токен.
+func (c *Client) DeleteAccount(ctx context.Context, password string) error {
+	if !c.IsAuthenticated() {
+		return fmt.Errorf("not authenticated")
+	}
+
+	req := &pb.DeleteAccountRequest{Password: password}
+	if c.usesSRP {
+		loginID, proof, err := c.srpConfirmation(ctx, password)
+		if err != nil {
+			return err
+		}
+		req = &pb.DeleteAccountRequest{SrpLoginId: loginID, SrpClientProof: proof}
+	}
+	ctx = c.addAuthToContext(ctx)
+
+	if _, err := c.grpcClient.DeleteAccount(ctx, req); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	c.token = ""
+	c.expiresAt = time.Time{}
+	c.wrappedVaultKey = nil
+	c.vaultKey = nil
+
+	c.logger.Info("Account deleted")
 	return nil
 }
 
+// ExportAccount получает зашифрованный архив со всеми данными аккаунта.
+// Архив можно открыть функцией OpenAccountExport с тем же паролем. Для аккаунтов
+// с SRP сервер не знает пароль, поэтому архив шифруется на клиенте.
+func (c *Client) ExportAccount(ctx context.Context, password string) ([]byte, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	req := &pb.ExportAccountRequest{Password: password}
+	if c.usesSRP {
+		loginID, proof, err := c.srpConfirmation(ctx, password)
+		if err != nil {
+			return nil, err
+		}
+		req = &pb.ExportAccountRequest{SrpLoginId: loginID, SrpClientProof: proof}
+	}
+	ctx = c.addAuthToContext(ctx)
+
+	resp, err := c.grpcClient.ExportAccount(ctx, req, grpc.MaxCallRecvMsgSize(maxExportSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export account: %w", err)
+	}
+
+	if len(resp.Archive) > 0 {
+		return resp.Archive, nil
+	}
+
+	archive, err := crypto.EncryptWithPassword(resp.Export, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt export: %w", err)
+	}
+	return archive, nil
+}
+
+// OpenAccountExport расшифровывает архив, полученный через ExportAccount.
+func OpenAccountExport(archive []byte, password string) (*models.AccountExport, error) {
+	data, err := crypto.DecryptWithPassword(archive, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt export: %w", err)
+	}
+
+	var export models.AccountExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+
+	return &export, nil
+}
+
+// WrappedVaultKey возвращает ключ хранилища, полученный при аутентификации.
+func (c *Client) WrappedVaultKey() []byte {
+	return c.wrappedVaultKey
+}
+
+// VaultKey возвращает ключ хранилища, расшифрованный при входе, или nil,
+// если клиент вошел без пароля или ключ не удалось расшифровать.
+func (c *Client) VaultKey() []byte {
+	return c.vaultKey
+}
+
+// WrappedPrivateKey возвращает закрытый ключ для общих записей, полученный при аутентификации.
+func (c *Client) WrappedPrivateKey() []byte {
+	return c.wrappedPrivateKey
+}
+
 // IsAuthenticated проверяет, аутентифицирован ли пользователь.
+// Срок действия персонального токена доступа проверяет сервер.
 func (c *Client) IsAuthenticated() bool {
+	if auth.IsAccessToken(c.token) {
+		return true
+	}
 	return c.token != "" && time.Now().Before(c.expiresAt)
 }
 
+// UseAccessToken настраивает клиент на работу с персональным токеном доступа
+// вместо входа по паролю, например в скриптах и CI.
+func (c *Client) UseAccessToken(token string) error {
+	if !auth.IsAccessToken(token) {
+		return fmt.Errorf("invalid access token format")
+	}
+	c.token = token
+	c.expiresAt = time.Time{}
+	return nil
+}
+
+// CreateAccessToken выпускает персональный токен доступа.
+// Значение токена возвращается только один раз.
+func (c *Client) CreateAccessToken(ctx context.Context, req *pb.CreateAccessTokenRequest) (*pb.CreateAccessTokenResponse, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.CreateAccessToken(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListAccessTokens получает список персональных токенов доступа.
+func (c *Client) ListAccessTokens(ctx context.Context) ([]*pb.AccessToken, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.ListAccessTokens(ctx, &pb.ListAccessTokensRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access tokens: %w", err)
+	}
+
+	return resp.AccessTokens, nil
+}
+
+// RevokeAccessToken отзывает персональный токен доступа.
+func (c *Client) RevokeAccessToken(ctx context.Context, id string) error {
+	if !c.IsAuthenticated() {
+		return fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	if _, err := c.grpcClient.RevokeAccessToken(ctx, &pb.RevokeAccessTokenRequest{Id: id}); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
 // RefreshToken обновляет токен аутентификации.
 func (c *Client) RefreshToken(ctx context.Context) error {
 	if c.token == "" {
@@ -136,8 +545,7 @@ func (c *Client) RefreshToken(ctx context.Context) error {
 		return fmt.Errorf("token refresh failed: %w", err)
 	}
 
-	c.token = resp.Token
-	c.expiresAt = resp.ExpiresAt.AsTime()
+	c.setAuth(resp)
 
 	c.logger.Debug("Token refreshed successfully")
 	return nil
@@ -194,6 +602,26 @@ func (c *Client) ListData(ctx context.Context, dataType *pb.DataType) ([]*pb.Dat
 	return resp.DataEntries, nil
 }
 
+// ListDueForRotation получает записи, пароли которых пора сменить по политике
+// записи или коллекции, включая те, срок смены которых наступит в течение withinDays дней.
+func (c *Client) ListDueForRotation(ctx context.Context, withinDays int) ([]*pb.DataEntry, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	req := &pb.ListDueForRotationRequest{
+		WithinDays: int32(withinDays),
+	}
+	ctx = c.addAuthToContext(ctx)
+
+	resp, err := c.grpcClient.ListDueForRotation(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries due for rotation: %w", err)
+	}
+
+	return resp.DataEntries, nil
+}
+
 // UpdateData обновляет запись данных.
 func (c *Client) UpdateData(ctx context.Context, req *pb.UpdateDataRequest) (*pb.DataEntry, error) {
 	if !c.IsAuthenticated() {
@@ -245,6 +673,401 @@ func (c *Client) SyncData(ctx context.Context, lastSyncTime time.Time) (*pb.Sync
 	return resp, nil
 }
 
+// PublishSharingKey публикует открытый ключ для получения общих записей.
+// wrappedPrivateKey - закрытый ключ, зашифрованный ключом хранилища.
+func (c *Client) PublishSharingKey(ctx context.Context, publicKey, wrappedPrivateKey []byte) error {
+	if !c.IsAuthenticated() {
+		return fmt.Errorf("not authenticated")
+	}
+
+	req := &pb.SetPublicKeyRequest{
+		PublicKey:         publicKey,
+		WrappedPrivateKey: wrappedPrivateKey,
+	}
+	ctx = c.addAuthToContext(ctx)
+
+	if _, err := c.grpcClient.SetPublicKey(ctx, req); err != nil {
+		return fmt.Errorf("failed to publish public key: %w", err)
+	}
+
+	c.wrappedPrivateKey = wrappedPrivateKey
+	return nil
+}
+
+// ShareEntry предоставляет пользователю доступ к записи. Ключ записи
+// шифруется открытым ключом получателя и на сервер в открытом виде не передается.
+func (c *Client) ShareEntry(ctx context.Context, entryID, recipient string, entryKey []byte, permission pb.SharePermission) (*pb.EntryShare, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	keyResp, err := c.grpcClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: recipient})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient public key: %w", err)
+	}
+
+	wrappedKey, err := crypto.WrapKeyForRecipient(entryKey, keyResp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap entry key: %w", err)
+	}
+
+	resp, err := c.grpcClient.ShareEntry(ctx, &pb.ShareEntryRequest{
+		EntryId:           entryID,
+		RecipientUsername: recipient,
+		WrappedKey:        wrappedKey,
+		Permission:        permission,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to share entry: %w", err)
+	}
+
+	return resp.Share, nil
+}
+
+// ListEntryShares получает список пользователей, которым предоставлен доступ к записи.
+func (c *Client) ListEntryShares(ctx context.Context, entryID string) ([]*pb.EntryShare, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.ListEntryShares(ctx, &pb.ListEntrySharesRequest{EntryId: entryID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entry shares: %w", err)
+	}
+
+	return resp.Shares, nil
+}
+
+// RevokeShare отзывает доступ к записи или отказывается от общей записи.
+func (c *Client) RevokeShare(ctx context.Context, shareID string) error {
+	if !c.IsAuthenticated() {
+		return fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	if _, err := c.grpcClient.RevokeShare(ctx, &pb.RevokeShareRequest{Id: shareID}); err != nil {
+		return fmt.Errorf("failed to revoke share: %w", err)
+	}
+
+	return nil
+}
+
+// ListSharedWithMe получает записи других пользователей, доступ к которым предоставлен.
+func (c *Client) ListSharedWithMe(ctx context.Context) ([]*pb.DataEntry, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.ListSharedWithMe(ctx, &pb.ListSharedWithMeRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shared entries: %w", err)
+	}
+
+	return resp.DataEntries, nil
+}
+
+// OpenSharedEntryKey расшифровывает ключ общей записи закрытым ключом получателя.
+func OpenSharedEntryKey(entry *pb.DataEntry, privateKey []byte) ([]byte, error) {
+	if entry.Share == nil {
+		return nil, fmt.Errorf("entry is not shared")
+	}
+	return crypto.UnwrapSharedKey(entry.Share.WrappedKey, privateKey)
+}
+
+// AddAttachment шифрует файл новым ключом вложения и прикрепляет его к записи.
+// Ключ вложения шифруется ключом entryKey, который есть только у клиентов:
+// ключом хранилища (VaultKey) для собственных записей или ключом общей записи.
+// Тип содержимого на сервер не передается.
+func (c *Client) AddAttachment(ctx context.Context, entryID, name string, content, entryKey []byte) (*pb.Attachment, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+	if len(entryKey) == 0 {
+		return nil, ErrNoAttachmentKey
+	}
+
+	key, err := crypto.GenerateAESKey()
+	if err != nil {
+		return nil, err
+	}
+	encryptedData, err := crypto.EncryptAES(content, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt attachment: %w", err)
+	}
+	if len(encryptedData) > models.MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment is larger than %d MB", models.MaxAttachmentSize>>20)
+	}
+
+	wrappedKey, err := crypto.EncryptAES(key, entryKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap attachment key: %w", err)
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.AddAttachment(ctx, &pb.AddAttachmentRequest{
+		EntryId:       entryID,
+		Name:          name,
+		WrappedKey:    wrappedKey,
+		EncryptedData: encryptedData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	return resp.Attachment, nil
+}
+
+// ListAttachments получает вложения записи без содержимого.
+func (c *Client) ListAttachments(ctx context.Context, entryID string) ([]*pb.Attachment, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.ListAttachments(ctx, &pb.ListAttachmentsRequest{EntryId: entryID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+
+	return resp.Attachments, nil
+}
+
+// GetAttachment загружает вложение записи и расшифровывает его содержимое.
+// entryKey - ключ записи, которым зашифрован ключ вложения, как в AddAttachment.
+func (c *Client) GetAttachment(ctx context.Context, entryID, attachmentID string, entryKey []byte) (*pb.Attachment, []byte, error) {
+	if !c.IsAuthenticated() {
+		return nil, nil, fmt.Errorf("not authenticated")
+	}
+	if len(entryKey) == 0 {
+		return nil, nil, ErrNoAttachmentKey
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.GetAttachment(ctx, &pb.GetAttachmentRequest{EntryId: entryID, Id: attachmentID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	key, err := crypto.DecryptAES(resp.Attachment.WrappedKey, entryKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unwrap attachment key: %w", err)
+	}
+	content, err := crypto.DecryptAES(resp.EncryptedData, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt attachment: %w", err)
+	}
+
+	return resp.Attachment, content, nil
+}
+
+// RemoveAttachment удаляет вложение записи.
+func (c *Client) RemoveAttachment(ctx context.Context, entryID, attachmentID string) error {
+	if !c.IsAuthenticated() {
+		return fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	if _, err := c.grpcClient.RemoveAttachment(ctx, &pb.RemoveAttachmentRequest{EntryId: entryID, Id: attachmentID}); err != nil {
+		return fmt.Errorf("failed to remove attachment: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOrganization создает организацию, текущий пользователь становится ее владельцем.
+func (c *Client) CreateOrganization(ctx context.Context, name string) (*pb.Organization, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.CreateOrganization(ctx, &pb.CreateOrganizationRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return resp.Organization, nil
+}
+
+// ListOrganizations получает организации, в которых состоит пользователь.
+func (c *Client) ListOrganizations(ctx context.Context) ([]*pb.Organization, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.ListOrganizations(ctx, &pb.ListOrganizationsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return resp.Organizations, nil
+}
+
+// RotateCollectionKey сменяет ключ коллекции после исключения участника.
+// Новый ключ шифруется для каждого оставшегося участника, а все записи
+// коллекции перешифровываются на клиенте и отправляются одним запросом.
+func (c *Client) RotateCollectionKey(ctx context.Context, collection *pb.Collection, oldKey, newKey []byte) (*pb.Collection, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	membersResp, err := c.grpcClient.ListOrganizationMembers(ctx, &pb.ListOrganizationMembersRequest{
+		OrganizationId: collection.OrganizationId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	keys := make([]*pb.CollectionKey, 0, len(membersResp.Members))
+	for _, member := range membersResp.Members {
+		keyResp, err := c.grpcClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: member.Username})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key of %s: %w", member.Username, err)
+		}
+		wrappedKey, err := crypto.WrapKeyForRecipient(newKey, keyResp.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap collection key: %w", err)
+		}
+		keys = append(keys, &pb.CollectionKey{UserId: member.UserId, WrappedKey: wrappedKey})
+	}
+
+	entriesResp, err := c.grpcClient.ListCollectionEntries(ctx, &pb.ListCollectionEntriesRequest{
+		CollectionId: collection.Id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection entries: %w", err)
+	}
+
+	entries := make([]*pb.CollectionEntryUpdate, 0, len(entriesResp.DataEntries))
+	for _, entry := range entriesResp.DataEntries {
+		plaintext, err := crypto.DecryptAES(entry.EncryptedData, oldKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt entry %s: %w", entry.Id, err)
+		}
+		ciphertext, err := crypto.EncryptAES(plaintext, newKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt entry %s: %w", entry.Id, err)
+		}
+		entries = append(entries, &pb.CollectionEntryUpdate{
+			Id:            entry.Id,
+			EncryptedData: ciphertext,
+			Version:       entry.Version,
+		})
+	}
+
+	resp, err := c.grpcClient.RotateCollectionKey(ctx, &pb.RotateCollectionKeyRequest{
+		CollectionId: collection.Id,
+		KeyVersion:   collection.KeyVersion + 1,
+		Keys:         keys,
+		Entries:      entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate collection key: %w", err)
+	}
+
+	return resp.Collection, nil
+}
+
+// CreateSecretLink шифрует секрет новым ключом и создает одноразовую ссылку.
+// Ключ помещается во фрагмент адреса, который браузер не отправляет на сервер.
+func (c *Client) CreateSecretLink(ctx context.Context, secret []byte, maxViews int32, expiresAt time.Time) (string, error) {
+	if !c.IsAuthenticated() {
+		return "", fmt.Errorf("not authenticated")
+	}
+
+	key, err := crypto.GenerateAESKey()
+	if err != nil {
+		return "", err
+	}
+	encryptedData, err := crypto.EncryptAES(secret, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	req := &pb.CreateSecretLinkRequest{
+		EncryptedData: encryptedData,
+		MaxViews:      maxViews,
+	}
+	if !expiresAt.IsZero() {
+		req.ExpiresAt = timestamppb.New(expiresAt)
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.CreateSecretLink(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create secret link: %w", err)
+	}
+
+	scheme := "http"
+	if c.config.EnableTLS {
+		scheme = "https"
+	}
+	link := url.URL{
+		Scheme:   scheme,
+		Host:     c.config.ServerAddress,
+		Path:     resp.Link.Path,
+		Fragment: base64.RawURLEncoding.EncodeToString(key),
+	}
+
+	return link.String(), nil
+}
+
+// AddEmergencyContact назначает контакт для экстренного доступа. Ключ хранилища
+// шифруется открытым ключом контакта на клиенте.
+func (c *Client) AddEmergencyContact(ctx context.Context, username string, waitDays int32, vaultKey []byte) (*pb.EmergencyAccess, error) {
+	if !c.IsAuthenticated() {
+		return nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	keyResp, err := c.grpcClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Username: username})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact public key: %w", err)
+	}
+
+	wrappedKey, err := crypto.WrapKeyForRecipient(vaultKey, keyResp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap vault key: %w", err)
+	}
+
+	resp, err := c.grpcClient.AddEmergencyContact(ctx, &pb.AddEmergencyContactRequest{
+		Username:   username,
+		WaitDays:   waitDays,
+		WrappedKey: wrappedKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add emergency contact: %w", err)
+	}
+
+	return resp.EmergencyAccess, nil
+}
+
+// OpenEmergencyVault получает хранилище владельца по предоставленному экстренному
+// доступу и расшифровывает его ключ закрытым ключом контакта.
+func (c *Client) OpenEmergencyVault(ctx context.Context, accessID string, privateKey []byte) ([]byte, []*pb.DataEntry, error) {
+	if !c.IsAuthenticated() {
+		return nil, nil, fmt.Errorf("not authenticated")
+	}
+
+	ctx = c.addAuthToContext(ctx)
+	resp, err := c.grpcClient.GetEmergencyVault(ctx, &pb.GetEmergencyVaultRequest{Id: accessID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get emergency vault: %w", err)
+	}
+
+	vaultKey, err := crypto.UnwrapSharedKey(resp.WrappedKey, privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unwrap vault key: %w", err)
+	}
+
+	return vaultKey, resp.DataEntries, nil
+}
+
 // GenerateOTP генерирует OTP код.
 func (c *Client) GenerateOTP(ctx context.Context, secret string) (*pb.GenerateOTPResponse, error) {
 	req := &pb.GenerateOTPRequest{Secret: secret}
@@ -257,6 +1080,194 @@ func (c *Client) GenerateOTP(ctx context.Context, secret string) (*pb.GenerateOT
 	return resp, nil
 }
 
+// ParseOTPData разбирает расшифрованное содержимое записи типа OTP.
+func ParseOTPData(payload []byte) (*models.OTPData, error) {
+	var data models.OTPData
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse OTP entry: %w", err)
+	}
+	if data.Secret == "" {
+		return nil, fmt.Errorf("OTP entry has no secret")
+	}
+
+	return &data, nil
+}
+
+// otpKeyFromData преобразует содержимое записи OTP в ключ пакета otp.
+func otpKeyFromData(data *models.OTPData) *otp.Key {
+	keyType := data.Type
+	if keyType == "" {
+		keyType = otp.TypeTOTP
+	}
+
+	return &otp.Key{
+		Type:        keyType,
+		Secret:      data.Secret,
+		Issuer:      data.Issuer,
+		AccountName: data.AccountName,
+		Params: otp.Params{
+			Algorithm: data.Algorithm,
+			Digits:    data.Digits,
+			Period:    data.Period,
+		},
+		Counter: data.Counter,
+	}
+}
+
+// OTPDataFromURI разбирает otpauth://totp или otpauth://hotp URI в содержимое записи OTP.
+func OTPDataFromURI(uri string) (*models.OTPData, error) {
+	key, err := otp.ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OTPData{
+		Type:        key.Type,
+		Secret:      key.Secret,
+		Issuer:      key.Issuer,
+		AccountName: key.AccountName,
+		Algorithm:   key.Algorithm,
+		Digits:      key.Digits,
+		Period:      key.Period,
+		Counter:     key.Counter,
+	}, nil
+}
+
+// OTPDataURI формирует otpauth:// URI для переноса записи OTP в другое приложение.
+func OTPDataURI(data *models.OTPData) (string, error) {
+	return otpKeyFromData(data).URI()
+}
+
+// DecodeOTPQRCodeFile распознает QR код на изображении (PNG, JPEG или GIF),
+// например на снимке экрана настройки двухфакторной аутентификации,
+// и разбирает содержащийся в нем otpauth URI.
+func DecodeOTPQRCodeFile(path string) (*models.OTPData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	uri, err := otp.DecodeQRImage(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return OTPDataFromURI(uri)
+}
+
+// ImportOTPEntry сохраняет секрет одноразовых паролей как запись типа OTP.
+// Если имя не задано, используется издатель или имя аккаунта.
+func (c *Client) ImportOTPEntry(ctx context.Context, data *models.OTPData, name string) (*pb.DataEntry, error) {
+	if name == "" {
+		name = data.Issuer
+	}
+	if name == "" {
+		name = data.AccountName
+	}
+	if name == "" {
+		return nil, fmt.Errorf("entry name is required")
+	}
+
+	stamped := *data
+	stamped.SchemaVersion = models.PayloadSchemaVersion
+	payload, err := json.Marshal(stamped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OTP entry: %w", err)
+	}
+
+	return c.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_OTP,
+		Name:          name,
+		Description:   data.AccountName,
+		EncryptedData: payload,
+	})
+}
+
+// GenerateEntryOTP вычисляет TOTP код из расшифрованного содержимого записи OTP
+// без обращения к серверу. Возвращает код и количество секунд до его смены.
+// Коды HOTP вычисляются через NextHOTPCode, так как требуют сохранения счетчика.
+func GenerateEntryOTP(payload []byte, now time.Time) (string, int, error) {
+	data, err := ParseOTPData(payload)
+	if err != nil {
+		return "", 0, err
+	}
+
+	key := otpKeyFromData(data)
+	if key.Type != otp.TypeTOTP {
+		return "", 0, fmt.Errorf("OTP entry of type %s has no time-based code", key.Type)
+	}
+
+	code, err := otp.GenerateCodeAt(key.Secret, key.Params, now)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return code, key.Params.TimeRemaining(now), nil
+}
+
+// NextHOTPCode вычисляет HOTP код записи для текущего значения счетчика
+// и сохраняет увеличенный счетчик. Код возвращается только после успешного
+// сохранения, поэтому один и тот же код не будет выдан дважды. Запись
+// обновляется с проверкой версии, чтобы параллельные клиенты не затерли счетчик.
+// entryKey - ключ шифрования содержимого записи, nil для открытого содержимого.
+func (c *Client) NextHOTPCode(ctx context.Context, entry *pb.DataEntry, entryKey []byte) (string, *pb.DataEntry, error) {
+	payload := entry.EncryptedData
+	if entryKey != nil {
+		var err error
+		if payload, err = crypto.DecryptAES(entry.EncryptedData, entryKey); err != nil {
+			return "", nil, fmt.Errorf("failed to decrypt OTP entry: %w", err)
+		}
+	}
+
+	data, err := ParseOTPData(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	if data.Type != otp.TypeHOTP {
+		return "", nil, fmt.Errorf("OTP entry is not counter-based")
+	}
+	// Поля более новой версии формата потерялись бы при перезаписи счетчика
+	if !data.SupportsSchema() {
+		return "", nil, fmt.Errorf("OTP entry uses payload schema version %d, update the client", data.SchemaVersion)
+	}
+
+	code, err := otp.GenerateHOTP(data.Secret, otpKeyFromData(data).Params, data.Counter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data.Counter++
+	payload, err = json.Marshal(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode OTP entry: %w", err)
+	}
+	if entryKey != nil {
+		if payload, err = crypto.EncryptAES(payload, entryKey); err != nil {
+			return "", nil, fmt.Errorf("failed to encrypt OTP entry: %w", err)
+		}
+	}
+
+	updated, err := c.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id:            entry.Id,
+		Name:          entry.Name,
+		Description:   entry.Description,
+		EncryptedData: payload,
+		Metadata:      entry.Metadata,
+		Version:       entry.Version,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to save HOTP counter: %w", err)
+	}
+
+	return code, updated, nil
+}
+
 // CreateOTPSecret создает новый OTP секрет.
 func (c *Client) CreateOTPSecret(ctx context.Context, issuer, accountName string) (*pb.CreateOTPSecretResponse, error) {
 	req := &pb.CreateOTPSecretRequest{
@@ -272,6 +1283,21 @@ func (c *Client) CreateOTPSecret(ctx context.Context, issuer, accountName string
 	return resp, nil
 }
 
+// setAuth сохраняет данные из ответа аутентификации.
+func (c *Client) setAuth(resp *pb.AuthResponse) {
+	c.token = resp.Token
+	c.expiresAt = resp.ExpiresAt.AsTime()
+	if resp.User != nil {
+		c.username = resp.User.Username
+	}
+	if len(resp.WrappedVaultKey) > 0 {
+		c.wrappedVaultKey = resp.WrappedVaultKey
+	}
+	if len(resp.WrappedPrivateKey) > 0 {
+		c.wrappedPrivateKey = resp.WrappedPrivateKey
+	}
+}
+
 // addAuthToContext добавляет токен аутентификации в контекст.
 func (c *Client) addAuthToContext(ctx context.Context) context.Context {
 	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"github.com/GophKeeper/internal/passgen"
+)
+
+// GeneratorMode определяет, что создает генератор паролей.
+type GeneratorMode int
+
+const (
+	GeneratorRandom        GeneratorMode = iota // случайный пароль из выбранных классов символов
+	GeneratorPronounceable                      // произносимый пароль из чередующихся слогов
+	GeneratorPassphrase                         // парольная фраза из списка слов EFF
+)
+
+// String возвращает название режима генератора для интерфейса.
+func (m GeneratorMode) String() string {
+	switch m {
+	case GeneratorPronounceable:
+		return "произносимый"
+	case GeneratorPassphrase:
+		return "парольная фраза"
+	default:
+		return "случайный"
+	}
+}
+
+// Next возвращает следующий режим генератора по кругу.
+func (m GeneratorMode) Next() GeneratorMode {
+	return (m + 1) % (GeneratorPassphrase + 1)
+}
+
+// GeneratorOptions содержит параметры генератора паролей и парольных фраз.
+type GeneratorOptions struct {
+	Mode       GeneratorMode
+	Password   passgen.Options           // для GeneratorRandom и GeneratorPronounceable
+	Passphrase passgen.PassphraseOptions // для GeneratorPassphrase
+}
+
+// DefaultGeneratorOptions возвращает параметры генератора по умолчанию для режима.
+func DefaultGeneratorOptions(mode GeneratorMode) GeneratorOptions {
+	opts := GeneratorOptions{
+		Mode:       mode,
+		Password:   passgen.DefaultOptions(),
+		Passphrase: passgen.DefaultPassphraseOptions(),
+	}
+	if mode == GeneratorPronounceable {
+		opts.Password.Length = 16
+		opts.Password.Pronounceable = true
+	}
+	return opts
+}
+
+// GeneratePassword создает пароль или парольную фразу локально, без обращения к серверу.
+func GeneratePassword(opts GeneratorOptions) (string, error) {
+	if opts.Mode == GeneratorPassphrase {
+		return passgen.Passphrase(opts.Passphrase)
+	}
+	opts.Password.Pronounceable = opts.Mode == GeneratorPronounceable
+	return passgen.Generate(opts.Password)
+}
@@ -0,0 +1,300 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/payload"
+	"github.com/GophKeeper/internal/strength"
+	pb "github.com/GophKeeper/proto/gen/proto"
+)
+
+// passwordMaxAge — возраст пароля, после которого его стоит сменить.
+const passwordMaxAge = 365 * 24 * time.Hour
+
+// HealthIssueKind определяет вид проблемы в отчете о здоровье хранилища.
+type HealthIssueKind int
+
+const (
+	HealthBreached     HealthIssueKind = iota // пароль найден в базе утечек
+	HealthWeak                                // слабый пароль
+	HealthReused                              // пароль используется в нескольких записях
+	HealthOld                                 // пароль давно не менялся
+	HealthMissing2FA                          // для сайта нет записи OTP
+	HealthExpiringCard                        // срок действия карты истек или скоро истечет
+)
+
+// HealthIssueKinds перечисляет виды проблем в порядке вывода.
+var HealthIssueKinds = []HealthIssueKind{HealthBreached, HealthWeak, HealthReused, HealthOld, HealthMissing2FA, HealthExpiringCard}
+
+// String возвращает название вида проблемы для интерфейса.
+func (k HealthIssueKind) String() string {
+	switch k {
+	case HealthBreached:
+		return "Скомпрометированные пароли"
+	case HealthWeak:
+		return "Слабые пароли"
+	case HealthReused:
+		return "Повторяющиеся пароли"
+	case HealthOld:
+		return "Старые пароли"
+	case HealthMissing2FA:
+		return "Без двухфакторной аутентификации"
+	case HealthExpiringCard:
+		return "Истекающие карты"
+	default:
+		return "Неизвестная проблема"
+	}
+}
+
+// HealthIssue описывает проблему конкретной записи.
+type HealthIssue struct {
+	Entry  *pb.DataEntry
+	Kind   HealthIssueKind
+	Detail string
+}
+
+// HealthReport содержит результаты проверки хранилища. Отчет строится
+// локально по расшифрованным записям, пароли на сервер не передаются.
+type HealthReport struct {
+	Issues    map[HealthIssueKind][]HealthIssue
+	Passwords int // число проверенных паролей
+	Healthy   int // число паролей без проблем
+}
+
+// Count возвращает число проблем вида kind.
+func (r *HealthReport) Count(kind HealthIssueKind) int {
+	return len(r.Issues[kind])
+}
+
+// Total возвращает общее число проблем.
+func (r *HealthReport) Total() int {
+	total := 0
+	for _, issues := range r.Issues {
+		total += len(issues)
+	}
+	return total
+}
+
+// vaultPassword — пароль записи с данными, которые атакующий может знать о владельце.
+type vaultPassword struct {
+	entry    *pb.DataEntry
+	password string
+	inputs   []string
+	site     string // домен сайта для поиска записи OTP
+}
+
+// vaultPasswords извлекает пароли учетных данных и сетей Wi-Fi из расшифрованных записей.
+func vaultPasswords(entries []*pb.DataEntry) []vaultPassword {
+	var passwords []vaultPassword
+	for _, entry := range entries {
+		switch entry.Type {
+		case pb.DataType_DATA_TYPE_CREDENTIALS:
+			var creds models.Credentials
+			if err := json.Unmarshal(entry.EncryptedData, &creds); err != nil || creds.Password == "" {
+				continue
+			}
+			site := siteDomain(creds.URL)
+			passwords = append(passwords, vaultPassword{
+				entry:    entry,
+				password: creds.Password,
+				inputs:   []string{entry.Name, creds.Login, site},
+				site:     site,
+			})
+		case pb.DataType_DATA_TYPE_WIFI:
+			var wifi models.WiFiData
+			if err := json.Unmarshal(entry.EncryptedData, &wifi); err != nil || wifi.Password == "" {
+				continue
+			}
+			passwords = append(passwords, vaultPassword{
+				entry:    entry,
+				password: wifi.Password,
+				inputs:   []string{entry.Name, wifi.SSID},
+			})
+		}
+	}
+	return passwords
+}
+
+// BuildHealthReport проверяет расшифрованные записи: стойкость и повторное
+// использование паролей учетных данных и сетей Wi-Fi, их возраст и сроки смены
+// по политикам, наличие записи OTP для сайтов и срок действия карт. breached
+// содержит результаты проверки по базе утечек (ID записи -> число появлений
+// пароля), nil — проверка не выполнялась.
+func BuildHealthReport(entries []*pb.DataEntry, breached map[string]int, now time.Time) *HealthReport {
+	report := &HealthReport{Issues: make(map[HealthIssueKind][]HealthIssue)}
+	add := func(entry *pb.DataEntry, kind HealthIssueKind, detail string) {
+		report.Issues[kind] = append(report.Issues[kind], HealthIssue{Entry: entry, Kind: kind, Detail: detail})
+	}
+
+	passwords := vaultPasswords(entries)
+	var otpEntries []*pb.DataEntry
+	for _, entry := range entries {
+		switch entry.Type {
+		case pb.DataType_DATA_TYPE_OTP:
+			otpEntries = append(otpEntries, entry)
+		case pb.DataType_DATA_TYPE_CARD:
+			var card models.CardData
+			if err := json.Unmarshal(entry.EncryptedData, &card); err != nil || card.ExpiryDate == "" {
+				continue
+			}
+			if detail, ok := cardExpiryIssue(card.ExpiryDate, now); ok {
+				add(entry, HealthExpiringCard, detail)
+			}
+		}
+	}
+
+	// Записи с одинаковыми паролями
+	reuse := make(map[string][]*pb.DataEntry)
+	for _, p := range passwords {
+		reuse[p.password] = append(reuse[p.password], p.entry)
+	}
+
+	otpNames := otpSiteNames(otpEntries)
+	report.Passwords = len(passwords)
+	for _, p := range passwords {
+		issues := report.Total()
+
+		if count := breached[p.entry.Id]; count > 0 {
+			add(p.entry, HealthBreached, fmt.Sprintf("найден в утечках %d раз", count))
+		}
+
+		if result := strength.Estimate(p.password, p.inputs...); result.Weak() {
+			detail := fmt.Sprintf("%s, подбор: %s", strength.ScoreLabel(result.Score), result.CrackTimeDisplay())
+			if result.Warning != "" {
+				detail += ". " + result.Warning
+			}
+			add(p.entry, HealthWeak, detail)
+		}
+
+		if same := reuse[p.password]; len(same) > 1 {
+			add(p.entry, HealthReused, "также в: "+otherEntryNames(same, p.entry))
+		}
+
+		if detail, ok := passwordAgeIssue(p.entry, now); ok {
+			add(p.entry, HealthOld, detail)
+		}
+
+		if p.site != "" && !hasOTPFor(otpNames, p.site) {
+			add(p.entry, HealthMissing2FA, "нет записи OTP для "+p.site)
+		}
+
+		if report.Total() == issues {
+			report.Healthy++
+		}
+	}
+
+	for _, kind := range HealthIssueKinds {
+		issues := report.Issues[kind]
+		sort.SliceStable(issues, func(i, j int) bool {
+			return strings.ToLower(issues[i].Entry.Name) < strings.ToLower(issues[j].Entry.Name)
+		})
+	}
+	return report
+}
+
+// passwordAgeIssue сообщает, что пароль пора сменить: по политике записи или
+// коллекции, а без политики — если он не менялся дольше passwordMaxAge.
+// Для записей, сохраненных до учета времени смены пароля, берется время изменения.
+func passwordAgeIssue(entry *pb.DataEntry, now time.Time) (string, bool) {
+	if due := entry.GetRotationDueAt(); due != nil {
+		if due.AsTime().After(now) {
+			return "", false
+		}
+		days := int(now.Sub(due.AsTime()).Hours() / 24)
+		return fmt.Sprintf("смена по политике просрочена на %d дн.", days), true
+	}
+
+	changed := entry.GetPasswordChangedAt()
+	if changed == nil {
+		changed = entry.GetUpdatedAt()
+	}
+	if changed == nil || now.Sub(changed.AsTime()) <= passwordMaxAge {
+		return "", false
+	}
+	days := int(now.Sub(changed.AsTime()).Hours() / 24)
+	return fmt.Sprintf("не менялся %d дн.", days), true
+}
+
+// cardExpiryIssue сообщает, истек ли срок действия карты или истекает ли он
+// в ближайшие expiryWarningDays дней.
+func cardExpiryIssue(expiry string, now time.Time) (string, bool) {
+	expiresAt, err := payload.ParseCardExpiry(expiry)
+	if err != nil {
+		return "", false
+	}
+	days := int(expiresAt.Sub(now).Hours() / 24)
+	switch {
+	case !expiresAt.After(now):
+		return fmt.Sprintf("срок действия %s истек", expiry), true
+	case days <= expiryWarningDays:
+		return fmt.Sprintf("срок действия %s истекает через %d дн.", expiry, days), true
+	default:
+		return "", false
+	}
+}
+
+// siteDomain возвращает домен сайта без поддомена www: "accounts.google.com"
+// превращается в "google.com". Пустая строка означает, что адрес не указан.
+func siteDomain(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return ""
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	labels := strings.Split(strings.ToLower(u.Hostname()), ".")
+	if len(labels) > 2 {
+		labels = labels[len(labels)-2:]
+	}
+	return strings.Join(labels, ".")
+}
+
+// otpSiteNames возвращает названия сервисов записей OTP в нижнем регистре:
+// издателя и название записи.
+func otpSiteNames(entries []*pb.DataEntry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.ToLower(entry.Name))
+		if data, err := ParseOTPData(entry.EncryptedData); err == nil && data.Issuer != "" {
+			names = append(names, strings.ToLower(data.Issuer))
+		}
+	}
+	return names
+}
+
+// hasOTPFor проверяет, есть ли запись OTP для домена: название сервиса должно
+// содержать имя домена без зоны ("github" для "github.com").
+func hasOTPFor(otpNames []string, domain string) bool {
+	name, _, _ := strings.Cut(domain, ".")
+	if name == "" {
+		return false
+	}
+	for _, otpName := range otpNames {
+		if strings.Contains(otpName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// otherEntryNames перечисляет названия записей, кроме entry.
+func otherEntryNames(entries []*pb.DataEntry, entry *pb.DataEntry) string {
+	names := make([]string, 0, len(entries)-1)
+	for _, other := range entries {
+		if other != entry {
+			names = append(names, other.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
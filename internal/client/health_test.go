@@ -0,0 +1,84 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// healthEntries возвращает записи с проблемами всех видов на момент now
+func healthEntries(now time.Time) []*pb.DataEntry {
+	fresh := timestamppb.New(now.Add(-24 * time.Hour))
+	return []*pb.DataEntry{
+		{Id: "1", Name: "GitHub", Type: pb.DataType_DATA_TYPE_CREDENTIALS, UpdatedAt: fresh,
+			EncryptedData: []byte(`{"login":"alice","password":"correct-horse-battery-staple","url":"https://github.com/login"}`)},
+		{Id: "2", Name: "Почта", Type: pb.DataType_DATA_TYPE_CREDENTIALS, UpdatedAt: fresh,
+			EncryptedData: []byte(`{"login":"alice","password":"P@ssw0rd","url":"https://mail.example.com"}`)},
+		{Id: "3", Name: "Форум", Type: pb.DataType_DATA_TYPE_CREDENTIALS,
+			UpdatedAt:     timestamppb.New(now.AddDate(-2, 0, 0)),
+			EncryptedData: []byte(`{"login":"alice","password":"P@ssw0rd"}`)},
+		{Id: "4", Name: "Дом", Type: pb.DataType_DATA_TYPE_WIFI, UpdatedAt: fresh,
+			EncryptedData: []byte(`{"ssid":"HomeNet","password":"HomeNet2024"}`)},
+		{Id: "5", Name: "GitHub 2FA", Type: pb.DataType_DATA_TYPE_OTP,
+			EncryptedData: []byte(`{"secret":"JBSWY3DPEHPK3PXP","issuer":"GitHub"}`)},
+		{Id: "6", Name: "Зарплатная", Type: pb.DataType_DATA_TYPE_CARD,
+			EncryptedData: []byte(`{"number":"4111111111111111","expiry_date":"` + now.Format("01/06") + `","holder":"ALICE","cvv":"123"}`)},
+		{Id: "7", Name: "Старая карта", Type: pb.DataType_DATA_TYPE_CARD,
+			EncryptedData: []byte(`{"number":"4111111111111111","expiry_date":"01/20","holder":"ALICE","cvv":"123"}`)},
+		{Id: "8", Name: "Новая карта", Type: pb.DataType_DATA_TYPE_CARD,
+			EncryptedData: []byte(`{"number":"4111111111111111","expiry_date":"` + now.AddDate(3, 0, 0).Format("01/06") + `","holder":"ALICE","cvv":"123"}`)},
+	}
+}
+
+// issueNames возвращает названия записей с проблемой вида kind
+func issueNames(report *HealthReport, kind HealthIssueKind) []string {
+	var names []string
+	for _, issue := range report.Issues[kind] {
+		names = append(names, issue.Entry.Name)
+	}
+	return names
+}
+
+func TestBuildHealthReport(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	report := BuildHealthReport(healthEntries(now), nil, now)
+
+	assert.Equal(t, 4, report.Passwords)
+	assert.Equal(t, 1, report.Healthy)
+
+	assert.Equal(t, []string{"Дом", "Почта", "Форум"}, issueNames(report, HealthWeak))
+	assert.Equal(t, []string{"Почта", "Форум"}, issueNames(report, HealthReused))
+	assert.Contains(t, report.Issues[HealthReused][0].Detail, "Форум")
+	assert.Equal(t, []string{"Форум"}, issueNames(report, HealthOld))
+	assert.Equal(t, []string{"Почта"}, issueNames(report, HealthMissing2FA))
+	assert.Contains(t, report.Issues[HealthMissing2FA][0].Detail, "example.com")
+	assert.Equal(t, []string{"Зарплатная", "Старая карта"}, issueNames(report, HealthExpiringCard))
+	assert.Contains(t, report.Issues[HealthExpiringCard][1].Detail, "истек")
+
+	assert.Equal(t, 9, report.Total())
+}
+
+func TestBuildHealthReport_Empty(t *testing.T) {
+	report := BuildHealthReport(nil, nil, time.Now())
+	require.NotNil(t, report)
+	assert.Zero(t, report.Total())
+	assert.Zero(t, report.Passwords)
+}
+
+func TestSiteDomain(t *testing.T) {
+	assert.Equal(t, "google.com", siteDomain("https://accounts.google.com/signin"))
+	assert.Equal(t, "github.com", siteDomain("www.github.com"))
+	assert.Equal(t, "", siteDomain(""))
+}
+
+func TestPasswordStrengthHint(t *testing.T) {
+	assert.Empty(t, passwordStrengthHint(""))
+	assert.Contains(t, passwordStrengthHint("123456"), "очень слабый")
+	assert.Contains(t, passwordStrengthHint("correct-horse-battery-staple"), "очень надежный")
+	// Пароль из названия записи слабее, чем без него
+	assert.Contains(t, passwordStrengthHint("gophkeeper2024", "GophKeeper"), "слабый")
+}
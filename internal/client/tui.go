@@ -8,6 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GophKeeper/internal/breach"
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/otp"
 	pb "github.com/GophKeeper/proto/gen/proto"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -30,6 +33,8 @@ const (
 	stateCreate
 	stateView
 	stateOTP
+	stateOTPBoard
+	stateHealth
 )
 
 // TUIModel представляет модель для TUI интерфейса.
@@ -51,6 +56,14 @@ type TUIModel struct {
 	message       string
 	selectedEntry *listItem     // выбранная запись для просмотра
 	viewingEntry  *pb.DataEntry // полная информация о просматриваемой записи
+	hotpCode      string        // последний выданный код HOTP просматриваемой записи
+	showQRCode    bool          // показывать QR код записи OTP для переноса в аутентификатор
+
+	// Состояние вложений просматриваемой записи
+	attachments       []*pb.Attachment
+	attachmentCursor  int
+	attachmentPicker  filePicker
+	pickingAttachment bool
 
 	// OTP состояние
 	otpAccountInput textinput.Model
@@ -60,6 +73,25 @@ type TUIModel struct {
 	otpBackupCodes  []string
 	otpMessage      string
 
+	// Состояние доски кодов OTP
+	otpBoardItems      []otpBoardItem
+	otpBoardFilter     textinput.Model
+	otpBoardCursor     int
+	otpBoardMessage    string
+	otpBoardGeneration int // номер открытия доски, устаревшие обновления отбрасываются
+
+	// Состояние экрана здоровья хранилища
+	healthReport      *HealthReport
+	healthCursor      int  // выбранный вид проблем
+	healthDrill       bool // показываются записи выбранного вида проблем
+	healthIssueCursor int
+	viewFromHealth    bool // запись открыта с экрана здоровья, Esc возвращает на него
+
+	// Проверка паролей по базе утечек, nil — проверка отключена
+	breachChecker *breach.Checker
+	breached      map[string]int // ID записи -> число появлений пароля в утечках
+	breachError   string
+
 	// Состояние создания записи
 	createNameInput        textinput.Model
 	createDescriptionInput textinput.Model
@@ -67,11 +99,15 @@ type TUIModel struct {
 	createTypeInput        textinput.Model
 	createMetadataInput    textinput.Model
 	createDataType         pb.DataType
+	createFocus            int           // индекс поля формы в фокусе
+	form                   entryForm     // поля типизированных форм
+	editingEntry           *pb.DataEntry // запись, открытая в форме для изменения
 
 	// Состояние синхронизации
 	lastSyncTime time.Time
 	syncMessage  string
 	entriesCount int // Количество записей
+	rotationDue  int // Количество записей, пароли которых пора сменить
 
 	// Состояние загрузки
 	isLoading      bool
@@ -99,8 +135,15 @@ type ClientInterface interface {
 	DeleteData(ctx context.Context, id string) error
 	SyncData(ctx context.Context, lastSyncTime time.Time) (*pb.SyncDataResponse, error)
 	CreateData(ctx context.Context, req *pb.CreateDataRequest) (*pb.DataEntry, error)
+	UpdateData(ctx context.Context, req *pb.UpdateDataRequest) (*pb.DataEntry, error)
 	CreateOTPSecret(ctx context.Context, issuer, accountName string) (*pb.CreateOTPSecretResponse, error)
 	GenerateOTP(ctx context.Context, secret string) (*pb.GenerateOTPResponse, error)
+	NextHOTPCode(ctx context.Context, entry *pb.DataEntry, entryKey []byte) (string, *pb.DataEntry, error)
+	AddAttachment(ctx context.Context, entryID, name string, content, entryKey []byte) (*pb.Attachment, error)
+	ListAttachments(ctx context.Context, entryID string) ([]*pb.Attachment, error)
+	GetAttachment(ctx context.Context, entryID, attachmentID string, entryKey []byte) (*pb.Attachment, []byte, error)
+	RemoveAttachment(ctx context.Context, entryID, attachmentID string) error
+	VaultKey() []byte
 	Close() error
 }
 
@@ -136,6 +179,11 @@ func NewTUIModel(client ClientInterface, logger *zap.Logger) *TUIModel {
 	otpAccountInput.CharLimit = 50
 	otpAccountInput.Width = 30
 
+	otpBoardFilter := textinput.New()
+	otpBoardFilter.Placeholder = "Название или аккаунт"
+	otpBoardFilter.CharLimit = 50
+	otpBoardFilter.Width = 30
+
 	// Создаем поля для создания записи
 	createNameInput := textinput.New()
 	createNameInput.Placeholder = "Название записи"
@@ -148,12 +196,12 @@ func NewTUIModel(client ClientInterface, logger *zap.Logger) *TUIModel {
 	createDescriptionInput.Width = 40
 
 	createDataInput := textinput.New()
-	createDataInput.Placeholder = "Данные (пароль, текст, и т.д.)"
+	createDataInput.Placeholder = "Секрет base32 или otpauth:// URI"
 	createDataInput.CharLimit = 500
 	createDataInput.Width = 40
 
 	createTypeInput := textinput.New()
-	createTypeInput.Placeholder = "Тип данных (1-credentials, 2-text, 3-binary, 4-card)"
+	createTypeInput.Placeholder = "Тип данных (1-10)"
 	createTypeInput.CharLimit = 10
 	createTypeInput.Width = 40
 
@@ -170,12 +218,14 @@ func NewTUIModel(client ClientInterface, logger *zap.Logger) *TUIModel {
 		passwordInput:          passwordInput,
 		list:                   l,
 		otpAccountInput:        otpAccountInput,
+		otpBoardFilter:         otpBoardFilter,
 		createNameInput:        createNameInput,
 		createDescriptionInput: createDescriptionInput,
 		createDataInput:        createDataInput,
 		createTypeInput:        createTypeInput,
 		createMetadataInput:    createMetadataInput,
 		createDataType:         pb.DataType_DATA_TYPE_CREDENTIALS, // По умолчанию
+		form:                   newEntryForm(),
 	}
 }
 
@@ -212,10 +262,33 @@ func (m *TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateView(msg)
 		case stateOTP:
 			return m.updateOTP(msg)
+		case stateOTPBoard:
+			return m.updateOTPBoard(msg)
+		case stateHealth:
+			return m.updateHealth(msg)
 		case stateCreate:
 			return m.updateCreate(msg)
 		}
 
+	case otpBoardMsg:
+		m.otpBoardItems = msg.items
+		return m, nil
+
+	case healthReportMsg:
+		if msg.breach != nil {
+			m.setBreached(*msg.breach)
+		}
+		m.setHealthReport(msg.report)
+		return m, nil
+
+	case otpBoardTickMsg:
+		// Обновление кодов прекращается при уходе с доски, а обновления
+		// от прошлых открытий доски отбрасываются
+		if m.state == stateOTPBoard && msg.generation == m.otpBoardGeneration {
+			return m, m.otpBoardTick()
+		}
+		return m, nil
+
 	case loginSuccessMsg:
 		m.currentUser = msg.username
 		m.state = stateMain
@@ -246,10 +319,11 @@ func (m *TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		now := time.Now()
 		items := make([]list.Item, len(pbEntries))
 		for i, entry := range pbEntries {
 			items[i] = &listItem{
-				title:       entry.Name,
+				title:       m.breachedTitle(entry.Id, entryStatusBadges(entry, now)+entry.Name),
 				description: entry.Description,
 				id:          entry.Id,
 			}
@@ -258,13 +332,20 @@ func (m *TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Обновляем счетчик синхронизации
 		m.entriesCount = len(pbEntries)
+		m.rotationDue = countRotationDue(pbEntries, now)
 		if len(pbEntries) > 0 {
 			m.syncMessage = fmt.Sprintf("Загружено %d записей", len(pbEntries))
 		} else {
 			m.syncMessage = "Записей не найдено"
 		}
+		return m, m.checkBreaches(pbEntries)
+	case breachCheckMsg:
+		m.setBreached(msg)
 		return m, nil
 	case entryDeletedMsg:
+		if m.viewFromHealth {
+			return m, tea.Batch(m.syncData(), m.returnToHealth())
+		}
 		m.state = stateList
 		// Выполняем синхронизацию после удаления
 		return m, tea.Batch(m.syncData(), m.loadDataList())
@@ -307,19 +388,48 @@ func (m *TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.loadDataEntry(msg.entry.id)
 	case dataEntryLoadedMsg:
 		m.viewingEntry = msg.entry
+		m.hotpCode = ""
+		m.showQRCode = false
+		m.attachments = nil
+		m.attachmentCursor = 0
+		m.pickingAttachment = false
+		if msg.entry.CollectionId != "" {
+			return m, nil
+		}
+		return m, m.loadAttachments(msg.entry.Id)
+	case attachmentsMsg:
+		m.setAttachments(msg)
+		return m, nil
+	case attachmentAddedMsg:
+		m.message = fmt.Sprintf("Вложение '%s' добавлено", msg.attachment.Name)
+		return m, m.loadAttachments(msg.attachment.EntryId)
+	case attachmentSavedMsg:
+		m.message = "Вложение сохранено: " + msg.path
+		return m, nil
+	case attachmentRemovedMsg:
+		m.message = fmt.Sprintf("Вложение '%s' удалено", msg.name)
+		if m.viewingEntry != nil {
+			return m, m.loadAttachments(m.viewingEntry.Id)
+		}
+		return m, nil
+	case hotpCodeMsg:
+		m.viewingEntry = msg.entry
+		m.hotpCode = msg.code
 		return m, nil
 	case dataCreatedMsg:
 		m.state = stateMain
 		m.message = fmt.Sprintf("Запись '%s' успешно создана", msg.entry.Name)
 		// Очищаем поля ввода
-		m.createNameInput.SetValue("")
-		m.createDescriptionInput.SetValue("")
-		m.createDataInput.SetValue("")
-		m.createTypeInput.SetValue("")
-		m.createMetadataInput.SetValue("")
+		m.resetCreateForm()
 		// Сначала загружаем список, потом синхронизируемся
 		return m, m.loadDataList()
 
+	case dataUpdatedMsg:
+		return m, m.handleDataUpdated(msg.entry)
+	case versionConflictMsg:
+		m.handleVersionConflict(msg.latest)
+		return m, nil
+
 	case tickMsg:
 		// Автоматическая синхронизация каждые 5 секунд
 		if m.currentUser != "" {
@@ -346,6 +456,10 @@ func (m *TUIModel) View() string {
 		return m.viewView()
 	case stateOTP:
 		return m.viewOTP()
+	case stateOTPBoard:
+		return m.viewOTPBoard()
+	case stateHealth:
+		return m.viewHealth()
 	case stateCreate:
 		return m.viewCreate()
 	default:
@@ -453,13 +567,18 @@ func (m *TUIModel) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(m.syncData(), m.loadDataList())
 		case "2":
 			m.state = stateCreate
+			m.message = ""
 			// Устанавливаем фокус на первое поле
-			m.createNameInput.Focus()
+			m.focusCreateField(0)
 			return m, nil
 		case "3":
 			m.state = stateOTP
 			m.otpAccountInput.Focus()
 			return m, nil
+		case "4":
+			return m, m.openOTPBoard()
+		case "5":
+			return m, m.openHealth()
 		case "s":
 			// Ручная синхронизация данных
 			return m, m.syncData()
@@ -485,7 +604,9 @@ func (m *TUIModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			selectedItem := m.list.SelectedItem()
 			if item, ok := selectedItem.(*listItem); ok {
 				m.selectedEntry = item
+				m.viewFromHealth = false
 				m.state = stateView
+				m.message = ""
 				return m, m.loadDataEntry(item.id)
 			}
 		}
@@ -504,71 +625,115 @@ func (m *TUIModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateView обновляет состояние просмотра записи.
 func (m *TUIModel) updateView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.pickingAttachment {
+		return m.updateAttachmentPicker(msg)
+	}
+	if m.viewingEntry != nil {
+		if cmd, ok := m.updateAttachments(msg); ok {
+			return m, cmd
+		}
+	}
+
 	switch msg.Type {
 	case tea.KeyCtrlC, tea.KeyEsc:
+		if m.viewFromHealth {
+			return m, m.returnToHealth()
+		}
 		m.state = stateList
 		return m, nil
 	case tea.KeyDelete, tea.KeyBackspace:
 		if m.selectedEntry != nil {
 			return m, m.deleteEntry(m.selectedEntry.id)
 		}
+	case tea.KeyCtrlN:
+		if m.viewingEntry != nil && m.viewingEntry.Type == pb.DataType_DATA_TYPE_OTP {
+			return m, m.nextHOTPCode(m.viewingEntry)
+		}
+	case tea.KeyCtrlQ:
+		if m.viewingEntry != nil && m.viewingEntry.Type == pb.DataType_DATA_TYPE_OTP {
+			m.showQRCode = !m.showQRCode
+		}
+	case tea.KeyCtrlE:
+		if m.viewingEntry != nil {
+			return m, m.startEdit(m.viewingEntry)
+		}
 	}
 	return m, nil
 }
 
 // updateCreate обновляет состояние создания записи.
 func (m *TUIModel) updateCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+	if m.form.pickingFile {
+		return m.updateFilePicker(msg)
+	}
 
-	switch msg.Type {
-	case tea.KeyCtrlC, tea.KeyEsc:
+	// В многострочном тексте Enter и стрелки редактируют текст
+	field := m.focusedCreateField()
+	inText := m.createTextArea(field) != nil
+
+	switch {
+	case msg.Type == tea.KeyCtrlC || msg.Type == tea.KeyEsc:
+		if m.editingEntry != nil {
+			m.cancelEdit()
+			return m, nil
+		}
 		m.state = stateMain
 		// Сбрасываем фокус
-		m.createNameInput.Blur()
-		m.createDescriptionInput.Blur()
-		m.createDataInput.Blur()
-		m.createTypeInput.Blur()
-		m.createMetadataInput.Blur()
+		m.blurCreateFields()
 		return m, nil
 
-	case tea.KeyTab, tea.KeyShiftTab, tea.KeyEnter, tea.KeyUp, tea.KeyDown:
-		// Переключаем фокус между полями
-		if m.createNameInput.Focused() {
-			m.createNameInput.Blur()
-			m.createDescriptionInput.Focus()
-		} else if m.createDescriptionInput.Focused() {
-			m.createDescriptionInput.Blur()
-			m.createDataInput.Focus()
-		} else if m.createDataInput.Focused() {
-			m.createDataInput.Blur()
-			m.createTypeInput.Focus()
-		} else if m.createTypeInput.Focused() {
-			m.createTypeInput.Blur()
-			m.createMetadataInput.Focus()
-		} else {
-			m.createMetadataInput.Blur()
-			m.createNameInput.Focus()
-		}
+	case msg.Type == tea.KeyEnter && field == fieldFile:
+		m.form.picker = newFilePicker()
+		m.form.pickingFile = true
+		return m, nil
 
-	case tea.KeyCtrlS:
+	case msg.Type == tea.KeyTab || (!inText && (msg.Type == tea.KeyEnter || msg.Type == tea.KeyDown)):
+		m.focusCreateField(m.createFocus + 1)
+		return m, nil
+
+	case msg.Type == tea.KeyShiftTab || (!inText && msg.Type == tea.KeyUp):
+		m.focusCreateField(m.createFocus - 1)
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlR:
+		m.form.setShowSecrets(!m.form.showSecrets)
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlN:
+		m.addCustomFormField()
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlX && field >= fieldCustomBase:
+		m.removeCustomFormField(field)
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlG && field == fieldPassword:
+		m.generateFormPassword()
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlT && field == fieldPassword:
+		m.form.generatorMode = m.form.generatorMode.Next()
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlT && field >= fieldCustomBase:
+		index, _ := customFieldIndex(field)
+		m.form.cycleCustomFieldType(index)
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlL && m.editingEntry != nil:
+		m.reloadEditForm()
+		return m, nil
+
+	case msg.Type == tea.KeyCtrlS:
+		if m.editingEntry != nil {
+			return m, m.updateDataEntry()
+		}
 		// Создание записи
 		return m, m.createDataEntry()
 	}
 
 	// Обновляем активное поле ввода
-	if m.createNameInput.Focused() {
-		m.createNameInput, cmd = m.createNameInput.Update(msg)
-	} else if m.createDescriptionInput.Focused() {
-		m.createDescriptionInput, cmd = m.createDescriptionInput.Update(msg)
-	} else if m.createDataInput.Focused() {
-		m.createDataInput, cmd = m.createDataInput.Update(msg)
-	} else if m.createTypeInput.Focused() {
-		m.createTypeInput, cmd = m.createTypeInput.Update(msg)
-	} else if m.createMetadataInput.Focused() {
-		m.createMetadataInput, cmd = m.createMetadataInput.Update(msg)
-	}
-
-	return m, cmd
+	return m, m.updateCreateInput(msg)
 }
 
 // updateOTP обновляет состояние генерации OTP.
@@ -686,12 +851,17 @@ func (m *TUIModel) viewMain() string {
 	} else if m.syncMessage == "Данные актуальны" {
 		b.WriteString("📊 Данные актуальны\n")
 	}
+	if m.rotationDue > 0 {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("%sПора сменить пароли: %d записей", rotationBadge, m.rotationDue)) + "\n")
+	}
 	b.WriteString("\n")
 
 	b.WriteString("Выберите действие:\n\n")
 	b.WriteString("1. 📋 Просмотр данных\n")
 	b.WriteString("2. ➕ Добавить данные\n")
 	b.WriteString("3. 🔑 Генератор OTP\n")
+	b.WriteString("4. ⏱️ Коды OTP\n")
+	b.WriteString("5. 🩺 Здоровье хранилища\n")
 	b.WriteString("s. 🔄 Синхронизировать данные\n")
 	b.WriteString("q. ❌ Выход\n\n")
 
@@ -711,21 +881,41 @@ func (m *TUIModel) viewList() string {
 
 // viewOTP отображает экран генерации OTP.
 func (m *TUIModel) viewView() string {
+	if m.pickingAttachment {
+		return m.viewAttachmentPicker()
+	}
+
 	var b strings.Builder
 	b.WriteString(titleStyle.Render("📋 Просмотр записи"))
 	b.WriteString("\n\n")
 
+	if m.message != "" {
+		b.WriteString(m.message)
+		b.WriteString("\n\n")
+	}
+
 	if m.viewingEntry != nil {
 		b.WriteString("Название: " + m.viewingEntry.Name + "\n")
 		b.WriteString("Описание: " + m.viewingEntry.Description + "\n")
 		b.WriteString("ID: " + m.viewingEntry.Id + "\n")
 		b.WriteString("Тип: " + m.getDataTypeString(m.viewingEntry.Type) + "\n")
-		b.WriteString("Данные: " + string(m.viewingEntry.EncryptedData) + "\n")
+		b.WriteString(m.viewBreachWarning(m.viewingEntry))
+		b.WriteString(m.viewRotationWarning(m.viewingEntry))
+		switch m.viewingEntry.Type {
+		case pb.DataType_DATA_TYPE_OTP:
+			b.WriteString(m.viewOTPEntry(m.viewingEntry.EncryptedData))
+		case pb.DataType_DATA_TYPE_SSH_KEY, pb.DataType_DATA_TYPE_API_KEY, pb.DataType_DATA_TYPE_IDENTITY,
+			pb.DataType_DATA_TYPE_WIFI, pb.DataType_DATA_TYPE_SECURE_NOTE:
+			b.WriteString(m.viewExtendedEntry(m.viewingEntry))
+		default:
+			b.WriteString("Данные: " + string(m.viewingEntry.EncryptedData) + "\n")
+		}
 		if m.viewingEntry.Metadata != "" {
 			b.WriteString("Метаданные: " + m.viewingEntry.Metadata + "\n")
 		}
+		b.WriteString(m.viewAttachments())
 		b.WriteString("\n")
-		b.WriteString(helpStyle.Render("Delete: удалить запись • Esc: назад"))
+		b.WriteString(helpStyle.Render("Ctrl+E: изменить • Delete: удалить запись • Esc: назад"))
 	} else {
 		b.WriteString("Запись не найдена\n")
 		b.WriteString(helpStyle.Render("Esc: назад"))
@@ -734,33 +924,109 @@ func (m *TUIModel) viewView() string {
 	return containerStyle.Render(b.String())
 }
 
+// viewOTPEntry отображает текущий код записи типа OTP, вычисленный локально.
+// Для HOTP код выдается по Ctrl+N, так как каждый код расходует значение счетчика.
+func (m *TUIModel) viewOTPEntry(payload []byte) string {
+	data, err := ParseOTPData(payload)
+	if err != nil {
+		return errorStyle.Render("Ошибка OTP: "+err.Error()) + "\n"
+	}
+
+	var qr string
+	if m.showQRCode {
+		if uri, err := OTPDataURI(data); err != nil {
+			qr = errorStyle.Render("Ошибка QR: "+err.Error()) + "\n"
+		} else if qr, err = renderQRCode(uri); err != nil {
+			qr = errorStyle.Render("Ошибка QR: "+err.Error()) + "\n"
+		} else {
+			qr += "\n"
+		}
+	}
+
+	if data.Type == otp.TypeHOTP {
+		var b strings.Builder
+		b.WriteString(qr)
+		b.WriteString(fmt.Sprintf("Счетчик HOTP: %d\n", data.Counter))
+		if m.hotpCode != "" {
+			b.WriteString("Код HOTP: " + m.hotpCode + "\n")
+		}
+		b.WriteString(helpStyle.Render("Ctrl+N: следующий код HOTP • Ctrl+Q: QR код") + "\n")
+		return b.String()
+	}
+
+	code, remaining, err := GenerateEntryOTP(payload, time.Now())
+	if err != nil {
+		return errorStyle.Render("Ошибка OTP: "+err.Error()) + "\n"
+	}
+	return qr + fmt.Sprintf("Текущий OTP: %s (осталось %d сек)\n", code, remaining) +
+		helpStyle.Render("Ctrl+Q: QR код") + "\n"
+}
+
+// renderQRCode рисует QR код символами полублоков: каждая строка терминала
+// содержит два ряда модулей. Цвета заданы явно, чтобы код читался сканерами
+// и в светлых, и в темных темах терминала.
+func renderQRCode(content string) (string, error) {
+	matrix, err := otp.QRCodeMatrix(content)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, (len(matrix)+1)/2)
+	for y := 0; y < len(matrix); y += 2 {
+		var line strings.Builder
+		for x := range matrix[y] {
+			top := !matrix[y][x]
+			bottom := y+1 < len(matrix) && !matrix[y+1][x]
+			switch {
+			case top && bottom:
+				line.WriteString("█")
+			case top:
+				line.WriteString("▀")
+			case bottom:
+				line.WriteString("▄")
+			default:
+				line.WriteString(" ")
+			}
+		}
+		lines = append(lines, qrCodeStyle.Render(line.String()))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// viewCreate отображает форму создания записи для выбранного типа данных.
 func (m *TUIModel) viewCreate() string {
 	var b strings.Builder
-	b.WriteString(titleStyle.Render("➕ Добавление записи"))
-	b.WriteString("\n\n")
-
-	// Показываем поля ввода
-	b.WriteString("Название записи:\n")
-	b.WriteString(m.createNameInput.View())
-	b.WriteString("\n\n")
+	if m.form.pickingFile {
+		b.WriteString(titleStyle.Render("📂 Выбор файла"))
+		b.WriteString("\n\n")
+		b.WriteString(m.form.picker.view())
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("↑/↓: выбор • Enter: открыть • Backspace: наверх • Esc: отмена"))
+		return containerStyle.Render(b.String())
+	}
 
-	b.WriteString("Описание (необязательно):\n")
-	b.WriteString(m.createDescriptionInput.View())
+	b.WriteString(titleStyle.Render(m.editTitle()))
 	b.WriteString("\n\n")
 
-	b.WriteString("Данные (пароль, текст, и т.д.):\n")
-	b.WriteString(m.createDataInput.View())
-	b.WriteString("\n\n")
+	if m.message != "" {
+		b.WriteString(errorStyle.Render(m.message))
+		b.WriteString("\n\n")
+	}
 
-	b.WriteString("Тип данных (1-credentials, 2-text, 3-binary, 4-card):\n")
-	b.WriteString(m.createTypeInput.View())
-	b.WriteString("\n\n")
+	// Тип сохраненной записи не меняется
+	if m.editingEntry != nil {
+		b.WriteString(fmt.Sprintf("Тип данных: %s (версия %d)\n\n", m.getDataTypeString(m.editingEntry.Type), m.editingEntry.Version))
+	}
 
-	b.WriteString("Метаданные (необязательно):\n")
-	b.WriteString(m.createMetadataInput.View())
-	b.WriteString("\n\n")
+	// Показываем поля формы выбранного типа
+	for _, field := range m.createFields() {
+		b.WriteString(m.createFieldLabel(field) + ":\n")
+		b.WriteString(m.viewCreateField(field))
+		b.WriteString("\n\n")
+	}
 
-	b.WriteString(helpStyle.Render("Tab: переключение полей • Ctrl+S: создать запись • Esc: назад"))
+	b.WriteString(helpStyle.Render(m.editHelp()))
 	return containerStyle.Render(b.String())
 }
 
@@ -773,6 +1039,9 @@ func (m *TUIModel) viewOTP() string {
 	if m.otpSecret != "" {
 		b.WriteString("Секрет: " + m.otpSecret + "\n")
 		b.WriteString("QR: " + m.otpQRCodeURL + "\n")
+		if qr, err := renderQRCode(m.otpQRCodeURL); err == nil {
+			b.WriteString(qr + "\n")
+		}
 		b.WriteString("Резервные коды:\n")
 		for _, code := range m.otpBackupCodes {
 			b.WriteString("  " + code + "\n")
@@ -798,6 +1067,18 @@ func (m *TUIModel) getDataTypeString(dataType pb.DataType) string {
 		return "Бинарные данные"
 	case pb.DataType_DATA_TYPE_CARD:
 		return "Банковская карта"
+	case pb.DataType_DATA_TYPE_OTP:
+		return "Одноразовые пароли"
+	case pb.DataType_DATA_TYPE_SSH_KEY:
+		return "Ключ SSH"
+	case pb.DataType_DATA_TYPE_API_KEY:
+		return "Ключ API"
+	case pb.DataType_DATA_TYPE_IDENTITY:
+		return "Документ"
+	case pb.DataType_DATA_TYPE_WIFI:
+		return "Сеть Wi-Fi"
+	case pb.DataType_DATA_TYPE_SECURE_NOTE:
+		return "Защищенная заметка"
 	default:
 		return "Неизвестный тип"
 	}
@@ -903,25 +1184,21 @@ func (m *TUIModel) createDataEntry() tea.Cmd {
 		if m.createNameInput.Value() == "" {
 			return errorMsg{error: "Название записи обязательно"}
 		}
-		if m.createDataInput.Value() == "" {
-			return errorMsg{error: "Данные записи обязательны"}
-		}
-
-		// Определяем тип данных
-		dataType := m.createDataType
-		if m.createTypeInput.Value() != "" {
-			switch m.createTypeInput.Value() {
-			case "1":
-				dataType = pb.DataType_DATA_TYPE_CREDENTIALS
-			case "2":
-				dataType = pb.DataType_DATA_TYPE_TEXT
-			case "3":
-				dataType = pb.DataType_DATA_TYPE_BINARY
-			case "4":
-				dataType = pb.DataType_DATA_TYPE_CARD
-			default:
-				return errorMsg{error: "Неверный тип данных. Используйте 1-4"}
-			}
+
+		dataType, errText := m.createFormType()
+		if errText != "" {
+			return errorMsg{error: errText}
+		}
+
+		// Собираем содержимое из формы выбранного типа
+		payload, errText := m.createPayload(dataType)
+		if errText != "" {
+			return errorMsg{error: errText}
+		}
+
+		rotationDays, errText := m.formRotationDays(dataType)
+		if errText != "" {
+			return errorMsg{error: errText}
 		}
 
 		// Создаем запрос
@@ -929,8 +1206,9 @@ func (m *TUIModel) createDataEntry() tea.Cmd {
 			Type:          dataType,
 			Name:          m.createNameInput.Value(),
 			Description:   m.createDescriptionInput.Value(),
-			EncryptedData: []byte(m.createDataInput.Value()), // TODO: Зашифровать данные
+			EncryptedData: payload, // TODO: Зашифровать данные
 			Metadata:      m.createMetadataInput.Value(),
+			RotationDays:  rotationDays,
 		}
 
 		// Отправляем запрос
@@ -943,6 +1221,43 @@ func (m *TUIModel) createDataEntry() tea.Cmd {
 	}
 }
 
+// parseOTPInput разбирает поле данных записи OTP: otpauth:// URI со всеми
+// параметрами или секрет base32 с параметрами TOTP по умолчанию.
+func (m *TUIModel) parseOTPInput() (*models.OTPData, string) {
+	value := strings.TrimSpace(m.createDataInput.Value())
+	if strings.HasPrefix(value, "otpauth://") {
+		otpData, err := OTPDataFromURI(value)
+		if err != nil {
+			return nil, fmt.Sprintf("Неверный otpauth URI: %v", err)
+		}
+		if otpData.Issuer == "" {
+			otpData.Issuer = m.createNameInput.Value()
+		}
+		return otpData, ""
+	}
+
+	otpData := &models.OTPData{
+		Secret: otp.NormalizeSecret(value),
+		Issuer: m.createNameInput.Value(),
+	}
+	if _, err := otp.GenerateCodeAt(otpData.Secret, otp.Params{}, time.Now()); err != nil {
+		return nil, "Неверный OTP секрет: ожидается строка base32 или otpauth:// URI"
+	}
+	return otpData, ""
+}
+
+// nextHOTPCode выдает следующий код HOTP и сохраняет увеличенный счетчик.
+func (m *TUIModel) nextHOTPCode(entry *pb.DataEntry) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		code, updated, err := m.client.NextHOTPCode(ctx, entry, nil)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка HOTP: %v", err)}
+		}
+		return hotpCodeMsg{code: code, entry: updated}
+	}
+}
+
 // Сообщения
 type loginSuccessMsg struct{ username string }
 type registerSuccessMsg struct{ username string }
@@ -957,6 +1272,10 @@ type otpSecretMsg struct {
 	backups []string
 }
 type otpCodeMsg struct{ code string }
+type hotpCodeMsg struct {
+	code  string
+	entry *pb.DataEntry
+}
 type syncDataMsg struct {
 	lastSyncTime time.Time
 	message      string
@@ -980,6 +1299,11 @@ var (
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#626262"))
 
+	// Светлые модули рисуются белым, темные - черным фоном
+	qrCodeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#000000"))
+
 	paginationStyle = list.DefaultStyles().PaginationStyle.
 			PaddingLeft(4)
 )
@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Максимальное число попыток подобрать свободное имя при сохранении вложения
+const maxAttachmentFileSuffix = 100
+
+// Сообщения вложений записи
+type attachmentsMsg struct {
+	entryID     string
+	attachments []*pb.Attachment
+}
+type attachmentAddedMsg struct{ attachment *pb.Attachment }
+type attachmentSavedMsg struct{ path string }
+type attachmentRemovedMsg struct{ name string }
+
+// updateAttachments обрабатывает клавиши вложений на экране просмотра записи.
+// Возвращает false, если клавиша не относится к вложениям.
+func (m *TUIModel) updateAttachments(msg tea.KeyMsg) (tea.Cmd, bool) {
+	entry := m.viewingEntry
+	// Записи коллекций вложений не поддерживают
+	if entry.CollectionId != "" {
+		return nil, false
+	}
+	switch msg.Type {
+	case tea.KeyCtrlA:
+		m.attachmentPicker = newFilePicker()
+		m.pickingAttachment = true
+		return nil, true
+	case tea.KeyUp:
+		if m.attachmentCursor > 0 {
+			m.attachmentCursor--
+		}
+		return nil, true
+	case tea.KeyDown:
+		if m.attachmentCursor < len(m.attachments)-1 {
+			m.attachmentCursor++
+		}
+		return nil, true
+	case tea.KeyCtrlD:
+		if attachment := m.selectedAttachment(); attachment != nil {
+			return m.saveAttachment(entry, attachment), true
+		}
+		return nil, true
+	case tea.KeyCtrlX:
+		if attachment := m.selectedAttachment(); attachment != nil {
+			return m.removeAttachment(entry.Id, attachment), true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// updateAttachmentPicker обрабатывает клавиши в окне выбора прикрепляемого файла.
+func (m *TUIModel) updateAttachmentPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC {
+		m.pickingAttachment = false
+		return m, nil
+	}
+
+	path := m.attachmentPicker.update(msg)
+	if path == "" {
+		return m, nil
+	}
+
+	content, err := readAttachmentFile(path)
+	if err != nil {
+		m.attachmentPicker.err = err
+		return m, nil
+	}
+	m.pickingAttachment = false
+	return m, m.addAttachment(m.viewingEntry, filepath.Base(path), content)
+}
+
+// selectedAttachment возвращает вложение под курсором.
+func (m *TUIModel) selectedAttachment() *pb.Attachment {
+	if m.attachmentCursor < 0 || m.attachmentCursor >= len(m.attachments) {
+		return nil
+	}
+	return m.attachments[m.attachmentCursor]
+}
+
+// setAttachments показывает вложения записи, если она все еще открыта.
+func (m *TUIModel) setAttachments(msg attachmentsMsg) {
+	if m.viewingEntry == nil || m.viewingEntry.Id != msg.entryID {
+		return
+	}
+	m.attachments = msg.attachments
+	if m.attachmentCursor >= len(m.attachments) {
+		m.attachmentCursor = len(m.attachments) - 1
+	}
+	if m.attachmentCursor < 0 {
+		m.attachmentCursor = 0
+	}
+}
+
+// viewAttachments отображает список вложений просматриваемой записи.
+func (m *TUIModel) viewAttachments() string {
+	var b strings.Builder
+	b.WriteString("\nВложения:\n")
+	if m.viewingEntry != nil && m.viewingEntry.CollectionId != "" {
+		b.WriteString(helpStyle.Render("  не поддерживаются для записей коллекций") + "\n")
+		return b.String()
+	}
+	if len(m.attachments) == 0 {
+		b.WriteString(helpStyle.Render("  нет вложений") + "\n")
+	}
+	for i, attachment := range m.attachments {
+		cursor := "  "
+		if i == m.attachmentCursor {
+			cursor = "> "
+		}
+		b.WriteString(fmt.Sprintf("%s📎 %s (%d байт)\n", cursor, attachment.Name, attachment.Size))
+	}
+	b.WriteString(helpStyle.Render("Ctrl+A: прикрепить файл • ↑/↓: выбор • Ctrl+D: сохранить на диск • Ctrl+X: удалить вложение") + "\n")
+	return b.String()
+}
+
+// viewAttachmentPicker отображает окно выбора прикрепляемого файла.
+func (m *TUIModel) viewAttachmentPicker() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("📎 Выбор вложения"))
+	b.WriteString("\n\n")
+	b.WriteString(m.attachmentPicker.view())
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓: выбор • Enter: прикрепить • Backspace: наверх • Esc: отмена"))
+	return containerStyle.Render(b.String())
+}
+
+// loadAttachments загружает список вложений записи.
+func (m *TUIModel) loadAttachments(entryID string) tea.Cmd {
+	return func() tea.Msg {
+		attachments, err := m.client.ListAttachments(context.Background(), entryID)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка загрузки вложений: %v", err)}
+		}
+		return attachmentsMsg{entryID: entryID, attachments: attachments}
+	}
+}
+
+// attachmentKey возвращает ключ, которым шифруются ключи вложений записи.
+// TUI хранит только ключ хранилища, поэтому вложения общих записей, ключа
+// которых у него нет, недоступны.
+func (m *TUIModel) attachmentKey(entry *pb.DataEntry) ([]byte, error) {
+	if entry.Share != nil {
+		return nil, errors.New("для вложений общей записи нужен ключ записи")
+	}
+	key := m.client.VaultKey()
+	if key == nil {
+		return nil, errors.New("ключ хранилища недоступен, войдите с паролем заново")
+	}
+	return key, nil
+}
+
+// addAttachment прикрепляет файл к записи.
+func (m *TUIModel) addAttachment(entry *pb.DataEntry, name string, content []byte) tea.Cmd {
+	return func() tea.Msg {
+		key, err := m.attachmentKey(entry)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка добавления вложения: %v", err)}
+		}
+		attachment, err := m.client.AddAttachment(context.Background(), entry.Id, name, content, key)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка добавления вложения: %v", err)}
+		}
+		return attachmentAddedMsg{attachment: attachment}
+	}
+}
+
+// saveAttachment загружает вложение и сохраняет его в текущий каталог.
+func (m *TUIModel) saveAttachment(entry *pb.DataEntry, attachment *pb.Attachment) tea.Cmd {
+	return func() tea.Msg {
+		key, err := m.attachmentKey(entry)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка загрузки вложения: %v", err)}
+		}
+		_, content, err := m.client.GetAttachment(context.Background(), entry.Id, attachment.Id, key)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка загрузки вложения: %v", err)}
+		}
+
+		dir, err := os.Getwd()
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка сохранения вложения: %v", err)}
+		}
+		path, err := saveAttachmentFile(dir, attachment.Name, content)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка сохранения вложения: %v", err)}
+		}
+		return attachmentSavedMsg{path: path}
+	}
+}
+
+// removeAttachment удаляет вложение записи.
+func (m *TUIModel) removeAttachment(entryID string, attachment *pb.Attachment) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.client.RemoveAttachment(context.Background(), entryID, attachment.Id); err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка удаления вложения: %v", err)}
+		}
+		return attachmentRemovedMsg{name: attachment.Name}
+	}
+}
+
+// readAttachmentFile читает прикрепляемый файл, проверяя ограничение размера.
+func readAttachmentFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > models.MaxAttachmentSize {
+		return nil, fmt.Errorf("файл больше %d МБ", models.MaxAttachmentSize>>20)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return nil, errors.New("файл пуст")
+	}
+	return content, nil
+}
+
+// saveAttachmentFile записывает вложение в каталог dir. Имя вложения задано
+// другим клиентом, поэтому из него берется только последний элемент пути;
+// существующие файлы не перезаписываются, к имени добавляется номер.
+func saveAttachmentFile(dir, name string, content []byte) (string, error) {
+	name = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(name, "\\", "/")))
+	if name == "/" || name == "." {
+		name = "attachment"
+	}
+
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 0; i < maxAttachmentFileSuffix; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", stem, i, ext)
+		}
+
+		path := filepath.Join(dir, candidate)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := file.Write(content); err != nil {
+			file.Close()
+			return "", err
+		}
+		return path, file.Close()
+	}
+
+	return "", fmt.Errorf("файл %s уже существует", name)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTUIModel_Attachments(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scan.pdf"), []byte("%PDF-1.4"), 0o600))
+
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+	mockClient := &MockClient{vaultKey: vaultKey}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateView
+
+	entry := &pb.DataEntry{Id: "entry-id", Name: "Паспорт", Type: pb.DataType_DATA_TYPE_TEXT}
+	attachment := &pb.Attachment{Id: "att-id", EntryId: "entry-id", Name: "scan.pdf", Size: 8}
+	mockClient.On("ListAttachments", mock.Anything, "entry-id").Return([]*pb.Attachment{}, nil).Once()
+
+	// Вложения загружаются вместе с записью
+	_, cmd := model.Update(dataEntryLoadedMsg{entry: entry})
+	require.NotNil(t, cmd)
+	model.Update(cmd())
+	assert.Contains(t, model.View(), "нет вложений")
+
+	// Файл выбирается в окне выбора, ключ вложения шифруется ключом хранилища
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlA})
+	require.True(t, model.pickingAttachment)
+	assert.Contains(t, model.View(), "Выбор вложения")
+
+	mockClient.On("AddAttachment", mock.Anything, "entry-id", "scan.pdf", []byte("%PDF-1.4"), vaultKey).
+		Return(attachment, nil)
+	mockClient.On("ListAttachments", mock.Anything, "entry-id").Return([]*pb.Attachment{attachment}, nil)
+
+	_, cmd = model.updateView(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, model.pickingAttachment)
+	_, cmd = model.Update(cmd())
+	assert.Contains(t, model.message, "scan.pdf")
+	model.Update(cmd())
+	assert.Contains(t, model.View(), "📎 scan.pdf (8 байт)")
+
+	// Сохранение не перезаписывает существующий файл
+	mockClient.On("GetAttachment", mock.Anything, "entry-id", "att-id", vaultKey).
+		Return(attachment, []byte("downloaded"), nil)
+	_, cmd = model.updateView(tea.KeyMsg{Type: tea.KeyCtrlD})
+	saved, ok := cmd().(attachmentSavedMsg)
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(dir, "scan (1).pdf"), saved.path)
+	content, err := os.ReadFile(saved.path)
+	require.NoError(t, err)
+	assert.Equal(t, "downloaded", string(content))
+
+	mockClient.On("RemoveAttachment", mock.Anything, "entry-id", "att-id").Return(nil)
+	_, cmd = model.updateView(tea.KeyMsg{Type: tea.KeyCtrlX})
+	_, ok = cmd().(attachmentRemovedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTUIModel_Attachments_RequireClientKey(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+
+	// Без ключа хранилища ключ вложения не отправляется на сервер
+	entry := &pb.DataEntry{Id: "entry-id", Type: pb.DataType_DATA_TYPE_TEXT}
+	msg := model.addAttachment(entry, "scan.pdf", []byte("%PDF-1.4"))()
+	assert.Contains(t, msg.(errorMsg).error, "ключ хранилища недоступен")
+
+	// У общей записи ключ вложений - ключ записи, которого у TUI нет
+	model = NewTUIModel(&MockClient{vaultKey: []byte("0123456789abcdef0123456789abcdef")}, zap.NewNop())
+	shared := &pb.DataEntry{Id: "entry-id", Type: pb.DataType_DATA_TYPE_TEXT, Share: &pb.EntryShare{}}
+	msg = model.saveAttachment(shared, &pb.Attachment{Id: "att-id", Name: "scan.pdf"})()
+	assert.Contains(t, msg.(errorMsg).error, "нужен ключ записи")
+
+	// Записи коллекций вложений не поддерживают, список не загружается
+	model.state = stateView
+	_, cmd := model.Update(dataEntryLoadedMsg{entry: &pb.DataEntry{Id: "team-id", Type: pb.DataType_DATA_TYPE_TEXT, CollectionId: "collection-id"}})
+	assert.Nil(t, cmd)
+	assert.Contains(t, model.View(), "не поддерживаются для записей коллекций")
+}
+
+func TestSaveAttachmentFile_SanitizesName(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := saveAttachmentFile(dir, "../../etc/passwd", []byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "passwd"), path)
+
+	path, err = saveAttachmentFile(dir, `..\..\report.txt`, []byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "report.txt"), path)
+
+	path, err = saveAttachmentFile(dir, "..", []byte("x"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "attachment"), path)
+}
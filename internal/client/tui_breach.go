@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GophKeeper/internal/breach"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// breachedBadge отмечает в списке записи со скомпрометированными паролями.
+const breachedBadge = "⚠️ "
+
+// Сообщение с результатами проверки паролей по базе утечек
+type breachCheckMsg struct {
+	breached map[string]int
+	err      error
+}
+
+// SetBreachChecker включает проверку паролей по базе утечек.
+func (m *TUIModel) SetBreachChecker(checker *breach.Checker) {
+	m.breachChecker = checker
+}
+
+// checkBreaches проверяет пароли загруженных записей, если проверка включена.
+func (m *TUIModel) checkBreaches(entries []*pb.DataEntry) tea.Cmd {
+	if m.breachChecker == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		breached, err := CheckBreachedEntries(context.Background(), m.breachChecker, entries)
+		return breachCheckMsg{breached: breached, err: err}
+	}
+}
+
+// setBreached сохраняет результаты проверки и обновляет отметки в списке.
+// При ошибке прежние результаты сохраняются.
+func (m *TUIModel) setBreached(msg breachCheckMsg) {
+	if msg.err != nil {
+		m.breachError = msg.err.Error()
+		m.logger.Warn("Breached passwords check failed", zap.Error(msg.err))
+		return
+	}
+	m.breached = msg.breached
+	m.breachError = ""
+
+	for _, item := range m.list.Items() {
+		if entry, ok := item.(*listItem); ok {
+			entry.title = m.breachedTitle(entry.id, strings.TrimPrefix(entry.title, breachedBadge))
+		}
+	}
+	m.list.SetItems(m.list.Items())
+}
+
+// breachedTitle добавляет к названию записи отметку, если ее пароль найден в утечках.
+func (m *TUIModel) breachedTitle(id, name string) string {
+	if m.breached[id] > 0 {
+		return breachedBadge + name
+	}
+	return name
+}
+
+// viewBreachWarning предупреждает, что пароль просматриваемой записи найден в утечках.
+func (m *TUIModel) viewBreachWarning(entry *pb.DataEntry) string {
+	count := m.breached[entry.Id]
+	if count == 0 {
+		return ""
+	}
+	return errorStyle.Render(fmt.Sprintf("%sПароль найден в утечках %d раз, смените его", breachedBadge, count)) + "\n"
+}
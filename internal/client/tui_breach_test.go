@@ -0,0 +1,121 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/breach"
+	"github.com/GophKeeper/internal/config"
+	"github.com/GophKeeper/internal/tlsutil"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeBreachCorpus создает локальную базу утечек, в которой есть только
+// пароль "P@ssw0rd", и пустые диапазоны остальных паролей healthEntries
+func writeBreachCorpus(t *testing.T) string {
+	dir := t.TempDir()
+	for _, password := range []string{"correct-horse-battery-staple", "HomeNet2024"} {
+		prefix, _ := breach.HashPassword(password)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, prefix+".txt"), nil, 0o600))
+	}
+	prefix, suffix := breach.HashPassword("P@ssw0rd")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, prefix+".txt"), []byte(suffix+":52456\r\n"), 0o600))
+	return dir
+}
+
+func TestCheckBreachedEntries(t *testing.T) {
+	checker, err := NewBreachChecker(&config.ClientConfig{BreachDir: writeBreachCorpus(t)})
+	require.NoError(t, err)
+	require.NotNil(t, checker)
+
+	breached, err := CheckBreachedEntries(t.Context(), checker, healthEntries(time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"2": 52456, "3": 52456}, breached)
+
+	// Неполная база: диапазона нет
+	checker, err = NewBreachChecker(&config.ClientConfig{BreachDir: t.TempDir()})
+	require.NoError(t, err)
+	_, err = CheckBreachedEntries(t.Context(), checker, healthEntries(time.Now()))
+	require.ErrorIs(t, err, breach.ErrRangeNotFound)
+
+	checker, err = NewBreachChecker(&config.ClientConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, checker)
+}
+
+func TestNewBreachChecker_TLSMirror(t *testing.T) {
+	mirror := httptest.NewTLSServer(http.StripPrefix("/breach", breach.RangeHandler(breach.NewDirSource(writeBreachCorpus(t)))))
+	defer mirror.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mirror.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+
+	// Сертификат зеркала проверяется CA из конфигурации клиента
+	checker, err := NewBreachChecker(&config.ClientConfig{BreachURL: mirror.URL + "/breach", CertFile: caFile})
+	require.NoError(t, err)
+	breached, err := CheckBreachedEntries(t.Context(), checker, healthEntries(time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"2": 52456, "3": 52456}, breached)
+
+	// Пин чужого ключа: соединение отклоняется
+	checker, err = NewBreachChecker(&config.ClientConfig{
+		BreachURL:  mirror.URL + "/breach",
+		CertFile:   caFile,
+		PinnedSPKI: "sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+	})
+	require.NoError(t, err)
+	_, err = CheckBreachedEntries(t.Context(), checker, healthEntries(time.Now()))
+	require.ErrorIs(t, err, tlsutil.ErrPinMismatch)
+
+	_, err = NewBreachChecker(&config.ClientConfig{BreachURL: "keeper.internal/breach"})
+	require.Error(t, err)
+}
+
+func TestTUIModel_BreachedEntries(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	checker, err := NewBreachChecker(&config.ClientConfig{BreachDir: writeBreachCorpus(t)})
+	require.NoError(t, err)
+	model.SetBreachChecker(checker)
+	model.state = stateList
+
+	// После загрузки списка пароли проверяются, записи отмечаются
+	entries := healthEntries(time.Now())
+	_, cmd := model.Update(dataListMsg{entries: entries})
+	require.NotNil(t, cmd)
+	model.Update(cmd())
+
+	titles := make(map[string]string)
+	for _, item := range model.list.Items() {
+		titles[item.(*listItem).id] = item.(*listItem).title
+	}
+	assert.Equal(t, breachedBadge+"Почта", titles["2"])
+	assert.Equal(t, "GitHub", titles["1"])
+
+	// Повторная загрузка не дублирует отметку
+	_, cmd = model.Update(dataListMsg{entries: entries})
+	model.Update(cmd())
+	assert.Equal(t, breachedBadge+"Почта", model.list.Items()[1].(*listItem).title)
+
+	// Предупреждение на экране записи
+	model.state = stateView
+	mockClient.On("ListAttachments", mock.Anything, "2").Return([]*pb.Attachment{}, nil)
+	model.Update(dataEntryLoadedMsg{entry: entries[1]})
+	assert.Contains(t, model.View(), "Пароль найден в утечках 52456 раз")
+
+	// Отчет о здоровье включает скомпрометированные пароли
+	mockClient.On("ListData", mock.Anything, (*pb.DataType)(nil)).Return(entries, nil)
+	model.Update(model.openHealth()())
+	assert.Equal(t, []string{"Почта", "Форум"}, issueNames(model.healthReport, HealthBreached))
+	assert.NotContains(t, model.View(), "базе утечек")
+}
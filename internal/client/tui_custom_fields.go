@@ -0,0 +1,208 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// fieldCustomBase - номер первого поля пользовательских полей в форме.
+// Каждому пользовательскому полю соответствуют два поля формы: название и значение.
+const fieldCustomBase formField = 100
+
+// Подписи типов пользовательских полей
+var customFieldTypeLabels = map[models.CustomFieldType]string{
+	models.CustomFieldText:    "текст",
+	models.CustomFieldHidden:  "скрытое",
+	models.CustomFieldURL:     "URL",
+	models.CustomFieldEmail:   "email",
+	models.CustomFieldDate:    "дата",
+	models.CustomFieldBoolean: "да/нет",
+}
+
+// Подсказки для значений пользовательских полей по типам
+var customFieldPlaceholders = map[models.CustomFieldType]string{
+	models.CustomFieldText:    "Значение",
+	models.CustomFieldHidden:  "Скрытое значение",
+	models.CustomFieldURL:     "https://example.com",
+	models.CustomFieldEmail:   "user@example.com",
+	models.CustomFieldDate:    "ГГГГ-ММ-ДД",
+	models.CustomFieldBoolean: "да или нет",
+}
+
+// customFieldInput - строка формы с пользовательским полем.
+type customFieldInput struct {
+	fieldType models.CustomFieldType
+	name      textinput.Model
+	value     textinput.Model
+}
+
+// customFieldIndex возвращает номер пользовательского поля для поля формы
+// и признак того, что это поле значения.
+func customFieldIndex(field formField) (int, bool) {
+	offset := int(field - fieldCustomBase)
+	return offset / 2, offset%2 == 1
+}
+
+// customFormFields возвращает поля формы для всех пользовательских полей.
+func (f *entryForm) customFormFields() []formField {
+	fields := make([]formField, 0, 2*len(f.customFields))
+	for i := range f.customFields {
+		fields = append(fields, fieldCustomBase+formField(2*i), fieldCustomBase+formField(2*i+1))
+	}
+	return fields
+}
+
+// customInput возвращает поле ввода названия или значения пользовательского поля.
+func (f *entryForm) customInput(field formField) *textinput.Model {
+	index, isValue := customFieldIndex(field)
+	if index >= len(f.customFields) {
+		return nil
+	}
+	if isValue {
+		return &f.customFields[index].value
+	}
+	return &f.customFields[index].name
+}
+
+// addCustomField добавляет пользовательское поле в конец формы.
+// Возвращает false, если достигнуто максимальное количество полей.
+func (f *entryForm) addCustomField(fieldType models.CustomFieldType, name, value string) bool {
+	if len(f.customFields) >= models.MaxCustomFields {
+		return false
+	}
+
+	row := customFieldInput{
+		name:  newFormInput("Название поля", 100, false),
+		value: newFormInput("", 1000, false),
+	}
+	row.name.SetValue(name)
+	row.value.SetValue(value)
+	f.customFields = append(f.customFields, row)
+	f.setCustomFieldType(len(f.customFields)-1, fieldType)
+	return true
+}
+
+// removeCustomField удаляет пользовательское поле с номером index.
+func (f *entryForm) removeCustomField(index int) {
+	f.customFields = append(f.customFields[:index], f.customFields[index+1:]...)
+}
+
+// setCustomFieldType меняет тип пользовательского поля и маскирование его значения.
+func (f *entryForm) setCustomFieldType(index int, fieldType models.CustomFieldType) {
+	row := &f.customFields[index]
+	row.fieldType = fieldType
+	row.value.Placeholder = customFieldPlaceholders[fieldType]
+	row.value.EchoCharacter = '•'
+	row.value.EchoMode = textinput.EchoNormal
+	if fieldType == models.CustomFieldHidden && !f.showSecrets {
+		row.value.EchoMode = textinput.EchoPassword
+	}
+}
+
+// cycleCustomFieldType переключает тип пользовательского поля на следующий.
+func (f *entryForm) cycleCustomFieldType(index int) {
+	types := models.CustomFieldTypes
+	next := types[0]
+	for i, fieldType := range types {
+		if fieldType == f.customFields[index].fieldType {
+			next = types[(i+1)%len(types)]
+			break
+		}
+	}
+	f.setCustomFieldType(index, next)
+}
+
+// customFieldsPayload собирает пользовательские поля для содержимого записи.
+// Строки без названия и значения пропускаются, логические значения
+// приводятся к true или false.
+func (f *entryForm) customFieldsPayload() models.CustomFields {
+	custom := models.CustomFields{SchemaVersion: models.PayloadSchemaVersion}
+	for _, row := range f.customFields {
+		name := strings.TrimSpace(row.name.Value())
+		value := row.value.Value()
+		if row.fieldType != models.CustomFieldHidden {
+			value = strings.TrimSpace(value)
+		}
+		if name == "" && value == "" {
+			continue
+		}
+		if row.fieldType == models.CustomFieldBoolean {
+			value = normalizeBoolean(value)
+		}
+		custom.Fields = append(custom.Fields, models.CustomField{Name: name, Type: row.fieldType, Value: value})
+	}
+	return custom
+}
+
+// fillCustomFields заполняет форму пользовательскими полями записи.
+func (f *entryForm) fillCustomFields(custom models.CustomFields) {
+	f.customFields = nil
+	for _, field := range custom.Fields {
+		f.addCustomField(field.Type, field.Name, field.Value)
+	}
+}
+
+// normalizeBoolean приводит ответ да/нет к значению логического поля.
+// Нераспознанное значение возвращается без изменений и не пройдет проверку.
+func normalizeBoolean(value string) string {
+	switch strings.ToLower(value) {
+	case "да", "д", "yes", "y", "true", "1":
+		return "true"
+	case "нет", "н", "no", "n", "false", "0":
+		return "false"
+	}
+	return value
+}
+
+// customFieldLabel возвращает подпись поля формы пользовательского поля.
+func (f *entryForm) customFieldLabel(field formField) string {
+	index, isValue := customFieldIndex(field)
+	if isValue {
+		return fmt.Sprintf("Поле %d: значение", index+1)
+	}
+	return fmt.Sprintf("Поле %d: название (%s, Ctrl+T - сменить тип)",
+		index+1, customFieldTypeLabels[f.customFields[index].fieldType])
+}
+
+// addCustomFormField добавляет пользовательское поле и переводит фокус на его название.
+func (m *TUIModel) addCustomFormField() {
+	if _, errText := m.createFormType(); errText != "" {
+		m.message = errText
+		return
+	}
+	if !m.form.addCustomField(models.CustomFieldText, "", "") {
+		m.message = fmt.Sprintf("Можно добавить не больше %d полей", models.MaxCustomFields)
+		return
+	}
+
+	m.message = ""
+	added := fieldCustomBase + formField(2*(len(m.form.customFields)-1))
+	for i, field := range m.createFields() {
+		if field == added {
+			m.focusCreateField(i)
+			return
+		}
+	}
+}
+
+// removeCustomFormField удаляет пользовательское поле, находящееся в фокусе.
+func (m *TUIModel) removeCustomFormField(field formField) {
+	index, isValue := customFieldIndex(field)
+	m.form.removeCustomField(index)
+	// Фокус переходит на следующее поле или на метаданные
+	if isValue {
+		m.createFocus--
+	}
+	m.focusCreateField(m.createFocus)
+}
+
+// createFieldLabel возвращает подпись поля формы записи.
+func (m *TUIModel) createFieldLabel(field formField) string {
+	if field >= fieldCustomBase {
+		return m.form.customFieldLabel(field)
+	}
+	return formFieldLabels[field]
+}
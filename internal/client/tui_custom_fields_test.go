@@ -0,0 +1,138 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTUIModel_CustomFields_Create(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateCreate
+	model.createNameInput.SetValue("Заметка")
+	model.createTypeInput.SetValue("2")
+	model.form.text.SetValue("content")
+
+	// Новое поле добавляется перед метаданными, фокус на его названии
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlN})
+	require.Len(t, model.form.customFields, 1)
+	assert.Equal(t, fieldCustomBase, model.focusedCreateField())
+	assert.Equal(t, fieldMetadata, model.createFields()[len(model.createFields())-1])
+	typeRunes(model, "Recovery")
+
+	// Скрытое значение маскируется, пока секреты не показаны
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlT})
+	assert.Equal(t, models.CustomFieldHidden, model.form.customFields[0].fieldType)
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyTab})
+	typeRunes(model, "words")
+	assert.NotContains(t, model.viewCreate(), "words")
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlR})
+	assert.Contains(t, model.viewCreate(), "words")
+
+	// Логическое поле принимает ответ да/нет
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlN})
+	typeRunes(model, "Shared")
+	for model.form.customFields[1].fieldType != models.CustomFieldBoolean {
+		model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlT})
+	}
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyTab})
+	typeRunes(model, "Да")
+
+	// Пустая строка пропускается
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.Contains(t, model.viewCreate(), "Поле 3: название (текст")
+
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var text models.TextData
+		if err := json.Unmarshal(req.EncryptedData, &text); err != nil {
+			return false
+		}
+		return assert.ObjectsAreEqual(models.CustomFields{
+			SchemaVersion: models.PayloadSchemaVersion,
+			Fields: []models.CustomField{
+				{Name: "Recovery", Type: models.CustomFieldHidden, Value: "words"},
+				{Name: "Shared", Type: models.CustomFieldBoolean, Value: "true"},
+			},
+		}, text.CustomFields)
+	})).Return(&pb.DataEntry{Id: "text-id", Name: "Заметка"}, nil)
+
+	_, ok := model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+
+	model.resetCreateForm()
+	assert.Empty(t, model.form.customFields)
+}
+
+func TestTUIModel_CustomFields_Validation(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.createNameInput.SetValue("GitHub")
+	model.form.login.SetValue("alice")
+	model.form.password.SetValue("s3cret")
+	model.form.addCustomField(models.CustomFieldURL, "Portal", "example.com")
+	model.form.addCustomField(models.CustomFieldDate, "Renewal", "31.01.2026")
+
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	require.True(t, ok)
+	assert.Contains(t, errMsg.error, "Поле 1: must be an absolute URL")
+	assert.Contains(t, errMsg.error, "Поле 2: must be a date")
+
+	// Ctrl+X удаляет поле в фокусе
+	model.state = stateCreate
+	model.focusCreateField(len(model.createFields()) - 2)
+	require.Equal(t, fieldCustomBase+3, model.focusedCreateField())
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlX})
+	require.Len(t, model.form.customFields, 1)
+	assert.Equal(t, "Portal", model.form.customFields[0].name.Value())
+	assert.Equal(t, fieldMetadata, model.focusedCreateField())
+}
+
+func TestTUIModel_CustomFields_EditRoundTrip(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	custom := models.CustomFields{
+		SchemaVersion: models.PayloadSchemaVersion,
+		Fields: []models.CustomField{
+			{Name: "Owner", Type: models.CustomFieldEmail, Value: "alice@example.com"},
+			{Name: "Recovery", Type: models.CustomFieldHidden, Value: " padded "},
+		},
+	}
+
+	// Поля сохраняются при редактировании OTP, хотя форма OTP заполняется из URI
+	data, err := json.Marshal(models.OTPData{Type: "hotp", Secret: "JBSWY3DPEHPK3PXP", Issuer: "Bank", Counter: 3, CustomFields: custom})
+	require.NoError(t, err)
+	entry := &pb.DataEntry{Id: "otp-id", Name: "Bank", Type: pb.DataType_DATA_TYPE_OTP, EncryptedData: data, Version: 1}
+
+	require.Empty(t, model.fillEditForm(entry))
+	model.editingEntry = entry
+	payload, errText := model.createPayload(pb.DataType_DATA_TYPE_OTP)
+	require.Empty(t, errText)
+	otpData, err := ParseOTPData(payload)
+	require.NoError(t, err)
+	assert.Equal(t, custom, otpData.CustomFields)
+	assert.Equal(t, uint64(3), otpData.Counter)
+
+	// Запись более новой версии формата не открывается для изменения
+	model = NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateView
+	model.viewingEntry = &pb.DataEntry{
+		Type:          pb.DataType_DATA_TYPE_TEXT,
+		EncryptedData: []byte(`{"content":"x","schema_version":99,"fields":[{"name":"a","type":"color","value":"red"}]}`),
+	}
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	assert.Equal(t, stateView, model.state)
+	assert.Contains(t, model.View(), "версии 99")
+}
+
+func TestNormalizeBoolean(t *testing.T) {
+	assert.Equal(t, "true", normalizeBoolean("Да"))
+	assert.Equal(t, "false", normalizeBoolean("0"))
+	assert.Equal(t, "может быть", normalizeBoolean("может быть"))
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"testing"
+	"time"
 
 	pb "github.com/GophKeeper/proto/gen/proto"
 	"github.com/stretchr/testify/assert"
@@ -146,6 +147,12 @@ func TestTUI_DataAccess_DataTypes(t *testing.T) {
 		{pb.DataType_DATA_TYPE_TEXT, "Текст"},
 		{pb.DataType_DATA_TYPE_BINARY, "Бинарные данные"},
 		{pb.DataType_DATA_TYPE_CARD, "Банковская карта"},
+		{pb.DataType_DATA_TYPE_OTP, "Одноразовые пароли"},
+		{pb.DataType_DATA_TYPE_SSH_KEY, "Ключ SSH"},
+		{pb.DataType_DATA_TYPE_API_KEY, "Ключ API"},
+		{pb.DataType_DATA_TYPE_IDENTITY, "Документ"},
+		{pb.DataType_DATA_TYPE_WIFI, "Сеть Wi-Fi"},
+		{pb.DataType_DATA_TYPE_SECURE_NOTE, "Защищенная заметка"},
 	}
 
 	for _, tc := range testCases {
@@ -241,6 +248,37 @@ func TestTUI_DataAccess_ViewDataWithMetadata(t *testing.T) {
 	assert.Contains(t, view, "bank: TestBank, card_type: Visa")
 }
 
+// TestTUI_DataAccess_ViewOTPEntry тестирует локальное вычисление кода записи OTP
+func TestTUI_DataAccess_ViewOTPEntry(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, logger)
+	model.state = stateView
+
+	payload := []byte(`{"secret":"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ","issuer":"GitHub","digits":8}`)
+	model.viewingEntry = &pb.DataEntry{
+		Id:            "otp-id",
+		Name:          "GitHub",
+		Type:          pb.DataType_DATA_TYPE_OTP,
+		EncryptedData: payload,
+		CreatedAt:     timestamppb.Now(),
+	}
+
+	code, remaining, err := GenerateEntryOTP(payload, time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, code, 8)
+	assert.True(t, remaining > 0 && remaining <= 30)
+
+	// Секрет не отображается, только код
+	view := model.View()
+	assert.Contains(t, view, "Одноразовые пароли")
+	assert.Contains(t, view, "Текущий OTP:")
+	assert.NotContains(t, view, "GEZDGNBVGY3TQOJQ")
+
+	model.viewingEntry.EncryptedData = []byte("not-json")
+	assert.Contains(t, model.View(), "Ошибка OTP")
+}
+
 // TestTUI_DataAccess_ViewDataWithoutMetadata тестирует отображение данных без метаданных
 func TestTUI_DataAccess_ViewDataWithoutMetadata(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Сообщения редактирования записи
+type dataUpdatedMsg struct{ entry *pb.DataEntry }
+type versionConflictMsg struct{ latest *pb.DataEntry }
+
+// startEdit открывает форму записи с текущим содержимым для редактирования.
+func (m *TUIModel) startEdit(entry *pb.DataEntry) tea.Cmd {
+	m.resetCreateForm()
+	if errText := m.fillEditForm(entry); errText != "" {
+		m.resetCreateForm()
+		m.message = errText
+		return nil
+	}
+
+	m.editingEntry = entry
+	m.state = stateCreate
+	m.message = ""
+	m.focusCreateField(0)
+	return nil
+}
+
+// cancelEdit закрывает форму редактирования без сохранения.
+func (m *TUIModel) cancelEdit() {
+	m.editingEntry = nil
+	m.resetCreateForm()
+	m.state = stateView
+}
+
+// fillEditForm заполняет форму полями записи и ее содержимым.
+// Содержимое, сохраненное до появления типизированных форм одной строкой,
+// попадает в пароль учетных данных или в текст.
+func (m *TUIModel) fillEditForm(entry *pb.DataEntry) string {
+	m.createNameInput.SetValue(entry.Name)
+	m.createDescriptionInput.SetValue(entry.Description)
+	m.createMetadataInput.SetValue(entry.Metadata)
+	if entry.RotationDays > 0 {
+		m.form.rotationDays.SetValue(strconv.Itoa(int(entry.RotationDays)))
+	}
+
+	data := entry.EncryptedData
+
+	// Пользовательские поля одинаковы для всех типов. Запись в формате более
+	// новой версии клиента не изменяется, чтобы не потерять неизвестные поля.
+	var custom models.CustomFields
+	if err := json.Unmarshal(data, &custom); err == nil {
+		if !custom.SupportsSchema() {
+			return fmt.Sprintf("Запись сохранена в формате версии %d, обновите клиент, чтобы изменить ее", custom.SchemaVersion)
+		}
+		m.form.fillCustomFields(custom)
+	}
+
+	switch entry.Type {
+	case pb.DataType_DATA_TYPE_CREDENTIALS:
+		var creds models.Credentials
+		if err := json.Unmarshal(data, &creds); err != nil {
+			creds = models.Credentials{Password: string(data)}
+		}
+		m.form.login.SetValue(creds.Login)
+		m.form.password.SetValue(creds.Password)
+		m.form.url.SetValue(creds.URL)
+		m.form.notes.SetValue(creds.Notes)
+	case pb.DataType_DATA_TYPE_CARD:
+		var card models.CardData
+		if err := json.Unmarshal(data, &card); err != nil {
+			return "Не удалось разобрать данные карты: " + err.Error()
+		}
+		m.form.cardNumber.SetValue(card.Number)
+		m.form.cardExpiry.SetValue(card.ExpiryDate)
+		m.form.cardHolder.SetValue(card.Holder)
+		m.form.cardCVV.SetValue(card.CVV)
+		m.form.cardPIN.SetValue(card.PIN)
+		m.form.notes.SetValue(card.Notes)
+	case pb.DataType_DATA_TYPE_TEXT:
+		var text models.TextData
+		if err := json.Unmarshal(data, &text); err != nil {
+			text = models.TextData{Content: string(data)}
+		}
+		m.form.text.SetValue(text.Content)
+		m.form.notes.SetValue(text.Notes)
+	case pb.DataType_DATA_TYPE_BINARY:
+		var file models.BinaryData
+		if err := json.Unmarshal(data, &file); err != nil {
+			return "Не удалось разобрать данные файла: " + err.Error()
+		}
+		m.form.notes.SetValue(file.Notes)
+		file.Notes = ""
+		file.CustomFields = models.CustomFields{}
+		m.form.file = &file
+	case pb.DataType_DATA_TYPE_OTP:
+		otpData, err := ParseOTPData(data)
+		if err != nil {
+			return "Не удалось разобрать данные OTP: " + err.Error()
+		}
+		// URI сохраняет все параметры, включая счетчик HOTP
+		uri, err := OTPDataURI(otpData)
+		if err != nil {
+			return "Не удалось разобрать данные OTP: " + err.Error()
+		}
+		m.createDataInput.SetValue(uri)
+		m.form.notes.SetValue(otpData.Notes)
+	default:
+		return m.fillExtendedForm(entry.Type, data)
+	}
+	return ""
+}
+
+// updateDataEntry сохраняет изменения записи с версией, на которой основана форма.
+// Если запись за это время изменили на другом устройстве, загружает новую версию.
+func (m *TUIModel) updateDataEntry() tea.Cmd {
+	entry := m.editingEntry
+	return func() tea.Msg {
+		ctx := context.Background()
+
+		if m.createNameInput.Value() == "" {
+			return errorMsg{error: "Название записи обязательно"}
+		}
+
+		payload, errText := m.formPayload(entry.Type, entry)
+		if errText != "" {
+			return errorMsg{error: errText}
+		}
+
+		rotationDays, errText := m.formRotationDays(entry.Type)
+		if errText != "" {
+			return errorMsg{error: errText}
+		}
+		// Политика передается, только если форма показывает поле периода
+		var rotationPolicy *int32
+		if rotatableType(entry.Type) {
+			rotationPolicy = &rotationDays
+		}
+
+		// Время смены пароля обновляется, только если изменился сам секрет
+		updated, err := m.client.UpdateData(ctx, &pb.UpdateDataRequest{
+			Id:              entry.Id,
+			Name:            m.createNameInput.Value(),
+			Description:     m.createDescriptionInput.Value(),
+			EncryptedData:   payload, // TODO: Зашифровать данные
+			Metadata:        m.createMetadataInput.Value(),
+			Version:         entry.Version,
+			RotationDays:    rotationPolicy,
+			PasswordChanged: passwordChanged(entry.Type, entry.EncryptedData, payload),
+		})
+		if status.Code(err) == codes.Aborted {
+			latest, getErr := m.client.GetData(ctx, entry.Id)
+			if getErr != nil {
+				return errorMsg{error: fmt.Sprintf("запись изменена или удалена на другом устройстве: %v", getErr)}
+			}
+			return versionConflictMsg{latest: latest}
+		}
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка сохранения записи: %v", err)}
+		}
+
+		return dataUpdatedMsg{entry: updated}
+	}
+}
+
+// handleVersionConflict сохраняет введенные изменения в форме и переводит ее
+// на новую версию записи: повторное сохранение перезапишет ее осознанно.
+func (m *TUIModel) handleVersionConflict(latest *pb.DataEntry) {
+	m.editingEntry = latest
+	m.message = fmt.Sprintf("Запись изменена на другом устройстве (версия %d). Ваши изменения остались в форме: "+
+		"Ctrl+S сохранит их поверх новой версии, Ctrl+L загрузит новую версию, Esc отменит", latest.Version)
+}
+
+// reloadEditForm заменяет введенные изменения текущей версией записи.
+func (m *TUIModel) reloadEditForm() {
+	entry := m.editingEntry
+	m.resetCreateForm()
+	if errText := m.fillEditForm(entry); errText != "" {
+		m.message = errText
+		return
+	}
+	m.message = ""
+	m.focusCreateField(0)
+}
+
+// handleDataUpdated показывает сохраненную запись и обновляет список.
+func (m *TUIModel) handleDataUpdated(entry *pb.DataEntry) tea.Cmd {
+	m.editingEntry = nil
+	m.resetCreateForm()
+	m.state = stateView
+	m.viewingEntry = entry
+	m.hotpCode = ""
+	m.showQRCode = false
+	if m.selectedEntry != nil {
+		m.selectedEntry.title = entry.Name
+		m.selectedEntry.description = entry.Description
+	}
+	m.message = fmt.Sprintf("Запись '%s' сохранена", entry.Name)
+	return m.loadDataList()
+}
+
+// editTitle возвращает заголовок формы записи.
+func (m *TUIModel) editTitle() string {
+	if m.editingEntry != nil {
+		return "✏️ Редактирование записи"
+	}
+	return "➕ Добавление записи"
+}
+
+// editHelp возвращает подсказку по клавишам формы записи.
+func (m *TUIModel) editHelp() string {
+	keys := []string{"Tab/Shift+Tab: переключение полей", "Ctrl+R: показать/скрыть секреты",
+		"Ctrl+N: добавить поле", "Ctrl+X: удалить поле"}
+	if m.editingEntry != nil {
+		keys = append(keys, "Ctrl+S: сохранить", "Ctrl+L: загрузить текущую версию", "Esc: отмена")
+	} else {
+		keys = append(keys, "Ctrl+S: создать запись", "Esc: назад")
+	}
+	return strings.Join(keys, " • ")
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// credentialsEntry создает запись учетных данных с содержимым creds.
+func credentialsEntry(t *testing.T, version int64, creds models.Credentials) *pb.DataEntry {
+	data, err := json.Marshal(creds)
+	require.NoError(t, err)
+	return &pb.DataEntry{
+		Id:            "creds-id",
+		Name:          "GitHub",
+		Description:   "work",
+		Metadata:      "tag:dev",
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		EncryptedData: data,
+		Version:       version,
+	}
+}
+
+func TestTUIModel_EditEntry(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateView
+	model.selectedEntry = &listItem{title: "GitHub", id: "creds-id"}
+	model.viewingEntry = credentialsEntry(t, 3, models.Credentials{Login: "alice", Password: "old", URL: "https://github.com"})
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.Equal(t, stateCreate, model.state)
+	assert.Equal(t, "GitHub", model.createNameInput.Value())
+	assert.Equal(t, "work", model.createDescriptionInput.Value())
+	assert.Equal(t, "tag:dev", model.createMetadataInput.Value())
+	assert.Equal(t, "alice", model.form.login.Value())
+	assert.Equal(t, "old", model.form.password.Value())
+	assert.NotContains(t, model.createFields(), fieldType)
+	assert.Contains(t, model.viewCreate(), "Редактирование записи")
+
+	model.createNameInput.SetValue("GitHub (work)")
+	model.form.password.SetValue("new")
+
+	updated := credentialsEntry(t, 4, models.Credentials{Login: "alice", Password: "new", URL: "https://github.com"})
+	updated.Name = "GitHub (work)"
+	mockClient.On("UpdateData", mock.Anything, mock.MatchedBy(func(req *pb.UpdateDataRequest) bool {
+		var creds models.Credentials
+		if err := json.Unmarshal(req.EncryptedData, &creds); err != nil {
+			return false
+		}
+		return req.Id == "creds-id" && req.Version == 3 && req.Name == "GitHub (work)" &&
+			req.Description == "work" && req.Metadata == "tag:dev" && creds.Password == "new"
+	})).Return(updated, nil)
+
+	_, cmd := model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(dataUpdatedMsg)
+	require.True(t, ok)
+	mockClient.AssertExpectations(t)
+
+	// После сохранения показывается новая версия, список перезагружается
+	_, cmd = model.Update(msg)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateView, model.state)
+	assert.Equal(t, updated, model.viewingEntry)
+	assert.Nil(t, model.editingEntry)
+	assert.Equal(t, "GitHub (work)", model.selectedEntry.title)
+	assert.Contains(t, model.View(), "сохранена")
+}
+
+func TestTUIModel_EditEntry_VersionConflict(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateView
+	model.viewingEntry = credentialsEntry(t, 3, models.Credentials{Login: "alice", Password: "old"})
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	model.form.password.SetValue("mine")
+
+	latest := credentialsEntry(t, 5, models.Credentials{Login: "alice", Password: "theirs"})
+	mockClient.On("UpdateData", mock.Anything, mock.MatchedBy(func(req *pb.UpdateDataRequest) bool {
+		return req.Version == 3
+	})).Return((*pb.DataEntry)(nil), status.Error(codes.Aborted, "data entry was modified, reload it and retry")).Once()
+	mockClient.On("GetData", mock.Anything, "creds-id").Return(latest, nil).Once()
+
+	msg, ok := model.updateDataEntry()().(versionConflictMsg)
+	require.True(t, ok)
+	model.Update(msg)
+
+	// Изменения пользователя остаются в форме, сохранение пойдет с новой версией
+	assert.Equal(t, stateCreate, model.state)
+	assert.Equal(t, "mine", model.form.password.Value())
+	assert.Equal(t, int64(5), model.editingEntry.Version)
+	assert.Contains(t, model.viewCreate(), "версия 5")
+
+	// Ctrl+L заменяет изменения текущей версией
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlL})
+	assert.Equal(t, "theirs", model.form.password.Value())
+
+	mockClient.On("UpdateData", mock.Anything, mock.MatchedBy(func(req *pb.UpdateDataRequest) bool {
+		return req.Version == 5
+	})).Return(credentialsEntry(t, 6, models.Credentials{Login: "alice", Password: "theirs"}), nil).Once()
+	_, ok = model.updateDataEntry()().(dataUpdatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTUIModel_EditEntry_Cancel(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateView
+	model.viewingEntry = credentialsEntry(t, 1, models.Credentials{Login: "alice", Password: "old"})
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateView, model.state)
+	assert.Nil(t, model.editingEntry)
+	assert.Empty(t, model.form.login.Value())
+}
+
+func TestTUIModel_FillEditForm(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+
+	// Текст, сохраненный до типизированных форм одной строкой
+	errText := model.fillEditForm(&pb.DataEntry{Type: pb.DataType_DATA_TYPE_TEXT, EncryptedData: []byte("plain note")})
+	require.Empty(t, errText)
+	assert.Equal(t, "plain note", model.form.text.Value())
+
+	// Запись HOTP сохраняет счетчик и параметры
+	model.resetCreateForm()
+	errText = model.fillEditForm(&pb.DataEntry{
+		Name:          "Bank",
+		Type:          pb.DataType_DATA_TYPE_OTP,
+		EncryptedData: []byte(`{"type":"hotp","secret":"JBSWY3DPEHPK3PXP","issuer":"Bank","digits":8,"counter":7,"notes":"backup"}`),
+	})
+	require.Empty(t, errText)
+	assert.Contains(t, model.createDataInput.Value(), "counter=7")
+	assert.Equal(t, "backup", model.form.notes.Value())
+
+	model.editingEntry = &pb.DataEntry{Type: pb.DataType_DATA_TYPE_OTP}
+	payload, errText := model.createPayload(pb.DataType_DATA_TYPE_OTP)
+	require.Empty(t, errText)
+	data, err := ParseOTPData(payload)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), data.Counter)
+	assert.Equal(t, 8, data.Digits)
+	assert.Equal(t, "backup", data.Notes)
+
+	// Поврежденные данные карты не открываются в форме
+	model = NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateView
+	model.viewingEntry = &pb.DataEntry{Type: pb.DataType_DATA_TYPE_CARD, EncryptedData: []byte("4111")}
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	assert.Equal(t, stateView, model.state)
+	assert.Contains(t, model.View(), "Не удалось разобрать данные карты")
+}
+
+func TestTUIModel_EditEntry_ExpiredCard(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateView
+	entry := &pb.DataEntry{
+		Id:            "card-id",
+		Name:          "Visa",
+		Type:          pb.DataType_DATA_TYPE_CARD,
+		EncryptedData: []byte(`{"number":"4111111111111111","expiry_date":"01/20","holder":"ALICE","cvv":"123"}`),
+		Version:       1,
+	}
+	model.viewingEntry = entry
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.Equal(t, stateCreate, model.state)
+
+	// Истекшую карту можно переименовать, не меняя срок действия
+	model.createNameInput.SetValue("Old Visa")
+	payload, errText := model.formPayload(entry.Type, model.editingEntry)
+	require.Empty(t, errText)
+	assert.Contains(t, string(payload), `"expiry_date":"01/20"`)
+
+	// Создание карты с истекшим сроком отклоняется
+	_, errText = model.createPayload(entry.Type)
+	assert.NotEmpty(t, errText)
+}
@@ -0,0 +1,326 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/payload"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/crypto/ssh"
+)
+
+// expiryWarningDays - за сколько дней до окончания срока действия
+// ключа или документа показывается предупреждение.
+const expiryWarningDays = 30
+
+// Типы документов в порядке номеров в форме
+var identityDocumentTypes = []string{"passport", "driver_license", "id_card", "other"}
+
+// Названия типов документов
+var identityDocumentLabels = map[string]string{
+	"passport":       "Паспорт",
+	"driver_license": "Водительское удостоверение",
+	"id_card":        "Удостоверение личности",
+	"other":          "Другой документ",
+}
+
+// markdownHeadingStyle выделяет заголовки защищенных заметок.
+var markdownHeadingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+
+// identityDocumentType возвращает тип документа по номеру или названию из формы.
+func identityDocumentType(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for i, docType := range identityDocumentTypes {
+		if value == fmt.Sprint(i+1) {
+			return docType
+		}
+	}
+	// Неизвестное значение отклонит проверка содержимого
+	return value
+}
+
+// extendedPayload собирает содержимое записей с ключами SSH и API, документов,
+// сетей Wi-Fi и защищенных заметок.
+func (m *TUIModel) extendedPayload(dataType pb.DataType, notes string, custom models.CustomFields) (models.DataType, interface{}, string) {
+	f := &m.form
+	switch dataType {
+	case pb.DataType_DATA_TYPE_SSH_KEY:
+		// Ключи OpenSSH без завершающего перевода строки не разбираются
+		privateKey := strings.TrimSpace(f.sshPrivateKey.Value())
+		if privateKey != "" {
+			privateKey += "\n"
+		}
+		return models.DataTypeSSHKey, models.SSHKeyData{
+			PrivateKey:   privateKey,
+			PublicKey:    strings.TrimSpace(f.sshPublicKey.Value()),
+			Passphrase:   f.sshPassphrase.Value(),
+			Notes:        notes,
+			CustomFields: custom,
+		}, ""
+	case pb.DataType_DATA_TYPE_API_KEY:
+		return models.DataTypeAPIKey, models.APIKeyData{
+			Key:          strings.TrimSpace(f.apiKey.Value()),
+			Secret:       strings.TrimSpace(f.apiSecret.Value()),
+			URL:          strings.TrimSpace(f.url.Value()),
+			ExpiresAt:    strings.TrimSpace(f.apiExpiry.Value()),
+			Notes:        notes,
+			CustomFields: custom,
+		}, ""
+	case pb.DataType_DATA_TYPE_IDENTITY:
+		return models.DataTypeIdentity, models.IdentityData{
+			DocumentType: identityDocumentType(f.identityDocType.Value()),
+			Number:       strings.TrimSpace(f.identityNumber.Value()),
+			FullName:     strings.TrimSpace(f.identityName.Value()),
+			BirthDate:    strings.TrimSpace(f.identityBirthDate.Value()),
+			Country:      strings.ToUpper(strings.TrimSpace(f.identityCountry.Value())),
+			IssuedBy:     strings.TrimSpace(f.identityIssuedBy.Value()),
+			IssuedAt:     strings.TrimSpace(f.identityIssuedAt.Value()),
+			ExpiresAt:    strings.TrimSpace(f.identityExpiry.Value()),
+			Notes:        notes,
+			CustomFields: custom,
+		}, ""
+	case pb.DataType_DATA_TYPE_WIFI:
+		hidden := normalizeBoolean(strings.TrimSpace(f.wifiHidden.Value()))
+		if hidden != "" && hidden != "true" && hidden != "false" {
+			return "", nil, "Скрытая сеть: укажите да или нет"
+		}
+		return models.DataTypeWiFi, models.WiFiData{
+			SSID:         f.wifiSSID.Value(),
+			Password:     f.wifiPassword.Value(),
+			Security:     strings.ToLower(strings.TrimSpace(f.wifiSecurity.Value())),
+			Hidden:       hidden == "true",
+			Notes:        notes,
+			CustomFields: custom,
+		}, ""
+	default:
+		return models.DataTypeSecureNote, models.SecureNoteData{
+			Content:      f.text.Value(),
+			CustomFields: custom,
+		}, ""
+	}
+}
+
+// fillExtendedForm заполняет форму содержимым записей новых типов.
+func (m *TUIModel) fillExtendedForm(dataType pb.DataType, data []byte) string {
+	f := &m.form
+	switch dataType {
+	case pb.DataType_DATA_TYPE_SSH_KEY:
+		var key models.SSHKeyData
+		if err := json.Unmarshal(data, &key); err != nil {
+			return "Не удалось разобрать ключ SSH: " + err.Error()
+		}
+		f.sshPrivateKey.SetValue(strings.TrimSpace(key.PrivateKey))
+		f.sshPublicKey.SetValue(key.PublicKey)
+		f.sshPassphrase.SetValue(key.Passphrase)
+		f.notes.SetValue(key.Notes)
+	case pb.DataType_DATA_TYPE_API_KEY:
+		var key models.APIKeyData
+		if err := json.Unmarshal(data, &key); err != nil {
+			return "Не удалось разобрать ключ API: " + err.Error()
+		}
+		f.apiKey.SetValue(key.Key)
+		f.apiSecret.SetValue(key.Secret)
+		f.url.SetValue(key.URL)
+		f.apiExpiry.SetValue(key.ExpiresAt)
+		f.notes.SetValue(key.Notes)
+	case pb.DataType_DATA_TYPE_IDENTITY:
+		var identity models.IdentityData
+		if err := json.Unmarshal(data, &identity); err != nil {
+			return "Не удалось разобрать данные документа: " + err.Error()
+		}
+		f.identityDocType.SetValue(identity.DocumentType)
+		f.identityNumber.SetValue(identity.Number)
+		f.identityName.SetValue(identity.FullName)
+		f.identityBirthDate.SetValue(identity.BirthDate)
+		f.identityCountry.SetValue(identity.Country)
+		f.identityIssuedBy.SetValue(identity.IssuedBy)
+		f.identityIssuedAt.SetValue(identity.IssuedAt)
+		f.identityExpiry.SetValue(identity.ExpiresAt)
+		f.notes.SetValue(identity.Notes)
+	case pb.DataType_DATA_TYPE_WIFI:
+		var wifi models.WiFiData
+		if err := json.Unmarshal(data, &wifi); err != nil {
+			return "Не удалось разобрать данные сети: " + err.Error()
+		}
+		f.wifiSSID.SetValue(wifi.SSID)
+		f.wifiPassword.SetValue(wifi.Password)
+		f.wifiSecurity.SetValue(wifi.Security)
+		if wifi.Hidden {
+			f.wifiHidden.SetValue("да")
+		}
+		f.notes.SetValue(wifi.Notes)
+	case pb.DataType_DATA_TYPE_SECURE_NOTE:
+		var note models.SecureNoteData
+		if err := json.Unmarshal(data, &note); err != nil {
+			return "Не удалось разобрать заметку: " + err.Error()
+		}
+		f.text.SetValue(note.Content)
+	default:
+		return "Записи этого типа нельзя изменить"
+	}
+	return ""
+}
+
+// viewExtendedEntry отображает содержимое записей новых типов. Закрытый ключ
+// SSH не выводится на экран: он доступен в форме редактирования.
+func (m *TUIModel) viewExtendedEntry(entry *pb.DataEntry) string {
+	var b strings.Builder
+	now := time.Now()
+	switch entry.Type {
+	case pb.DataType_DATA_TYPE_SSH_KEY:
+		var key models.SSHKeyData
+		if err := json.Unmarshal(entry.EncryptedData, &key); err != nil {
+			return errorStyle.Render("Ошибка ключа SSH: "+err.Error()) + "\n"
+		}
+		b.WriteString(sshKeySummary(&key))
+		if key.PublicKey != "" {
+			b.WriteString("Открытый ключ: " + strings.TrimSpace(key.PublicKey) + "\n")
+		}
+		writeOptional(&b, "Заметки", key.Notes)
+	case pb.DataType_DATA_TYPE_API_KEY:
+		var key models.APIKeyData
+		if err := json.Unmarshal(entry.EncryptedData, &key); err != nil {
+			return errorStyle.Render("Ошибка ключа API: "+err.Error()) + "\n"
+		}
+		b.WriteString("Ключ: " + key.Key + "\n")
+		writeOptional(&b, "Секрет", key.Secret)
+		writeOptional(&b, "URL", key.URL)
+		if key.ExpiresAt != "" {
+			b.WriteString("Действует до: " + expiryStatus(key.ExpiresAt, now) + "\n")
+		}
+		writeOptional(&b, "Заметки", key.Notes)
+	case pb.DataType_DATA_TYPE_IDENTITY:
+		var identity models.IdentityData
+		if err := json.Unmarshal(entry.EncryptedData, &identity); err != nil {
+			return errorStyle.Render("Ошибка документа: "+err.Error()) + "\n"
+		}
+		docLabel, ok := identityDocumentLabels[identity.DocumentType]
+		if !ok {
+			docLabel = identity.DocumentType
+		}
+		b.WriteString(docLabel + " № " + identity.Number + "\n")
+		b.WriteString("Владелец: " + identity.FullName + "\n")
+		writeOptional(&b, "Дата рождения", identity.BirthDate)
+		writeOptional(&b, "Страна", identity.Country)
+		writeOptional(&b, "Кем выдан", identity.IssuedBy)
+		writeOptional(&b, "Дата выдачи", identity.IssuedAt)
+		if identity.ExpiresAt != "" {
+			b.WriteString("Действует до: " + expiryStatus(identity.ExpiresAt, now) + "\n")
+		}
+		writeOptional(&b, "Заметки", identity.Notes)
+	case pb.DataType_DATA_TYPE_WIFI:
+		var wifi models.WiFiData
+		if err := json.Unmarshal(entry.EncryptedData, &wifi); err != nil {
+			return errorStyle.Render("Ошибка данных сети: "+err.Error()) + "\n"
+		}
+		b.WriteString("Сеть: " + wifi.SSID + "\n")
+		writeOptional(&b, "Пароль", wifi.Password)
+		security := wifi.Security
+		if security == "" {
+			security = "wpa2"
+		}
+		b.WriteString("Защита: " + strings.ToUpper(security) + "\n")
+		if wifi.Hidden {
+			b.WriteString("Скрытая сеть\n")
+		}
+		writeOptional(&b, "Заметки", wifi.Notes)
+		// QR код подключения распознают камеры Android и iOS
+		if qr, err := renderQRCode(wifiQRContent(&wifi)); err == nil {
+			b.WriteString(qr + "\n")
+		}
+	case pb.DataType_DATA_TYPE_SECURE_NOTE:
+		var note models.SecureNoteData
+		if err := json.Unmarshal(entry.EncryptedData, &note); err != nil {
+			return errorStyle.Render("Ошибка заметки: "+err.Error()) + "\n"
+		}
+		b.WriteString("\n" + renderMarkdown(note.Content) + "\n")
+	}
+	return b.String()
+}
+
+// writeOptional выводит строку с подписью, если значение задано.
+func writeOptional(b *strings.Builder, label, value string) {
+	if value != "" {
+		b.WriteString(label + ": " + value + "\n")
+	}
+}
+
+// sshKeySummary описывает тип и отпечаток ключа SSH.
+func sshKeySummary(key *models.SSHKeyData) string {
+	public, err := payload.SSHPublicKey(key)
+	if err != nil {
+		return errorStyle.Render("Отпечаток недоступен: "+err.Error()) + "\n"
+	}
+
+	summary := fmt.Sprintf("Тип ключа: %s\nОтпечаток: %s\n", public.Type(), ssh.FingerprintSHA256(public))
+	if strings.Contains(key.PrivateKey, "ENCRYPTED") || key.Passphrase != "" {
+		summary += "Закрытый ключ защищен парольной фразой\n"
+	}
+	return summary
+}
+
+// expiryStatus описывает дату окончания срока действия и предупреждает
+// об истекшем или скоро истекающем сроке.
+func expiryStatus(date string, now time.Time) string {
+	expiresAt, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(expiresAt.Sub(today).Hours() / 24)
+	switch {
+	case days < 0:
+		return errorStyle.Render(date + " (срок истек)")
+	case days <= expiryWarningDays:
+		return errorStyle.Render(fmt.Sprintf("%s (истекает через %d дн.)", date, days))
+	default:
+		return date
+	}
+}
+
+// wifiQRContent формирует строку подключения к сети в формате WIFI:
+// для QR кода. Специальные символы экранируются обратной косой чертой.
+func wifiQRContent(wifi *models.WiFiData) string {
+	escape := strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`).Replace
+
+	auth := "WPA"
+	switch wifi.Security {
+	case "open":
+		auth = "nopass"
+	case "wep":
+		auth = "WEP"
+	}
+
+	content := fmt.Sprintf("WIFI:T:%s;S:%s;", auth, escape(wifi.SSID))
+	if auth != "nopass" {
+		content += "P:" + escape(wifi.Password) + ";"
+	}
+	if wifi.Hidden {
+		content += "H:true;"
+	}
+	return content + ";"
+}
+
+// renderMarkdown отображает основные элементы Markdown в терминале:
+// заголовки, списки и цитаты. Остальной текст выводится как есть.
+func renderMarkdown(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			lines[i] = markdownHeadingStyle.Render(strings.TrimSpace(strings.TrimLeft(trimmed, "#")))
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+			lines[i] = indent + "• " + trimmed[2:]
+		case strings.HasPrefix(trimmed, "> "):
+			lines[i] = helpStyle.Render("│ " + trimmed[2:])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
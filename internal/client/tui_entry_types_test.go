@@ -0,0 +1,157 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestTUIModel_CreateSSHKeyForm(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(private, "deploy")
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateCreate
+	model.createNameInput.SetValue("Deploy")
+	model.createTypeInput.SetValue("6")
+	assert.Equal(t, []formField{fieldName, fieldDescription, fieldType, fieldSSHPrivateKey, fieldSSHPublicKey,
+		fieldSSHPassphrase, fieldNotes, fieldMetadata}, model.createFields())
+
+	// Закрытый ключ вводится в многострочное поле
+	model.focusCreateField(3)
+	typeRunes(model, "-----BEGIN")
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, fieldSSHPrivateKey, model.focusedCreateField())
+
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	require.True(t, ok)
+	assert.Contains(t, errMsg.error, "Закрытый ключ")
+
+	model.form.sshPrivateKey.SetValue(string(pem.EncodeToMemory(block)))
+	var saved []byte
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		saved = req.EncryptedData
+		return req.Type == pb.DataType_DATA_TYPE_SSH_KEY
+	})).Return(&pb.DataEntry{Id: "ssh-id", Name: "Deploy"}, nil)
+
+	_, ok = model.createDataEntry()().(dataCreatedMsg)
+	require.True(t, ok)
+	mockClient.AssertExpectations(t)
+
+	// На экране просмотра виден отпечаток, но не сам закрытый ключ
+	model.state = stateView
+	model.viewingEntry = &pb.DataEntry{Name: "Deploy", Type: pb.DataType_DATA_TYPE_SSH_KEY, EncryptedData: saved}
+	view := model.View()
+	assert.Contains(t, view, ssh.FingerprintSHA256(signer.PublicKey()))
+	assert.Contains(t, view, "ssh-ed25519")
+	assert.NotContains(t, view, "BEGIN OPENSSH")
+}
+
+func TestTUIModel_CreateIdentityForm(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.createNameInput.SetValue("Паспорт")
+	model.createTypeInput.SetValue("8")
+	model.form.identityDocType.SetValue("1")
+	model.form.identityName.SetValue("Alice Smith")
+	model.form.identityCountry.SetValue("ru")
+	model.form.identityIssuedAt.SetValue("2020-01-01")
+	model.form.identityExpiry.SetValue("2019-01-01")
+
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	require.True(t, ok)
+	assert.Contains(t, errMsg.error, "Номер документа: is required")
+	assert.Contains(t, errMsg.error, "Действует до: must be after issued_at")
+
+	model.form.identityNumber.SetValue("4510 123456")
+	model.form.identityExpiry.SetValue("2030-01-01")
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var identity models.IdentityData
+		if err := json.Unmarshal(req.EncryptedData, &identity); err != nil {
+			return false
+		}
+		return identity.DocumentType == "passport" && identity.Country == "RU"
+	})).Return(&pb.DataEntry{Id: "doc-id", Name: "Паспорт"}, nil)
+
+	_, ok = model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTUIModel_EditWiFiEntry(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateView
+	model.viewingEntry = &pb.DataEntry{
+		Id:            "wifi-id",
+		Name:          "Home",
+		Type:          pb.DataType_DATA_TYPE_WIFI,
+		EncryptedData: []byte(`{"ssid":"Home;Net","password":"correct horse","security":"wpa3","hidden":true}`),
+		Version:       1,
+	}
+
+	view := model.View()
+	assert.Contains(t, view, "Сеть: Home;Net")
+	assert.Contains(t, view, "WPA3")
+	assert.Contains(t, view, "Скрытая сеть")
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.Equal(t, stateCreate, model.state)
+	assert.Equal(t, "да", model.form.wifiHidden.Value())
+
+	// Пароль скрыт, пока секреты не показаны
+	assert.NotContains(t, model.viewCreate(), "correct horse")
+
+	model.form.wifiHidden.SetValue("может быть")
+	_, errText := model.createPayload(pb.DataType_DATA_TYPE_WIFI)
+	assert.Contains(t, errText, "Скрытая сеть")
+
+	model.form.wifiHidden.SetValue("нет")
+	data, errText := model.createPayload(pb.DataType_DATA_TYPE_WIFI)
+	require.Empty(t, errText)
+	var wifi models.WiFiData
+	require.NoError(t, json.Unmarshal(data, &wifi))
+	assert.Equal(t, models.WiFiData{
+		SSID: "Home;Net", Password: "correct horse", Security: "wpa3",
+		CustomFields: models.CustomFields{SchemaVersion: models.PayloadSchemaVersion},
+	}, wifi)
+}
+
+func TestWiFiQRContent(t *testing.T) {
+	assert.Equal(t, `WIFI:T:WPA;S:Home\;Net;P:pa\:ss;H:true;;`,
+		wifiQRContent(&models.WiFiData{SSID: "Home;Net", Password: "pa:ss", Hidden: true}))
+	assert.Equal(t, "WIFI:T:nopass;S:Cafe;;", wifiQRContent(&models.WiFiData{SSID: "Cafe", Security: "open"}))
+}
+
+func TestExpiryStatus(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 15, 0, 0, 0, time.UTC)
+	assert.Contains(t, expiryStatus("2026-02-28", now), "срок истек")
+	assert.Contains(t, expiryStatus("2026-03-11", now), "истекает через 10 дн.")
+	assert.Equal(t, "2027-01-01", expiryStatus("2027-01-01", now))
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	rendered := renderMarkdown("# Доступ\n- сервер\n  * резерв\n> важно\nтекст")
+	assert.Contains(t, rendered, "Доступ")
+	assert.NotContains(t, rendered, "# Доступ")
+	assert.Contains(t, rendered, "• сервер")
+	assert.Contains(t, rendered, "  • резерв")
+	assert.Contains(t, rendered, "│ важно")
+	assert.Contains(t, rendered, "текст")
+}
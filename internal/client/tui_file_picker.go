@@ -0,0 +1,129 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Количество строк каталога, видимых в окне выбора файла
+const filePickerHeight = 10
+
+// filePicker позволяет выбрать файл, перемещаясь по каталогам.
+type filePicker struct {
+	dir     string
+	entries []os.DirEntry
+	cursor  int
+	err     error
+}
+
+// newFilePicker открывает выбор файла в текущем каталоге или в домашнем,
+// если текущий определить не удалось.
+func newFilePicker() filePicker {
+	dir, err := os.Getwd()
+	if err != nil {
+		if dir, err = os.UserHomeDir(); err != nil {
+			dir = string(filepath.Separator)
+		}
+	}
+
+	var p filePicker
+	p.open(dir)
+	return p
+}
+
+// open читает каталог. Каталоги показываются первыми, скрытые файлы пропускаются.
+// При ошибке остается открытым прежний каталог.
+func (p *filePicker) open(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		p.err = err
+		return
+	}
+
+	visible := entries[:0]
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), ".") {
+			visible = append(visible, entry)
+		}
+	}
+	sort.SliceStable(visible, func(i, j int) bool {
+		return visible[i].IsDir() && !visible[j].IsDir()
+	})
+
+	p.dir, p.entries, p.cursor, p.err = dir, visible, 0, nil
+}
+
+// update обрабатывает клавиши и возвращает путь выбранного файла.
+// Пустая строка означает, что файл еще не выбран.
+func (p *filePicker) update(msg tea.KeyMsg) string {
+	switch msg.Type {
+	case tea.KeyUp:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case tea.KeyDown:
+		if p.cursor < len(p.entries)-1 {
+			p.cursor++
+		}
+	case tea.KeyBackspace, tea.KeyLeft:
+		p.open(filepath.Dir(p.dir))
+	case tea.KeyEnter, tea.KeyRight:
+		if p.cursor >= len(p.entries) {
+			return ""
+		}
+		path := filepath.Join(p.dir, p.entries[p.cursor].Name())
+		// Stat следует по символическим ссылкам на каталоги
+		info, err := os.Stat(path)
+		if err != nil {
+			p.err = err
+			return ""
+		}
+		if info.IsDir() {
+			p.open(path)
+			return ""
+		}
+		return path
+	}
+	return ""
+}
+
+// view отображает окно каталога вокруг выбранной строки.
+func (p filePicker) view() string {
+	var b strings.Builder
+	b.WriteString("📂 " + p.dir + "\n\n")
+
+	if len(p.entries) == 0 {
+		b.WriteString(helpStyle.Render("Каталог пуст") + "\n")
+	}
+
+	start := 0
+	if p.cursor >= filePickerHeight {
+		start = p.cursor - filePickerHeight + 1
+	}
+	end := start + filePickerHeight
+	if end > len(p.entries) {
+		end = len(p.entries)
+	}
+
+	for i := start; i < end; i++ {
+		cursor := "  "
+		if i == p.cursor {
+			cursor = "> "
+		}
+		name := p.entries[i].Name()
+		if p.entries[i].IsDir() {
+			name += string(filepath.Separator)
+		}
+		b.WriteString(cursor + name + "\n")
+	}
+
+	if p.err != nil {
+		b.WriteString("\n" + errorStyle.Render(fmt.Sprintf("Ошибка: %v", p.err)) + "\n")
+	}
+	return b.String()
+}
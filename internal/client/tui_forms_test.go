@@ -0,0 +1,292 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/passgen"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// typeRunes вводит текст в форму посимвольно, как с клавиатуры.
+func typeRunes(model *TUIModel, text string) {
+	for _, r := range text {
+		model.updateCreate(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestTUIModel_CreateForm_FieldsByType(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+
+	// По умолчанию форма учетных данных
+	assert.Equal(t, []formField{fieldName, fieldDescription, fieldType, fieldLogin, fieldPassword, fieldURL, fieldRotationDays, fieldNotes, fieldMetadata},
+		model.createFields())
+
+	model.createTypeInput.SetValue("4")
+	assert.Contains(t, model.createFields(), fieldCardCVV)
+	assert.NotContains(t, model.createFields(), fieldLogin)
+	assert.NotContains(t, model.createFields(), fieldRotationDays)
+
+	model.createTypeInput.SetValue("11")
+	assert.Equal(t, []formField{fieldName, fieldDescription, fieldType, fieldMetadata}, model.createFields())
+	assert.Contains(t, model.viewCreate(), "Неверный тип данных")
+}
+
+func TestTUIModel_CreateForm_Navigation(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateMain
+	model.updateMain(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	require.True(t, model.createNameInput.Focused())
+
+	// Тип выбирается в третьем поле, затем фокус переходит к полям карты
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyTab})
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, model.createTypeInput.Focused())
+	typeRunes(model, "4")
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyDown})
+	assert.True(t, model.form.cardNumber.Focused())
+
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.True(t, model.createTypeInput.Focused())
+
+	// Переход с последнего поля возвращает к первому
+	model.focusCreateField(len(model.createFields()) - 1)
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyTab})
+	assert.True(t, model.createNameInput.Focused())
+
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMain, model.state)
+	assert.False(t, model.createNameInput.Focused())
+}
+
+func TestTUIModel_CreateCredentialsForm(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+
+	model.createNameInput.SetValue("GitHub")
+	model.form.login.SetValue(" alice ")
+	model.form.password.SetValue("s3cret")
+	model.form.url.SetValue("github.com")
+
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	require.True(t, ok)
+	assert.Contains(t, errMsg.error, "URL")
+
+	model.form.url.SetValue("https://github.com/login")
+	created := &pb.DataEntry{Id: "creds-id", Name: "GitHub"}
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var creds models.Credentials
+		if err := json.Unmarshal(req.EncryptedData, &creds); err != nil {
+			return false
+		}
+		return req.Type == pb.DataType_DATA_TYPE_CREDENTIALS && assert.ObjectsAreEqual(models.Credentials{
+			Login: "alice", Password: "s3cret", URL: "https://github.com/login",
+			CustomFields: models.CustomFields{SchemaVersion: models.PayloadSchemaVersion},
+		}, creds)
+	})).Return(created, nil)
+
+	_, ok = model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+
+	// После создания форма очищается
+	model.Update(dataCreatedMsg{entry: created})
+	assert.Empty(t, model.form.login.Value())
+	assert.Empty(t, model.createNameInput.Value())
+}
+
+func TestTUIModel_CreateCredentialsForm_GeneratePassword(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateCreate
+	model.createTypeInput.SetValue("1")
+
+	// Генератор доступен только в поле пароля
+	model.focusCreateField(3)
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlG})
+	assert.Empty(t, model.form.password.Value())
+
+	model.focusCreateField(4)
+	require.Equal(t, fieldPassword, model.focusedCreateField())
+	assert.Contains(t, model.viewCreate(), "режим генератора (случайный)")
+
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlG})
+	password := model.form.password.Value()
+	assert.Len(t, password, passgen.DefaultLength)
+	assert.True(t, model.form.showSecrets)
+	assert.Contains(t, model.viewCreate(), password)
+
+	// Ctrl+T переключает режим: произносимый пароль, затем парольная фраза
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlT})
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlT})
+	assert.Contains(t, model.viewCreate(), "режим генератора (парольная фраза)")
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlG})
+	assert.Len(t, strings.Split(model.form.password.Value(), "-"), passgen.DefaultWords)
+
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlT})
+	assert.Equal(t, GeneratorRandom, model.form.generatorMode)
+}
+
+func TestTUIModel_CreateCardForm(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	expiry := time.Now().AddDate(2, 0, 0).Format("01/06")
+
+	model.createNameInput.SetValue("Visa")
+	model.createTypeInput.SetValue("4")
+	model.form.cardNumber.SetValue("4111 1111 1111 1112")
+	model.form.cardExpiry.SetValue(expiry)
+	model.form.cardHolder.SetValue("ALICE")
+	model.form.cardCVV.SetValue("12")
+
+	// Номер маскируется вне фокуса, ошибка Луна видна сразу
+	view := model.viewCreate()
+	assert.Contains(t, view, "•••• •••• •••• 1112")
+	assert.NotContains(t, view, "4111 1111")
+	assert.Contains(t, view, "алгоритму Луна")
+
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	require.True(t, ok)
+	assert.Contains(t, errMsg.error, "Номер карты")
+	assert.Contains(t, errMsg.error, "CVV")
+
+	model.form.cardNumber.SetValue("4111-1111-1111-1111")
+	model.form.cardCVV.SetValue("123")
+	assert.Contains(t, model.viewCreate(), "контрольная сумма верна")
+
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var card models.CardData
+		if err := json.Unmarshal(req.EncryptedData, &card); err != nil {
+			return false
+		}
+		return req.Type == pb.DataType_DATA_TYPE_CARD && card.Number == "4111111111111111" &&
+			card.ExpiryDate == expiry && card.CVV == "123"
+	})).Return(&pb.DataEntry{Id: "card-id", Name: "Visa"}, nil)
+
+	_, ok = model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTUIModel_CreateCardForm_ShowSecrets(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateCreate
+	model.createTypeInput.SetValue("4")
+	model.form.cardNumber.SetValue("4111111111111111")
+	model.form.cardCVV.SetValue("987")
+
+	assert.NotContains(t, model.viewCreate(), "987")
+
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlR})
+	view := model.viewCreate()
+	assert.Contains(t, view, "987")
+	assert.Contains(t, view, "4111111111111111")
+}
+
+func TestMaskCardNumber(t *testing.T) {
+	assert.Equal(t, "•••• •••• •••• 1111", maskCardNumber("4111 1111 1111 1111"))
+	assert.Equal(t, "•••• •••• •••• 3456", maskCardNumber("1234-5678-9012-3456"))
+	assert.Equal(t, "123", maskCardNumber("123"))
+}
+
+func TestTUIModel_CreateTextForm(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateCreate
+	model.createNameInput.SetValue("Заметка")
+	model.createTypeInput.SetValue("2")
+
+	model.focusCreateField(3)
+	require.Equal(t, fieldText, model.focusedCreateField())
+
+	// Enter в многострочном тексте переводит строку, а не фокус
+	typeRunes(model, "first")
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	typeRunes(model, "second")
+	assert.Equal(t, fieldText, model.focusedCreateField())
+	assert.Equal(t, "first\nsecond", model.form.text.Value())
+
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var text models.TextData
+		if err := json.Unmarshal(req.EncryptedData, &text); err != nil {
+			return false
+		}
+		return req.Type == pb.DataType_DATA_TYPE_TEXT && text.Content == "first\nsecond"
+	})).Return(&pb.DataEntry{Id: "text-id", Name: "Заметка"}, nil)
+
+	_, ok := model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTUIModel_CreateBinaryForm(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docs", "note.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.bin"), nil, 0o600))
+
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateCreate
+	model.createTypeInput.SetValue("3")
+
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	require.True(t, ok)
+	assert.Contains(t, errMsg.error, "Название")
+
+	model.focusCreateField(3)
+	require.Equal(t, fieldFile, model.focusedCreateField())
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, model.form.pickingFile)
+
+	// Каталоги идут первыми, пустой файл не выбирается
+	model.form.picker.open(dir)
+	assert.Contains(t, model.viewCreate(), "docs"+string(filepath.Separator))
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyDown})
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.True(t, model.form.pickingFile)
+	assert.Contains(t, model.viewCreate(), "файл пуст")
+
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyUp})
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, filepath.Join(dir, "docs"), model.form.picker.dir)
+	model.updateCreate(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, model.form.pickingFile)
+	require.NotNil(t, model.form.file)
+	assert.Equal(t, "note.txt", model.createNameInput.Value())
+	assert.Contains(t, model.viewCreate(), "note.txt (5 байт, text/plain")
+
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var binary models.BinaryData
+		if err := json.Unmarshal(req.EncryptedData, &binary); err != nil {
+			return false
+		}
+		return req.Type == pb.DataType_DATA_TYPE_BINARY && binary.Filename == "note.txt" &&
+			string(binary.Content) == "hello" && binary.MimeType == "text/plain; charset=utf-8"
+	})).Return(&pb.DataEntry{Id: "file-id", Name: "note.txt"}, nil)
+
+	_, ok = model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestFilePicker_Parent(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("x"), 0o600))
+
+	var picker filePicker
+	picker.open(dir)
+	assert.Empty(t, picker.entries)
+
+	picker.update(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, filepath.Dir(dir), picker.dir)
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Сообщение с построенным отчетом о здоровье хранилища и результатами
+// проверки по базе утечек, если она включена
+type healthReportMsg struct {
+	report *HealthReport
+	breach *breachCheckMsg
+}
+
+// openHealth переключает TUI на экран здоровья хранилища и строит отчет.
+func (m *TUIModel) openHealth() tea.Cmd {
+	m.state = stateHealth
+	m.healthReport = nil
+	m.healthCursor = 0
+	m.healthDrill = false
+	m.healthIssueCursor = 0
+	return m.loadHealthReport()
+}
+
+// loadHealthReport загружает все записи, проверяет пароли по базе утечек
+// и строит отчет локально. Если база недоступна, отчет строится без нее.
+func (m *TUIModel) loadHealthReport() tea.Cmd {
+	checker := m.breachChecker
+	return func() tea.Msg {
+		ctx := context.Background()
+		entries, err := m.client.ListData(ctx, nil)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка загрузки записей: %v", err)}
+		}
+
+		var breached map[string]int
+		var check *breachCheckMsg
+		if checker != nil {
+			breached, err = CheckBreachedEntries(ctx, checker, entries)
+			check = &breachCheckMsg{breached: breached, err: err}
+		}
+		return healthReportMsg{report: BuildHealthReport(entries, breached, time.Now()), breach: check}
+	}
+}
+
+// selectedHealthIssues возвращает проблемы выбранного вида.
+func (m *TUIModel) selectedHealthIssues() []HealthIssue {
+	if m.healthReport == nil {
+		return nil
+	}
+	return m.healthReport.Issues[HealthIssueKinds[m.healthCursor]]
+}
+
+// updateHealth обрабатывает клавиши экрана здоровья. Enter на виде проблемы
+// показывает записи с ней, Enter на записи открывает ее просмотр.
+func (m *TUIModel) updateHealth(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.healthDrill {
+		issues := m.selectedHealthIssues()
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.healthDrill = false
+		case tea.KeyUp:
+			if m.healthIssueCursor > 0 {
+				m.healthIssueCursor--
+			}
+		case tea.KeyDown:
+			if m.healthIssueCursor < len(issues)-1 {
+				m.healthIssueCursor++
+			}
+		case tea.KeyEnter:
+			if m.healthIssueCursor < len(issues) {
+				entry := issues[m.healthIssueCursor].Entry
+				m.selectedEntry = &listItem{title: entry.Name, description: entry.Description, id: entry.Id}
+				m.viewFromHealth = true
+				m.state = stateView
+				m.message = ""
+				return m, m.loadDataEntry(entry.Id)
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.state = stateMain
+	case tea.KeyUp:
+		if m.healthCursor > 0 {
+			m.healthCursor--
+		}
+	case tea.KeyDown:
+		if m.healthCursor < len(HealthIssueKinds)-1 {
+			m.healthCursor++
+		}
+	case tea.KeyEnter:
+		if len(m.selectedHealthIssues()) > 0 {
+			m.healthDrill = true
+			m.healthIssueCursor = 0
+		}
+	case tea.KeyRunes:
+		if string(msg.Runes) == "r" {
+			return m, m.loadHealthReport()
+		}
+	}
+	return m, nil
+}
+
+// returnToHealth возвращает TUI из просмотра записи на экран здоровья
+// и перестраивает отчет, так как запись могла измениться.
+func (m *TUIModel) returnToHealth() tea.Cmd {
+	m.viewFromHealth = false
+	m.state = stateHealth
+	return m.loadHealthReport()
+}
+
+// setHealthReport сохраняет отчет, не давая курсорам выйти за границы.
+func (m *TUIModel) setHealthReport(report *HealthReport) {
+	m.healthReport = report
+	if m.healthDrill && len(m.selectedHealthIssues()) == 0 {
+		m.healthDrill = false
+	}
+	if issues := m.selectedHealthIssues(); m.healthIssueCursor >= len(issues) {
+		m.healthIssueCursor = max(len(issues)-1, 0)
+	}
+}
+
+// viewHealth отображает отчет о здоровье хранилища или записи выбранного вида проблем.
+func (m *TUIModel) viewHealth() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🩺 Здоровье хранилища"))
+	b.WriteString("\n\n")
+
+	report := m.healthReport
+	if report == nil {
+		b.WriteString(helpStyle.Render("⏳ Проверка записей..."))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("Esc: назад"))
+		return containerStyle.Render(b.String())
+	}
+
+	if m.healthDrill {
+		kind := HealthIssueKinds[m.healthCursor]
+		b.WriteString(fmt.Sprintf("%s: %d\n\n", kind, report.Count(kind)))
+		for i, issue := range m.selectedHealthIssues() {
+			cursor := "  "
+			if i == m.healthIssueCursor {
+				cursor = "> "
+			}
+			b.WriteString(fmt.Sprintf("%s%-30s %s\n", cursor, issue.Entry.Name, helpStyle.Render(issue.Detail)))
+		}
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("↑/↓: выбор • Enter: открыть запись • Esc: к списку проблем"))
+		return containerStyle.Render(b.String())
+	}
+
+	b.WriteString(fmt.Sprintf("Проверено паролей: %d, без проблем: %d\n", report.Passwords, report.Healthy))
+	switch {
+	case m.breachChecker == nil:
+		b.WriteString(helpStyle.Render("Проверка по базе утечек отключена (-breach-dir или -breach-url)") + "\n")
+	case m.breachError != "":
+		b.WriteString(errorStyle.Render("Ошибка проверки по базе утечек: "+m.breachError) + "\n")
+	}
+	b.WriteString("\n")
+	for i, kind := range HealthIssueKinds {
+		cursor := "  "
+		if i == m.healthCursor {
+			cursor = "> "
+		}
+		count := report.Count(kind)
+		line := fmt.Sprintf("%s%-36s %d", cursor, kind, count)
+		if count > 0 {
+			line = errorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if m.message != "" {
+		b.WriteString("\n" + errorStyle.Render(m.message) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓: выбор • Enter: записи с проблемой • r: проверить заново • Esc: назад"))
+	return containerStyle.Render(b.String())
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTUIModel_Health(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateMain
+
+	entries := healthEntries(time.Now())
+	mockClient.On("ListData", mock.Anything, (*pb.DataType)(nil)).Return(entries, nil)
+
+	_, cmd := model.updateMain(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, stateHealth, model.state)
+	assert.Contains(t, model.View(), "Проверка записей")
+
+	model.Update(cmd())
+	require.NotNil(t, model.healthReport)
+	view := model.View()
+	assert.Contains(t, view, "Здоровье хранилища")
+	assert.Contains(t, view, "Проверено паролей: 4, без проблем: 1")
+	assert.Contains(t, view, "Слабые пароли")
+
+	// Без проверки по базе утечек скомпрометированных паролей нет
+	assert.Contains(t, view, "Проверка по базе утечек отключена")
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyEnter})
+	require.False(t, model.healthDrill)
+
+	// Переход к записям со слабыми паролями
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyDown})
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, model.healthDrill)
+	assert.Contains(t, model.View(), "Почта")
+
+	// Открытие записи и возврат на экран здоровья
+	mockClient.On("GetData", mock.Anything, "4").Return(entries[3], nil)
+	mockClient.On("ListAttachments", mock.Anything, "4").Return([]*pb.Attachment{}, nil)
+	_, cmd = model.updateHealth(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, stateView, model.state)
+	assert.True(t, model.viewFromHealth)
+	model.Update(cmd())
+	require.NotNil(t, model.viewingEntry)
+	assert.Equal(t, "Дом", model.viewingEntry.Name)
+
+	_, cmd = model.updateView(tea.KeyMsg{Type: tea.KeyEsc})
+	require.NotNil(t, cmd)
+	assert.Equal(t, stateHealth, model.state)
+	assert.False(t, model.viewFromHealth)
+	assert.True(t, model.healthDrill)
+
+	// Esc возвращает к видам проблем, затем в главное меню
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, model.healthDrill)
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMain, model.state)
+}
+
+func TestTUIModel_Health_EmptyCategory(t *testing.T) {
+	model := NewTUIModel(&MockClient{}, zap.NewNop())
+	model.state = stateHealth
+	model.setHealthReport(BuildHealthReport(nil, nil, time.Now()))
+
+	// Вид проблем без записей не открывается
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, model.healthDrill)
+
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, model.healthCursor)
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyUp})
+	model.updateHealth(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, 0, model.healthCursor)
+}
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/otp"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// Интервал обновления кодов на доске OTP
+const otpBoardRefreshInterval = time.Second
+
+// Ширина полосы обратного отсчета в символах
+const otpBoardBarWidth = 20
+
+// copyToClipboard копирует текст в буфер обмена, подменяется в тестах.
+var copyToClipboard = clipboard.WriteAll
+
+// otpBoardItem представляет запись OTP на доске кодов.
+type otpBoardItem struct {
+	entry *pb.DataEntry
+	data  *models.OTPData
+	err   error
+}
+
+// name возвращает отображаемое имя записи для поиска и вывода.
+func (i otpBoardItem) name() string {
+	if i.data != nil && i.data.AccountName != "" {
+		return i.entry.Name + " (" + i.data.AccountName + ")"
+	}
+	return i.entry.Name
+}
+
+// otpBoardCode содержит код записи, вычисленный на момент отрисовки.
+type otpBoardCode struct {
+	code      string
+	remaining int
+	period    int
+	err       error
+}
+
+// code вычисляет текущий код записи локально, секреты на сервер не передаются.
+func (i otpBoardItem) code(now time.Time) otpBoardCode {
+	if i.err != nil {
+		return otpBoardCode{err: i.err}
+	}
+	if i.data.Type == otp.TypeHOTP {
+		return otpBoardCode{err: fmt.Errorf("HOTP: код выдается на экране записи")}
+	}
+
+	params := otpKeyFromData(i.data).Params
+	code, err := otp.GenerateCodeAt(i.data.Secret, params, now)
+	if err != nil {
+		return otpBoardCode{err: err}
+	}
+
+	period := params.Period
+	if period == 0 {
+		period = otp.DefaultPeriod
+	}
+	return otpBoardCode{code: code, remaining: params.TimeRemaining(now), period: period}
+}
+
+// Сообщения доски OTP
+type otpBoardMsg struct{ items []otpBoardItem }
+type otpBoardTickMsg struct{ generation int }
+
+// openOTPBoard переключает TUI на доску кодов и загружает записи OTP.
+func (m *TUIModel) openOTPBoard() tea.Cmd {
+	m.state = stateOTPBoard
+	m.otpBoardCursor = 0
+	m.otpBoardFilter.SetValue("")
+	m.otpBoardFilter.Focus()
+	m.otpBoardMessage = ""
+	// Цепочка обновлений от прошлого открытия доски могла еще не прерваться
+	m.otpBoardGeneration++
+	return tea.Batch(m.loadOTPBoard(), m.otpBoardTick())
+}
+
+// loadOTPBoard загружает записи OTP и разбирает их содержимое.
+func (m *TUIModel) loadOTPBoard() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		otpType := pb.DataType_DATA_TYPE_OTP
+		entries, err := m.client.ListData(ctx, &otpType)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("ошибка загрузки кодов: %v", err)}
+		}
+
+		items := make([]otpBoardItem, 0, len(entries))
+		for _, entry := range entries {
+			data, err := ParseOTPData(entry.EncryptedData)
+			items = append(items, otpBoardItem{entry: entry, data: data, err: err})
+		}
+		return otpBoardMsg{items: items}
+	}
+}
+
+// otpBoardTick планирует следующее обновление доски кодов текущего открытия доски.
+func (m *TUIModel) otpBoardTick() tea.Cmd {
+	generation := m.otpBoardGeneration
+	return tea.Tick(otpBoardRefreshInterval, func(time.Time) tea.Msg {
+		return otpBoardTickMsg{generation: generation}
+	})
+}
+
+// filteredOTPBoard возвращает записи, подходящие под нечеткий фильтр,
+// в порядке убывания релевантности.
+func (m *TUIModel) filteredOTPBoard() []otpBoardItem {
+	pattern := strings.TrimSpace(m.otpBoardFilter.Value())
+	if pattern == "" {
+		return m.otpBoardItems
+	}
+
+	names := make([]string, len(m.otpBoardItems))
+	for i, item := range m.otpBoardItems {
+		names[i] = item.name()
+	}
+
+	matches := fuzzy.Find(pattern, names)
+	items := make([]otpBoardItem, 0, len(matches))
+	for _, match := range matches {
+		items = append(items, m.otpBoardItems[match.Index])
+	}
+	return items
+}
+
+// updateOTPBoard обрабатывает клавиши на доске кодов. Ввод текста
+// изменяет фильтр, Enter копирует код выбранной записи.
+func (m *TUIModel) updateOTPBoard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := m.filteredOTPBoard()
+
+	switch msg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.otpBoardFilter.Blur()
+		m.state = stateMain
+		return m, nil
+	case tea.KeyUp:
+		if m.otpBoardCursor > 0 {
+			m.otpBoardCursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.otpBoardCursor < len(items)-1 {
+			m.otpBoardCursor++
+		}
+		return m, nil
+	case tea.KeyEnter:
+		if m.otpBoardCursor < len(items) {
+			m.copyOTPBoardCode(items[m.otpBoardCursor])
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.otpBoardFilter, cmd = m.otpBoardFilter.Update(msg)
+	m.otpBoardCursor = 0
+	return m, cmd
+}
+
+// copyOTPBoardCode копирует текущий код записи в буфер обмена.
+func (m *TUIModel) copyOTPBoardCode(item otpBoardItem) {
+	code := item.code(time.Now())
+	if code.err != nil {
+		m.otpBoardMessage = errorStyle.Render("Ошибка: " + code.err.Error())
+		return
+	}
+	if err := copyToClipboard(code.code); err != nil {
+		m.otpBoardMessage = errorStyle.Render("Ошибка копирования: " + err.Error())
+		return
+	}
+	m.otpBoardMessage = fmt.Sprintf("Код %s скопирован в буфер обмена", item.entry.Name)
+}
+
+// viewOTPBoard отображает доску кодов с обратным отсчетом.
+func (m *TUIModel) viewOTPBoard() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⏱️ Коды OTP"))
+	b.WriteString("\n\n")
+	b.WriteString("Поиск: " + m.otpBoardFilter.View() + "\n\n")
+
+	items := m.filteredOTPBoard()
+	if len(m.otpBoardItems) == 0 {
+		b.WriteString("Нет сохраненных записей OTP\n")
+	} else if len(items) == 0 {
+		b.WriteString("Ничего не найдено\n")
+	}
+
+	now := time.Now()
+	for i, item := range items {
+		cursor := "  "
+		if i == m.otpBoardCursor {
+			cursor = "> "
+		}
+
+		code := item.code(now)
+		if code.err != nil {
+			b.WriteString(fmt.Sprintf("%s%-30s %s\n", cursor, item.name(), helpStyle.Render(code.err.Error())))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s%-30s %s %s %2dс\n", cursor, item.name(),
+			otpCodeStyle.Render(formatOTPCode(code.code)), renderCountdownBar(code.remaining, code.period), code.remaining))
+	}
+
+	if m.otpBoardMessage != "" {
+		b.WriteString("\n" + m.otpBoardMessage + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓: выбор • Enter: копировать код • ввод текста: поиск • Esc: назад"))
+	return containerStyle.Render(b.String())
+}
+
+// formatOTPCode разделяет код пробелом посередине для удобства чтения.
+func formatOTPCode(code string) string {
+	half := len(code) / 2
+	return code[:half] + " " + code[half:]
+}
+
+// renderCountdownBar рисует полосу оставшегося времени действия кода.
+// Последние пять секунд полоса выделяется цветом.
+func renderCountdownBar(remaining, period int) string {
+	filled := otpBoardBarWidth * remaining / period
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", otpBoardBarWidth-filled)
+	if remaining <= 5 {
+		return errorStyle.Render(bar)
+	}
+	return otpBarStyle.Render(bar)
+}
+
+// Стили доски OTP
+var (
+	otpCodeStyle = lipgloss.NewStyle().Bold(true)
+
+	otpBarStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#04B575"))
+)
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/otp"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// otpBoardEntries возвращает записи OTP для тестов доски кодов
+func otpBoardEntries() []*pb.DataEntry {
+	return []*pb.DataEntry{
+		{Id: "1", Name: "GitHub", Type: pb.DataType_DATA_TYPE_OTP,
+			EncryptedData: []byte(`{"secret":"JBSWY3DPEHPK3PXP","account_name":"alice"}`)},
+		{Id: "2", Name: "Google", Type: pb.DataType_DATA_TYPE_OTP,
+			EncryptedData: []byte(`{"secret":"GEZDGNBVGY3TQOJQ","digits":8,"period":60}`)},
+		{Id: "3", Name: "Bank", Type: pb.DataType_DATA_TYPE_OTP,
+			EncryptedData: []byte(`{"type":"hotp","secret":"JBSWY3DPEHPK3PXP","counter":1}`)},
+	}
+}
+
+// newOTPBoardModel открывает доску кодов с загруженными записями
+func newOTPBoardModel(t *testing.T) (*TUIModel, *MockClient) {
+	logger, _ := zap.NewDevelopment()
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, logger)
+	model.state = stateMain
+
+	otpType := pb.DataType_DATA_TYPE_OTP
+	mockClient.On("ListData", mock.Anything, &otpType).Return(otpBoardEntries(), nil)
+
+	_, cmd := model.updateMain(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, stateOTPBoard, model.state)
+
+	model.Update(model.loadOTPBoard()())
+	assert.Len(t, model.otpBoardItems, 3)
+	return model, mockClient
+}
+
+func TestTUIModel_OTPBoard_View(t *testing.T) {
+	model, mockClient := newOTPBoardModel(t)
+	mockClient.AssertExpectations(t)
+
+	view := model.View()
+	assert.Contains(t, view, "Коды OTP")
+	assert.Contains(t, view, "GitHub (alice)")
+	assert.Contains(t, view, "Google")
+	assert.Contains(t, view, "HOTP")
+	// Полоса обратного отсчета, в последнюю секунду периода она пуста
+	assert.Regexp(t, fmt.Sprintf("[█░]{%d}", otpBoardBarWidth), view)
+
+	// Код вычисляется локально с параметрами записи
+	code, err := otp.GenerateCodeAt("GEZDGNBVGY3TQOJQ", otp.Params{Digits: 8, Period: 60}, time.Now())
+	assert.NoError(t, err)
+	assert.Contains(t, view, formatOTPCode(code))
+}
+
+func TestTUIModel_OTPBoard_FilterAndCopy(t *testing.T) {
+	model, _ := newOTPBoardModel(t)
+
+	var copied string
+	originalCopy := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = originalCopy }()
+
+	// Нечеткий фильтр: "gogl" находит Google
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("gogl")})
+	items := model.filteredOTPBoard()
+	assert.Len(t, items, 1)
+	assert.Equal(t, "Google", items[0].entry.Name)
+	assert.NotContains(t, model.View(), "GitHub")
+
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Len(t, copied, 8)
+	assert.Contains(t, model.View(), "скопирован")
+
+	// Ошибка буфера обмена показывается пользователю
+	copyToClipboard = func(string) error { return errors.New("no clipboard") }
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, model.View(), "no clipboard")
+}
+
+func TestTUIModel_OTPBoard_Navigation(t *testing.T) {
+	model, _ := newOTPBoardModel(t)
+
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyDown})
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyDown})
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 2, model.otpBoardCursor)
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, 1, model.otpBoardCursor)
+
+	// Тик продолжает обновление только на доске кодов
+	tick := otpBoardTickMsg{generation: model.otpBoardGeneration}
+	_, cmd := model.Update(tick)
+	assert.NotNil(t, cmd)
+
+	model.updateOTPBoard(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, stateMain, model.state)
+	_, cmd = model.Update(tick)
+	assert.Nil(t, cmd)
+
+	// После повторного открытия доски цепочка прошлого открытия не продолжается
+	model.updateMain(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	assert.Equal(t, stateOTPBoard, model.state)
+	_, cmd = model.Update(tick)
+	assert.Nil(t, cmd)
+	_, cmd = model.Update(otpBoardTickMsg{generation: model.otpBoardGeneration})
+	assert.NotNil(t, cmd)
+}
+
+func TestRenderCountdownBar(t *testing.T) {
+	bar := renderCountdownBar(15, 30)
+	assert.Equal(t, otpBoardBarWidth/2, strings.Count(bar, "█"))
+	assert.Equal(t, otpBoardBarWidth/2, strings.Count(bar, "░"))
+	assert.Equal(t, "1234 5678", formatOTPCode("12345678"))
+	assert.Equal(t, "123 456", formatOTPCode("123456"))
+}
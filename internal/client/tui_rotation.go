@@ -0,0 +1,160 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+)
+
+// Отметки в списке записей: пора сменить пароль, истекает срок действия карты
+const (
+	rotationBadge   = "🔄 "
+	cardExpiryBadge = "💳 "
+)
+
+// rotationReminderDays - за сколько дней до срока смены пароля запись
+// отмечается в списке и попадает в напоминание на главном экране.
+const rotationReminderDays = 7
+
+// rotatableType проверяет, действует ли на записи типа политика смены паролей.
+func rotatableType(dataType pb.DataType) bool {
+	switch dataType {
+	case pb.DataType_DATA_TYPE_CREDENTIALS, pb.DataType_DATA_TYPE_WIFI, pb.DataType_DATA_TYPE_API_KEY:
+		return true
+	default:
+		return false
+	}
+}
+
+// rotationDueSoon проверяет, наступит ли срок смены пароля записи
+// в ближайшие rotationReminderDays дней или он уже прошел.
+func rotationDueSoon(entry *pb.DataEntry, now time.Time) bool {
+	if entry.RotationDueAt == nil {
+		return false
+	}
+	return !entry.RotationDueAt.AsTime().After(now.AddDate(0, 0, rotationReminderDays))
+}
+
+// countRotationDue возвращает число записей, пароли которых пора сменить.
+func countRotationDue(entries []*pb.DataEntry, now time.Time) int {
+	count := 0
+	for _, entry := range entries {
+		if rotationDueSoon(entry, now) {
+			count++
+		}
+	}
+	return count
+}
+
+// entryCardExpiry возвращает срок действия карты или пустую строку,
+// если запись не является картой или срок не указан.
+func entryCardExpiry(entry *pb.DataEntry) string {
+	if entry.Type != pb.DataType_DATA_TYPE_CARD {
+		return ""
+	}
+	var card models.CardData
+	if err := json.Unmarshal(entry.EncryptedData, &card); err != nil {
+		return ""
+	}
+	return card.ExpiryDate
+}
+
+// entryStatusBadges возвращает отметки записи в списке: пора сменить пароль
+// или срок действия карты истек либо скоро истекает.
+func entryStatusBadges(entry *pb.DataEntry, now time.Time) string {
+	var badges string
+	if rotationDueSoon(entry, now) {
+		badges += rotationBadge
+	}
+	if _, ok := cardExpiryIssue(entryCardExpiry(entry), now); ok {
+		badges += cardExpiryBadge
+	}
+	return badges
+}
+
+// rotationStatus описывает политику смены пароля записи и срок следующей смены.
+func rotationStatus(entry *pb.DataEntry, now time.Time) string {
+	if entry.RotationDueAt == nil {
+		return ""
+	}
+
+	due := entry.RotationDueAt.AsTime()
+	policy := "по политике коллекции"
+	if entry.RotationDays > 0 {
+		policy = fmt.Sprintf("каждые %d дн.", entry.RotationDays)
+	}
+	line := fmt.Sprintf("Смена пароля: %s, до %s", policy, due.Local().Format("02.01.2006"))
+
+	days := int(due.Sub(now).Hours() / 24)
+	switch {
+	case !due.After(now):
+		return errorStyle.Render(fmt.Sprintf("%s%s (просрочена на %d дн.)", rotationBadge, line, -days)) + "\n"
+	case rotationDueSoon(entry, now):
+		return errorStyle.Render(fmt.Sprintf("%s%s (осталось %d дн.)", rotationBadge, line, days)) + "\n"
+	default:
+		return line + "\n"
+	}
+}
+
+// viewRotationWarning показывает срок смены пароля записи и предупреждает
+// об истекшем или скоро истекающем сроке действия карты.
+func (m *TUIModel) viewRotationWarning(entry *pb.DataEntry) string {
+	now := time.Now()
+	view := rotationStatus(entry, now)
+	if detail, ok := cardExpiryIssue(entryCardExpiry(entry), now); ok {
+		view += errorStyle.Render(cardExpiryBadge+"Карта: "+detail) + "\n"
+	}
+	return view
+}
+
+// formRotationDays разбирает поле периода смены пароля формы.
+// Для типов без пароля и пустого поля политика не задается.
+func (m *TUIModel) formRotationDays(dataType pb.DataType) (int32, string) {
+	value := strings.TrimSpace(m.form.rotationDays.Value())
+	if !rotatableType(dataType) || value == "" {
+		return 0, ""
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > models.MaxRotationDays {
+		return 0, fmt.Sprintf("Период смены пароля должен быть от 1 до %d дней", models.MaxRotationDays)
+	}
+	return int32(days), ""
+}
+
+// rotationSecret возвращает секрет записи, смена которого обновляет
+// время смены пароля: пароль учетных данных и сети, ключ и секрет API.
+func rotationSecret(dataType pb.DataType, data []byte) string {
+	switch dataType {
+	case pb.DataType_DATA_TYPE_CREDENTIALS:
+		var creds models.Credentials
+		if err := json.Unmarshal(data, &creds); err != nil {
+			return string(data)
+		}
+		return creds.Password
+	case pb.DataType_DATA_TYPE_WIFI:
+		var wifi models.WiFiData
+		if err := json.Unmarshal(data, &wifi); err != nil {
+			return ""
+		}
+		return wifi.Password
+	case pb.DataType_DATA_TYPE_API_KEY:
+		var key models.APIKeyData
+		if err := json.Unmarshal(data, &key); err != nil {
+			return ""
+		}
+		return key.Key + "\x00" + key.Secret
+	default:
+		return ""
+	}
+}
+
+// passwordChanged проверяет, изменился ли секрет записи при редактировании.
+func passwordChanged(dataType pb.DataType, before, after []byte) bool {
+	return rotatableType(dataType) && rotationSecret(dataType, before) != rotationSecret(dataType, after)
+}
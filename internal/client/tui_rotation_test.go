@@ -0,0 +1,138 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rotationEntries возвращает записи с просроченной, близкой и далекой сменой
+// пароля и карту, срок действия которой истек
+func rotationEntries(now time.Time) []*pb.DataEntry {
+	return []*pb.DataEntry{
+		{Id: "1", Name: "Почта", Type: pb.DataType_DATA_TYPE_CREDENTIALS, RotationDays: 90,
+			PasswordChangedAt: timestamppb.New(now.AddDate(0, 0, -100)),
+			RotationDueAt:     timestamppb.New(now.AddDate(0, 0, -10)),
+			EncryptedData:     []byte(`{"login":"alice","password":"correct-horse-battery-staple"}`)},
+		{Id: "2", Name: "VPN", Type: pb.DataType_DATA_TYPE_CREDENTIALS,
+			PasswordChangedAt: timestamppb.New(now.AddDate(0, 0, -27)),
+			RotationDueAt:     timestamppb.New(now.AddDate(0, 0, 3)),
+			EncryptedData:     []byte(`{"login":"alice","password":"correct-horse-battery-staple"}`)},
+		{Id: "3", Name: "GitHub", Type: pb.DataType_DATA_TYPE_CREDENTIALS, RotationDays: 90,
+			PasswordChangedAt: timestamppb.New(now.AddDate(0, 0, -1)),
+			RotationDueAt:     timestamppb.New(now.AddDate(0, 0, 89)),
+			EncryptedData:     []byte(`{"login":"alice","password":"correct-horse-battery-staple"}`)},
+		{Id: "4", Name: "Старая карта", Type: pb.DataType_DATA_TYPE_CARD,
+			EncryptedData: []byte(`{"number":"4111111111111111","expiry_date":"01/20","holder":"ALICE","cvv":"123"}`)},
+	}
+}
+
+func TestTUIModel_RotationBadges(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateMain
+
+	entries := rotationEntries(time.Now())
+	model.Update(dataListMsg{entries: entries})
+
+	titles := make(map[string]string)
+	for _, item := range model.list.Items() {
+		titles[item.(*listItem).id] = item.(*listItem).title
+	}
+	assert.Equal(t, rotationBadge+"Почта", titles["1"])
+	assert.Equal(t, rotationBadge+"VPN", titles["2"])
+	assert.Equal(t, "GitHub", titles["3"])
+	assert.Equal(t, cardExpiryBadge+"Старая карта", titles["4"])
+
+	// Напоминание на главном экране
+	assert.Equal(t, 2, model.rotationDue)
+	assert.Contains(t, model.View(), "Пора сменить пароли: 2 записей")
+
+	// Предупреждения на экране записи
+	model.state = stateView
+	mockClient.On("ListAttachments", mock.Anything, mock.Anything).Return([]*pb.Attachment{}, nil)
+	model.Update(dataEntryLoadedMsg{entry: entries[0]})
+	assert.Contains(t, model.View(), "каждые 90 дн.")
+	assert.Contains(t, model.View(), "просрочена на 10 дн.")
+
+	model.Update(dataEntryLoadedMsg{entry: entries[1]})
+	assert.Contains(t, model.View(), "по политике коллекции")
+
+	model.Update(dataEntryLoadedMsg{entry: entries[3]})
+	assert.Contains(t, model.View(), "срок действия 01/20 истек")
+}
+
+func TestTUIModel_EditEntry_Rotation(t *testing.T) {
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, zap.NewNop())
+	model.state = stateView
+	entry := credentialsEntry(t, 1, models.Credentials{Login: "alice", Password: "old"})
+	entry.RotationDays = 90
+	model.viewingEntry = entry
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.Equal(t, stateCreate, model.state)
+	assert.Equal(t, "90", model.form.rotationDays.Value())
+
+	// Неверный период не отправляется на сервер
+	model.form.rotationDays.SetValue("0")
+	_, cmd := model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	assert.Equal(t, errorMsg{error: "Период смены пароля должен быть от 1 до 3650 дней"}, cmd())
+
+	// Изменение логина не считается сменой пароля
+	model.form.rotationDays.SetValue("30")
+	model.form.login.SetValue("bob")
+	mockClient.On("UpdateData", mock.Anything, mock.MatchedBy(func(req *pb.UpdateDataRequest) bool {
+		return req.GetRotationDays() == 30 && !req.PasswordChanged
+	})).Return(entry, nil).Once()
+	_, cmd = model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	_, ok := cmd().(dataUpdatedMsg)
+	require.True(t, ok)
+
+	model.form.password.SetValue("new")
+	mockClient.On("UpdateData", mock.Anything, mock.MatchedBy(func(req *pb.UpdateDataRequest) bool {
+		return req.GetRotationDays() == 30 && req.PasswordChanged
+	})).Return(entry, nil).Once()
+	_, cmd = model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	_, ok = cmd().(dataUpdatedMsg)
+	require.True(t, ok)
+
+	// Пустое поле снимает политику явным нулем
+	model.form.rotationDays.SetValue("")
+	mockClient.On("UpdateData", mock.Anything, mock.MatchedBy(func(req *pb.UpdateDataRequest) bool {
+		return req.RotationDays != nil && *req.RotationDays == 0
+	})).Return(entry, nil).Once()
+	_, cmd = model.updateCreate(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	_, ok = cmd().(dataUpdatedMsg)
+	require.True(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBuildHealthReport_RotationPolicy(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	report := BuildHealthReport(rotationEntries(now), nil, now)
+
+	// Срок смены по политике важнее общего ограничения возраста пароля
+	assert.Equal(t, []string{"Почта"}, issueNames(report, HealthOld))
+	assert.Contains(t, report.Issues[HealthOld][0].Detail, "просрочена на 10 дн.")
+
+	entry := &pb.DataEntry{
+		PasswordChangedAt: timestamppb.New(now.AddDate(-2, 0, 0)),
+		UpdatedAt:         timestamppb.New(now),
+	}
+	detail, ok := passwordAgeIssue(entry, now)
+	assert.True(t, ok)
+	assert.Equal(t, "не менялся 730 дн.", detail)
+}
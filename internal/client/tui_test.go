@@ -2,9 +2,15 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/otp"
 	pb "github.com/GophKeeper/proto/gen/proto"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +22,11 @@ import (
 // MockClient - мок для тестирования
 type MockClient struct {
 	mock.Mock
+	vaultKey []byte
+}
+
+func (m *MockClient) VaultKey() []byte {
+	return m.vaultKey
 }
 
 func (m *MockClient) Login(ctx context.Context, username, password string) error {
@@ -53,6 +64,31 @@ func (m *MockClient) CreateOTPSecret(ctx context.Context, issuer, accountName st
 	return args.Get(0).(*pb.CreateOTPSecretResponse), args.Error(1)
 }
 
+func (m *MockClient) NextHOTPCode(ctx context.Context, entry *pb.DataEntry, entryKey []byte) (string, *pb.DataEntry, error) {
+	args := m.Called(ctx, entry, entryKey)
+	return args.String(0), args.Get(1).(*pb.DataEntry), args.Error(2)
+}
+
+func (m *MockClient) AddAttachment(ctx context.Context, entryID, name string, content, entryKey []byte) (*pb.Attachment, error) {
+	args := m.Called(ctx, entryID, name, content, entryKey)
+	return args.Get(0).(*pb.Attachment), args.Error(1)
+}
+
+func (m *MockClient) ListAttachments(ctx context.Context, entryID string) ([]*pb.Attachment, error) {
+	args := m.Called(ctx, entryID)
+	return args.Get(0).([]*pb.Attachment), args.Error(1)
+}
+
+func (m *MockClient) GetAttachment(ctx context.Context, entryID, attachmentID string, entryKey []byte) (*pb.Attachment, []byte, error) {
+	args := m.Called(ctx, entryID, attachmentID, entryKey)
+	return args.Get(0).(*pb.Attachment), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *MockClient) RemoveAttachment(ctx context.Context, entryID, attachmentID string) error {
+	args := m.Called(ctx, entryID, attachmentID)
+	return args.Error(0)
+}
+
 func (m *MockClient) GenerateOTP(ctx context.Context, secret string) (*pb.GenerateOTPResponse, error) {
 	args := m.Called(ctx, secret)
 	return args.Get(0).(*pb.GenerateOTPResponse), args.Error(1)
@@ -699,3 +735,147 @@ func TestTUIModel_LoadDataEntry_Command(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestTUIModel_CreateOTPEntry_Command(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, logger)
+
+	model.createNameInput.SetValue("GitHub")
+	model.createDataInput.SetValue("gezd gnbv gy3t qojq")
+	model.createTypeInput.SetValue("5")
+
+	created := &pb.DataEntry{Id: "otp-id", Type: pb.DataType_DATA_TYPE_OTP}
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var data models.OTPData
+		if err := json.Unmarshal(req.EncryptedData, &data); err != nil {
+			return false
+		}
+		return req.Type == pb.DataType_DATA_TYPE_OTP && data.Secret == "GEZDGNBVGY3TQOJQ" && data.Issuer == "GitHub"
+	})).Return(created, nil)
+
+	msg := model.createDataEntry()()
+	createdMsg, ok := msg.(dataCreatedMsg)
+	assert.True(t, ok)
+	assert.Equal(t, created, createdMsg.entry)
+	mockClient.AssertExpectations(t)
+
+	// Секрет не в base32 отклоняется до обращения к серверу
+	model.createDataInput.SetValue("not a secret!")
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	assert.True(t, ok)
+	assert.Contains(t, errMsg.error, "OTP")
+}
+
+func TestTUIModel_CreateOTPEntryFromURI_Command(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, logger)
+
+	// Тип не указан: otpauth:// URI определяет запись OTP
+	model.createNameInput.SetValue("Bank")
+	model.createDataInput.SetValue("otpauth://hotp/Bank:alice?secret=JBSWY3DPEHPK3PXP&algorithm=SHA512&digits=8&counter=5")
+
+	created := &pb.DataEntry{Id: "otp-id", Type: pb.DataType_DATA_TYPE_OTP}
+	mockClient.On("CreateData", mock.Anything, mock.MatchedBy(func(req *pb.CreateDataRequest) bool {
+		var data models.OTPData
+		if err := json.Unmarshal(req.EncryptedData, &data); err != nil {
+			return false
+		}
+		return req.Type == pb.DataType_DATA_TYPE_OTP && data.Type == "hotp" &&
+			data.Algorithm == "SHA512" && data.Digits == 8 && data.Counter == 5 && data.Issuer == "Bank"
+	})).Return(created, nil)
+
+	_, ok := model.createDataEntry()().(dataCreatedMsg)
+	assert.True(t, ok)
+	mockClient.AssertExpectations(t)
+
+	model.createDataInput.SetValue("otpauth://hotp/Bank:alice?secret=JBSWY3DPEHPK3PXP")
+	errMsg, ok := model.createDataEntry()().(errorMsg)
+	assert.True(t, ok)
+	assert.Contains(t, errMsg.error, "otpauth")
+}
+
+func TestTUIModel_UpdateView_NextHOTPCode(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, logger)
+	model.state = stateView
+
+	entry := &pb.DataEntry{
+		Id:            "hotp-id",
+		Name:          "Bank",
+		Type:          pb.DataType_DATA_TYPE_OTP,
+		EncryptedData: []byte(`{"type":"hotp","secret":"JBSWY3DPEHPK3PXP","counter":3}`),
+		Version:       1,
+	}
+	model.viewingEntry = entry
+	assert.Contains(t, model.View(), "Счетчик HOTP: 3")
+	assert.NotContains(t, model.View(), "Текущий OTP")
+
+	updated := &pb.DataEntry{
+		Id:            "hotp-id",
+		Name:          "Bank",
+		Type:          pb.DataType_DATA_TYPE_OTP,
+		EncryptedData: []byte(`{"type":"hotp","secret":"JBSWY3DPEHPK3PXP","counter":4}`),
+		Version:       2,
+	}
+	mockClient.On("NextHOTPCode", mock.Anything, entry, []byte(nil)).Return("123456", updated, nil)
+
+	_, cmd := model.updateView(tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.NotNil(t, cmd)
+	newModel, _ := model.Update(cmd())
+	updatedModel := newModel.(*TUIModel)
+
+	assert.Equal(t, updated, updatedModel.viewingEntry)
+	view := updatedModel.View()
+	assert.Contains(t, view, "Код HOTP: 123456")
+	assert.Contains(t, view, "Счетчик HOTP: 4")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDecodeOTPQRCodeFile(t *testing.T) {
+	uri := "otpauth://totp/GitHub:alice?secret=JBSWY3DPEHPK3PXP&issuer=GitHub&algorithm=SHA1&digits=6&period=30"
+	data, err := otp.QRCodePNG(uri, 256)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "qr.png")
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+
+	otpData, err := DecodeOTPQRCodeFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", otpData.Secret)
+	assert.Equal(t, "GitHub", otpData.Issuer)
+	assert.Equal(t, "alice", otpData.AccountName)
+
+	_, err = DecodeOTPQRCodeFile(filepath.Join(t.TempDir(), "missing.png"))
+	assert.Error(t, err)
+}
+
+func TestTUIModel_View_OTPEntryQRCode(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	mockClient := &MockClient{}
+	model := NewTUIModel(mockClient, logger)
+	model.state = stateView
+	model.viewingEntry = &pb.DataEntry{
+		Id:            "otp-id",
+		Name:          "GitHub",
+		Type:          pb.DataType_DATA_TYPE_OTP,
+		EncryptedData: []byte(`{"secret":"JBSWY3DPEHPK3PXP","issuer":"GitHub","account_name":"alice"}`),
+	}
+
+	assert.NotContains(t, model.View(), "▀")
+
+	model.updateView(tea.KeyMsg{Type: tea.KeyCtrlQ})
+	assert.True(t, model.showQRCode)
+	view := model.View()
+	assert.Contains(t, view, "▀")
+	assert.Contains(t, view, "Текущий OTP:")
+
+	qr, err := renderQRCode("otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP")
+	assert.NoError(t, err)
+	matrix, err := otp.QRCodeMatrix("otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP")
+	assert.NoError(t, err)
+	// Каждая строка терминала содержит два ряда модулей
+	assert.Len(t, strings.Split(qr, "\n"), (len(matrix)+1)/2)
+}
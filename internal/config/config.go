@@ -11,20 +11,26 @@ import (
 
 // ServerConfig содержит все параметры конфигурации сервера.
 type ServerConfig struct {
-	ServerAddress   string
-	GRPCAddress     string
-	EnableTLS       bool
-	CertFile        string
-	KeyFile         string
-	DatabaseURI     string
-	MigrationsPath  string
-	JWTSecret       string
-	EncryptionKey   string
-	LogLevel        string
-	ReadTimeout     time.Duration
-	WriteTimeout    time.Duration
-	IdleTimeout     time.Duration
-	ShutdownTimeout time.Duration
+	ServerAddress       string
+	GRPCAddress         string
+	EnableTLS           bool
+	CertFile            string
+	KeyFile             string
+	ClientCAFile        string
+	RequireClientCert   bool
+	DatabaseURI         string
+	MigrationsPath      string
+	JWTSecret           string
+	JWTKeyFile          string
+	JWTPreviousKeyFiles string
+	EncryptionKey       string
+	TypedPayloads       bool
+	BreachCorpusDir     string // каталог базы утечек для зеркала диапазонов, пусто — зеркало отключено
+	LogLevel            string
+	ReadTimeout         time.Duration
+	WriteTimeout        time.Duration
+	IdleTimeout         time.Duration
+	ShutdownTimeout     time.Duration
 }
 
 // ClientConfig содержит все параметры конфигурации клиента.
@@ -33,6 +39,12 @@ type ClientConfig struct {
 	GRPCAddress   string
 	EnableTLS     bool
 	CertFile      string
+	ServerName    string
+	PinnedSPKI    string
+	ClientCert    string
+	ClientKey     string
+	BreachDir     string // локальная база утечек для проверки паролей
+	BreachURL     string // зеркало базы утечек с API Pwned Passwords
 	ConfigPath    string
 	LogLevel      string
 	Timeout       time.Duration
@@ -110,10 +122,16 @@ func LoadServerConfig() (*ServerConfig, error) {
 	flag.BoolVar(&cfg.EnableTLS, "s", cfg.EnableTLS, "Enable TLS")
 	flag.StringVar(&cfg.CertFile, "cert", cfg.CertFile, "TLS certificate file")
 	flag.StringVar(&cfg.KeyFile, "key", cfg.KeyFile, "TLS private key file")
+	flag.StringVar(&cfg.ClientCAFile, "client-ca", cfg.ClientCAFile, "CA file for client certificate authentication (mTLS)")
+	flag.BoolVar(&cfg.RequireClientCert, "mtls-required", cfg.RequireClientCert, "Require client certificates for all connections")
 	flag.StringVar(&cfg.DatabaseURI, "d", cfg.DatabaseURI, "Database connection string")
 	flag.StringVar(&cfg.MigrationsPath, "m", cfg.MigrationsPath, "Migrations directory path")
 	flag.StringVar(&cfg.JWTSecret, "jwt", cfg.JWTSecret, "JWT secret key")
+	flag.StringVar(&cfg.JWTKeyFile, "jwt-key", cfg.JWTKeyFile, "JWT signing key file (Ed25519 or ECDSA P-256 PEM)")
+	flag.StringVar(&cfg.JWTPreviousKeyFiles, "jwt-prev-keys", cfg.JWTPreviousKeyFiles, "Comma-separated previous JWT signing key files")
 	flag.StringVar(&cfg.EncryptionKey, "enc", cfg.EncryptionKey, "Encryption key")
+	flag.BoolVar(&cfg.TypedPayloads, "typed-payloads", cfg.TypedPayloads, "Validate plaintext entry payloads by data type (disables end-to-end encryption)")
+	flag.StringVar(&cfg.BreachCorpusDir, "breach-dir", cfg.BreachCorpusDir, "Breached passwords range directory to serve at /breach/range/{prefix}")
 	flag.StringVar(&cfg.LogLevel, "l", cfg.LogLevel, "Log level")
 
 	flag.Parse()
@@ -124,9 +142,15 @@ func LoadServerConfig() (*ServerConfig, error) {
 	cfg.EnableTLS = loadEnvBool(cfg.EnableTLS, "ENABLE_TLS")
 	cfg.CertFile = loadEnvStringIfEmpty(cfg.CertFile, "CERT_FILE")
 	cfg.KeyFile = loadEnvStringIfEmpty(cfg.KeyFile, "KEY_FILE")
+	cfg.ClientCAFile = loadEnvStringIfEmpty(cfg.ClientCAFile, "CLIENT_CA_FILE")
+	cfg.RequireClientCert = loadEnvBool(cfg.RequireClientCert, "REQUIRE_CLIENT_CERT")
 	cfg.DatabaseURI = loadEnvStringIfEmpty(cfg.DatabaseURI, "DATABASE_URI")
 	cfg.JWTSecret = loadEnvStringIfEmpty(cfg.JWTSecret, "JWT_SECRET")
+	cfg.JWTKeyFile = loadEnvStringIfEmpty(cfg.JWTKeyFile, "JWT_KEY_FILE")
+	cfg.JWTPreviousKeyFiles = loadEnvStringIfEmpty(cfg.JWTPreviousKeyFiles, "JWT_PREVIOUS_KEY_FILES")
 	cfg.EncryptionKey = loadEnvStringIfEmpty(cfg.EncryptionKey, "ENCRYPTION_KEY")
+	cfg.TypedPayloads = loadEnvBool(cfg.TypedPayloads, "TYPED_PAYLOADS")
+	cfg.BreachCorpusDir = loadEnvStringIfEmpty(cfg.BreachCorpusDir, "BREACH_CORPUS_DIR")
 	cfg.LogLevel = loadEnvString(cfg.LogLevel, "info", "LOG_LEVEL")
 
 	// Валидируем конфигурацию на раннем этапе
@@ -144,7 +168,13 @@ func LoadClientConfig() (*ClientConfig, error) {
 	flag.StringVar(&cfg.ServerAddress, "server", cfg.ServerAddress, "Server address")
 	flag.StringVar(&cfg.GRPCAddress, "grpc", cfg.GRPCAddress, "gRPC server address")
 	flag.BoolVar(&cfg.EnableTLS, "tls", cfg.EnableTLS, "Enable TLS")
-	flag.StringVar(&cfg.CertFile, "cert", cfg.CertFile, "TLS certificate file")
+	flag.StringVar(&cfg.CertFile, "cert", cfg.CertFile, "CA bundle or pinned server certificate file")
+	flag.StringVar(&cfg.ServerName, "server-name", cfg.ServerName, "TLS server name (defaults to gRPC address host)")
+	flag.StringVar(&cfg.PinnedSPKI, "pin", cfg.PinnedSPKI, "Comma-separated SPKI SHA-256 pins (sha256/<base64>)")
+	flag.StringVar(&cfg.ClientCert, "client-cert", cfg.ClientCert, "Client certificate file for mTLS")
+	flag.StringVar(&cfg.ClientKey, "client-key", cfg.ClientKey, "Client private key file for mTLS")
+	flag.StringVar(&cfg.BreachDir, "breach-dir", cfg.BreachDir, "Local breached passwords range directory")
+	flag.StringVar(&cfg.BreachURL, "breach-url", cfg.BreachURL, "Breached passwords range mirror URL")
 	flag.StringVar(&cfg.ConfigPath, "config", cfg.ConfigPath, "Configuration file path")
 	flag.StringVar(&cfg.LogLevel, "log", cfg.LogLevel, "Log level")
 
@@ -155,20 +185,49 @@ func LoadClientConfig() (*ClientConfig, error) {
 	cfg.GRPCAddress = loadEnvString(cfg.GRPCAddress, "localhost:8081", "GRPC_ADDRESS")
 	cfg.EnableTLS = loadEnvBool(cfg.EnableTLS, "ENABLE_TLS")
 	cfg.CertFile = loadEnvStringIfEmpty(cfg.CertFile, "CERT_FILE")
+	cfg.ServerName = loadEnvStringIfEmpty(cfg.ServerName, "TLS_SERVER_NAME")
+	cfg.PinnedSPKI = loadEnvStringIfEmpty(cfg.PinnedSPKI, "TLS_PINS")
+	cfg.ClientCert = loadEnvStringIfEmpty(cfg.ClientCert, "CLIENT_CERT_FILE")
+	cfg.ClientKey = loadEnvStringIfEmpty(cfg.ClientKey, "CLIENT_KEY_FILE")
+	cfg.BreachDir = loadEnvStringIfEmpty(cfg.BreachDir, "BREACH_CORPUS_DIR")
+	cfg.BreachURL = loadEnvStringIfEmpty(cfg.BreachURL, "BREACH_MIRROR_URL")
 	cfg.ConfigPath = loadEnvString(cfg.ConfigPath, "./config.json", "CONFIG_PATH")
 	cfg.LogLevel = loadEnvString(cfg.LogLevel, "info", "LOG_LEVEL")
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// Validate проверяет корректность конфигурации клиента.
+func (c *ClientConfig) Validate() error {
+	usesTLSOptions := c.CertFile != "" || c.ServerName != "" || c.PinnedSPKI != "" ||
+		c.ClientCert != "" || c.ClientKey != ""
+	if !c.EnableTLS && usesTLSOptions {
+		return fmt.Errorf("TLS options require TLS to be enabled")
+	}
+
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return fmt.Errorf("both client certificate and key files are required for mTLS")
+	}
+
+	if c.BreachDir != "" && c.BreachURL != "" {
+		return fmt.Errorf("use either a local breach corpus or a breach mirror, not both")
+	}
+
+	return nil
+}
+
 // Validate проверяет корректность конфигурации сервера.
 func (c *ServerConfig) Validate() error {
 	if c.DatabaseURI == "" {
 		return fmt.Errorf("database URI is required")
 	}
 
-	if c.JWTSecret == "" {
-		return fmt.Errorf("JWT secret is required")
+	if c.JWTSecret == "" && c.JWTKeyFile == "" {
+		return fmt.Errorf("JWT secret or signing key file is required")
 	}
 
 	if c.EncryptionKey == "" {
@@ -184,5 +243,13 @@ func (c *ServerConfig) Validate() error {
 		}
 	}
 
+	if !c.EnableTLS && (c.ClientCAFile != "" || c.RequireClientCert) {
+		return fmt.Errorf("client certificate authentication requires TLS")
+	}
+
+	if c.RequireClientCert && c.ClientCAFile == "" {
+		return fmt.Errorf("client CA file is required when client certificates are required")
+	}
+
 	return nil
 }
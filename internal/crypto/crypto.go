@@ -2,8 +2,10 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -12,6 +14,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+
+	"golang.org/x/crypto/argon2"
 )
 
 const (
@@ -19,6 +23,18 @@ const (
 	AESKeySize = 32
 	// MaxRSABlockSize максимальный размер блока для RSA шифрования
 	MaxRSABlockSize = 190 // для RSA-2048 с OAEP padding
+	// PasswordSaltSize размер соли для получения ключа из пароля
+	PasswordSaltSize = 16
+)
+
+// passwordBoxMagic заголовок данных, зашифрованных ключом из пароля.
+var passwordBoxMagic = []byte("GKPW1")
+
+// Параметры Argon2id для получения ключа из пароля
+const (
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
 )
 
 // Service предоставляет методы для шифрования и дешифрования данных.
@@ -102,6 +118,55 @@ func DecryptAES(ciphertext, key []byte) ([]byte, error) {
 	return plaintext, nil
 }
 
+// DeriveKeyFromPassword получает ключ AES-256 из пароля с помощью Argon2id.
+func DeriveKeyFromPassword(password string, salt []byte) []byte {
+	return argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, AESKeySize)
+}
+
+// EncryptWithPassword шифрует данные ключом, полученным из пароля.
+// Результат содержит заголовок, соль и шифротекст AES-GCM.
+func EncryptWithPassword(data []byte, password string) ([]byte, error) {
+	salt := make([]byte, PasswordSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	ciphertext, err := EncryptAES(data, DeriveKeyFromPassword(password, salt))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 0, len(passwordBoxMagic)+len(salt)+len(ciphertext))
+	result = append(result, passwordBoxMagic...)
+	result = append(result, salt...)
+	result = append(result, ciphertext...)
+	return result, nil
+}
+
+// DecryptWithPassword дешифрует данные, зашифрованные с помощью EncryptWithPassword.
+func DecryptWithPassword(data []byte, password string) ([]byte, error) {
+	if !bytes.HasPrefix(data, passwordBoxMagic) {
+		return nil, errors.New("unknown encrypted data format")
+	}
+	data = data[len(passwordBoxMagic):]
+
+	if len(data) < PasswordSaltSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	salt, ciphertext := data[:PasswordSaltSize], data[PasswordSaltSize:]
+	return DecryptAES(ciphertext, DeriveKeyFromPassword(password, salt))
+}
+
+// DeriveSecret получает из закрытого ключа сервера постоянный секрет для
+// назначения label. Секрет не меняется между перезапусками сервера.
+func (s *Service) DeriveSecret(label string) []byte {
+	key := sha256.Sum256(x509.MarshalPKCS1PrivateKey(s.privateKey))
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
 // EncryptRSA шифрует данные с помощью RSA-OAEP.
 func (s *Service) EncryptRSA(data []byte) ([]byte, error) {
 	hash := sha256.New()
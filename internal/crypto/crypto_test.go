@@ -59,3 +59,45 @@ func TestEncryptLargeData(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, data, dec)
 }
+
+func TestEncryptWithPassword(t *testing.T) {
+	data := []byte("account export")
+	enc, err := EncryptWithPassword(data, "master-password")
+	require.NoError(t, err)
+	require.NotEqual(t, data, enc)
+
+	dec, err := DecryptWithPassword(enc, "master-password")
+	require.NoError(t, err)
+	require.Equal(t, data, dec)
+
+	_, err = DecryptWithPassword(enc, "wrong-password")
+	require.Error(t, err)
+
+	_, err = DecryptWithPassword([]byte("garbage"), "master-password")
+	require.Error(t, err)
+}
+
+func TestWrapKeyForRecipient(t *testing.T) {
+	privateKey, publicKey, err := GenerateSharingKeyPair()
+	require.NoError(t, err)
+	require.Len(t, publicKey, SharingKeySize)
+
+	entryKey, err := GenerateAESKey()
+	require.NoError(t, err)
+
+	wrapped, err := WrapKeyForRecipient(entryKey, publicKey)
+	require.NoError(t, err)
+
+	unwrapped, err := UnwrapSharedKey(wrapped, privateKey)
+	require.NoError(t, err)
+	require.Equal(t, entryKey, unwrapped)
+
+	// Чужой закрытый ключ не расшифровывает ключ записи
+	otherPrivateKey, _, err := GenerateSharingKeyPair()
+	require.NoError(t, err)
+	_, err = UnwrapSharedKey(wrapped, otherPrivateKey)
+	require.Error(t, err)
+
+	_, err = WrapKeyForRecipient(entryKey, []byte("short"))
+	require.Error(t, err)
+}
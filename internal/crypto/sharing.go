@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// SharingKeySize размер открытого и закрытого ключа X25519 для обмена записями.
+const SharingKeySize = 32
+
+// sharedKeyMagic заголовок ключа, зашифрованного для получателя.
+var sharedKeyMagic = []byte("GKSH1")
+
+// sharedKeyInfo контекст HKDF для ключей обмена записями.
+var sharedKeyInfo = "gophkeeper entry share"
+
+// GenerateSharingKeyPair создает пару ключей X25519 для получения общих записей.
+// Открытый ключ публикуется на сервере, закрытый хранится зашифрованным ключом хранилища.
+func GenerateSharingKeyPair() (privateKey, publicKey []byte, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate sharing key: %w", err)
+	}
+	return key.Bytes(), key.PublicKey().Bytes(), nil
+}
+
+// WrapKeyForRecipient шифрует ключ записи открытым ключом получателя.
+// Используется эфемерный ключ X25519, общий секрет которого через HKDF-SHA256
+// превращается в ключ AES-GCM. Результат: заголовок | эфемерный открытый ключ | шифротекст.
+func WrapKeyForRecipient(key, recipientPublicKey []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(recipientPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient public key: %w", err)
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	wrappingKey, err := deriveSharedKey(ephemeral, recipient, ephemeral.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := EncryptAES(key, wrappingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 0, len(sharedKeyMagic)+SharingKeySize+len(ciphertext))
+	result = append(result, sharedKeyMagic...)
+	result = append(result, ephemeral.PublicKey().Bytes()...)
+	result = append(result, ciphertext...)
+	return result, nil
+}
+
+// UnwrapSharedKey расшифровывает ключ записи закрытым ключом получателя.
+func UnwrapSharedKey(wrapped, privateKey []byte) ([]byte, error) {
+	if !bytes.HasPrefix(wrapped, sharedKeyMagic) {
+		return nil, errors.New("unknown shared key format")
+	}
+	wrapped = wrapped[len(sharedKeyMagic):]
+	if len(wrapped) < SharingKeySize {
+		return nil, errors.New("shared key too short")
+	}
+
+	recipient, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	ephemeralPublic, err := ecdh.X25519().NewPublicKey(wrapped[:SharingKeySize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+
+	wrappingKey, err := deriveSharedKey(recipient, ephemeralPublic, wrapped[:SharingKeySize])
+	if err != nil {
+		return nil, err
+	}
+
+	return DecryptAES(wrapped[SharingKeySize:], wrappingKey)
+}
+
+// deriveSharedKey вычисляет ключ AES-256 из общего секрета X25519.
+// Эфемерный открытый ключ используется как соль.
+func deriveSharedKey(private *ecdh.PrivateKey, public *ecdh.PublicKey, salt []byte) ([]byte, error) {
+	secret, err := private.ECDH(public)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute shared secret: %w", err)
+	}
+	key, err := hkdf.Key(sha256.New, secret, salt, sharedKeyInfo, AESKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive wrapping key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,217 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AddAttachment прикрепляет к записи файл, зашифрованный на клиенте собственным
+// ключом вложения. Добавлять вложения может владелец записи и получатель
+// с правом изменения.
+func (s *Server) AddAttachment(ctx context.Context, req *pb.AddAttachmentRequest) (*pb.AttachmentResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if req.Name == "" || len(req.Name) > 255 {
+		return nil, status.Error(codes.InvalidArgument, "attachment name must be 1-255 characters")
+	}
+	if len(req.MimeType) > 255 {
+		return nil, status.Error(codes.InvalidArgument, "invalid MIME type")
+	}
+	if len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+	if len(req.EncryptedData) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "encrypted data is required")
+	}
+	if len(req.EncryptedData) > models.MaxAttachmentSize {
+		return nil, status.Error(codes.InvalidArgument, "attachment is too large")
+	}
+
+	entry, err := s.getAttachmentEntry(ctx, userID, req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Share != nil && !entry.Share.CanWrite() {
+		return nil, status.Error(codes.PermissionDenied, "entry is shared read-only")
+	}
+
+	attachment := &models.Attachment{
+		EntryID:       entry.ID,
+		UploadedBy:    userID,
+		Name:          req.Name,
+		MimeType:      req.MimeType,
+		Size:          int64(len(req.EncryptedData)),
+		WrappedKey:    req.WrappedKey,
+		EncryptedData: req.EncryptedData,
+	}
+	err = s.storage.CreateAttachment(ctx, attachment)
+	if errors.Is(err, storage.ErrAttachmentLimit) {
+		return nil, status.Error(codes.ResourceExhausted, "entry attachment limit exceeded")
+	}
+	if err != nil {
+		s.logger.Error("Failed to create attachment", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create attachment")
+	}
+
+	s.logger.Info("Attachment added",
+		zap.String("entry_id", entry.ID.String()),
+		zap.String("attachment_id", attachment.ID.String()),
+		zap.Int64("size", attachment.Size))
+
+	return &pb.AttachmentResponse{
+		Attachment: convertToProtoAttachment(attachment),
+	}, nil
+}
+
+// ListAttachments возвращает вложения записи без содержимого.
+func (s *Server) ListAttachments(ctx context.Context, req *pb.ListAttachmentsRequest) (*pb.ListAttachmentsResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	entry, err := s.getAttachmentEntry(ctx, userID, req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.storage.GetAttachments(ctx, entry.ID)
+	if err != nil {
+		s.logger.Error("Failed to get attachments", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get attachments")
+	}
+
+	var totalSize int64
+	protoAttachments := make([]*pb.Attachment, len(attachments))
+	for i := range attachments {
+		protoAttachments[i] = convertToProtoAttachment(&attachments[i])
+		totalSize += attachments[i].Size
+	}
+
+	return &pb.ListAttachmentsResponse{
+		Attachments: protoAttachments,
+		TotalSize:   totalSize,
+	}, nil
+}
+
+// GetAttachment возвращает вложение записи с зашифрованным содержимым.
+func (s *Server) GetAttachment(ctx context.Context, req *pb.GetAttachmentRequest) (*pb.GetAttachmentResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	attachmentID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid attachment ID")
+	}
+
+	entry, err := s.getAttachmentEntry(ctx, userID, req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.storage.GetAttachment(ctx, entry.ID, attachmentID)
+	if errors.Is(err, storage.ErrAttachmentNotFound) {
+		return nil, status.Error(codes.NotFound, "attachment not found")
+	}
+	if err != nil {
+		s.logger.Error("Failed to get attachment", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get attachment")
+	}
+
+	return &pb.GetAttachmentResponse{
+		Attachment:    convertToProtoAttachment(attachment),
+		EncryptedData: attachment.EncryptedData,
+	}, nil
+}
+
+// RemoveAttachment удаляет вложение записи. Удалять вложения может владелец
+// записи и получатель с правом изменения.
+func (s *Server) RemoveAttachment(ctx context.Context, req *pb.RemoveAttachmentRequest) (*pb.RemoveAttachmentResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	attachmentID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid attachment ID")
+	}
+
+	entry, err := s.getAttachmentEntry(ctx, userID, req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Share != nil && !entry.Share.CanWrite() {
+		return nil, status.Error(codes.PermissionDenied, "entry is shared read-only")
+	}
+
+	err = s.storage.DeleteAttachment(ctx, entry.ID, attachmentID)
+	if errors.Is(err, storage.ErrAttachmentNotFound) {
+		return nil, status.Error(codes.NotFound, "attachment not found")
+	}
+	if err != nil {
+		s.logger.Error("Failed to delete attachment", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete attachment")
+	}
+
+	s.logger.Info("Attachment removed",
+		zap.String("entry_id", entry.ID.String()),
+		zap.String("attachment_id", attachmentID.String()))
+
+	return &pb.RemoveAttachmentResponse{
+		Success: true,
+	}, nil
+}
+
+// getAttachmentEntry получает собственную или общую запись, к которой относятся
+// вложения. Записи коллекций вложений не поддерживают: при исключении участника
+// ключ коллекции меняется и записи перешифровываются, а вместе с ними пришлось бы
+// перешифровывать ключи всех вложений, поэтому для них возвращается FailedPrecondition.
+func (s *Server) getAttachmentEntry(ctx context.Context, userID uuid.UUID, id string) (*models.DataEntry, error) {
+	entryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid entry ID")
+	}
+
+	entry, err := s.storage.GetDataEntry(ctx, userID, entryID)
+	if err != nil {
+		entry, err = s.storage.GetSharedEntry(ctx, userID, entryID)
+	}
+	if err != nil {
+		if _, collectionErr := s.storage.GetCollectionEntry(ctx, userID, entryID); collectionErr == nil {
+			return nil, status.Error(codes.FailedPrecondition, "attachments are not supported for collection entries")
+		}
+	}
+	if err != nil || !allowsDataType(ctx, entry.Type) {
+		return nil, status.Error(codes.NotFound, "data entry not found")
+	}
+
+	return entry, nil
+}
+
+// convertToProtoAttachment преобразует модель Attachment в proto Attachment без содержимого.
+func convertToProtoAttachment(attachment *models.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:         attachment.ID.String(),
+		EntryId:    attachment.EntryID.String(),
+		Name:       attachment.Name,
+		MimeType:   attachment.MimeType,
+		Size:       attachment.Size,
+		WrappedKey: attachment.WrappedKey,
+		CreatedAt:  timestamppb.New(attachment.CreatedAt),
+	}
+}
@@ -5,6 +5,9 @@ package grpc
 type contextKey string
 
 const (
-	UserIDKey   contextKey = "user_id"
-	UsernameKey contextKey = "username"
+	UserIDKey    contextKey = "user_id"
+	UsernameKey  contextKey = "username"
+	SessionIDKey contextKey = "session_id"
+	// AccessTokenKey хранит персональный токен доступа, если запрос выполнен с ним
+	AccessTokenKey contextKey = "access_token"
 ) 
\ No newline at end of file
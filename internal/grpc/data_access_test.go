@@ -187,6 +187,24 @@ func TestDataAccess_ListData_ValidRequest(t *testing.T) {
 			Description:   "Credit card",
 			EncryptedData: []byte("card data"),
 		},
+		{
+			Type:          pb.DataType_DATA_TYPE_OTP,
+			Name:          "GitHub 2FA",
+			Description:   "TOTP secret",
+			EncryptedData: []byte("otp data"),
+		},
+		{
+			Type:          pb.DataType_DATA_TYPE_SSH_KEY,
+			Name:          "Deploy key",
+			Description:   "SSH key pair",
+			EncryptedData: []byte("ssh key data"),
+		},
+		{
+			Type:          pb.DataType_DATA_TYPE_WIFI,
+			Name:          "Home Wi-Fi",
+			Description:   "Wi-Fi network",
+			EncryptedData: []byte("wifi data"),
+		},
 	}
 
 	for _, entry := range entries {
@@ -198,11 +216,12 @@ func TestDataAccess_ListData_ValidRequest(t *testing.T) {
 	listResp, err := client.ListData(ctx, &pb.ListDataRequest{})
 	require.NoError(t, err)
 	require.NotNil(t, listResp)
-	require.Len(t, listResp.DataEntries, 3)
-	require.Equal(t, int32(3), listResp.Total)
+	require.Len(t, listResp.DataEntries, 6)
+	require.Equal(t, int32(6), listResp.Total)
 
 	// Проверяем, что все записи принадлежат пользователю
 	for _, entry := range listResp.DataEntries {
+		require.NotEqual(t, pb.DataType_DATA_TYPE_UNSPECIFIED, entry.Type)
 		require.NotEmpty(t, entry.Id)
 		require.NotEmpty(t, entry.Name)
 		require.NotEmpty(t, entry.EncryptedData)
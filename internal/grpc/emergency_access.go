@@ -0,0 +1,495 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Ограничения периода ожидания экстренного доступа.
+const (
+	defaultEmergencyWaitDays = 7
+	maxEmergencyWaitDays     = 90
+)
+
+// EmergencyAccessCheckInterval период проверки запросов экстренного доступа
+// с истекшим периодом ожидания.
+const EmergencyAccessCheckInterval = 5 * time.Minute
+
+// AddEmergencyContact назначает контакт, который сможет запросить доступ
+// к хранилищу. Ключ хранилища шифруется клиентом открытым ключом контакта
+// и выдается только после одобрения или истечения периода ожидания.
+func (s *Server) AddEmergencyContact(ctx context.Context, req *pb.AddEmergencyContactRequest) (*pb.EmergencyAccessResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+	waitDays := int(req.WaitDays)
+	if waitDays == 0 {
+		waitDays = defaultEmergencyWaitDays
+	}
+	if waitDays < 1 || waitDays > maxEmergencyWaitDays {
+		return nil, status.Error(codes.InvalidArgument, "wait period must be between 1 and 90 days")
+	}
+
+	grantee, err := s.storage.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if grantee.ID == userID {
+		return nil, status.Error(codes.InvalidArgument, "cannot designate yourself as an emergency contact")
+	}
+	if len(grantee.PublicKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "user has not published a public key")
+	}
+
+	existing, err := s.storage.GetEmergencyAccessList(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get emergency access", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to add emergency contact")
+	}
+	for _, access := range existing {
+		if access.GrantorID == userID && access.GranteeID == grantee.ID {
+			return nil, status.Error(codes.AlreadyExists, "emergency contact already exists")
+		}
+	}
+
+	grantor, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	access := &models.EmergencyAccess{
+		GrantorID:       userID,
+		GrantorUsername: grantor.Username,
+		GranteeID:       grantee.ID,
+		GranteeUsername: grantee.Username,
+		WaitDays:        waitDays,
+		WrappedKey:      req.WrappedKey,
+	}
+	message := emergencyNotificationMessage(access, models.EmergencyEventDesignated)
+	if err := s.storage.CreateEmergencyAccess(ctx, access, message); err != nil {
+		s.logger.Error("Failed to create emergency access", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to add emergency contact")
+	}
+
+	s.logger.Info("Emergency contact designated",
+		zap.String("grantor_id", userID.String()),
+		zap.String("grantee_id", grantee.ID.String()),
+		zap.Int("wait_days", waitDays))
+
+	return &pb.EmergencyAccessResponse{
+		EmergencyAccess: convertToProtoEmergencyAccess(access),
+	}, nil
+}
+
+// ListEmergencyAccess возвращает назначенные пользователем контакты и доступы,
+// в которых пользователь сам назначен контактом.
+func (s *Server) ListEmergencyAccess(ctx context.Context, req *pb.ListEmergencyAccessRequest) (*pb.ListEmergencyAccessResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	accessList, err := s.storage.GetEmergencyAccessList(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get emergency access", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list emergency access")
+	}
+
+	resp := &pb.ListEmergencyAccessResponse{}
+	for _, access := range accessList {
+		if access.GrantorID == userID {
+			resp.Granted = append(resp.Granted, convertToProtoEmergencyAccess(&access))
+		} else {
+			resp.Trusted = append(resp.Trusted, convertToProtoEmergencyAccess(&access))
+		}
+	}
+
+	return resp, nil
+}
+
+// RemoveEmergencyContact удаляет экстренный доступ. Удалить доступ может
+// владелец хранилища или сам контакт. Журнал событий сохраняется.
+func (s *Server) RemoveEmergencyContact(ctx context.Context, req *pb.RemoveEmergencyContactRequest) (*pb.RemoveEmergencyContactResponse, error) {
+	userID, access, err := s.getEmergencyAccess(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &models.EmergencyAccessEvent{ActorID: &userID, Event: models.EmergencyEventRevoked}
+	message := emergencyNotificationMessage(access, models.EmergencyEventRevoked)
+	if err := s.storage.DeleteEmergencyAccess(ctx, access, event, message); err != nil {
+		s.logger.Error("Failed to delete emergency access", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "emergency access not found")
+	}
+
+	s.logger.Info("Emergency access removed",
+		zap.String("user_id", userID.String()),
+		zap.String("emergency_access_id", access.ID.String()))
+
+	return &pb.RemoveEmergencyContactResponse{
+		Success: true,
+	}, nil
+}
+
+// RequestEmergencyAccess запускает период ожидания. Если владелец не отклонит
+// запрос до его окончания, контакт получит доступ к хранилищу.
+func (s *Server) RequestEmergencyAccess(ctx context.Context, req *pb.RequestEmergencyAccessRequest) (*pb.EmergencyAccessResponse, error) {
+	userID, access, err := s.getEmergencyAccess(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if access.GranteeID != userID {
+		return nil, status.Error(codes.PermissionDenied, "only the emergency contact can request access")
+	}
+
+	access, err = s.transitionEmergencyAccess(ctx, access, &userID,
+		[]models.EmergencyAccessStatus{models.EmergencyAccessIdle}, models.EmergencyAccessRequested,
+		models.EmergencyEventRequested)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Warn("Emergency access requested",
+		zap.String("grantor_id", access.GrantorID.String()),
+		zap.String("grantee_id", access.GranteeID.String()),
+		zap.Timep("available_at", access.AvailableAt()))
+
+	return &pb.EmergencyAccessResponse{
+		EmergencyAccess: convertToProtoEmergencyAccess(access),
+	}, nil
+}
+
+// ApproveEmergencyAccess досрочно предоставляет запрошенный доступ.
+func (s *Server) ApproveEmergencyAccess(ctx context.Context, req *pb.ApproveEmergencyAccessRequest) (*pb.EmergencyAccessResponse, error) {
+	userID, access, err := s.getEmergencyAccess(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if access.GrantorID != userID {
+		return nil, status.Error(codes.PermissionDenied, "only the vault owner can approve access")
+	}
+
+	access, err = s.transitionEmergencyAccess(ctx, access, &userID,
+		[]models.EmergencyAccessStatus{models.EmergencyAccessRequested}, models.EmergencyAccessApproved,
+		models.EmergencyEventApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EmergencyAccessResponse{
+		EmergencyAccess: convertToProtoEmergencyAccess(access),
+	}, nil
+}
+
+// DenyEmergencyAccess отклоняет запрос или отзывает уже предоставленный доступ.
+// Контакт остается назначенным и может запросить доступ повторно.
+func (s *Server) DenyEmergencyAccess(ctx context.Context, req *pb.DenyEmergencyAccessRequest) (*pb.EmergencyAccessResponse, error) {
+	userID, access, err := s.getEmergencyAccess(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if access.GrantorID != userID {
+		return nil, status.Error(codes.PermissionDenied, "only the vault owner can deny access")
+	}
+
+	access, err = s.transitionEmergencyAccess(ctx, access, &userID,
+		[]models.EmergencyAccessStatus{models.EmergencyAccessRequested, models.EmergencyAccessApproved},
+		models.EmergencyAccessIdle, models.EmergencyEventDenied)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EmergencyAccessResponse{
+		EmergencyAccess: convertToProtoEmergencyAccess(access),
+	}, nil
+}
+
+// GetEmergencyVault возвращает контакту ключ и записи хранилища владельца.
+// Доступ открывается после одобрения владельцем или истечения периода ожидания;
+// каждое обращение записывается в журнал и сообщается владельцу.
+func (s *Server) GetEmergencyVault(ctx context.Context, req *pb.GetEmergencyVaultRequest) (*pb.GetEmergencyVaultResponse, error) {
+	userID, access, err := s.getEmergencyAccess(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if access.GranteeID != userID {
+		return nil, status.Error(codes.PermissionDenied, "only the emergency contact can access the vault")
+	}
+
+	if access.Status == models.EmergencyAccessRequested && access.WaitElapsed(time.Now()) {
+		access, err = s.transitionEmergencyAccess(ctx, access, nil,
+			[]models.EmergencyAccessStatus{models.EmergencyAccessRequested}, models.EmergencyAccessApproved,
+			models.EmergencyEventGranted)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if access.Status != models.EmergencyAccessApproved {
+		return nil, status.Error(codes.FailedPrecondition, "emergency access has not been granted")
+	}
+
+	entries, err := s.storage.GetDataEntries(ctx, access.GrantorID, nil)
+	if err != nil {
+		s.logger.Error("Failed to get data entries", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get vault")
+	}
+
+	event := &models.EmergencyAccessEvent{ActorID: &userID, Event: models.EmergencyEventVaultAccessed}
+	message := emergencyNotificationMessage(access, models.EmergencyEventVaultAccessed)
+	if err := s.storage.RecordEmergencyAccessEvent(ctx, access, event, message); err != nil {
+		s.logger.Error("Failed to record emergency access event", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get vault")
+	}
+
+	s.logger.Warn("Vault accessed with emergency access",
+		zap.String("grantor_id", access.GrantorID.String()),
+		zap.String("grantee_id", access.GranteeID.String()))
+
+	protoEntries := make([]*pb.DataEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = convertToProtoDataEntry(&entry)
+	}
+
+	return &pb.GetEmergencyVaultResponse{
+		EmergencyAccess: convertToProtoEmergencyAccess(access),
+		WrappedKey:      access.WrappedKey,
+		DataEntries:     protoEntries,
+	}, nil
+}
+
+// ListEmergencyAccessEvents возвращает журнал экстренного доступа его участнику.
+func (s *Server) ListEmergencyAccessEvents(ctx context.Context, req *pb.ListEmergencyAccessEventsRequest) (*pb.ListEmergencyAccessEventsResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	accessID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid emergency access ID")
+	}
+
+	events, err := s.storage.GetEmergencyAccessEvents(ctx, userID, accessID)
+	if err != nil {
+		s.logger.Error("Failed to get emergency access events", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list emergency access events")
+	}
+
+	protoEvents := make([]*pb.EmergencyAccessEvent, len(events))
+	for i, event := range events {
+		protoEvents[i] = &pb.EmergencyAccessEvent{
+			Id:                event.ID.String(),
+			EmergencyAccessId: event.EmergencyAccessID.String(),
+			ActorUsername:     event.ActorUsername,
+			Event:             string(event.Event),
+			CreatedAt:         timestamppb.New(event.CreatedAt),
+		}
+	}
+
+	return &pb.ListEmergencyAccessEventsResponse{
+		Events: protoEvents,
+	}, nil
+}
+
+// ListNotifications возвращает последние уведомления пользователя.
+func (s *Server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.storage.GetNotifications(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get notifications", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list notifications")
+	}
+
+	protoNotifications := make([]*pb.Notification, len(notifications))
+	for i, notification := range notifications {
+		protoNotifications[i] = &pb.Notification{
+			Id:        notification.ID.String(),
+			Message:   notification.Message,
+			CreatedAt: timestamppb.New(notification.CreatedAt),
+		}
+	}
+
+	return &pb.ListNotificationsResponse{
+		Notifications: protoNotifications,
+	}, nil
+}
+
+// GrantElapsedEmergencyAccess периодически предоставляет доступ по запросам,
+// период ожидания которых истек без отказа владельца, до отмены контекста.
+func (s *Server) GrantElapsedEmergencyAccess(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.grantElapsedEmergencyAccess(ctx, time.Now())
+		}
+	}
+}
+
+// grantElapsedEmergencyAccess предоставляет доступ по истекшим запросам.
+func (s *Server) grantElapsedEmergencyAccess(ctx context.Context, now time.Time) {
+	elapsed, err := s.storage.GetElapsedEmergencyRequests(ctx, now)
+	if err != nil {
+		s.logger.Error("Failed to get elapsed emergency requests", zap.Error(err))
+		return
+	}
+
+	for i := range elapsed {
+		// Запрос мог быть отклонен или одобрен после выборки
+		if _, err := s.transitionEmergencyAccess(ctx, &elapsed[i], nil,
+			[]models.EmergencyAccessStatus{models.EmergencyAccessRequested}, models.EmergencyAccessApproved,
+			models.EmergencyEventGranted); err != nil && status.Code(err) != codes.FailedPrecondition {
+			s.logger.Error("Failed to grant emergency access", zap.Error(err))
+		}
+	}
+}
+
+// getEmergencyAccess получает экстренный доступ, участником которого является пользователь.
+func (s *Server) getEmergencyAccess(ctx context.Context, id string) (uuid.UUID, *models.EmergencyAccess, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return uuid.Nil, nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	accessID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, nil, status.Error(codes.InvalidArgument, "invalid emergency access ID")
+	}
+
+	access, err := s.storage.GetEmergencyAccess(ctx, userID, accessID)
+	if err != nil {
+		return uuid.Nil, nil, status.Error(codes.NotFound, "emergency access not found")
+	}
+
+	return userID, access, nil
+}
+
+// transitionEmergencyAccess меняет состояние доступа с записью события и уведомлением.
+// actorID не задается для переходов, выполняемых сервером.
+func (s *Server) transitionEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, actorID *uuid.UUID, from []models.EmergencyAccessStatus, to models.EmergencyAccessStatus, eventType models.EmergencyAccessEventType) (*models.EmergencyAccess, error) {
+	event := &models.EmergencyAccessEvent{ActorID: actorID, Event: eventType}
+	message := emergencyNotificationMessage(access, eventType)
+
+	updated, err := s.storage.TransitionEmergencyAccess(ctx, access.ID, from, to, event, message)
+	if errors.Is(err, storage.ErrEmergencyAccessState) {
+		return nil, status.Error(codes.FailedPrecondition, "emergency access is not in the expected state")
+	}
+	if err != nil {
+		s.logger.Error("Failed to update emergency access", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update emergency access")
+	}
+
+	s.logger.Info("Emergency access updated",
+		zap.String("emergency_access_id", updated.ID.String()),
+		zap.String("event", string(eventType)),
+		zap.String("status", string(updated.Status)))
+
+	return updated, nil
+}
+
+// emergencyNotificationMessage возвращает текст уведомления о событии экстренного доступа.
+func emergencyNotificationMessage(access *models.EmergencyAccess, event models.EmergencyAccessEventType) string {
+	switch event {
+	case models.EmergencyEventDesignated:
+		return fmt.Sprintf("%s designated you as an emergency contact", access.GrantorUsername)
+	case models.EmergencyEventRequested:
+		return fmt.Sprintf("%s requested emergency access to your vault; access will be granted in %d days unless you deny it",
+			access.GranteeUsername, access.WaitDays)
+	case models.EmergencyEventApproved:
+		return fmt.Sprintf("%s approved your emergency access request", access.GrantorUsername)
+	case models.EmergencyEventDenied:
+		return fmt.Sprintf("%s denied emergency access to their vault", access.GrantorUsername)
+	case models.EmergencyEventGranted:
+		return fmt.Sprintf("Emergency access of %s to the vault of %s was granted after the waiting period",
+			access.GranteeUsername, access.GrantorUsername)
+	case models.EmergencyEventVaultAccessed:
+		return fmt.Sprintf("%s accessed your vault using emergency access", access.GranteeUsername)
+	case models.EmergencyEventRevoked:
+		return fmt.Sprintf("Emergency access of %s to the vault of %s was removed",
+			access.GranteeUsername, access.GrantorUsername)
+	default:
+		return fmt.Sprintf("Emergency access event: %s", event)
+	}
+}
+
+// convertToProtoEmergencyAccessStatus преобразует состояние экстренного доступа в proto.
+func convertToProtoEmergencyAccessStatus(accessStatus models.EmergencyAccessStatus) pb.EmergencyAccessStatus {
+	switch accessStatus {
+	case models.EmergencyAccessIdle:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_IDLE
+	case models.EmergencyAccessRequested:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_REQUESTED
+	case models.EmergencyAccessApproved:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_APPROVED
+	default:
+		return pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_UNSPECIFIED
+	}
+}
+
+// convertToProtoEmergencyAccess преобразует модель EmergencyAccess в proto.
+// Зашифрованный ключ хранилища возвращается только GetEmergencyVault.
+func convertToProtoEmergencyAccess(access *models.EmergencyAccess) *pb.EmergencyAccess {
+	protoAccess := &pb.EmergencyAccess{
+		Id:              access.ID.String(),
+		GrantorId:       access.GrantorID.String(),
+		GrantorUsername: access.GrantorUsername,
+		GranteeId:       access.GranteeID.String(),
+		GranteeUsername: access.GranteeUsername,
+		WaitDays:        int32(access.WaitDays),
+		Status:          convertToProtoEmergencyAccessStatus(access.Status),
+		CreatedAt:       timestamppb.New(access.CreatedAt),
+	}
+	if access.RequestedAt != nil {
+		protoAccess.RequestedAt = timestamppb.New(*access.RequestedAt)
+	}
+	if availableAt := access.AvailableAt(); availableAt != nil {
+		protoAccess.AvailableAt = timestamppb.New(*availableAt)
+	}
+	if access.ApprovedAt != nil {
+		protoAccess.ApprovedAt = timestamppb.New(*access.ApprovedAt)
+	}
+	return protoAccess
+}
@@ -2,14 +2,25 @@
 package grpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/GophKeeper/internal/middleware"
 	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/payload"
+	"github.com/GophKeeper/internal/storage"
+	"github.com/GophKeeper/internal/templates"
 	pb "github.com/GophKeeper/proto/gen/proto"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -29,8 +40,10 @@ func (s *Server) HandleRegister(w http.ResponseWriter, r *http.Request) {
 
 	// Вызываем gRPC метод
 	grpcReq := &pb.RegisterRequest{
-		Username: req.Username,
-		Password: req.Password,
+		Username:    req.Username,
+		Password:    req.Password,
+		SrpSalt:     req.SRPSalt,
+		SrpVerifier: req.SRPVerifier,
 	}
 
 	resp, err := s.Register(r.Context(), grpcReq)
@@ -75,6 +88,68 @@ func (s *Server) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// HandleBeginSRPLogin обрабатывает HTTP запрос на начало входа по SRP-6a.
+func (s *Server) HandleBeginSRPLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.BeginSRPLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.BeginSRPLoginRequest{
+		Username:        req.Username,
+		ClientPublicKey: req.ClientPublicKey,
+	}
+
+	resp, err := s.BeginSRPLogin(r.Context(), grpcReq)
+	if err != nil {
+		s.logger.Error("SRP login failed", zap.Error(err))
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleFinishSRPLogin обрабатывает HTTP запрос на завершение входа по SRP-6a.
+func (s *Server) HandleFinishSRPLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.FinishSRPLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.FinishSRPLoginRequest{
+		LoginId:     req.LoginID,
+		ClientProof: req.ClientProof,
+	}
+
+	resp, err := s.FinishSRPLogin(r.Context(), grpcReq)
+	if err != nil {
+		s.logger.Error("SRP login failed", zap.Error(err))
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
 // HandleRefreshToken обрабатывает HTTP запрос на обновление токена.
 func (s *Server) HandleRefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -101,6 +176,188 @@ func (s *Server) HandleRefreshToken(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// HandleJWKS отдает открытые ключи для проверки JWT токенов другими сервисами.
+func (s *Server) HandleJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(s.authService.JWKS())
+}
+
+// HandleChangePassword обрабатывает HTTP запрос на смену пароля.
+func (s *Server) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.ChangePasswordRequest{
+		OldPassword:     req.OldPassword,
+		NewPassword:     req.NewPassword,
+		WrappedVaultKey: req.WrappedVaultKey,
+		SrpSalt:         req.SRPSalt,
+		SrpVerifier:     req.SRPVerifier,
+		SrpLoginId:      req.SRPLoginID,
+		SrpClientProof:  req.SRPClientProof,
+	}
+
+	resp, err := s.ChangePassword(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Password change failed", zap.Error(err))
+		http.Error(w, "Password change failed", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleDeleteAccount обрабатывает HTTP запрос на удаление аккаунта.
+func (s *Server) HandleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	var req models.AccountPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.DeleteAccountRequest{
+		Password:       req.Password,
+		SrpLoginId:     req.SRPLoginID,
+		SrpClientProof: req.SRPClientProof,
+	}
+
+	resp, err := s.DeleteAccount(httpAuthContext(r), grpcReq)
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(w, "Transfer organization ownership before deleting the account", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Account deletion failed", zap.Error(err))
+		http.Error(w, "Account deletion failed", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleExportAccount обрабатывает HTTP запрос на экспорт аккаунта.
+func (s *Server) HandleExportAccount(w http.ResponseWriter, r *http.Request) {
+	var req models.AccountPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.ExportAccountRequest{
+		Password:       req.Password,
+		SrpLoginId:     req.SRPLoginID,
+		SrpClientProof: req.SRPClientProof,
+	}
+
+	resp, err := s.ExportAccount(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Account export failed", zap.Error(err))
+		http.Error(w, "Account export failed", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCreateAccessToken обрабатывает HTTP запрос на создание персонального токена доступа.
+func (s *Server) HandleCreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.CreateAccessTokenRequest{
+		Name:              req.Name,
+		ReadOnly:          req.ReadOnly,
+		ClientCertificate: []byte(req.ClientCertificate),
+	}
+	for _, dataType := range req.DataTypes {
+		grpcReq.DataTypes = append(grpcReq.DataTypes, convertToProtoDataType(dataType))
+	}
+	if !req.ExpiresAt.IsZero() {
+		grpcReq.ExpiresAt = timestamppb.New(req.ExpiresAt)
+	}
+
+	resp, err := s.CreateAccessToken(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to create access token", zap.Error(err))
+		http.Error(w, "Failed to create access token", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListAccessTokens обрабатывает HTTP запрос на получение персональных токенов доступа.
+func (s *Server) HandleListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListAccessTokens(httpAuthContext(r), &pb.ListAccessTokensRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list access tokens", zap.Error(err))
+		http.Error(w, "Failed to list access tokens", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRevokeAccessToken обрабатывает HTTP запрос на отзыв персонального токена доступа.
+func (s *Server) HandleRevokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.RevokeAccessToken(httpAuthContext(r), &pb.RevokeAccessTokenRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to revoke access token", zap.Error(err))
+		http.Error(w, "Access token not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
 // HandleCreateData обрабатывает HTTP запрос на создание данных.
 func (s *Server) HandleCreateData(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateDataRequest
@@ -116,14 +373,20 @@ func (s *Server) HandleCreateData(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Получаем userID из контекста (проверяем авторизацию)
-	_, ok := getUserIDFromContext(r.Context())
+	_, ok := getUserIDFromContext(httpAuthContext(r))
 	if !ok {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
+	plaintext, err := httpPayload(req.Data)
+	if err != nil {
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
 	// Шифруем данные
-	encryptedData, err := s.cryptoService.EncryptLargeData([]byte(req.Data.(string)))
+	encryptedData, err := s.cryptoService.EncryptLargeData(plaintext)
 	if err != nil {
 		s.logger.Error("Failed to encrypt data", zap.Error(err))
 		http.Error(w, "Failed to encrypt data", http.StatusInternalServerError)
@@ -137,9 +400,13 @@ func (s *Server) HandleCreateData(w http.ResponseWriter, r *http.Request) {
 		Description:   req.Description,
 		EncryptedData: encryptedData,
 		Metadata:      req.Metadata,
+		RotationDays:  int32(req.RotationDays),
 	}
 
-	resp, err := s.CreateData(r.Context(), grpcReq)
+	resp, err := s.createData(httpAuthContext(r), grpcReq, plaintext)
+	if writePayloadError(w, err) {
+		return
+	}
 	if err != nil {
 		s.logger.Error("Failed to create data", zap.Error(err))
 		http.Error(w, "Failed to create data", http.StatusInternalServerError)
@@ -163,7 +430,7 @@ func (s *Server) HandleGetData(w http.ResponseWriter, r *http.Request) {
 		Id: id,
 	}
 
-	resp, err := s.GetData(r.Context(), grpcReq)
+	resp, err := s.GetData(httpAuthContext(r), grpcReq)
 	if err != nil {
 		s.logger.Error("Failed to get data", zap.Error(err))
 		http.Error(w, "Failed to get data", http.StatusInternalServerError)
@@ -205,7 +472,7 @@ func (s *Server) HandleListData(w http.ResponseWriter, r *http.Request) {
 		grpcReq.Type = &protoType
 	}
 
-	resp, err := s.ListData(r.Context(), grpcReq)
+	resp, err := s.ListData(httpAuthContext(r), grpcReq)
 	if err != nil {
 		s.logger.Error("Failed to list data", zap.Error(err))
 		http.Error(w, "Failed to list data", http.StatusInternalServerError)
@@ -236,8 +503,14 @@ func (s *Server) HandleUpdateData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	plaintext, err := httpPayload(req.Data)
+	if err != nil {
+		http.Error(w, "Invalid data", http.StatusBadRequest)
+		return
+	}
+
 	// Шифруем данные
-	encryptedData, err := s.cryptoService.EncryptLargeData([]byte(req.Data.(string)))
+	encryptedData, err := s.cryptoService.EncryptLargeData(plaintext)
 	if err != nil {
 		s.logger.Error("Failed to encrypt data", zap.Error(err))
 		http.Error(w, "Failed to encrypt data", http.StatusInternalServerError)
@@ -246,15 +519,27 @@ func (s *Server) HandleUpdateData(w http.ResponseWriter, r *http.Request) {
 
 	// Вызываем gRPC метод
 	grpcReq := &pb.UpdateDataRequest{
-		Id:            id,
-		Name:          req.Name,
-		Description:   req.Description,
-		EncryptedData: encryptedData,
-		Metadata:      req.Metadata,
-		Version:       req.Version,
+		Id:              id,
+		Name:            req.Name,
+		Description:     req.Description,
+		EncryptedData:   encryptedData,
+		Metadata:        req.Metadata,
+		Version:         req.Version,
+		PasswordChanged: req.PasswordChanged,
+	}
+	if req.RotationDays != nil {
+		rotationDays := int32(*req.RotationDays)
+		grpcReq.RotationDays = &rotationDays
 	}
 
-	resp, err := s.UpdateData(r.Context(), grpcReq)
+	resp, err := s.updateData(httpAuthContext(r), grpcReq, plaintext)
+	if writePayloadError(w, err) {
+		return
+	}
+	if status.Code(err) == codes.Aborted {
+		http.Error(w, "Data entry was modified, reload it and retry", http.StatusConflict)
+		return
+	}
 	if err != nil {
 		s.logger.Error("Failed to update data", zap.Error(err))
 		http.Error(w, "Failed to update data", http.StatusInternalServerError)
@@ -278,7 +563,7 @@ func (s *Server) HandleDeleteData(w http.ResponseWriter, r *http.Request) {
 		Id: id,
 	}
 
-	resp, err := s.DeleteData(r.Context(), grpcReq)
+	resp, err := s.DeleteData(httpAuthContext(r), grpcReq)
 	if err != nil {
 		s.logger.Error("Failed to delete data", zap.Error(err))
 		http.Error(w, "Failed to delete data", http.StatusInternalServerError)
@@ -302,7 +587,7 @@ func (s *Server) HandleSyncData(w http.ResponseWriter, r *http.Request) {
 		LastSyncTime: timestamppb.New(req.LastSyncTime),
 	}
 
-	resp, err := s.SyncData(r.Context(), grpcReq)
+	resp, err := s.SyncData(httpAuthContext(r), grpcReq)
 	if err != nil {
 		s.logger.Error("Failed to sync data", zap.Error(err))
 		http.Error(w, "Failed to sync data", http.StatusInternalServerError)
@@ -313,9 +598,9 @@ func (s *Server) HandleSyncData(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// HandleGenerateOTP обрабатывает HTTP запрос на генерацию OTP.
-func (s *Server) HandleGenerateOTP(w http.ResponseWriter, r *http.Request) {
-	var req models.OTPRequest
+// HandleSetVaultKey обрабатывает HTTP запрос на сохранение ключа хранилища.
+func (s *Server) HandleSetVaultKey(w http.ResponseWriter, r *http.Request) {
+	var req models.SetVaultKeyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
@@ -327,15 +612,44 @@ func (s *Server) HandleGenerateOTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Вызываем gRPC метод
-	grpcReq := &pb.GenerateOTPRequest{
-		Secret: req.Secret,
+	resp, err := s.SetVaultKey(httpAuthContext(r), &pb.SetVaultKeyRequest{WrappedVaultKey: req.WrappedVaultKey})
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(w, "Vault key is already set", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to set vault key", zap.Error(err))
+		http.Error(w, "Failed to set vault key", http.StatusBadRequest)
+		return
 	}
 
-	resp, err := s.GenerateOTP(r.Context(), grpcReq)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleSetPublicKey обрабатывает HTTP запрос на публикацию открытого ключа.
+func (s *Server) HandleSetPublicKey(w http.ResponseWriter, r *http.Request) {
+	var req models.SetPublicKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.SetPublicKeyRequest{
+		PublicKey:         req.PublicKey,
+		WrappedPrivateKey: req.WrappedPrivateKey,
+	}
+
+	resp, err := s.SetPublicKey(httpAuthContext(r), grpcReq)
 	if err != nil {
-		s.logger.Error("Failed to generate OTP", zap.Error(err))
-		http.Error(w, "Failed to generate OTP", http.StatusInternalServerError)
+		s.logger.Error("Failed to set public key", zap.Error(err))
+		http.Error(w, "Failed to set public key", http.StatusBadRequest)
 		return
 	}
 
@@ -343,27 +657,1098 @@ func (s *Server) HandleGenerateOTP(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// HandleCreateOTPSecret обрабатывает HTTP запрос на создание OTP секрета.
-func (s *Server) HandleCreateOTPSecret(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Issuer      string `json:"issuer"`
-		AccountName string `json:"account_name"`
+// HandleGetPublicKey обрабатывает HTTP запрос на получение открытого ключа пользователя.
+func (s *Server) HandleGetPublicKey(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	if username == "" {
+		http.Error(w, "Username is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.GetPublicKey(httpAuthContext(r), &pb.GetPublicKeyRequest{Username: username})
+	if err != nil {
+		s.logger.Error("Failed to get public key", zap.Error(err))
+		http.Error(w, "Public key not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleShareEntry обрабатывает HTTP запрос на предоставление доступа к записи.
+func (s *Server) HandleShareEntry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
 	}
+
+	var req models.ShareEntryRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	// Вызываем gRPC метод
-	grpcReq := &pb.CreateOTPSecretRequest{
-		Issuer:      req.Issuer,
-		AccountName: req.AccountName,
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
 	}
 
-	resp, err := s.CreateOTPSecret(r.Context(), grpcReq)
+	grpcReq := &pb.ShareEntryRequest{
+		EntryId:           id,
+		RecipientUsername: req.RecipientUsername,
+		WrappedKey:        req.WrappedKey,
+		Permission:        convertToProtoSharePermission(req.Permission),
+	}
+
+	resp, err := s.ShareEntry(httpAuthContext(r), grpcReq)
 	if err != nil {
-		s.logger.Error("Failed to create OTP secret", zap.Error(err))
-		http.Error(w, "Failed to create OTP secret", http.StatusInternalServerError)
+		s.logger.Error("Failed to share entry", zap.Error(err))
+		http.Error(w, "Failed to share entry", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListEntryShares обрабатывает HTTP запрос на получение списка получателей записи.
+func (s *Server) HandleListEntryShares(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ListEntryShares(httpAuthContext(r), &pb.ListEntrySharesRequest{EntryId: id})
+	if err != nil {
+		s.logger.Error("Failed to list entry shares", zap.Error(err))
+		http.Error(w, "Failed to list entry shares", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRevokeShare обрабатывает HTTP запрос на отзыв доступа к записи.
+func (s *Server) HandleRevokeShare(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.RevokeShare(httpAuthContext(r), &pb.RevokeShareRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to revoke share", zap.Error(err))
+		http.Error(w, "Share not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListSharedWithMe обрабатывает HTTP запрос на получение записей, к которым предоставлен доступ.
+func (s *Server) HandleListSharedWithMe(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListSharedWithMe(httpAuthContext(r), &pb.ListSharedWithMeRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list shared entries", zap.Error(err))
+		http.Error(w, "Failed to list shared entries", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCreateOrganization обрабатывает HTTP запрос на создание организации.
+func (s *Server) HandleCreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.CreateOrganization(httpAuthContext(r), &pb.CreateOrganizationRequest{Name: req.Name})
+	if err != nil {
+		s.logger.Error("Failed to create organization", zap.Error(err))
+		http.Error(w, "Failed to create organization", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListOrganizations обрабатывает HTTP запрос на получение организаций пользователя.
+func (s *Server) HandleListOrganizations(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListOrganizations(httpAuthContext(r), &pb.ListOrganizationsRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list organizations", zap.Error(err))
+		http.Error(w, "Failed to list organizations", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListOrganizationMembers обрабатывает HTTP запрос на получение участников организации.
+func (s *Server) HandleListOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ListOrganizationMembers(httpAuthContext(r), &pb.ListOrganizationMembersRequest{OrganizationId: id})
+	if err != nil {
+		s.logger.Error("Failed to list organization members", zap.Error(err))
+		http.Error(w, "Organization not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleInviteMember обрабатывает HTTP запрос на приглашение в организацию.
+func (s *Server) HandleInviteMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.InviteMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.InviteMemberRequest{
+		OrganizationId: id,
+		Username:       req.Username,
+		Role:           convertToProtoOrganizationRole(req.Role),
+	}
+
+	resp, err := s.InviteMember(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to invite member", zap.Error(err))
+		http.Error(w, "Failed to invite member", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListInvitations обрабатывает HTTP запрос на получение приглашений пользователя.
+func (s *Server) HandleListInvitations(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListInvitations(httpAuthContext(r), &pb.ListInvitationsRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list invitations", zap.Error(err))
+		http.Error(w, "Failed to list invitations", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleAcceptInvitation обрабатывает HTTP запрос на принятие приглашения.
+func (s *Server) HandleAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.AcceptInvitation(httpAuthContext(r), &pb.AcceptInvitationRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to accept invitation", zap.Error(err))
+		http.Error(w, "Invitation not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleDeclineInvitation обрабатывает HTTP запрос на отклонение или отзыв приглашения.
+func (s *Server) HandleDeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.DeclineInvitation(httpAuthContext(r), &pb.DeclineInvitationRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to decline invitation", zap.Error(err))
+		http.Error(w, "Invitation not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleUpdateMemberRole обрабатывает HTTP запрос на изменение роли участника.
+func (s *Server) HandleUpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "user_id")
+	if id == "" || userID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.UpdateMemberRoleRequest{
+		OrganizationId: id,
+		UserId:         userID,
+		Role:           convertToProtoOrganizationRole(req.Role),
+	}
+
+	resp, err := s.UpdateMemberRole(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to update member role", zap.Error(err))
+		http.Error(w, "Failed to update member role", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRemoveMember обрабатывает HTTP запрос на исключение участника из организации.
+func (s *Server) HandleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "user_id")
+	if id == "" || userID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.RemoveMember(httpAuthContext(r), &pb.RemoveMemberRequest{OrganizationId: id, UserId: userID})
+	if err != nil {
+		s.logger.Error("Failed to remove member", zap.Error(err))
+		http.Error(w, "Failed to remove member", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCreateCollection обрабатывает HTTP запрос на создание коллекции.
+func (s *Server) HandleCreateCollection(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateCollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.CreateCollectionRequest{
+		OrganizationId: id,
+		Name:           req.Name,
+		WrappedKey:     req.WrappedKey,
+		RotationDays:   int32(req.RotationDays),
+	}
+
+	resp, err := s.CreateCollection(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to create collection", zap.Error(err))
+		http.Error(w, "Failed to create collection", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListCollections обрабатывает HTTP запрос на получение коллекций организации.
+func (s *Server) HandleListCollections(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ListCollections(httpAuthContext(r), &pb.ListCollectionsRequest{OrganizationId: id})
+	if err != nil {
+		s.logger.Error("Failed to list collections", zap.Error(err))
+		http.Error(w, "Organization not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleGrantCollectionKey обрабатывает HTTP запрос на передачу ключа коллекции участнику.
+func (s *Server) HandleGrantCollectionKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "user_id")
+	if id == "" || userID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.GrantCollectionKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.GrantCollectionKeyRequest{
+		CollectionId: id,
+		UserId:       userID,
+		WrappedKey:   req.WrappedKey,
+		KeyVersion:   int32(req.KeyVersion),
+	}
+
+	resp, err := s.GrantCollectionKey(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to grant collection key", zap.Error(err))
+		http.Error(w, "Failed to grant collection key", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRotateCollectionKey обрабатывает HTTP запрос на смену ключа коллекции.
+func (s *Server) HandleRotateCollectionKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.RotateCollectionKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.RotateCollectionKeyRequest{
+		CollectionId: id,
+		KeyVersion:   int32(req.KeyVersion),
+	}
+	for _, key := range req.Keys {
+		grpcReq.Keys = append(grpcReq.Keys, &pb.CollectionKey{
+			UserId:     key.UserID.String(),
+			WrappedKey: key.WrappedKey,
+		})
+	}
+	for _, entry := range req.Entries {
+		grpcReq.Entries = append(grpcReq.Entries, &pb.CollectionEntryUpdate{
+			Id:            entry.ID.String(),
+			EncryptedData: entry.EncryptedData,
+			Version:       entry.Version,
+		})
+	}
+
+	resp, err := s.RotateCollectionKey(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to rotate collection key", zap.Error(err))
+		http.Error(w, "Failed to rotate collection key", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCreateCollectionEntry обрабатывает HTTP запрос на создание записи в коллекции.
+func (s *Server) HandleCreateCollectionEntry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreateCollectionEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.CreateCollectionEntryRequest{
+		CollectionId:  id,
+		Type:          convertToProtoDataType(req.Type),
+		Name:          req.Name,
+		Description:   req.Description,
+		EncryptedData: req.EncryptedData,
+		Metadata:      req.Metadata,
+		RotationDays:  int32(req.RotationDays),
+	}
+
+	resp, err := s.CreateCollectionEntry(httpAuthContext(r), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to create collection entry", zap.Error(err))
+		http.Error(w, "Failed to create collection entry", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListCollectionEntries обрабатывает HTTP запрос на получение записей коллекции.
+func (s *Server) HandleListCollectionEntries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ListCollectionEntries(httpAuthContext(r), &pb.ListCollectionEntriesRequest{CollectionId: id})
+	if err != nil {
+		s.logger.Error("Failed to list collection entries", zap.Error(err))
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleGenerateOTP обрабатывает HTTP запрос на генерацию OTP.
+func (s *Server) HandleGenerateOTP(w http.ResponseWriter, r *http.Request) {
+	var req models.OTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.GenerateOTPRequest{
+		Secret:    req.Secret,
+		Algorithm: req.Algorithm,
+		Digits:    int32(req.Digits),
+		Period:    int32(req.Period),
+	}
+
+	resp, err := s.GenerateOTP(r.Context(), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to generate OTP", zap.Error(err))
+		http.Error(w, "Failed to generate OTP", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCreateOTPSecret обрабатывает HTTP запрос на создание OTP секрета.
+func (s *Server) HandleCreateOTPSecret(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Issuer      string `json:"issuer"`
+		AccountName string `json:"account_name"`
+		Algorithm   string `json:"algorithm"`
+		Digits      int32  `json:"digits"`
+		Period      int32  `json:"period"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.CreateOTPSecretRequest{
+		Issuer:      req.Issuer,
+		AccountName: req.AccountName,
+		Algorithm:   req.Algorithm,
+		Digits:      req.Digits,
+		Period:      req.Period,
+	}
+
+	resp, err := s.CreateOTPSecret(r.Context(), grpcReq)
+	if err != nil {
+		s.logger.Error("Failed to create OTP secret", zap.Error(err))
+		http.Error(w, "Failed to create OTP secret", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// httpPayload возвращает открытое содержимое записи из HTTP запроса: строка
+// передается как есть, JSON объект типизированного содержимого сериализуется.
+func httpPayload(data interface{}) ([]byte, error) {
+	if text, ok := data.(string); ok {
+		return []byte(text), nil
+	}
+	return json.Marshal(data)
+}
+
+// writePayloadError отвечает 400 с ошибками по полям, если содержимое записи
+// не прошло проверку в режиме типизированного содержимого.
+func writePayloadError(w http.ResponseWriter, err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return false
+	}
+
+	var fields []payload.FieldError
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, payload.FieldError{
+					Field:       violation.Field,
+					Description: violation.Description,
+				})
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(struct {
+		Error  string               `json:"error"`
+		Fields []payload.FieldError `json:"fields"`
+	}{
+		Error:  st.Message(),
+		Fields: fields,
+	})
+	return true
+}
+
+// httpAuthContext переносит данные аутентификации из HTTP middleware
+// в ключи контекста, которые используют gRPC обработчики.
+func httpAuthContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		ctx = context.WithValue(ctx, UserIDKey, userID)
+	}
+	if sessionID, ok := middleware.GetSessionIDFromContext(ctx); ok {
+		ctx = context.WithValue(ctx, SessionIDKey, sessionID)
+	}
+	if accessToken, ok := middleware.GetAccessTokenFromContext(ctx); ok {
+		ctx = context.WithValue(ctx, AccessTokenKey, accessToken)
+	}
+	return ctx
+}
+
+// HandleAddEmergencyContact обрабатывает HTTP запрос на назначение контакта для экстренного доступа.
+func (s *Server) HandleAddEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	var req models.AddEmergencyContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.AddEmergencyContact(httpAuthContext(r), &pb.AddEmergencyContactRequest{
+		Username:   req.Username,
+		WaitDays:   int32(req.WaitDays),
+		WrappedKey: req.WrappedKey,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add emergency contact", zap.Error(err))
+		http.Error(w, "Failed to add emergency contact", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListEmergencyAccess обрабатывает HTTP запрос на получение экстренных доступов.
+func (s *Server) HandleListEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListEmergencyAccess(httpAuthContext(r), &pb.ListEmergencyAccessRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list emergency access", zap.Error(err))
+		http.Error(w, "Failed to list emergency access", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRemoveEmergencyContact обрабатывает HTTP запрос на удаление экстренного доступа.
+func (s *Server) HandleRemoveEmergencyContact(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.RemoveEmergencyContact(httpAuthContext(r), &pb.RemoveEmergencyContactRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to remove emergency contact", zap.Error(err))
+		http.Error(w, "Emergency access not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRequestEmergencyAccess обрабатывает HTTP запрос контакта на экстренный доступ.
+func (s *Server) HandleRequestEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.RequestEmergencyAccess(httpAuthContext(r), &pb.RequestEmergencyAccessRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to request emergency access", zap.Error(err))
+		http.Error(w, "Failed to request emergency access", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleApproveEmergencyAccess обрабатывает HTTP запрос на одобрение экстренного доступа.
+func (s *Server) HandleApproveEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ApproveEmergencyAccess(httpAuthContext(r), &pb.ApproveEmergencyAccessRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to approve emergency access", zap.Error(err))
+		http.Error(w, "Failed to approve emergency access", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleDenyEmergencyAccess обрабатывает HTTP запрос на отклонение экстренного доступа.
+func (s *Server) HandleDenyEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.DenyEmergencyAccess(httpAuthContext(r), &pb.DenyEmergencyAccessRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to deny emergency access", zap.Error(err))
+		http.Error(w, "Failed to deny emergency access", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleGetEmergencyVault обрабатывает HTTP запрос контакта на получение хранилища владельца.
+func (s *Server) HandleGetEmergencyVault(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.GetEmergencyVault(httpAuthContext(r), &pb.GetEmergencyVaultRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to get emergency vault", zap.Error(err))
+		http.Error(w, "Emergency access not granted", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListEmergencyAccessEvents обрабатывает HTTP запрос на получение журнала экстренного доступа.
+func (s *Server) HandleListEmergencyAccessEvents(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ListEmergencyAccessEvents(httpAuthContext(r), &pb.ListEmergencyAccessEventsRequest{Id: id})
+	if err != nil {
+		s.logger.Error("Failed to list emergency access events", zap.Error(err))
+		http.Error(w, "Failed to list emergency access events", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListNotifications обрабатывает HTTP запрос на получение уведомлений.
+func (s *Server) HandleListNotifications(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.ListNotifications(httpAuthContext(r), &pb.ListNotificationsRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list notifications", zap.Error(err))
+		http.Error(w, "Failed to list notifications", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCreateSecretLink обрабатывает HTTP запрос на создание одноразовой ссылки.
+func (s *Server) HandleCreateSecretLink(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSecretLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	// Вызываем gRPC метод
+	grpcReq := &pb.CreateSecretLinkRequest{
+		EncryptedData: req.EncryptedData,
+		MaxViews:      int32(req.MaxViews),
+	}
+	if !req.ExpiresAt.IsZero() {
+		grpcReq.ExpiresAt = timestamppb.New(req.ExpiresAt)
+	}
+
+	resp, err := s.CreateSecretLink(httpAuthContext(r), grpcReq)
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, "Invalid secret link parameters", http.StatusBadRequest)
+		return
+	}
+	if status.Code(err) == codes.PermissionDenied {
+		http.Error(w, "Insufficient token scope", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to create secret link", zap.Error(err))
+		http.Error(w, "Failed to create secret link", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// setSecretLinkHeaders запрещает кэширование и передачу адреса ссылки третьим сторонам.
+func setSecretLinkHeaders(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+}
+
+// HandleSecretLinkPage отдает публичную страницу ссылки. Просмотр страницы
+// не расходует ссылку: секрет запрашивается отдельным POST запросом.
+func (s *Server) HandleSecretLinkPage(w http.ResponseWriter, r *http.Request) {
+	if _, err := uuid.Parse(chi.URLParam(r, "id")); err != nil {
+		http.Error(w, "Secret not found", http.StatusNotFound)
+		return
+	}
+
+	setSecretLinkHeaders(w)
+	w.Header().Set("Content-Security-Policy",
+		"default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(templates.SecretLinkPage))
+}
+
+// HandleOpenSecretLink обрабатывает публичный запрос секрета по ссылке.
+// Каждый запрос расходует один просмотр, после последнего ссылка уничтожается.
+func (s *Server) HandleOpenSecretLink(w http.ResponseWriter, r *http.Request) {
+	linkID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Secret not found", http.StatusNotFound)
+		return
+	}
+
+	link, err := s.storage.ConsumeSecretLink(r.Context(), linkID, time.Now())
+	if errors.Is(err, storage.ErrSecretLinkGone) {
+		http.Error(w, "Secret not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to open secret link", zap.Error(err))
+		http.Error(w, "Failed to open secret", http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("Secret link viewed",
+		zap.String("link_id", link.ID.String()),
+		zap.Int("views_left", link.ViewsLeft()))
+
+	setSecretLinkHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		EncryptedData []byte `json:"encrypted_data"`
+		ViewsLeft     int    `json:"views_left"`
+	}{
+		EncryptedData: link.EncryptedData,
+		ViewsLeft:     link.ViewsLeft(),
+	})
+}
+
+// HandleAddAttachment обрабатывает HTTP запрос на добавление вложения к записи.
+func (s *Server) HandleAddAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.AddAttachmentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 2*models.MaxAttachmentSize)).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.AddAttachmentRequest{
+		EntryId:       id,
+		Name:          req.Name,
+		MimeType:      req.MimeType,
+		WrappedKey:    req.WrappedKey,
+		EncryptedData: req.EncryptedData,
+	}
+
+	resp, err := s.AddAttachment(httpAuthContext(r), grpcReq)
+	if status.Code(err) == codes.ResourceExhausted {
+		http.Error(w, "Attachment limit exceeded", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to add attachment", zap.Error(err))
+		http.Error(w, "Failed to add attachment", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListAttachments обрабатывает HTTP запрос на получение списка вложений записи.
+func (s *Server) HandleListAttachments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.ListAttachments(httpAuthContext(r), &pb.ListAttachmentsRequest{EntryId: id})
+	if err != nil {
+		s.logger.Error("Failed to list attachments", zap.Error(err))
+		http.Error(w, "Failed to list attachments", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleGetAttachment обрабатывает HTTP запрос на получение вложения записи.
+func (s *Server) HandleGetAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentID")
+	if id == "" || attachmentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.GetAttachment(httpAuthContext(r), &pb.GetAttachmentRequest{EntryId: id, Id: attachmentID})
+	if err != nil {
+		s.logger.Error("Failed to get attachment", zap.Error(err))
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleRemoveAttachment обрабатывает HTTP запрос на удаление вложения записи.
+func (s *Server) HandleRemoveAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentID")
+	if id == "" || attachmentID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.RemoveAttachment(httpAuthContext(r), &pb.RemoveAttachmentRequest{EntryId: id, Id: attachmentID})
+	if err != nil {
+		s.logger.Error("Failed to remove attachment", zap.Error(err))
+		http.Error(w, "Failed to remove attachment", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleListDueForRotation обрабатывает HTTP запрос на получение записей,
+// пароли которых пора сменить.
+func (s *Server) HandleListDueForRotation(w http.ResponseWriter, r *http.Request) {
+	var withinDays int
+	if withinStr := r.URL.Query().Get("within_days"); withinStr != "" {
+		days, err := strconv.Atoi(withinStr)
+		if err != nil {
+			http.Error(w, "Invalid within_days", http.StatusBadRequest)
+			return
+		}
+		withinDays = days
+	}
+
+	resp, err := s.ListDueForRotation(httpAuthContext(r), &pb.ListDueForRotationRequest{WithinDays: int32(withinDays)})
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, "Invalid within_days", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to list entries due for rotation", zap.Error(err))
+		http.Error(w, "Failed to list entries due for rotation", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleSetCollectionRotationPolicy обрабатывает HTTP запрос на изменение
+// политики смены паролей коллекции.
+func (s *Server) HandleSetCollectionRotationPolicy(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetRotationPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Валидация
+	if err := s.validator.Struct(req); err != nil {
+		http.Error(w, "Validation failed", http.StatusBadRequest)
+		return
+	}
+
+	grpcReq := &pb.SetCollectionRotationPolicyRequest{
+		CollectionId: id,
+		RotationDays: int32(req.RotationDays),
+	}
+
+	resp, err := s.SetCollectionRotationPolicy(httpAuthContext(r), grpcReq)
+	if status.Code(err) == codes.InvalidArgument {
+		http.Error(w, "Invalid collection ID or rotation days", http.StatusBadRequest)
+		return
+	}
+	if status.Code(err) == codes.PermissionDenied {
+		http.Error(w, "Insufficient organization role", http.StatusForbidden)
+		return
+	}
+	if status.Code(err) == codes.NotFound {
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to set collection rotation policy", zap.Error(err))
+		http.Error(w, "Failed to set collection rotation policy", http.StatusInternalServerError)
 		return
 	}
 
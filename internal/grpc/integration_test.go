@@ -1,30 +1,40 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"image/png"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/GophKeeper/internal/auth"
 	"github.com/GophKeeper/internal/crypto"
+	"github.com/GophKeeper/internal/middleware"
 	"github.com/GophKeeper/internal/models"
 	"github.com/GophKeeper/internal/otp"
 	"github.com/GophKeeper/internal/storage"
 	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestRegister(t *testing.T) {
@@ -88,6 +98,190 @@ func TestCreateData(t *testing.T) {
 	require.Equal(t, "Test Credentials", resp.DataEntry.Name)
 }
 
+func TestTypedPayloads(t *testing.T) {
+	server, _, client := setupTestServer(t)
+	server.SetTypedPayloads(true)
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+
+	// Некорректная карта отклоняется с ошибками по полям
+	_, err = client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CARD,
+		Name:          "Card",
+		EncryptedData: []byte(`{"number":"4111111111111112","expiry_date":"01/20","holder":"ALICE","cvv":"12345"}`),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		require.True(t, ok)
+		for _, violation := range badRequest.FieldViolations {
+			fields = append(fields, violation.Field)
+		}
+	}
+	require.ElementsMatch(t, []string{"encrypted_data.number", "encrypted_data.expiry_date", "encrypted_data.cvv"}, fields)
+
+	// Новые типы проверяются по своим структурам и возвращаются с тем же типом
+	_, err = client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_WIFI,
+		Name:          "Home",
+		EncryptedData: []byte(`{"ssid":"Home","password":"short","security":"wpa2"}`),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	wifiResp, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_WIFI,
+		Name:          "Home",
+		EncryptedData: []byte(`{"ssid":"Home","password":"correct horse","security":"wpa2"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.DataType_DATA_TYPE_WIFI, wifiResp.DataEntry.Type)
+
+	// Корректное содержимое сохраняется и проверяется при обновлении
+	createResp, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "Login",
+		EncryptedData: []byte(`{"login":"alice","password":"secret","url":"https://example.com"}`),
+	})
+	require.NoError(t, err)
+
+	_, err = client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id:            createResp.DataEntry.Id,
+		Name:          "Login",
+		EncryptedData: []byte("opaque ciphertext"),
+		Version:       createResp.DataEntry.Version,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// HTTP API возвращает те же ошибки в теле ответа
+	rec := httptest.NewRecorder()
+	require.True(t, writePayloadError(rec, err))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body struct {
+		Error  string `json:"error"`
+		Fields []struct {
+			Field string `json:"field"`
+		} `json:"fields"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Equal(t, "invalid payload", body.Error)
+	require.Len(t, body.Fields, 1)
+	require.Equal(t, "encrypted_data", body.Fields[0].Field)
+
+	// Без режима типизированного содержимого данные не разбираются
+	server.SetTypedPayloads(false)
+	_, err = client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CARD,
+		Name:          "Card",
+		EncryptedData: []byte("opaque ciphertext"),
+	})
+	require.NoError(t, err)
+}
+
+func TestTypedPayloads_UpdateExpiredCard(t *testing.T) {
+	server, store, client := setupTestServer(t)
+	server.SetTypedPayloads(true)
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+
+	const card = `{"number":"4111111111111111","expiry_date":"%s","holder":"ALICE","cvv":"123"}`
+	createResp, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CARD,
+		Name:          "Card",
+		EncryptedData: []byte(fmt.Sprintf(card, "12/2099")),
+	})
+	require.NoError(t, err)
+	entry := createResp.DataEntry
+
+	// Срок действия карты истек после сохранения; HTTP API хранит содержимое
+	// зашифрованным на сервере
+	for _, stored := range [][]byte{[]byte(fmt.Sprintf(card, "01/2020")), nil} {
+		if stored == nil {
+			stored, err = server.cryptoService.EncryptLargeData([]byte(fmt.Sprintf(card, "01/2020")))
+			require.NoError(t, err)
+		}
+		store.data[uuid.MustParse(entry.Id)].EncryptedData = stored
+
+		// Запись можно изменить, не меняя срок действия
+		updateResp, err := client.UpdateData(ctx, &pb.UpdateDataRequest{
+			Id:            entry.Id,
+			Name:          "Old card",
+			EncryptedData: []byte(fmt.Sprintf(card, "01/2020")),
+			Version:       entry.Version,
+		})
+		require.NoError(t, err)
+		entry = updateResp.DataEntry
+	}
+
+	// Новый срок действия в прошлом отклоняется
+	_, err = client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id:            entry.Id,
+		Name:          "Old card",
+		EncryptedData: []byte(fmt.Sprintf(card, "02/2020")),
+		Version:       entry.Version,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateDataVersionConflict(t *testing.T) {
+	server, store, client := setupTestServer(t)
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+
+	createResp, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_TEXT,
+		Name:          "Note",
+		EncryptedData: []byte("v1"),
+	})
+	require.NoError(t, err)
+	entry := createResp.DataEntry
+
+	updateResp, err := client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id: entry.Id, Name: "Note", EncryptedData: []byte("v2"), Version: entry.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, entry.Version+1, updateResp.DataEntry.Version)
+
+	// Второе устройство сохраняет изменения поверх устаревшей версии
+	_, err = client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id: entry.Id, Name: "Note", EncryptedData: []byte("stale"), Version: entry.Version,
+	})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	getResp, err := client.GetData(ctx, &pb.GetDataRequest{Id: entry.Id})
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), getResp.DataEntry.EncryptedData)
+
+	// HTTP API отвечает 409 Conflict
+	router := chi.NewRouter()
+	router.Use(middleware.AuthMiddleware(server.authService, store, zap.NewNop()))
+	router.Put("/data/{id}", server.HandleUpdateData)
+
+	body := fmt.Sprintf(`{"id":%q,"name":"Note","data":"stale","version":%d}`, entry.Id, entry.Version)
+	req := httptest.NewRequest(http.MethodPut, "/data/"+entry.Id, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+registerResp.Token)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
+
 func TestListData(t *testing.T) {
 	client := setupTestClient(t)
 
@@ -137,6 +331,24 @@ func TestGenerateOTP(t *testing.T) {
 	require.NotEmpty(t, resp.Code)
 	require.Len(t, resp.Code, 6)
 	require.Greater(t, resp.TimeRemaining, int32(0))
+
+	// Нестандартные параметры: 8 цифр, SHA256 и период 60 секунд
+	resp, err = client.GenerateOTP(context.Background(), &pb.GenerateOTPRequest{
+		Secret:    secret,
+		Algorithm: "SHA256",
+		Digits:    8,
+		Period:    60,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Code, 8)
+	require.Greater(t, resp.TimeRemaining, int32(0))
+	require.LessOrEqual(t, resp.TimeRemaining, int32(60))
+
+	_, err = client.GenerateOTP(context.Background(), &pb.GenerateOTPRequest{
+		Secret:    secret,
+		Algorithm: "MD5",
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestCreateOTPSecret(t *testing.T) {
@@ -150,6 +362,25 @@ func TestCreateOTPSecret(t *testing.T) {
 	require.NotEmpty(t, resp.Secret)
 	require.NotEmpty(t, resp.QrCodeUrl)
 	require.Len(t, resp.BackupCodes, 10)
+
+	// PNG изображение содержит тот же otpauth URI
+	img, err := png.Decode(bytes.NewReader(resp.QrCodePng))
+	require.NoError(t, err)
+	decoded, err := otp.DecodeQRImage(img)
+	require.NoError(t, err)
+	require.Equal(t, resp.QrCodeUrl, decoded)
+
+	resp, err = client.CreateOTPSecret(context.Background(), &pb.CreateOTPSecretRequest{
+		Issuer:      "GophKeeper",
+		AccountName: "test@example.com",
+		Algorithm:   "SHA512",
+		Digits:      8,
+		Period:      60,
+	})
+	require.NoError(t, err)
+	require.Contains(t, resp.QrCodeUrl, "algorithm=SHA512")
+	require.Contains(t, resp.QrCodeUrl, "digits=8")
+	require.Contains(t, resp.QrCodeUrl, "period=60")
 }
 
 func TestUnauthorizedAccess(t *testing.T) {
@@ -179,140 +410,2361 @@ func TestInvalidCredentials(t *testing.T) {
 	require.Equal(t, codes.Unauthenticated, st.Code())
 }
 
-// setupTestClient создает тестовый клиент с собственным mockStorage
-func setupTestClient(t *testing.T) pb.GophKeeperClient {
-	// Настройка тестового окружения
-	logger, _ := zap.NewDevelopment()
+func TestChangePassword(t *testing.T) {
+	client := setupTestClient(t)
 
-	// Генерируем тестовые ключи программно
-	privateKey, publicKey := generateTestKeys(t)
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username:        "testuser",
+		Password:        "testpass123",
+		WrappedVaultKey: []byte("old-wrapped-key"),
+	})
+	require.NoError(t, err)
 
-	// Создаем сервисы
-	authService := auth.NewService("test-secret")
-	cryptoService, err := crypto.NewService(privateKey, publicKey)
+	// Вторая сессия того же пользователя
+	otherResp, err := client.Login(context.Background(), &pb.LoginRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
 	require.NoError(t, err)
-	otpService := otp.NewService()
+	require.Equal(t, []byte("old-wrapped-key"), otherResp.WrappedVaultKey)
 
-	// Создаем тестовое хранилище (in-memory для тестов)
-	storage := setupTestStorage(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
 
-	// Создаем сервер
-	server := NewServer(storage, authService, cryptoService, otpService, logger)
+	// Неверный старый пароль
+	_, err = client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		OldPassword: "wrongpassword",
+		NewPassword: "newpass123",
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
 
-	// Запускаем тестовый сервер
-	lis, err := startTestGRPCServer(server, "localhost:0", logger)
-	require.NoError(t, err)
-	t.Cleanup(func() {
-		lis.Close()
+	resp, err := client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		OldPassword:     "testpass123",
+		NewPassword:     "newpass123",
+		WrappedVaultKey: []byte("new-wrapped-key"),
 	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Token)
+	require.Equal(t, []byte("new-wrapped-key"), resp.WrappedVaultKey)
 
-	// Подключаемся к серверу
-	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Текущая сессия продолжает работать
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+resp.Token)
+	_, err = client.ListData(ctx, &pb.ListDataRequest{})
 	require.NoError(t, err)
-	t.Cleanup(func() {
-		conn.Close()
+
+	// Остальные сессии отозваны
+	otherCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+otherResp.Token)
+	_, err = client.ListData(otherCtx, &pb.ListDataRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.RefreshToken(context.Background(), &pb.RefreshTokenRequest{Token: otherResp.Token})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Вход возможен только с новым паролем
+	_, err = client.Login(context.Background(), &pb.LoginRequest{
+		Username: "testuser",
+		Password: "testpass123",
 	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
 
-	return pb.NewGophKeeperClient(conn)
+	loginResp, err := client.Login(context.Background(), &pb.LoginRequest{
+		Username: "testuser",
+		Password: "newpass123",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte("new-wrapped-key"), loginResp.WrappedVaultKey)
 }
 
-// setupTestStorage создает тестовое хранилище
-func setupTestStorage(t *testing.T) storage.Storage {
-	// Для интеграционных тестов используем in-memory хранилище
-	// В реальном проекте здесь можно использовать тестовую базу данных
-	return &mockStorage{}
-}
+func TestExportAndDeleteAccount(t *testing.T) {
+	client := setupTestClient(t)
 
-// mockStorage - простое in-memory хранилище для тестов
-type mockStorage struct {
-	users map[string]*models.User
-	data  map[uuid.UUID]*models.DataEntry
-}
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
 
-func (m *mockStorage) CreateUser(ctx context.Context, user *models.User) error {
-	if m.users == nil {
-		m.users = make(map[string]*models.User)
-	}
-	// Убеждаемся, что у пользователя есть ID
-	if user.ID == uuid.Nil {
-		user.ID = uuid.New()
-	}
-	m.users[user.Username] = user
-	return nil
-}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
 
-func (m *mockStorage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
-	if user, exists := m.users[username]; exists {
-		return user, nil
-	}
-	return nil, fmt.Errorf("user not found")
-}
+	noteResp, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_TEXT,
+		Name:          "Note",
+		EncryptedData: []byte("encrypted-note"),
+	})
+	require.NoError(t, err)
+	_, err = client.AddAttachment(ctx, &pb.AddAttachmentRequest{
+		EntryId:       noteResp.DataEntry.Id,
+		Name:          "scan.pdf",
+		WrappedKey:    []byte("wrapped-attachment-key"),
+		EncryptedData: []byte("encrypted-attachment"),
+	})
+	require.NoError(t, err)
 
-func (m *mockStorage) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
-	for _, user := range m.users {
-		if user.ID == userID {
-			return user, nil
-		}
-	}
-	return nil, fmt.Errorf("user not found")
-}
+	// Экспорт требует правильный пароль
+	_, err = client.ExportAccount(ctx, &pb.ExportAccountRequest{Password: "wrongpassword"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
 
-func (m *mockStorage) CreateDataEntry(ctx context.Context, entry *models.DataEntry) error {
-	if m.data == nil {
-		m.data = make(map[uuid.UUID]*models.DataEntry)
-	}
-	m.data[entry.ID] = entry
-	return nil
-}
+	exportResp, err := client.ExportAccount(ctx, &pb.ExportAccountRequest{Password: "testpass123"})
+	require.NoError(t, err)
 
-func (m *mockStorage) GetDataEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error) {
-	if entry, exists := m.data[entryID]; exists && entry.UserID == userID {
-		return entry, nil
-	}
-	return nil, fmt.Errorf("data entry not found")
-}
+	data, err := crypto.DecryptWithPassword(exportResp.Archive, "testpass123")
+	require.NoError(t, err)
 
-func (m *mockStorage) GetDataEntries(ctx context.Context, userID uuid.UUID, dataType *models.DataType) ([]models.DataEntry, error) {
-	var entries []models.DataEntry
-	for _, entry := range m.data {
-		if entry.UserID == userID {
-			if dataType == nil || entry.Type == *dataType {
-				entries = append(entries, *entry)
-			}
-		}
-	}
-	return entries, nil
+	var export models.AccountExport
+	require.NoError(t, json.Unmarshal(data, &export))
+	require.Equal(t, "testuser", export.Username)
+	require.Len(t, export.Entries, 1)
+	require.Equal(t, []byte("encrypted-note"), export.Entries[0].EncryptedData)
+	require.Len(t, export.Attachments, 1)
+	require.Equal(t, "scan.pdf", export.Attachments[0].Name)
+	require.Equal(t, []byte("wrapped-attachment-key"), export.Attachments[0].WrappedKey)
+	require.Equal(t, []byte("encrypted-attachment"), export.Attachments[0].EncryptedData)
+
+	// Удаление аккаунта
+	_, err = client.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "wrongpassword"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	deleteResp, err := client.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "testpass123"})
+	require.NoError(t, err)
+	require.True(t, deleteResp.Success)
+
+	// Токен удаленного аккаунта больше не действует
+	_, err = client.ListData(ctx, &pb.ListDataRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Login(context.Background(), &pb.LoginRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
 }
 
-func (m *mockStorage) UpdateDataEntry(ctx context.Context, entry *models.DataEntry) error {
-	if _, exists := m.data[entry.ID]; exists {
-		m.data[entry.ID] = entry
-		return nil
+func TestDeleteAccount_LastOrganizationOwner(t *testing.T) {
+	client := setupTestClient(t)
+
+	ctxs := make(map[string]context.Context)
+	ids := make(map[string]string)
+	for _, name := range []string{"alice", "bob"} {
+		resp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: name, Password: "testpass123"})
+		require.NoError(t, err)
+		ctxs[name] = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+resp.Token)
+		ids[name] = resp.User.Id
 	}
-	return fmt.Errorf("data entry not found")
+
+	_, err := client.CreateOrganization(ctxs["alice"], &pb.CreateOrganizationRequest{Name: "solo"})
+	require.NoError(t, err)
+	orgResp, err := client.CreateOrganization(ctxs["alice"], &pb.CreateOrganizationRequest{Name: "team"})
+	require.NoError(t, err)
+	orgID := orgResp.Organization.Id
+
+	_, err = client.InviteMember(ctxs["alice"], &pb.InviteMemberRequest{
+		OrganizationId: orgID,
+		Username:       "bob",
+		Role:           pb.OrganizationRole_ORGANIZATION_ROLE_EDITOR,
+	})
+	require.NoError(t, err)
+	invitations, err := client.ListInvitations(ctxs["bob"], &pb.ListInvitationsRequest{})
+	require.NoError(t, err)
+	_, err = client.AcceptInvitation(ctxs["bob"], &pb.AcceptInvitationRequest{Id: invitations.Invitations[0].Id})
+	require.NoError(t, err)
+
+	// Последний владелец организации с участниками должен сначала передать права
+	_, err = client.DeleteAccount(ctxs["alice"], &pb.DeleteAccountRequest{Password: "testpass123"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.UpdateMemberRole(ctxs["alice"], &pb.UpdateMemberRoleRequest{
+		OrganizationId: orgID,
+		UserId:         ids["bob"],
+		Role:           pb.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+	})
+	require.NoError(t, err)
+
+	_, err = client.DeleteAccount(ctxs["alice"], &pb.DeleteAccountRequest{Password: "testpass123"})
+	require.NoError(t, err)
+
+	// Организация без других участников удаляется вместе с аккаунтом
+	orgs, err := client.ListOrganizations(ctxs["bob"], &pb.ListOrganizationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, orgs.Organizations, 1)
+	require.Equal(t, "team", orgs.Organizations[0].Name)
+
+	members, err := client.ListOrganizationMembers(ctxs["bob"], &pb.ListOrganizationMembersRequest{OrganizationId: orgID})
+	require.NoError(t, err)
+	require.Len(t, members.Members, 1)
 }
 
-func (m *mockStorage) DeleteDataEntry(ctx context.Context, userID, entryID uuid.UUID) error {
-	if entry, exists := m.data[entryID]; exists && entry.UserID == userID {
-		delete(m.data, entryID)
-		return nil
-	}
-	return fmt.Errorf("data entry not found")
+func TestSetVaultKey(t *testing.T) {
+	client := setupTestClient(t)
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	require.Empty(t, registerResp.WrappedVaultKey)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+
+	_, err = client.SetVaultKey(ctx, &pb.SetVaultKeyRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SetVaultKey(ctx, &pb.SetVaultKeyRequest{WrappedVaultKey: []byte("wrapped-key")})
+	require.NoError(t, err)
+
+	// Заданный ключ меняется только вместе с паролем
+	_, err = client.SetVaultKey(ctx, &pb.SetVaultKeyRequest{WrappedVaultKey: []byte("other-key")})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	loginResp, err := client.Login(context.Background(), &pb.LoginRequest{Username: "testuser", Password: "testpass123"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("wrapped-key"), loginResp.WrappedVaultKey)
 }
 
-func (m *mockStorage) GetDataEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
-	var entries []models.DataEntry
-	for _, entry := range m.data {
-		if entry.UserID == userID && entry.UpdatedAt.After(after) {
-			entries = append(entries, *entry)
-		}
+func TestAccessTokens(t *testing.T) {
+	client := setupTestClient(t)
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+
+	for _, req := range []*pb.CreateDataRequest{
+		{Type: pb.DataType_DATA_TYPE_CREDENTIALS, Name: "login", EncryptedData: []byte("secret")},
+		{Type: pb.DataType_DATA_TYPE_TEXT, Name: "note", EncryptedData: []byte("secret")},
+	} {
+		_, err = client.CreateData(ctx, req)
+		require.NoError(t, err)
 	}
-	return entries, nil
-}
 
-func (m *mockStorage) GetDeletedEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]uuid.UUID, error) {
-	// Простая реализация для тестов
-	return []uuid.UUID{}, nil
+	// Срок действия больше года запрещен
+	_, err = client.CreateAccessToken(ctx, &pb.CreateAccessTokenRequest{
+		Name:      "ci",
+		ExpiresAt: timestamppb.New(time.Now().Add(2 * 365 * 24 * time.Hour)),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	tokenResp, err := client.CreateAccessToken(ctx, &pb.CreateAccessTokenRequest{
+		Name:      "ci",
+		ReadOnly:  true,
+		DataTypes: []pb.DataType{pb.DataType_DATA_TYPE_CREDENTIALS},
+	})
+	require.NoError(t, err)
+	require.True(t, auth.IsAccessToken(tokenResp.Token))
+
+	listResp, err := client.ListAccessTokens(ctx, &pb.ListAccessTokensRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.AccessTokens, 1)
+	require.Equal(t, "ci", listResp.AccessTokens[0].Name)
+
+	tokenCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tokenResp.Token)
+
+	// Видны только записи разрешенного типа
+	dataResp, err := client.ListData(tokenCtx, &pb.ListDataRequest{})
+	require.NoError(t, err)
+	require.Len(t, dataResp.DataEntries, 1)
+	require.Equal(t, "login", dataResp.DataEntries[0].Name)
+
+	// Токен только для чтения не может изменять данные и управлять токенами
+	_, err = client.CreateData(tokenCtx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "other",
+		EncryptedData: []byte("secret"),
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.ListAccessTokens(tokenCtx, &pb.ListAccessTokensRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Отозванный токен больше не принимается
+	_, err = client.RevokeAccessToken(ctx, &pb.RevokeAccessTokenRequest{Id: tokenResp.AccessToken.Id})
+	require.NoError(t, err)
+
+	_, err = client.ListData(tokenCtx, &pb.ListDataRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestEntrySharing(t *testing.T) {
+	client := setupTestClient(t)
+
+	aliceResp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: "alice", Password: "testpass123"})
+	require.NoError(t, err)
+	bobResp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: "bob", Password: "testpass123"})
+	require.NoError(t, err)
+	aliceCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+aliceResp.Token)
+	bobCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+bobResp.Token)
+
+	entryResp, err := client.CreateData(aliceCtx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "server",
+		EncryptedData: []byte("encrypted-with-entry-key"),
+	})
+	require.NoError(t, err)
+	entryID := entryResp.DataEntry.Id
+
+	// Получатель еще не опубликовал открытый ключ
+	_, err = client.ShareEntry(aliceCtx, &pb.ShareEntryRequest{EntryId: entryID, RecipientUsername: "bob", WrappedKey: []byte("key")})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	bobPrivateKey, bobPublicKey, err := crypto.GenerateSharingKeyPair()
+	require.NoError(t, err)
+	_, err = client.SetPublicKey(bobCtx, &pb.SetPublicKeyRequest{PublicKey: bobPublicKey, WrappedPrivateKey: []byte("wrapped-private-key")})
+	require.NoError(t, err)
+
+	loginResp, err := client.Login(context.Background(), &pb.LoginRequest{Username: "bob", Password: "testpass123"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("wrapped-private-key"), loginResp.WrappedPrivateKey)
+
+	// Владелец шифрует ключ записи открытым ключом получателя
+	keyResp, err := client.GetPublicKey(aliceCtx, &pb.GetPublicKeyRequest{Username: "bob"})
+	require.NoError(t, err)
+	entryKey, err := crypto.GenerateAESKey()
+	require.NoError(t, err)
+	wrappedKey, err := crypto.WrapKeyForRecipient(entryKey, keyResp.PublicKey)
+	require.NoError(t, err)
+
+	_, err = client.ShareEntry(aliceCtx, &pb.ShareEntryRequest{EntryId: entryID, RecipientUsername: "alice", WrappedKey: wrappedKey})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Чужой записью поделиться нельзя
+	_, err = client.ShareEntry(bobCtx, &pb.ShareEntryRequest{EntryId: entryID, RecipientUsername: "alice", WrappedKey: wrappedKey})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	shareResp, err := client.ShareEntry(aliceCtx, &pb.ShareEntryRequest{
+		EntryId:           entryID,
+		RecipientUsername: "bob",
+		WrappedKey:        wrappedKey,
+		Permission:        pb.SharePermission_SHARE_PERMISSION_READ,
+	})
+	require.NoError(t, err)
+
+	sharedResp, err := client.ListSharedWithMe(bobCtx, &pb.ListSharedWithMeRequest{})
+	require.NoError(t, err)
+	require.Len(t, sharedResp.DataEntries, 1)
+	shared := sharedResp.DataEntries[0]
+	require.Equal(t, "alice", shared.Share.OwnerUsername)
+	require.Equal(t, pb.SharePermission_SHARE_PERMISSION_READ, shared.Share.Permission)
+
+	unwrapped, err := crypto.UnwrapSharedKey(shared.Share.WrappedKey, bobPrivateKey)
+	require.NoError(t, err)
+	require.Equal(t, entryKey, unwrapped)
+
+	// Доступ только для чтения
+	_, err = client.UpdateData(bobCtx, &pb.UpdateDataRequest{Id: entryID, Name: "server", EncryptedData: []byte("changed"), Version: 1})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	syncResp, err := client.SyncData(bobCtx, &pb.SyncDataRequest{LastSyncTime: timestamppb.New(time.Time{})})
+	require.NoError(t, err)
+	require.Len(t, syncResp.DataEntries, 1)
+	require.Equal(t, entryID, syncResp.DataEntries[0].Id)
+
+	// Повторное предоставление меняет права
+	_, err = client.ShareEntry(aliceCtx, &pb.ShareEntryRequest{
+		EntryId:           entryID,
+		RecipientUsername: "bob",
+		WrappedKey:        wrappedKey,
+		Permission:        pb.SharePermission_SHARE_PERMISSION_WRITE,
+	})
+	require.NoError(t, err)
+
+	_, err = client.UpdateData(bobCtx, &pb.UpdateDataRequest{Id: entryID, Name: "server", EncryptedData: []byte("changed"), Version: 1})
+	require.NoError(t, err)
+
+	ownResp, err := client.GetData(aliceCtx, &pb.GetDataRequest{Id: entryID})
+	require.NoError(t, err)
+	require.Equal(t, []byte("changed"), ownResp.DataEntry.EncryptedData)
+	require.Nil(t, ownResp.DataEntry.Share)
+
+	sharesResp, err := client.ListEntryShares(aliceCtx, &pb.ListEntrySharesRequest{EntryId: entryID})
+	require.NoError(t, err)
+	require.Len(t, sharesResp.Shares, 1)
+	require.Equal(t, "bob", sharesResp.Shares[0].RecipientUsername)
+	require.Equal(t, shareResp.Share.Id, sharesResp.Shares[0].Id)
+
+	// После отзыва запись удаляется у получателя при синхронизации
+	revokedAt := time.Now()
+	_, err = client.RevokeShare(aliceCtx, &pb.RevokeShareRequest{Id: shareResp.Share.Id})
+	require.NoError(t, err)
+
+	syncResp, err = client.SyncData(bobCtx, &pb.SyncDataRequest{LastSyncTime: timestamppb.New(revokedAt)})
+	require.NoError(t, err)
+	require.Empty(t, syncResp.DataEntries)
+	require.Equal(t, []string{entryID}, syncResp.DeletedIds)
+
+	_, err = client.GetData(bobCtx, &pb.GetDataRequest{Id: entryID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAttachments(t *testing.T) {
+	client := setupTestClient(t)
+
+	aliceResp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: "alice", Password: "testpass123"})
+	require.NoError(t, err)
+	bobResp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: "bob", Password: "testpass123"})
+	require.NoError(t, err)
+	aliceCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+aliceResp.Token)
+	bobCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+bobResp.Token)
+
+	entryResp, err := client.CreateData(aliceCtx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "server",
+		EncryptedData: []byte("encrypted-with-entry-key"),
+	})
+	require.NoError(t, err)
+	entryID := entryResp.DataEntry.Id
+
+	addResp, err := client.AddAttachment(aliceCtx, &pb.AddAttachmentRequest{
+		EntryId:       entryID,
+		Name:          "recovery.pdf",
+		MimeType:      "application/pdf",
+		WrappedKey:    []byte("wrapped-attachment-key"),
+		EncryptedData: []byte("encrypted-attachment"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(len("encrypted-attachment")), addResp.Attachment.Size)
+
+	listResp, err := client.ListAttachments(aliceCtx, &pb.ListAttachmentsRequest{EntryId: entryID})
+	require.NoError(t, err)
+	require.Len(t, listResp.Attachments, 1)
+	require.Equal(t, "recovery.pdf", listResp.Attachments[0].Name)
+	require.Equal(t, addResp.Attachment.Size, listResp.TotalSize)
+
+	getResp, err := client.GetAttachment(aliceCtx, &pb.GetAttachmentRequest{EntryId: entryID, Id: addResp.Attachment.Id})
+	require.NoError(t, err)
+	require.Equal(t, []byte("encrypted-attachment"), getResp.EncryptedData)
+	require.Equal(t, []byte("wrapped-attachment-key"), getResp.Attachment.WrappedKey)
+
+	// Чужие вложения недоступны
+	_, err = client.ListAttachments(bobCtx, &pb.ListAttachmentsRequest{EntryId: entryID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.GetAttachment(bobCtx, &pb.GetAttachmentRequest{EntryId: entryID, Id: addResp.Attachment.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Ограничения размера
+	_, err = client.AddAttachment(aliceCtx, &pb.AddAttachmentRequest{
+		EntryId:       entryID,
+		Name:          "huge.bin",
+		WrappedKey:    []byte("key"),
+		EncryptedData: make([]byte, models.MaxAttachmentSize+1),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	for i := 0; i <= models.MaxEntryAttachmentsSize/models.MaxAttachmentSize; i++ {
+		_, err = client.AddAttachment(aliceCtx, &pb.AddAttachmentRequest{
+			EntryId:       entryID,
+			Name:          fmt.Sprintf("part%d.bin", i),
+			WrappedKey:    []byte("key"),
+			EncryptedData: make([]byte, models.MaxAttachmentSize),
+		})
+		if err != nil {
+			break
+		}
+	}
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = client.RemoveAttachment(aliceCtx, &pb.RemoveAttachmentRequest{EntryId: entryID, Id: addResp.Attachment.Id})
+	require.NoError(t, err)
+	_, err = client.GetAttachment(aliceCtx, &pb.GetAttachmentRequest{EntryId: entryID, Id: addResp.Attachment.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.RemoveAttachment(aliceCtx, &pb.RemoveAttachmentRequest{EntryId: entryID, Id: addResp.Attachment.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Получатель с доступом только для чтения видит вложения, но не меняет их
+	_, bobPublicKey, err := crypto.GenerateSharingKeyPair()
+	require.NoError(t, err)
+	_, err = client.SetPublicKey(bobCtx, &pb.SetPublicKeyRequest{PublicKey: bobPublicKey, WrappedPrivateKey: []byte("wrapped-private-key")})
+	require.NoError(t, err)
+	_, err = client.ShareEntry(aliceCtx, &pb.ShareEntryRequest{
+		EntryId:           entryID,
+		RecipientUsername: "bob",
+		WrappedKey:        []byte("wrapped-entry-key"),
+		Permission:        pb.SharePermission_SHARE_PERMISSION_READ,
+	})
+	require.NoError(t, err)
+
+	listResp, err = client.ListAttachments(bobCtx, &pb.ListAttachmentsRequest{EntryId: entryID})
+	require.NoError(t, err)
+	require.NotEmpty(t, listResp.Attachments)
+	_, err = client.RemoveAttachment(bobCtx, &pb.RemoveAttachmentRequest{EntryId: entryID, Id: listResp.Attachments[0].Id})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Вложения удаляются вместе с записью
+	_, err = client.DeleteData(aliceCtx, &pb.DeleteDataRequest{Id: entryID})
+	require.NoError(t, err)
+	_, err = client.ListAttachments(aliceCtx, &pb.ListAttachmentsRequest{EntryId: entryID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Записи коллекций вложений не поддерживают
+	orgResp, err := client.CreateOrganization(aliceCtx, &pb.CreateOrganizationRequest{Name: "infra"})
+	require.NoError(t, err)
+	collResp, err := client.CreateCollection(aliceCtx, &pb.CreateCollectionRequest{
+		OrganizationId: orgResp.Organization.Id,
+		Name:           "servers",
+		WrappedKey:     []byte("collection-key"),
+	})
+	require.NoError(t, err)
+	teamEntry, err := client.CreateCollectionEntry(aliceCtx, &pb.CreateCollectionEntryRequest{
+		CollectionId:  collResp.Collection.Id,
+		Type:          pb.DataType_DATA_TYPE_TEXT,
+		Name:          "note",
+		EncryptedData: []byte("encrypted-with-collection-key"),
+	})
+	require.NoError(t, err)
+	_, err = client.AddAttachment(aliceCtx, &pb.AddAttachmentRequest{
+		EntryId:       teamEntry.DataEntry.Id,
+		Name:          "scan.pdf",
+		WrappedKey:    []byte("key"),
+		EncryptedData: []byte("encrypted-attachment"),
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+// srpLogin выполняет вход по SRP-6a и проверяет доказательство сервера
+func srpLogin(t *testing.T, client pb.GophKeeperClient, username, password string) (*pb.AuthResponse, error) {
+	srpClient, err := auth.NewSRPClient(username, password)
+	require.NoError(t, err)
+
+	beginResp, err := client.BeginSRPLogin(context.Background(), &pb.BeginSRPLoginRequest{
+		Username:        username,
+		ClientPublicKey: srpClient.PublicKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := srpClient.ProcessChallenge(beginResp.Salt, beginResp.ServerPublicKey)
+	require.NoError(t, err)
+
+	finishResp, err := client.FinishSRPLogin(context.Background(), &pb.FinishSRPLoginRequest{
+		LoginId:     beginResp.LoginId,
+		ClientProof: proof,
+	})
+	if err != nil {
+		return nil, err
+	}
+	require.NoError(t, srpClient.VerifyServer(finishResp.ServerProof))
+
+	return finishResp.Auth, nil
+}
+
+// srpConfirmation начинает рукопожатие SRP и возвращает доказательство клиента
+// для подтверждения пароля в необратимых операциях
+func srpConfirmation(t *testing.T, client pb.GophKeeperClient, username, password string) (string, []byte) {
+	srpClient, err := auth.NewSRPClient(username, password)
+	require.NoError(t, err)
+
+	beginResp, err := client.BeginSRPLogin(context.Background(), &pb.BeginSRPLoginRequest{
+		Username:        username,
+		ClientPublicKey: srpClient.PublicKey(),
+	})
+	require.NoError(t, err)
+
+	proof, err := srpClient.ProcessChallenge(beginResp.Salt, beginResp.ServerPublicKey)
+	require.NoError(t, err)
+
+	return beginResp.LoginId, proof
+}
+
+func TestOrganizationCollections(t *testing.T) {
+	client := setupTestClient(t)
+
+	ctxs := make(map[string]context.Context)
+	ids := make(map[string]string)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		resp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: name, Password: "testpass123"})
+		require.NoError(t, err)
+		ctxs[name] = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+resp.Token)
+		ids[name] = resp.User.Id
+	}
+
+	orgResp, err := client.CreateOrganization(ctxs["alice"], &pb.CreateOrganizationRequest{Name: "infra"})
+	require.NoError(t, err)
+	require.Equal(t, pb.OrganizationRole_ORGANIZATION_ROLE_OWNER, orgResp.Organization.Role)
+	orgID := orgResp.Organization.Id
+
+	collResp, err := client.CreateCollection(ctxs["alice"], &pb.CreateCollectionRequest{
+		OrganizationId: orgID,
+		Name:           "servers",
+		WrappedKey:     []byte("key-v1-alice"),
+	})
+	require.NoError(t, err)
+	collectionID := collResp.Collection.Id
+
+	// Участники присоединяются по приглашению
+	for name, role := range map[string]pb.OrganizationRole{
+		"bob":   pb.OrganizationRole_ORGANIZATION_ROLE_EDITOR,
+		"carol": pb.OrganizationRole_ORGANIZATION_ROLE_VIEWER,
+	} {
+		_, err := client.InviteMember(ctxs["alice"], &pb.InviteMemberRequest{OrganizationId: orgID, Username: name, Role: role})
+		require.NoError(t, err)
+
+		invitations, err := client.ListInvitations(ctxs[name], &pb.ListInvitationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, invitations.Invitations, 1)
+		require.Equal(t, "alice", invitations.Invitations[0].InvitedBy)
+
+		accepted, err := client.AcceptInvitation(ctxs[name], &pb.AcceptInvitationRequest{Id: invitations.Invitations[0].Id})
+		require.NoError(t, err)
+		require.Equal(t, role, accepted.Organization.Role)
+
+		_, err = client.GrantCollectionKey(ctxs["alice"], &pb.GrantCollectionKeyRequest{
+			CollectionId: collectionID,
+			UserId:       ids[name],
+			WrappedKey:   []byte("key-v1-" + name),
+			KeyVersion:   1,
+		})
+		require.NoError(t, err)
+	}
+
+	members, err := client.ListOrganizationMembers(ctxs["carol"], &pb.ListOrganizationMembersRequest{OrganizationId: orgID})
+	require.NoError(t, err)
+	require.Len(t, members.Members, 3)
+
+	// Читатель не может создавать записи, редактор может
+	newEntry := &pb.CreateCollectionEntryRequest{
+		CollectionId:  collectionID,
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "db",
+		EncryptedData: []byte("encrypted-with-key-v1"),
+	}
+	_, err = client.CreateCollectionEntry(ctxs["carol"], newEntry)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	entryResp, err := client.CreateCollectionEntry(ctxs["bob"], newEntry)
+	require.NoError(t, err)
+	entryID := entryResp.DataEntry.Id
+	require.Equal(t, collectionID, entryResp.DataEntry.CollectionId)
+
+	getResp, err := client.GetData(ctxs["carol"], &pb.GetDataRequest{Id: entryID})
+	require.NoError(t, err)
+	require.Equal(t, collectionID, getResp.DataEntry.CollectionId)
+	_, err = client.UpdateData(ctxs["carol"], &pb.UpdateDataRequest{Id: entryID, Name: "db", EncryptedData: []byte("changed"), Version: 1})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.DeleteData(ctxs["carol"], &pb.DeleteDataRequest{Id: entryID})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	syncResp, err := client.SyncData(ctxs["carol"], &pb.SyncDataRequest{LastSyncTime: timestamppb.New(time.Time{})})
+	require.NoError(t, err)
+	require.Len(t, syncResp.DataEntries, 1)
+
+	// Редактор не управляет участниками; администратор не назначает администраторов
+	_, err = client.InviteMember(ctxs["bob"], &pb.InviteMemberRequest{OrganizationId: orgID, Username: "alice", Role: pb.OrganizationRole_ORGANIZATION_ROLE_VIEWER})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.UpdateMemberRole(ctxs["alice"], &pb.UpdateMemberRoleRequest{OrganizationId: orgID, UserId: ids["bob"], Role: pb.OrganizationRole_ORGANIZATION_ROLE_ADMIN})
+	require.NoError(t, err)
+	_, err = client.UpdateMemberRole(ctxs["bob"], &pb.UpdateMemberRoleRequest{OrganizationId: orgID, UserId: ids["carol"], Role: pb.OrganizationRole_ORGANIZATION_ROLE_ADMIN})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Последний владелец не может покинуть организацию
+	_, err = client.RemoveMember(ctxs["alice"], &pb.RemoveMemberRequest{OrganizationId: orgID, UserId: ids["alice"]})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Исключение участника требует смены ключа коллекции
+	removeResp, err := client.RemoveMember(ctxs["bob"], &pb.RemoveMemberRequest{OrganizationId: orgID, UserId: ids["carol"]})
+	require.NoError(t, err)
+	require.Equal(t, []string{collectionID}, removeResp.RotationRequiredCollectionIds)
+
+	_, err = client.ListCollectionEntries(ctxs["carol"], &pb.ListCollectionEntriesRequest{CollectionId: collectionID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	syncResp, err = client.SyncData(ctxs["carol"], &pb.SyncDataRequest{LastSyncTime: timestamppb.New(time.Time{})})
+	require.NoError(t, err)
+	require.Empty(t, syncResp.DataEntries)
+	require.Contains(t, syncResp.DeletedIds, entryID)
+
+	collections, err := client.ListCollections(ctxs["alice"], &pb.ListCollectionsRequest{OrganizationId: orgID})
+	require.NoError(t, err)
+	require.Len(t, collections.Collections, 1)
+	require.True(t, collections.Collections[0].RotationRequired)
+
+	// Новый ключ должен быть выдан всем текущим участникам и только им
+	rotation := &pb.RotateCollectionKeyRequest{
+		CollectionId: collectionID,
+		KeyVersion:   2,
+		Keys: []*pb.CollectionKey{
+			{UserId: ids["alice"], WrappedKey: []byte("key-v2-alice")},
+			{UserId: ids["carol"], WrappedKey: []byte("key-v2-carol")},
+		},
+		Entries: []*pb.CollectionEntryUpdate{
+			{Id: entryID, EncryptedData: []byte("encrypted-with-key-v2"), Version: 1},
+		},
+	}
+	_, err = client.RotateCollectionKey(ctxs["alice"], rotation)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	rotation.Keys[1] = &pb.CollectionKey{UserId: ids["bob"], WrappedKey: []byte("key-v2-bob")}
+	rotated, err := client.RotateCollectionKey(ctxs["alice"], rotation)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), rotated.Collection.KeyVersion)
+	require.False(t, rotated.Collection.RotationRequired)
+	require.Equal(t, []byte("key-v2-alice"), rotated.Collection.WrappedKey)
+
+	entries, err := client.ListCollectionEntries(ctxs["bob"], &pb.ListCollectionEntriesRequest{CollectionId: collectionID})
+	require.NoError(t, err)
+	require.Len(t, entries.DataEntries, 1)
+	require.Equal(t, []byte("encrypted-with-key-v2"), entries.DataEntries[0].EncryptedData)
+	require.Equal(t, int64(2), entries.DataEntries[0].Version)
+}
+
+func TestPasswordRotation(t *testing.T) {
+	server, store, client := setupTestServer(t)
+
+	tokens := make(map[string]string)
+	ctxs := make(map[string]context.Context)
+	for _, name := range []string{"alice", "bob"} {
+		resp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: name, Password: "testpass123"})
+		require.NoError(t, err)
+		tokens[name] = resp.Token
+		ctxs[name] = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+resp.Token)
+	}
+	ctx := ctxs["alice"]
+
+	_, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type: pb.DataType_DATA_TYPE_CREDENTIALS, Name: "Bad", EncryptedData: []byte("x"), RotationDays: -1,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Политика записи: срок смены считается от времени смены пароля
+	createResp, err := client.CreateData(ctx, &pb.CreateDataRequest{
+		Type: pb.DataType_DATA_TYPE_CREDENTIALS, Name: "Mail", EncryptedData: []byte("v1"), RotationDays: 90,
+	})
+	require.NoError(t, err)
+	entry := createResp.DataEntry
+	require.NotNil(t, entry.PasswordChangedAt)
+	require.Equal(t, entry.PasswordChangedAt.AsTime().AddDate(0, 0, 90), entry.RotationDueAt.AsTime())
+
+	_, err = client.CreateData(ctx, &pb.CreateDataRequest{
+		Type: pb.DataType_DATA_TYPE_CREDENTIALS, Name: "No policy", EncryptedData: []byte("v1"),
+	})
+	require.NoError(t, err)
+
+	dueResp, err := client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{})
+	require.NoError(t, err)
+	require.Empty(t, dueResp.DataEntries)
+
+	// Пароль сменен 100 дней назад
+	entryID := uuid.MustParse(entry.Id)
+	store.data[entryID].PasswordChangedAt = time.Now().AddDate(0, 0, -100)
+	dueResp, err = client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{})
+	require.NoError(t, err)
+	require.Len(t, dueResp.DataEntries, 1)
+	require.Equal(t, "Mail", dueResp.DataEntries[0].Name)
+
+	// Изменение без смены пароля не сбрасывает срок, а клиент без поддержки
+	// политик, не передающий период, не снимает политику записи
+	updateResp, err := client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id: entry.Id, Name: "Mail", EncryptedData: []byte("v2"), Version: entry.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(90), updateResp.DataEntry.RotationDays)
+	dueResp, err = client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{})
+	require.NoError(t, err)
+	require.Len(t, dueResp.DataEntries, 1)
+
+	rotationDays := int32(90)
+	updateResp, err = client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id: entry.Id, Name: "Mail", EncryptedData: []byte("v3"), Version: updateResp.DataEntry.Version,
+		RotationDays: &rotationDays, PasswordChanged: true,
+	})
+	require.NoError(t, err)
+	dueResp, err = client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{})
+	require.NoError(t, err)
+	require.Empty(t, dueResp.DataEntries)
+
+	// Срок наступит в течение 100 дней
+	dueResp, err = client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{WithinDays: 100})
+	require.NoError(t, err)
+	require.Len(t, dueResp.DataEntries, 1)
+
+	// Явный 0 снимает политику записи
+	rotationDays = 0
+	updateResp, err = client.UpdateData(ctx, &pb.UpdateDataRequest{
+		Id: entry.Id, Name: "Mail", EncryptedData: []byte("v3"), Version: updateResp.DataEntry.Version,
+		RotationDays: &rotationDays,
+	})
+	require.NoError(t, err)
+	require.Zero(t, updateResp.DataEntry.RotationDays)
+	dueResp, err = client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{WithinDays: 100})
+	require.NoError(t, err)
+	require.Empty(t, dueResp.DataEntries)
+
+	// Политика коллекции действует на записи с паролями без собственной политики
+	orgResp, err := client.CreateOrganization(ctx, &pb.CreateOrganizationRequest{Name: "infra"})
+	require.NoError(t, err)
+	collResp, err := client.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		OrganizationId: orgResp.Organization.Id, Name: "servers", WrappedKey: []byte("key"),
+	})
+	require.NoError(t, err)
+	collectionID := collResp.Collection.Id
+
+	var teamIDs []string
+	for _, dataType := range []pb.DataType{pb.DataType_DATA_TYPE_CREDENTIALS, pb.DataType_DATA_TYPE_TEXT} {
+		resp, err := client.CreateCollectionEntry(ctx, &pb.CreateCollectionEntryRequest{
+			CollectionId: collectionID, Type: dataType, Name: dataType.String(), EncryptedData: []byte("x"),
+		})
+		require.NoError(t, err)
+		require.Nil(t, resp.DataEntry.RotationDueAt)
+		teamIDs = append(teamIDs, resp.DataEntry.Id)
+	}
+	for _, id := range teamIDs {
+		store.teamEntries[uuid.MustParse(id)].PasswordChangedAt = time.Now().AddDate(0, 0, -40)
+	}
+
+	_, err = client.SetCollectionRotationPolicy(ctxs["bob"], &pb.SetCollectionRotationPolicyRequest{
+		CollectionId: collectionID, RotationDays: 30,
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	policyResp, err := client.SetCollectionRotationPolicy(ctx, &pb.SetCollectionRotationPolicyRequest{
+		CollectionId: collectionID, RotationDays: 30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(30), policyResp.Collection.RotationDays)
+
+	dueResp, err = client.ListDueForRotation(ctx, &pb.ListDueForRotationRequest{})
+	require.NoError(t, err)
+	require.Len(t, dueResp.DataEntries, 1)
+	require.Equal(t, teamIDs[0], dueResp.DataEntries[0].Id)
+	require.Zero(t, dueResp.DataEntries[0].RotationDays)
+
+	listResp, err := client.ListCollectionEntries(ctx, &pb.ListCollectionEntriesRequest{CollectionId: collectionID})
+	require.NoError(t, err)
+	for _, teamEntry := range listResp.DataEntries {
+		require.Equal(t, teamEntry.Id == teamIDs[0], teamEntry.RotationDueAt != nil)
+	}
+
+	// HTTP API
+	router := chi.NewRouter()
+	router.Use(middleware.AuthMiddleware(server.authService, store, zap.NewNop()))
+	router.Get("/data/rotation-due", server.HandleListDueForRotation)
+	router.Get("/data/{id}", server.HandleGetData)
+
+	for query, code := range map[string]int{"": http.StatusOK, "?within_days=100": http.StatusOK, "?within_days=-1": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodGet, "/data/rotation-due"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+tokens["alice"])
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, code, rec.Code, query)
+	}
+
+	router.Put("/collections/{id}/rotation", server.HandleSetCollectionRotationPolicy)
+	for id, code := range map[string]int{
+		collectionID:        http.StatusOK,
+		"not-a-uuid":        http.StatusBadRequest,
+		uuid.New().String(): http.StatusNotFound,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/collections/"+id+"/rotation", bytes.NewBufferString(`{"rotation_days":45}`))
+		req.Header.Set("Authorization", "Bearer "+tokens["alice"])
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, code, rec.Code, id)
+	}
+}
+
+func TestSecretLinks(t *testing.T) {
+	server, store, client := setupTestServer(t)
+
+	router := chi.NewRouter()
+	router.Get("/s/{id}", server.HandleSecretLinkPage)
+	router.Post("/s/{id}", server.HandleOpenSecretLink)
+
+	openLink := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		return rec
+	}
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+
+	// Создание без аутентификации и с некорректными параметрами запрещено
+	_, err = client.CreateSecretLink(context.Background(), &pb.CreateSecretLinkRequest{EncryptedData: []byte("secret")})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.CreateSecretLink(ctx, &pb.CreateSecretLinkRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateSecretLink(ctx, &pb.CreateSecretLinkRequest{EncryptedData: []byte("secret"), MaxViews: 101})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateSecretLink(ctx, &pb.CreateSecretLinkRequest{
+		EncryptedData: []byte("secret"),
+		ExpiresAt:     timestamppb.New(time.Now().Add(-time.Minute)),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// HTTP API отвечает 400 на некорректные параметры
+	api := chi.NewRouter()
+	api.Use(middleware.AuthMiddleware(server.authService, store, zap.NewNop()))
+	api.Post("/secret-links", server.HandleCreateSecretLink)
+	createLink := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/secret-links", bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+registerResp.Token)
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusBadRequest, createLink(`{"encrypted_data":"c2VjcmV0","expires_at":"2000-01-01T00:00:00Z"}`))
+	require.Equal(t, http.StatusCreated, createLink(`{"encrypted_data":"c2VjcmV0"}`))
+
+	// По умолчанию ссылка одноразовая и действует сутки
+	createResp, err := client.CreateSecretLink(ctx, &pb.CreateSecretLinkRequest{EncryptedData: []byte("secret")})
+	require.NoError(t, err)
+	link := createResp.Link
+	require.Equal(t, "/s/"+link.Id, link.Path)
+	require.EqualValues(t, 1, link.MaxViews)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), link.ExpiresAt.AsTime(), time.Minute)
+
+	// Страница ссылки не расходует просмотр
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link.Path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	rec = openLink(link.Path)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var payload struct {
+		EncryptedData []byte `json:"encrypted_data"`
+		ViewsLeft     int    `json:"views_left"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &payload))
+	require.Equal(t, []byte("secret"), payload.EncryptedData)
+	require.Zero(t, payload.ViewsLeft)
+
+	// После последнего просмотра ссылка уничтожена
+	require.Equal(t, http.StatusNotFound, openLink(link.Path).Code)
+	require.Equal(t, http.StatusNotFound, openLink("/s/not-a-uuid").Code)
+
+	// Ссылка с несколькими просмотрами
+	createResp, err = client.CreateSecretLink(ctx, &pb.CreateSecretLinkRequest{EncryptedData: []byte("secret"), MaxViews: 2})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, openLink(createResp.Link.Path).Code)
+	require.Equal(t, http.StatusOK, openLink(createResp.Link.Path).Code)
+	require.Equal(t, http.StatusNotFound, openLink(createResp.Link.Path).Code)
+
+	// Истекшая ссылка недоступна и удаляется фоновой очисткой
+	createResp, err = client.CreateSecretLink(ctx, &pb.CreateSecretLinkRequest{EncryptedData: []byte("secret")})
+	require.NoError(t, err)
+	expiredID := uuid.MustParse(createResp.Link.Id)
+	store.secretLinks[expiredID].ExpiresAt = time.Now().Add(-time.Second)
+	require.Equal(t, http.StatusNotFound, openLink(createResp.Link.Path).Code)
+
+	purgeCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		server.PurgeExpiredSecretLinks(purgeCtx, 5*time.Millisecond)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+	require.NotContains(t, store.secretLinks, expiredID)
+}
+
+func TestEmergencyAccess(t *testing.T) {
+	server, store, client := setupTestServer(t)
+
+	aliceResp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: "alice", Password: "testpass123"})
+	require.NoError(t, err)
+	bobResp, err := client.Register(context.Background(), &pb.RegisterRequest{Username: "bob", Password: "testpass123"})
+	require.NoError(t, err)
+	aliceCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+aliceResp.Token)
+	bobCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+bobResp.Token)
+
+	_, err = client.CreateData(aliceCtx, &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "bank",
+		EncryptedData: []byte("encrypted-with-vault-key"),
+	})
+	require.NoError(t, err)
+
+	// Контакт должен опубликовать открытый ключ
+	_, err = client.AddEmergencyContact(aliceCtx, &pb.AddEmergencyContactRequest{Username: "bob", WrappedKey: []byte("key")})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, bobPublicKey, err := crypto.GenerateSharingKeyPair()
+	require.NoError(t, err)
+	_, err = client.SetPublicKey(bobCtx, &pb.SetPublicKeyRequest{PublicKey: bobPublicKey})
+	require.NoError(t, err)
+
+	_, err = client.AddEmergencyContact(aliceCtx, &pb.AddEmergencyContactRequest{Username: "bob", WrappedKey: []byte("key"), WaitDays: 91})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.AddEmergencyContact(aliceCtx, &pb.AddEmergencyContactRequest{Username: "alice", WrappedKey: []byte("key")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	addResp, err := client.AddEmergencyContact(aliceCtx, &pb.AddEmergencyContactRequest{
+		Username:   "bob",
+		WaitDays:   3,
+		WrappedKey: []byte("vault-key-wrapped-for-bob"),
+	})
+	require.NoError(t, err)
+	accessID := addResp.EmergencyAccess.Id
+	require.Equal(t, pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_IDLE, addResp.EmergencyAccess.Status)
+
+	_, err = client.AddEmergencyContact(aliceCtx, &pb.AddEmergencyContactRequest{Username: "bob", WrappedKey: []byte("key")})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	listResp, err := client.ListEmergencyAccess(bobCtx, &pb.ListEmergencyAccessRequest{})
+	require.NoError(t, err)
+	require.Empty(t, listResp.Granted)
+	require.Len(t, listResp.Trusted, 1)
+	require.Equal(t, "alice", listResp.Trusted[0].GrantorUsername)
+
+	// До запроса и во время ожидания хранилище недоступно
+	_, err = client.GetEmergencyVault(bobCtx, &pb.GetEmergencyVaultRequest{Id: accessID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Запросить доступ может только контакт, одобрить и отклонить - только владелец
+	_, err = client.RequestEmergencyAccess(aliceCtx, &pb.RequestEmergencyAccessRequest{Id: accessID})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	requestResp, err := client.RequestEmergencyAccess(bobCtx, &pb.RequestEmergencyAccessRequest{Id: accessID})
+	require.NoError(t, err)
+	require.Equal(t, pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_REQUESTED, requestResp.EmergencyAccess.Status)
+	require.WithinDuration(t, time.Now().Add(72*time.Hour), requestResp.EmergencyAccess.AvailableAt.AsTime(), time.Minute)
+
+	_, err = client.RequestEmergencyAccess(bobCtx, &pb.RequestEmergencyAccessRequest{Id: accessID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = client.ApproveEmergencyAccess(bobCtx, &pb.ApproveEmergencyAccessRequest{Id: accessID})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetEmergencyVault(bobCtx, &pb.GetEmergencyVaultRequest{Id: accessID})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Владелец получает уведомление и отклоняет запрос
+	notificationsResp, err := client.ListNotifications(aliceCtx, &pb.ListNotificationsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, notificationsResp.Notifications)
+	require.Contains(t, notificationsResp.Notifications[0].Message, "bob requested emergency access")
+
+	denyResp, err := client.DenyEmergencyAccess(aliceCtx, &pb.DenyEmergencyAccessRequest{Id: accessID})
+	require.NoError(t, err)
+	require.Equal(t, pb.EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_IDLE, denyResp.EmergencyAccess.Status)
+
+	// Повторный запрос без отказа владельца предоставляет доступ после ожидания
+	_, err = client.RequestEmergencyAccess(bobCtx, &pb.RequestEmergencyAccessRequest{Id: accessID})
+	require.NoError(t, err)
+	requestedAt := time.Now().Add(-4 * 24 * time.Hour)
+	store.emergency[uuid.MustParse(accessID)].RequestedAt = &requestedAt
+
+	server.grantElapsedEmergencyAccess(context.Background(), time.Now())
+	require.Equal(t, models.EmergencyAccessApproved, store.emergency[uuid.MustParse(accessID)].Status)
+
+	vaultResp, err := client.GetEmergencyVault(bobCtx, &pb.GetEmergencyVaultRequest{Id: accessID})
+	require.NoError(t, err)
+	require.Equal(t, []byte("vault-key-wrapped-for-bob"), vaultResp.WrappedKey)
+	require.Len(t, vaultResp.DataEntries, 1)
+	require.Equal(t, "bank", vaultResp.DataEntries[0].Name)
+
+	// Все шаги записаны в журнал
+	eventsResp, err := client.ListEmergencyAccessEvents(aliceCtx, &pb.ListEmergencyAccessEventsRequest{Id: accessID})
+	require.NoError(t, err)
+	var events []string
+	for _, event := range eventsResp.Events {
+		events = append(events, event.Event)
+	}
+	require.Equal(t, []string{"designated", "requested", "denied", "requested", "granted", "vault_accessed"}, events)
+	require.Empty(t, eventsResp.Events[4].ActorUsername)
+
+	notificationsResp, err = client.ListNotifications(aliceCtx, &pb.ListNotificationsRequest{})
+	require.NoError(t, err)
+	require.Contains(t, notificationsResp.Notifications[0].Message, "bob accessed your vault")
+
+	// Владелец может отозвать доступ, журнал сохраняется
+	_, err = client.RemoveEmergencyContact(aliceCtx, &pb.RemoveEmergencyContactRequest{Id: accessID})
+	require.NoError(t, err)
+	_, err = client.GetEmergencyVault(bobCtx, &pb.GetEmergencyVaultRequest{Id: accessID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	eventsResp, err = client.ListEmergencyAccessEvents(bobCtx, &pb.ListEmergencyAccessEventsRequest{Id: accessID})
+	require.NoError(t, err)
+	require.Equal(t, "revoked", eventsResp.Events[len(eventsResp.Events)-1].Event)
+}
+
+func TestSRPLogin(t *testing.T) {
+	client := setupTestClient(t)
+
+	salt, verifier, err := auth.NewSRPVerifier("srpuser", "testpass123")
+	require.NoError(t, err)
+
+	_, err = client.Register(context.Background(), &pb.RegisterRequest{
+		Username:    "srpuser",
+		SrpSalt:     salt,
+		SrpVerifier: verifier,
+	})
+	require.NoError(t, err)
+
+	// Вход по паролю для аккаунта с SRP невозможен
+	_, err = client.Login(context.Background(), &pb.LoginRequest{
+		Username: "srpuser",
+		Password: "testpass123",
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = srpLogin(t, client, "srpuser", "wrongpassword")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	authResp, err := srpLogin(t, client, "srpuser", "testpass123")
+	require.NoError(t, err)
+	require.NotEmpty(t, authResp.Token)
+
+	// Необратимые операции подтверждаются свежим доказательством SRP, недавнего входа мало
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+authResp.Token)
+	_, err = client.DeleteAccount(ctx, &pb.DeleteAccountRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "testpass123"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	loginID, proof := srpConfirmation(t, client, "srpuser", "wrongpassword")
+	_, err = client.ExportAccount(ctx, &pb.ExportAccountRequest{SrpLoginId: loginID, SrpClientProof: proof})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Доказательство расходуется и действует только для своего пользователя
+	loginID, proof = srpConfirmation(t, client, "srpuser", "testpass123")
+	exportResp, err := client.ExportAccount(ctx, &pb.ExportAccountRequest{SrpLoginId: loginID, SrpClientProof: proof})
+	require.NoError(t, err)
+	require.Empty(t, exportResp.Archive)
+
+	var export models.AccountExport
+	require.NoError(t, json.Unmarshal(exportResp.Export, &export))
+	require.Equal(t, "srpuser", export.Username)
+
+	_, err = client.DeleteAccount(ctx, &pb.DeleteAccountRequest{SrpLoginId: loginID, SrpClientProof: proof})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	loginID, proof = srpConfirmation(t, client, "srpuser", "testpass123")
+	_, err = client.DeleteAccount(ctx, &pb.DeleteAccountRequest{SrpLoginId: loginID, SrpClientProof: proof})
+	require.NoError(t, err)
+}
+
+func TestSRPChangePassword(t *testing.T) {
+	client := setupTestClient(t)
+
+	salt, verifier, err := auth.NewSRPVerifier("srpuser", "testpass123")
+	require.NoError(t, err)
+	_, err = client.Register(context.Background(), &pb.RegisterRequest{Username: "srpuser", SrpSalt: salt, SrpVerifier: verifier})
+	require.NoError(t, err)
+
+	authResp, err := srpLogin(t, client, "srpuser", "testpass123")
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+authResp.Token)
+
+	newSalt, newVerifier, err := auth.NewSRPVerifier("srpuser", "newpass456")
+	require.NoError(t, err)
+
+	// Только что выданного токена недостаточно, нужен текущий пароль
+	_, err = client.ChangePassword(ctx, &pb.ChangePasswordRequest{SrpSalt: newSalt, SrpVerifier: newVerifier})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	loginID, proof := srpConfirmation(t, client, "srpuser", "wrongpassword")
+	_, err = client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		SrpSalt: newSalt, SrpVerifier: newVerifier, SrpLoginId: loginID, SrpClientProof: proof,
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srpLogin(t, client, "srpuser", "testpass123")
+	require.NoError(t, err)
+
+	loginID, proof = srpConfirmation(t, client, "srpuser", "testpass123")
+	_, err = client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		SrpSalt: newSalt, SrpVerifier: newVerifier, SrpLoginId: loginID, SrpClientProof: proof,
+	})
+	require.NoError(t, err)
+
+	_, err = srpLogin(t, client, "srpuser", "testpass123")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = srpLogin(t, client, "srpuser", "newpass456")
+	require.NoError(t, err)
+}
+
+func TestSRPMigration(t *testing.T) {
+	client := setupTestClient(t)
+
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+
+	// Рукопожатие SRP для аккаунта без SRP завершается так же, как с неверным паролем
+	_, err = srpLogin(t, client, "testuser", "testpass123")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	loginResp, err := client.Login(context.Background(), &pb.LoginRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	require.True(t, loginResp.SrpUpgradeRequired)
+
+	// Перевод аккаунта на SRP подтверждается старым паролем
+	salt, verifier, err := auth.NewSRPVerifier("testuser", "testpass123")
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+	_, err = client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		OldPassword: "testpass123",
+		SrpSalt:     salt,
+		SrpVerifier: verifier,
+	})
+	require.NoError(t, err)
+
+	_, err = client.Login(context.Background(), &pb.LoginRequest{
+		Username: "testuser",
+		Password: "testpass123",
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = srpLogin(t, client, "testuser", "testpass123")
+	require.NoError(t, err)
+}
+
+func TestSRPLogin_NoAccountEnumeration(t *testing.T) {
+	client := setupTestClient(t)
+
+	salt, verifier, err := auth.NewSRPVerifier("srpuser", "testpass123")
+	require.NoError(t, err)
+	_, err = client.Register(context.Background(), &pb.RegisterRequest{Username: "srpuser", SrpSalt: salt, SrpVerifier: verifier})
+	require.NoError(t, err)
+	_, err = client.Register(context.Background(), &pb.RegisterRequest{Username: "legacy", Password: "testpass123"})
+	require.NoError(t, err)
+
+	begin := func(username string) *pb.BeginSRPLoginResponse {
+		srpClient, err := auth.NewSRPClient(username, "testpass123")
+		require.NoError(t, err)
+		resp, err := client.BeginSRPLogin(context.Background(), &pb.BeginSRPLoginRequest{
+			Username:        username,
+			ClientPublicKey: srpClient.PublicKey(),
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	// Неизвестный пользователь и аккаунт без SRP получают ответ того же вида
+	// с постоянной солью, вход завершается общей ошибкой на шаге доказательства
+	real := begin("srpuser")
+	for _, username := range []string{"nobody", "legacy"} {
+		first, second := begin(username), begin(username)
+		require.Len(t, first.Salt, len(real.Salt))
+		require.Len(t, first.ServerPublicKey, len(real.ServerPublicKey))
+		require.Equal(t, first.Salt, second.Salt)
+		require.NotEqual(t, first.ServerPublicKey, second.ServerPublicKey)
+
+		_, err = srpLogin(t, client, username, "testpass123")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	require.NotEqual(t, begin("nobody").Salt, begin("nobody2").Salt)
+}
+
+// setupTestClient создает тестовый клиент с собственным mockStorage
+func setupTestClient(t *testing.T) pb.GophKeeperClient {
+	// Настройка тестового окружения
+	logger, _ := zap.NewDevelopment()
+
+	// Генерируем тестовые ключи программно
+	privateKey, publicKey := generateTestKeys(t)
+
+	// Создаем сервисы
+	authService := auth.NewService("test-secret")
+	cryptoService, err := crypto.NewService(privateKey, publicKey)
+	require.NoError(t, err)
+	otpService := otp.NewService()
+
+	// Создаем тестовое хранилище (in-memory для тестов)
+	storage := setupTestStorage(t)
+
+	// Создаем сервер
+	server := NewServer(storage, authService, cryptoService, otpService, logger)
+
+	// Запускаем тестовый сервер
+	lis, err := startTestGRPCServer(server, "localhost:0", logger)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		lis.Close()
+	})
+
+	// Подключаемся к серверу
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return pb.NewGophKeeperClient(conn)
+}
+
+// setupTestServer создает тестовый сервер с mockStorage и клиент к нему
+// для тестов, которым нужен прямой доступ к серверу и хранилищу
+func setupTestServer(t *testing.T) (*Server, *mockStorage, pb.GophKeeperClient) {
+	logger, _ := zap.NewDevelopment()
+	privateKey, publicKey := generateTestKeys(t)
+	cryptoService, err := crypto.NewService(privateKey, publicKey)
+	require.NoError(t, err)
+
+	store := &mockStorage{}
+	server := NewServer(store, auth.NewService("test-secret"), cryptoService, otp.NewService(), logger)
+	lis, err := startTestGRPCServer(server, "localhost:0", logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return server, store, pb.NewGophKeeperClient(conn)
+}
+
+// setupTestStorage создает тестовое хранилище
+func setupTestStorage(t *testing.T) storage.Storage {
+	// Для интеграционных тестов используем in-memory хранилище
+	// В реальном проекте здесь можно использовать тестовую базу данных
+	return &mockStorage{}
+}
+
+// mockStorage - простое in-memory хранилище для тестов
+type mockStorage struct {
+	users    map[string]*models.User
+	data     map[uuid.UUID]*models.DataEntry
+	sessions map[uuid.UUID]*models.Session
+	tokens   map[uuid.UUID]*models.AccessToken
+	shares   map[uuid.UUID]*models.EntryShare
+	deleted  []deletedEntry
+
+	orgs           map[uuid.UUID]*models.Organization
+	members        map[uuid.UUID]map[uuid.UUID]*models.OrganizationMember
+	invitations    map[uuid.UUID]*models.OrganizationInvitation
+	collections    map[uuid.UUID]*models.Collection
+	collectionKeys map[uuid.UUID]map[uuid.UUID]models.CollectionKey
+	teamEntries    map[uuid.UUID]*models.DataEntry
+
+	secretLinks map[uuid.UUID]*models.SecretLink
+	attachments map[uuid.UUID]*models.Attachment
+
+	emergency       map[uuid.UUID]*models.EmergencyAccess
+	emergencyEvents []mockEmergencyEvent
+	notifications   []models.Notification
+}
+
+// mockEmergencyEvent событие журнала вместе с участниками доступа
+type mockEmergencyEvent struct {
+	event     models.EmergencyAccessEvent
+	grantorID uuid.UUID
+	granteeID uuid.UUID
+}
+
+// deletedEntry запись об удалении для синхронизации
+type deletedEntry struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	deletedAt time.Time
+}
+
+func (m *mockStorage) CreateUser(ctx context.Context, user *models.User) error {
+	if m.users == nil {
+		m.users = make(map[string]*models.User)
+	}
+	// Убеждаемся, что у пользователя есть ID
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	m.users[user.Username] = user
+	return nil
+}
+
+func (m *mockStorage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	if user, exists := m.users[username]; exists {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (m *mockStorage) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	for _, user := range m.users {
+		if user.ID == userID {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (m *mockStorage) UpdateUserPassword(ctx context.Context, updated *models.User, keepSessionID uuid.UUID) error {
+	user, err := m.GetUserByID(ctx, updated.ID)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = updated.PasswordHash
+	user.SRPSalt = updated.SRPSalt
+	user.SRPVerifier = updated.SRPVerifier
+	if len(updated.WrappedVaultKey) > 0 {
+		user.WrappedVaultKey = updated.WrappedVaultKey
+	}
+	now := time.Now()
+	for id, session := range m.sessions {
+		if session.UserID == updated.ID && id != keepSessionID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockStorage) SetUserVaultKey(ctx context.Context, userID uuid.UUID, wrappedVaultKey []byte) error {
+	user, err := m.GetUserByID(ctx, userID)
+	if err != nil || len(user.WrappedVaultKey) > 0 {
+		return storage.ErrVaultKeyExists
+	}
+	user.WrappedVaultKey = wrappedVaultKey
+	return nil
+}
+
+func (m *mockStorage) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := m.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for orgID, members := range m.members {
+		member, ok := members[userID]
+		if ok && len(members) > 1 && member.Role == models.RoleOwner && m.countOwners(orgID) == 1 {
+			return storage.ErrLastOwner
+		}
+	}
+	for orgID, members := range m.members {
+		if _, ok := members[userID]; !ok {
+			continue
+		}
+		delete(members, userID)
+		if len(members) == 0 {
+			delete(m.members, orgID)
+			delete(m.orgs, orgID)
+		}
+	}
+	delete(m.users, user.Username)
+	for id, entry := range m.data {
+		if entry.UserID == userID {
+			delete(m.data, id)
+			m.deleteEntryAttachments(id)
+		}
+	}
+	for id, session := range m.sessions {
+		if session.UserID == userID {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
+
+func (m *mockStorage) CreateSession(ctx context.Context, session *models.Session) error {
+	if m.sessions == nil {
+		m.sessions = make(map[uuid.UUID]*models.Session)
+	}
+	if session.ID == uuid.Nil {
+		session.ID = uuid.New()
+	}
+	session.CreatedAt = time.Now()
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *mockStorage) IsSessionActive(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	session, exists := m.sessions[sessionID]
+	return exists && session.UserID == userID && session.RevokedAt == nil, nil
+}
+
+func (m *mockStorage) CreateAccessToken(ctx context.Context, token *models.AccessToken) error {
+	if m.tokens == nil {
+		m.tokens = make(map[uuid.UUID]*models.AccessToken)
+	}
+	for _, existing := range m.tokens {
+		if len(token.CertificateFingerprint) > 0 && string(existing.CertificateFingerprint) == string(token.CertificateFingerprint) {
+			return fmt.Errorf("access token already exists")
+		}
+	}
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockStorage) GetAccessTokenByHash(ctx context.Context, tokenHash []byte) (*models.AccessToken, error) {
+	for _, token := range m.tokens {
+		if string(token.TokenHash) == string(tokenHash) {
+			return token, nil
+		}
+	}
+	return nil, fmt.Errorf("access token not found")
+}
+
+func (m *mockStorage) GetAccessTokenByCertificate(ctx context.Context, fingerprint []byte) (*models.AccessToken, error) {
+	for _, token := range m.tokens {
+		if len(token.CertificateFingerprint) > 0 && string(token.CertificateFingerprint) == string(fingerprint) {
+			return token, nil
+		}
+	}
+	return nil, fmt.Errorf("access token not found")
+}
+
+func (m *mockStorage) ListAccessTokens(ctx context.Context, userID uuid.UUID) ([]models.AccessToken, error) {
+	var tokens []models.AccessToken
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *mockStorage) RevokeAccessToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	token, exists := m.tokens[tokenID]
+	if !exists || token.UserID != userID || token.RevokedAt != nil {
+		return fmt.Errorf("access token not found")
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	return nil
+}
+
+func (m *mockStorage) TouchAccessToken(ctx context.Context, tokenID uuid.UUID) error {
+	if token, exists := m.tokens[tokenID]; exists {
+		now := time.Now()
+		token.LastUsedAt = &now
+	}
+	return nil
+}
+
+func (m *mockStorage) CreateDataEntry(ctx context.Context, entry *models.DataEntry) error {
+	if m.data == nil {
+		m.data = make(map[uuid.UUID]*models.DataEntry)
+	}
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.Version == 0 {
+		entry.Version = 1
+	}
+	if entry.PasswordChangedAt.IsZero() {
+		entry.PasswordChangedAt = time.Now()
+	}
+	m.data[entry.ID] = entry
+	return nil
+}
+
+func (m *mockStorage) GetDataEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error) {
+	if entry, exists := m.data[entryID]; exists && entry.UserID == userID {
+		copied := *entry
+		return &copied, nil
+	}
+	return nil, fmt.Errorf("data entry not found")
+}
+
+func (m *mockStorage) GetDataEntries(ctx context.Context, userID uuid.UUID, dataType *models.DataType) ([]models.DataEntry, error) {
+	var entries []models.DataEntry
+	for _, entry := range m.data {
+		if entry.UserID == userID {
+			if dataType == nil || entry.Type == *dataType {
+				entries = append(entries, *entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockStorage) UpdateDataEntry(ctx context.Context, entry *models.DataEntry) error {
+	if current, exists := m.data[entry.ID]; exists {
+		if current.Version != entry.Version {
+			return storage.ErrVersionMismatch
+		}
+		entry.Version++
+		stored := *entry
+		stored.Share = nil
+		stored.UpdatedAt = time.Now()
+		m.data[entry.ID] = &stored
+		return nil
+	}
+	return fmt.Errorf("data entry not found")
+}
+
+func (m *mockStorage) DeleteDataEntry(ctx context.Context, userID, entryID uuid.UUID) error {
+	if entry, exists := m.data[entryID]; exists && entry.UserID == userID {
+		delete(m.data, entryID)
+		m.addDeleted(entryID, userID)
+		m.deleteEntryAttachments(entryID)
+		for id, share := range m.shares {
+			if share.EntryID == entryID {
+				m.addDeleted(entryID, share.RecipientID)
+				delete(m.shares, id)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("data entry not found")
+}
+
+func (m *mockStorage) addDeleted(entryID, userID uuid.UUID) {
+	m.deleted = append(m.deleted, deletedEntry{id: entryID, userID: userID, deletedAt: time.Now()})
+}
+
+func (m *mockStorage) GetDataEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
+	var entries []models.DataEntry
+	for _, entry := range m.data {
+		if entry.UserID == userID && entry.UpdatedAt.After(after) {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockStorage) GetDeletedEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	for _, deleted := range m.deleted {
+		if deleted.userID == userID && deleted.deletedAt.After(after) {
+			ids = append(ids, deleted.id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockStorage) SetUserPublicKey(ctx context.Context, userID uuid.UUID, publicKey, wrappedPrivateKey []byte) error {
+	user, err := m.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	user.PublicKey = publicKey
+	user.WrappedPrivateKey = wrappedPrivateKey
+	return nil
+}
+
+func (m *mockStorage) CreateEntryShare(ctx context.Context, share *models.EntryShare) error {
+	if m.shares == nil {
+		m.shares = make(map[uuid.UUID]*models.EntryShare)
+	}
+	for id, existing := range m.shares {
+		if existing.EntryID == share.EntryID && existing.RecipientID == share.RecipientID {
+			delete(m.shares, id)
+			share.ID = id
+		}
+	}
+	if share.ID == uuid.Nil {
+		share.ID = uuid.New()
+	}
+	share.CreatedAt = time.Now()
+	m.shares[share.ID] = share
+	return nil
+}
+
+func (m *mockStorage) GetEntryShares(ctx context.Context, ownerID, entryID uuid.UUID) ([]models.EntryShare, error) {
+	var shares []models.EntryShare
+	for _, share := range m.shares {
+		if share.OwnerID == ownerID && share.EntryID == entryID {
+			shares = append(shares, *share)
+		}
+	}
+	return shares, nil
+}
+
+func (m *mockStorage) DeleteEntryShare(ctx context.Context, userID, shareID uuid.UUID) error {
+	share, exists := m.shares[shareID]
+	if !exists || (share.OwnerID != userID && share.RecipientID != userID) {
+		return fmt.Errorf("entry share not found")
+	}
+	delete(m.shares, shareID)
+	m.addDeleted(share.EntryID, share.RecipientID)
+	return nil
+}
+
+func (m *mockStorage) GetSharedEntry(ctx context.Context, recipientID, entryID uuid.UUID) (*models.DataEntry, error) {
+	for _, share := range m.shares {
+		if share.RecipientID == recipientID && share.EntryID == entryID {
+			entry := *m.data[entryID]
+			entry.Share = share
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("data entry not found")
+}
+
+func (m *mockStorage) GetSharedEntries(ctx context.Context, recipientID uuid.UUID) ([]models.DataEntry, error) {
+	return m.GetSharedEntriesAfter(ctx, recipientID, time.Time{})
+}
+
+func (m *mockStorage) GetSharedEntriesAfter(ctx context.Context, recipientID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
+	var entries []models.DataEntry
+	for _, share := range m.shares {
+		entry := m.data[share.EntryID]
+		if share.RecipientID == recipientID && (entry.UpdatedAt.After(after) || share.CreatedAt.After(after)) {
+			shared := *entry
+			shared.Share = share
+			entries = append(entries, shared)
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockStorage) CreateOrganization(ctx context.Context, org *models.Organization, ownerID uuid.UUID) error {
+	if m.orgs == nil {
+		m.orgs = make(map[uuid.UUID]*models.Organization)
+		m.members = make(map[uuid.UUID]map[uuid.UUID]*models.OrganizationMember)
+	}
+	org.ID = uuid.New()
+	org.CreatedAt = time.Now()
+	org.Role = models.RoleOwner
+	stored := *org
+	m.orgs[org.ID] = &stored
+	m.members[org.ID] = map[uuid.UUID]*models.OrganizationMember{
+		ownerID: {OrganizationID: org.ID, UserID: ownerID, Role: models.RoleOwner, CreatedAt: org.CreatedAt},
+	}
+	return nil
+}
+
+func (m *mockStorage) GetOrganizations(ctx context.Context, userID uuid.UUID) ([]models.Organization, error) {
+	var orgs []models.Organization
+	for id, members := range m.members {
+		if member, ok := members[userID]; ok {
+			org := *m.orgs[id]
+			org.Role = member.Role
+			orgs = append(orgs, org)
+		}
+	}
+	return orgs, nil
+}
+
+func (m *mockStorage) GetOrganizationRole(ctx context.Context, orgID, userID uuid.UUID) (models.OrganizationRole, error) {
+	if member, ok := m.members[orgID][userID]; ok {
+		return member.Role, nil
+	}
+	return "", fmt.Errorf("organization member not found")
+}
+
+func (m *mockStorage) GetOrganizationMembers(ctx context.Context, userID, orgID uuid.UUID) ([]models.OrganizationMember, error) {
+	if _, ok := m.members[orgID][userID]; !ok {
+		return nil, nil
+	}
+	var members []models.OrganizationMember
+	for _, member := range m.members[orgID] {
+		user, _ := m.GetUserByID(ctx, member.UserID)
+		result := *member
+		result.Username = user.Username
+		members = append(members, result)
+	}
+	return members, nil
+}
+
+func (m *mockStorage) countOwners(orgID uuid.UUID) int {
+	owners := 0
+	for _, member := range m.members[orgID] {
+		if member.Role == models.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+func (m *mockStorage) UpdateOrganizationMemberRole(ctx context.Context, actorID, orgID, memberID uuid.UUID, role models.OrganizationRole) (*models.OrganizationMember, error) {
+	actor, ok := m.members[orgID][actorID]
+	member, exists := m.members[orgID][memberID]
+	if !ok || !exists {
+		return nil, fmt.Errorf("organization member not found")
+	}
+	if !actor.Role.CanManage(member.Role) || !actor.Role.CanManage(role) {
+		return nil, storage.ErrInsufficientRole
+	}
+	if member.Role == models.RoleOwner && role != models.RoleOwner && m.countOwners(orgID) == 1 {
+		return nil, storage.ErrLastOwner
+	}
+	member.Role = role
+	result := *member
+	return &result, nil
+}
+
+func (m *mockStorage) RemoveOrganizationMember(ctx context.Context, actorID, orgID, memberID uuid.UUID) ([]uuid.UUID, error) {
+	actor, ok := m.members[orgID][actorID]
+	member, exists := m.members[orgID][memberID]
+	if !ok || !exists {
+		return nil, fmt.Errorf("organization member not found")
+	}
+	if actorID != memberID && !actor.Role.CanManage(member.Role) {
+		return nil, storage.ErrInsufficientRole
+	}
+	if member.Role == models.RoleOwner && m.countOwners(orgID) == 1 {
+		return nil, storage.ErrLastOwner
+	}
+	delete(m.members[orgID], memberID)
+
+	var collectionIDs []uuid.UUID
+	for id, collection := range m.collections {
+		if collection.OrganizationID != orgID {
+			continue
+		}
+		delete(m.collectionKeys[id], memberID)
+		collection.RotationRequired = true
+		collectionIDs = append(collectionIDs, id)
+		for entryID, entry := range m.teamEntries {
+			if *entry.CollectionID == id {
+				m.addDeleted(entryID, memberID)
+			}
+		}
+	}
+	return collectionIDs, nil
+}
+
+func (m *mockStorage) CreateInvitation(ctx context.Context, actorID uuid.UUID, invitation *models.OrganizationInvitation) error {
+	actor, ok := m.members[invitation.OrganizationID][actorID]
+	if !ok || !actor.Role.CanManage(invitation.Role) {
+		return storage.ErrInsufficientRole
+	}
+	if m.invitations == nil {
+		m.invitations = make(map[uuid.UUID]*models.OrganizationInvitation)
+	}
+	invitation.ID = uuid.New()
+	invitation.InvitedBy = actorID
+	invitation.OrganizationName = m.orgs[invitation.OrganizationID].Name
+	invitation.CreatedAt = time.Now()
+	stored := *invitation
+	m.invitations[invitation.ID] = &stored
+	return nil
+}
+
+func (m *mockStorage) GetInvitations(ctx context.Context, inviteeID uuid.UUID) ([]models.OrganizationInvitation, error) {
+	var invitations []models.OrganizationInvitation
+	for _, invitation := range m.invitations {
+		if invitation.InviteeID == inviteeID && invitation.ExpiresAt.After(time.Now()) {
+			invitations = append(invitations, *invitation)
+		}
+	}
+	return invitations, nil
+}
+
+func (m *mockStorage) AcceptInvitation(ctx context.Context, inviteeID, invitationID uuid.UUID) (*models.Organization, error) {
+	invitation, ok := m.invitations[invitationID]
+	if !ok || invitation.InviteeID != inviteeID {
+		return nil, fmt.Errorf("invitation not found")
+	}
+	delete(m.invitations, invitationID)
+	m.members[invitation.OrganizationID][inviteeID] = &models.OrganizationMember{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         inviteeID,
+		Role:           invitation.Role,
+		CreatedAt:      time.Now(),
+	}
+	org := *m.orgs[invitation.OrganizationID]
+	org.Role = invitation.Role
+	return &org, nil
+}
+
+func (m *mockStorage) DeleteInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	invitation, ok := m.invitations[invitationID]
+	if !ok {
+		return fmt.Errorf("invitation not found")
+	}
+	actor, isMember := m.members[invitation.OrganizationID][userID]
+	if invitation.InviteeID != userID && (!isMember || !actor.Role.AtLeast(models.RoleAdmin)) {
+		return fmt.Errorf("invitation not found")
+	}
+	delete(m.invitations, invitationID)
+	return nil
+}
+
+func (m *mockStorage) CreateCollection(ctx context.Context, actorID uuid.UUID, collection *models.Collection) error {
+	actor, ok := m.members[collection.OrganizationID][actorID]
+	if !ok || !actor.Role.AtLeast(models.RoleAdmin) {
+		return storage.ErrInsufficientRole
+	}
+	if m.collections == nil {
+		m.collections = make(map[uuid.UUID]*models.Collection)
+		m.collectionKeys = make(map[uuid.UUID]map[uuid.UUID]models.CollectionKey)
+	}
+	collection.ID = uuid.New()
+	collection.KeyVersion = 1
+	collection.CreatedAt = time.Now()
+	stored := *collection
+	stored.WrappedKey = nil
+	m.collections[collection.ID] = &stored
+	m.collectionKeys[collection.ID] = map[uuid.UUID]models.CollectionKey{
+		actorID: {CollectionID: collection.ID, UserID: actorID, KeyVersion: 1, WrappedKey: collection.WrappedKey},
+	}
+	return nil
+}
+
+func (m *mockStorage) GetCollections(ctx context.Context, userID, orgID uuid.UUID) ([]models.Collection, error) {
+	var collections []models.Collection
+	for id, collection := range m.collections {
+		if collection.OrganizationID == orgID {
+			result, err := m.GetCollection(ctx, userID, id)
+			if err == nil {
+				collections = append(collections, *result)
+			}
+		}
+	}
+	return collections, nil
+}
+
+func (m *mockStorage) GetCollection(ctx context.Context, userID, collectionID uuid.UUID) (*models.Collection, error) {
+	collection, ok := m.collections[collectionID]
+	if !ok {
+		return nil, fmt.Errorf("collection not found")
+	}
+	if _, member := m.members[collection.OrganizationID][userID]; !member {
+		return nil, fmt.Errorf("collection not found")
+	}
+	result := *collection
+	if key, ok := m.collectionKeys[collectionID][userID]; ok && key.KeyVersion == collection.KeyVersion {
+		result.WrappedKey = key.WrappedKey
+	}
+	return &result, nil
+}
+
+func (m *mockStorage) SetCollectionRotationDays(ctx context.Context, actorID, collectionID uuid.UUID, days int) error {
+	role, ok := m.collectionRole(collectionID, actorID)
+	if !ok || !role.AtLeast(models.RoleAdmin) {
+		return storage.ErrInsufficientRole
+	}
+	m.collections[collectionID].RotationDays = days
+	return nil
+}
+
+func (m *mockStorage) SetCollectionKey(ctx context.Context, actorID uuid.UUID, key *models.CollectionKey) error {
+	collection, ok := m.collections[key.CollectionID]
+	if !ok || collection.KeyVersion != key.KeyVersion {
+		return storage.ErrCollectionKeyMismatch
+	}
+	actor, isMember := m.members[collection.OrganizationID][actorID]
+	if !isMember || !actor.Role.AtLeast(models.RoleAdmin) {
+		return storage.ErrCollectionKeyMismatch
+	}
+	if _, isMember := m.members[collection.OrganizationID][key.UserID]; !isMember {
+		return storage.ErrCollectionKeyMismatch
+	}
+	m.collectionKeys[key.CollectionID][key.UserID] = *key
+	return nil
+}
+
+func (m *mockStorage) RotateCollectionKey(ctx context.Context, actorID, collectionID uuid.UUID, keyVersion int, keys []models.CollectionKey, entries []models.DataEntry) error {
+	collection, ok := m.collections[collectionID]
+	if !ok {
+		return fmt.Errorf("collection not found")
+	}
+	members := m.members[collection.OrganizationID]
+	actor, isMember := members[actorID]
+	if !isMember || !actor.Role.AtLeast(models.RoleAdmin) {
+		return storage.ErrInsufficientRole
+	}
+	if keyVersion != collection.KeyVersion+1 || len(keys) != len(members) {
+		return storage.ErrCollectionKeyMismatch
+	}
+	newKeys := make(map[uuid.UUID]models.CollectionKey, len(keys))
+	for _, key := range keys {
+		if _, ok := members[key.UserID]; !ok {
+			return storage.ErrCollectionKeyMismatch
+		}
+		newKeys[key.UserID] = key
+	}
+	if len(newKeys) != len(members) {
+		return storage.ErrCollectionKeyMismatch
+	}
+
+	count := 0
+	for _, entry := range m.teamEntries {
+		if *entry.CollectionID == collectionID {
+			count++
+		}
+	}
+	if count != len(entries) {
+		return storage.ErrCollectionKeyMismatch
+	}
+	for _, update := range entries {
+		entry, ok := m.teamEntries[update.ID]
+		if !ok || *entry.CollectionID != collectionID || entry.Version != update.Version {
+			return storage.ErrCollectionKeyMismatch
+		}
+	}
+	for _, update := range entries {
+		entry := m.teamEntries[update.ID]
+		entry.EncryptedData = update.EncryptedData
+		entry.Version++
+		entry.UpdatedAt = time.Now()
+	}
+
+	m.collectionKeys[collectionID] = newKeys
+	collection.KeyVersion = keyVersion
+	collection.RotationRequired = false
+	return nil
+}
+
+func (m *mockStorage) collectionRole(collectionID, userID uuid.UUID) (models.OrganizationRole, bool) {
+	collection, ok := m.collections[collectionID]
+	if !ok {
+		return "", false
+	}
+	member, ok := m.members[collection.OrganizationID][userID]
+	if !ok {
+		return "", false
+	}
+	return member.Role, true
+}
+
+func (m *mockStorage) CreateCollectionEntry(ctx context.Context, actorID uuid.UUID, entry *models.DataEntry) error {
+	role, ok := m.collectionRole(*entry.CollectionID, actorID)
+	if !ok || !role.AtLeast(models.RoleEditor) {
+		return storage.ErrInsufficientRole
+	}
+	if m.teamEntries == nil {
+		m.teamEntries = make(map[uuid.UUID]*models.DataEntry)
+	}
+	entry.ID = uuid.New()
+	entry.UserID = actorID
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = entry.CreatedAt
+	entry.PasswordChangedAt = entry.CreatedAt
+	entry.Version = 1
+	stored := *entry
+	m.teamEntries[entry.ID] = &stored
+	return nil
+}
+
+// withCollectionPolicy возвращает копию записи коллекции с политикой смены паролей коллекции.
+func (m *mockStorage) withCollectionPolicy(entry *models.DataEntry) models.DataEntry {
+	result := *entry
+	result.CollectionRotationDays = m.collections[*entry.CollectionID].RotationDays
+	return result
+}
+
+func (m *mockStorage) GetCollectionEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error) {
+	entry, ok := m.teamEntries[entryID]
+	if !ok {
+		return nil, fmt.Errorf("data entry not found")
+	}
+	if _, ok := m.collectionRole(*entry.CollectionID, userID); !ok {
+		return nil, fmt.Errorf("data entry not found")
+	}
+	result := m.withCollectionPolicy(entry)
+	return &result, nil
+}
+
+func (m *mockStorage) GetCollectionEntries(ctx context.Context, userID, collectionID uuid.UUID) ([]models.DataEntry, error) {
+	var entries []models.DataEntry
+	for _, entry := range m.teamEntries {
+		if *entry.CollectionID != collectionID {
+			continue
+		}
+		if _, ok := m.collectionRole(collectionID, userID); ok {
+			entries = append(entries, m.withCollectionPolicy(entry))
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockStorage) GetCollectionEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
+	var entries []models.DataEntry
+	for _, entry := range m.teamEntries {
+		collection := m.collections[*entry.CollectionID]
+		member, ok := m.members[collection.OrganizationID][userID]
+		if ok && (entry.UpdatedAt.After(after) || member.CreatedAt.After(after)) {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockStorage) UpdateCollectionEntry(ctx context.Context, actorID uuid.UUID, entry *models.DataEntry) error {
+	stored, ok := m.teamEntries[entry.ID]
+	if !ok || stored.Version != entry.Version {
+		return storage.ErrVersionMismatch
+	}
+	role, ok := m.collectionRole(*stored.CollectionID, actorID)
+	if !ok || !role.AtLeast(models.RoleEditor) {
+		return storage.ErrVersionMismatch
+	}
+	entry.Version++
+	entry.UpdatedAt = time.Now()
+	updated := *entry
+	m.teamEntries[entry.ID] = &updated
+	return nil
+}
+
+func (m *mockStorage) DeleteCollectionEntry(ctx context.Context, actorID, entryID uuid.UUID) error {
+	entry, ok := m.teamEntries[entryID]
+	if !ok {
+		return fmt.Errorf("data entry not found")
+	}
+	role, ok := m.collectionRole(*entry.CollectionID, actorID)
+	if !ok || !role.AtLeast(models.RoleEditor) {
+		return fmt.Errorf("data entry not found")
+	}
+	delete(m.teamEntries, entryID)
+	for memberID := range m.members[m.collections[*entry.CollectionID].OrganizationID] {
+		m.addDeleted(entryID, memberID)
+	}
+	return nil
+}
+
+func (m *mockStorage) CreateSecretLink(ctx context.Context, link *models.SecretLink) error {
+	if m.secretLinks == nil {
+		m.secretLinks = make(map[uuid.UUID]*models.SecretLink)
+	}
+	link.ID = uuid.New()
+	link.CreatedAt = time.Now()
+	link.Views = 0
+	stored := *link
+	m.secretLinks[link.ID] = &stored
+	return nil
+}
+
+func (m *mockStorage) ConsumeSecretLink(ctx context.Context, linkID uuid.UUID, now time.Time) (*models.SecretLink, error) {
+	link, ok := m.secretLinks[linkID]
+	if !ok || link.ViewsLeft() == 0 || !link.ExpiresAt.After(now) {
+		return nil, storage.ErrSecretLinkGone
+	}
+	link.Views++
+	if link.ViewsLeft() == 0 {
+		delete(m.secretLinks, linkID)
+	}
+	result := *link
+	return &result, nil
+}
+
+func (m *mockStorage) DeleteExpiredSecretLinks(ctx context.Context, now time.Time) (int64, error) {
+	var purged int64
+	for id, link := range m.secretLinks {
+		if !link.ExpiresAt.After(now) {
+			delete(m.secretLinks, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (m *mockStorage) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	if m.attachments == nil {
+		m.attachments = make(map[uuid.UUID]*models.Attachment)
+	}
+	count, totalSize := 0, int64(0)
+	for _, existing := range m.attachments {
+		if existing.EntryID == attachment.EntryID {
+			count++
+			totalSize += existing.Size
+		}
+	}
+	if count >= models.MaxEntryAttachments || totalSize+attachment.Size > models.MaxEntryAttachmentsSize {
+		return storage.ErrAttachmentLimit
+	}
+	attachment.ID = uuid.New()
+	attachment.CreatedAt = time.Now()
+	stored := *attachment
+	m.attachments[attachment.ID] = &stored
+	return nil
+}
+
+func (m *mockStorage) GetAttachments(ctx context.Context, entryID uuid.UUID) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	for _, attachment := range m.attachments {
+		if attachment.EntryID == entryID {
+			listed := *attachment
+			listed.EncryptedData = nil
+			attachments = append(attachments, listed)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+func (m *mockStorage) GetAttachment(ctx context.Context, entryID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	attachment, ok := m.attachments[attachmentID]
+	if !ok || attachment.EntryID != entryID {
+		return nil, storage.ErrAttachmentNotFound
+	}
+	result := *attachment
+	return &result, nil
+}
+
+func (m *mockStorage) GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	for _, attachment := range m.attachments {
+		if entry, ok := m.data[attachment.EntryID]; ok && entry.UserID == userID {
+			attachments = append(attachments, *attachment)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+func (m *mockStorage) DeleteAttachment(ctx context.Context, entryID, attachmentID uuid.UUID) error {
+	attachment, ok := m.attachments[attachmentID]
+	if !ok || attachment.EntryID != entryID {
+		return storage.ErrAttachmentNotFound
+	}
+	delete(m.attachments, attachmentID)
+	return nil
+}
+
+func (m *mockStorage) deleteEntryAttachments(entryID uuid.UUID) {
+	for id, attachment := range m.attachments {
+		if attachment.EntryID == entryID {
+			delete(m.attachments, id)
+		}
+	}
+}
+
+func (m *mockStorage) recordEmergencyEvent(access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) {
+	event.ID = uuid.New()
+	event.EmergencyAccessID = access.ID
+	event.CreatedAt = time.Now()
+	if event.ActorID != nil {
+		if user, err := m.GetUserByID(context.Background(), *event.ActorID); err == nil {
+			event.ActorUsername = user.Username
+		}
+	}
+	m.emergencyEvents = append(m.emergencyEvents, mockEmergencyEvent{
+		event:     *event,
+		grantorID: access.GrantorID,
+		granteeID: access.GranteeID,
+	})
+	for _, userID := range []uuid.UUID{access.GrantorID, access.GranteeID} {
+		if event.ActorID != nil && *event.ActorID == userID {
+			continue
+		}
+		m.notifications = append(m.notifications, models.Notification{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Message:   message,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+}
+
+func (m *mockStorage) CreateEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, message string) error {
+	if m.emergency == nil {
+		m.emergency = make(map[uuid.UUID]*models.EmergencyAccess)
+	}
+	access.ID = uuid.New()
+	access.CreatedAt = time.Now()
+	access.UpdatedAt = access.CreatedAt
+	access.Status = models.EmergencyAccessIdle
+	stored := *access
+	m.emergency[access.ID] = &stored
+	m.recordEmergencyEvent(access, &models.EmergencyAccessEvent{ActorID: &access.GrantorID, Event: models.EmergencyEventDesignated}, message)
+	return nil
+}
+
+func (m *mockStorage) GetEmergencyAccess(ctx context.Context, userID, accessID uuid.UUID) (*models.EmergencyAccess, error) {
+	access, ok := m.emergency[accessID]
+	if !ok || (access.GrantorID != userID && access.GranteeID != userID) {
+		return nil, fmt.Errorf("emergency access not found")
+	}
+	result := *access
+	return &result, nil
+}
+
+func (m *mockStorage) GetEmergencyAccessList(ctx context.Context, userID uuid.UUID) ([]models.EmergencyAccess, error) {
+	var result []models.EmergencyAccess
+	for _, access := range m.emergency {
+		if access.GrantorID == userID || access.GranteeID == userID {
+			result = append(result, *access)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStorage) GetElapsedEmergencyRequests(ctx context.Context, now time.Time) ([]models.EmergencyAccess, error) {
+	var result []models.EmergencyAccess
+	for _, access := range m.emergency {
+		if access.WaitElapsed(now) {
+			result = append(result, *access)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStorage) TransitionEmergencyAccess(ctx context.Context, accessID uuid.UUID, from []models.EmergencyAccessStatus, to models.EmergencyAccessStatus, event *models.EmergencyAccessEvent, message string) (*models.EmergencyAccess, error) {
+	access, ok := m.emergency[accessID]
+	if !ok {
+		return nil, storage.ErrEmergencyAccessState
+	}
+	allowed := false
+	for _, status := range from {
+		if access.Status == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, storage.ErrEmergencyAccessState
+	}
+
+	now := time.Now()
+	access.Status = to
+	switch to {
+	case models.EmergencyAccessRequested:
+		access.RequestedAt = &now
+		access.ApprovedAt = nil
+	case models.EmergencyAccessApproved:
+		access.ApprovedAt = &now
+	case models.EmergencyAccessIdle:
+		access.RequestedAt = nil
+		access.ApprovedAt = nil
+	}
+	m.recordEmergencyEvent(access, event, message)
+	result := *access
+	return &result, nil
+}
+
+func (m *mockStorage) RecordEmergencyAccessEvent(ctx context.Context, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error {
+	m.recordEmergencyEvent(access, event, message)
+	return nil
+}
+
+func (m *mockStorage) DeleteEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error {
+	if _, ok := m.emergency[access.ID]; !ok {
+		return fmt.Errorf("emergency access not found")
+	}
+	delete(m.emergency, access.ID)
+	m.recordEmergencyEvent(access, event, message)
+	return nil
+}
+
+func (m *mockStorage) GetEmergencyAccessEvents(ctx context.Context, userID, accessID uuid.UUID) ([]models.EmergencyAccessEvent, error) {
+	var result []models.EmergencyAccessEvent
+	for _, recorded := range m.emergencyEvents {
+		if recorded.event.EmergencyAccessID == accessID && (recorded.grantorID == userID || recorded.granteeID == userID) {
+			result = append(result, recorded.event)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStorage) GetNotifications(ctx context.Context, userID uuid.UUID) ([]models.Notification, error) {
+	var result []models.Notification
+	for i := len(m.notifications) - 1; i >= 0; i-- {
+		if m.notifications[i].UserID == userID {
+			result = append(result, m.notifications[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockStorage) GetEntriesDueForRotation(ctx context.Context, userID uuid.UUID, dueBefore time.Time) ([]models.DataEntry, error) {
+	var entries []models.DataEntry
+	for _, entry := range m.data {
+		if entry.UserID == userID {
+			entries = append(entries, *entry)
+		}
+	}
+	for _, entry := range m.teamEntries {
+		if _, ok := m.collectionRole(*entry.CollectionID, userID); ok {
+			entries = append(entries, m.withCollectionPolicy(entry))
+		}
+	}
+
+	var due []models.DataEntry
+	for _, entry := range entries {
+		if dueAt := entry.RotationDueAt(); dueAt != nil && !dueAt.After(dueBefore) {
+			due = append(due, entry)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].RotationDueAt().Before(*due[j].RotationDueAt())
+	})
+	return due, nil
 }
 
 func (m *mockStorage) Close() {
@@ -327,7 +2779,7 @@ func startTestGRPCServer(srv *Server, addr string, logger *zap.Logger) (lisClose
 	}
 
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(AuthInterceptor(srv.authService, srv.logger)),
+		grpc.UnaryInterceptor(AuthInterceptor(srv.authService, srv.storage, srv.logger)),
 	)
 	pb.RegisterGophKeeperServer(grpcServer, srv)
 
@@ -3,18 +3,51 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"strings"
 
 	"github.com/GophKeeper/internal/auth"
+	"github.com/GophKeeper/internal/middleware"
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
+	pb "github.com/GophKeeper/proto/gen/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
-// AuthInterceptor создает gRPC interceptor для проверки JWT токенов.
-func AuthInterceptor(authService *auth.Service, logger *zap.Logger) grpc.UnaryServerInterceptor {
+// accessTokenReadMethods методы, доступные любому персональному токену доступа.
+var accessTokenReadMethods = map[string]bool{
+	pb.GophKeeper_GetData_FullMethodName:  true,
+	pb.GophKeeper_ListData_FullMethodName: true,
+	pb.GophKeeper_SyncData_FullMethodName: true,
+
+	pb.GophKeeper_ListDueForRotation_FullMethodName: true,
+
+	pb.GophKeeper_ListAttachments_FullMethodName: true,
+	pb.GophKeeper_GetAttachment_FullMethodName:   true,
+}
+
+// accessTokenWriteMethods методы, доступные персональному токену без ограничения "только чтение".
+var accessTokenWriteMethods = map[string]bool{
+	pb.GophKeeper_CreateData_FullMethodName: true,
+	pb.GophKeeper_UpdateData_FullMethodName: true,
+	pb.GophKeeper_DeleteData_FullMethodName: true,
+
+	pb.GophKeeper_AddAttachment_FullMethodName:    true,
+	pb.GophKeeper_RemoveAttachment_FullMethodName: true,
+}
+
+// AuthInterceptor создает gRPC interceptor для проверки JWT токенов и персональных токенов доступа.
+// Если credentials не nil, дополнительно проверяется, что сессия JWT токена не отозвана,
+// а запросы без заголовка authorization принимаются по клиентскому сертификату mTLS,
+// привязанному к персональному токену доступа, с ограничениями этого токена.
+func AuthInterceptor(authService *auth.Service, credentials storage.CredentialRepository, logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// Пропускаем аутентификацию для публичных методов
 		if isPublicMethod(info.FullMethod) {
@@ -22,14 +55,23 @@ func AuthInterceptor(authService *auth.Service, logger *zap.Logger) grpc.UnarySe
 		}
 
 		// Извлекаем токен из метаданных
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			logger.Warn("No metadata in request")
-			return nil, status.Error(codes.Unauthenticated, "user not authenticated")
-		}
-
+		md, _ := metadata.FromIncomingContext(ctx)
 		authHeaders := md.Get("authorization")
 		if len(authHeaders) == 0 {
+			// Клиентский сертификат предъявляет привязанный к нему токен доступа
+			accessToken, err := middleware.AuthenticateClientCert(ctx, credentials, peerTLSState(ctx))
+			if err == nil {
+				ctx, err = authorizeAccessToken(ctx, accessToken, info.FullMethod, logger)
+				if err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}
+			if !errors.Is(err, middleware.ErrNoClientCertificate) {
+				logger.Warn("Client certificate rejected", zap.Error(err))
+				return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+			}
+
 			logger.Warn("No authorization header")
 			return nil, status.Error(codes.Unauthenticated, "user not authenticated")
 		}
@@ -43,6 +85,23 @@ func AuthInterceptor(authService *auth.Service, logger *zap.Logger) grpc.UnarySe
 
 		token := strings.TrimPrefix(authHeader, "Bearer ")
 
+		// Персональный токен доступа
+		if auth.IsAccessToken(token) {
+			if credentials == nil {
+				return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+			}
+			accessToken, err := middleware.AuthenticateAccessToken(ctx, credentials, token)
+			if err != nil {
+				logger.Warn("Invalid access token", zap.Error(err))
+				return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+			}
+			ctx, err = authorizeAccessToken(ctx, accessToken, info.FullMethod, logger)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+
 		// Валидируем токен
 		claims, err := authService.ValidateToken(token)
 		if err != nil {
@@ -50,19 +109,71 @@ func AuthInterceptor(authService *auth.Service, logger *zap.Logger) grpc.UnarySe
 			return nil, status.Error(codes.Unauthenticated, "user not authenticated")
 		}
 
+		// Проверяем, что сессия не была отозвана (например, после смены пароля)
+		if credentials != nil {
+			active, err := credentials.IsSessionActive(ctx, claims.UserID, claims.SessionID)
+			if err != nil {
+				logger.Error("Failed to check session", zap.Error(err))
+				return nil, status.Error(codes.Internal, "failed to check session")
+			}
+			if !active {
+				logger.Warn("Session is revoked or unknown", zap.String("session_id", claims.SessionID.String()))
+				return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+			}
+		}
+
 		// Добавляем информацию о пользователе в контекст
 		ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
 		ctx = context.WithValue(ctx, UsernameKey, claims.Username)
+		ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
 
 		return handler(ctx, req)
 	}
 }
 
+// authorizeAccessToken проверяет область действия токена доступа для метода
+// и добавляет владельца токена и сам токен в контекст.
+func authorizeAccessToken(ctx context.Context, accessToken *models.AccessToken, method string, logger *zap.Logger) (context.Context, error) {
+	if !accessTokenAllowsMethod(accessToken, method) {
+		logger.Warn("Access token scope violation",
+			zap.String("token_id", accessToken.ID.String()),
+			zap.String("method", method))
+		return nil, status.Error(codes.PermissionDenied, "insufficient token scope")
+	}
+
+	ctx = context.WithValue(ctx, UserIDKey, accessToken.UserID)
+	ctx = context.WithValue(ctx, AccessTokenKey, accessToken)
+	return ctx, nil
+}
+
+// peerTLSState возвращает состояние TLS соединения клиента, если оно есть.
+func peerTLSState(ctx context.Context) *tls.ConnectionState {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	return &tlsInfo.State
+}
+
+// accessTokenAllowsMethod проверяет, может ли персональный токен вызвать метод.
+func accessTokenAllowsMethod(accessToken *models.AccessToken, method string) bool {
+	if accessTokenReadMethods[method] {
+		return true
+	}
+	return !accessToken.ReadOnly && accessTokenWriteMethods[method]
+}
+
 // isPublicMethod проверяет, является ли метод публичным (не требует аутентификации).
 func isPublicMethod(method string) bool {
 	publicMethods := []string{
 		"/gophkeeper.GophKeeper/Register",
 		"/gophkeeper.GophKeeper/Login",
+		"/gophkeeper.GophKeeper/BeginSRPLogin",
+		"/gophkeeper.GophKeeper/FinishSRPLogin",
 		"/gophkeeper.GophKeeper/RefreshToken",
 		"/gophkeeper.GophKeeper/GenerateOTP",
 		"/gophkeeper.GophKeeper/CreateOTPSecret",
@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/auth"
+	"github.com/GophKeeper/internal/crypto"
+	"github.com/GophKeeper/internal/otp"
+	"github.com/GophKeeper/internal/tlsutil"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// testCA выпускает тестовые сертификаты
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "GophKeeper Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue выпускает сертификат, подписанный CA, и возвращает его вместе с ключом в PEM
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// dialMTLS подключается к серверу с клиентским сертификатом
+func dialMTLS(t *testing.T, addr string, ca *testCA, certPEM, keyPEM []byte) pb.GophKeeperClient {
+	clientCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		ServerName:   "localhost",
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{clientCert},
+	})))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return pb.NewGophKeeperClient(conn)
+}
+
+func TestClientCertificateAuth(t *testing.T) {
+	logger := zap.NewNop()
+	ca := newTestCA(t)
+
+	// Файлы сертификатов сервера
+	dir := t.TempDir()
+	serverCert, serverKey := ca.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(certFile, serverCert, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, serverKey, 0o600))
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+	reloader, err := tlsutil.NewReloader(tlsutil.Options{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+	}, logger)
+	require.NoError(t, err)
+
+	privateKey, publicKey := generateTestKeys(t)
+	cryptoService, err := crypto.NewService(privateKey, publicKey)
+	require.NoError(t, err)
+	srv := NewServer(setupTestStorage(t), auth.NewService("test-secret"), cryptoService, otp.NewService(), logger)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	grpcSrv := NewGRPCServer(srv, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
+	go grpcSrv.Serve(lis)
+	t.Cleanup(grpcSrv.Stop)
+
+	certPEM, keyPEM := ca.issue(t, "service-account", x509.ExtKeyUsageClientAuth)
+	client := dialMTLS(t, lis.Addr().String(), ca, certPEM, keyPEM)
+	registerResp, err := client.Register(context.Background(), &pb.RegisterRequest{
+		Username: "service-account",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+
+	// Сертификат, выданный CA, сам по себе не дает доступа к аккаунту
+	_, err = client.ListData(context.Background(), &pb.ListDataRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Владелец привязывает сертификат к токену с ограниченными правами
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+registerResp.Token)
+	tokenResp, err := client.CreateAccessToken(ctx, &pb.CreateAccessTokenRequest{
+		Name:              "ci",
+		DataTypes:         []pb.DataType{pb.DataType_DATA_TYPE_TEXT},
+		ClientCertificate: certPEM,
+	})
+	require.NoError(t, err)
+	require.Empty(t, tokenResp.Token)
+	require.NotEmpty(t, tokenResp.AccessToken.CertificateFingerprint)
+
+	// Повторная привязка того же сертификата отклоняется
+	_, err = client.CreateAccessToken(ctx, &pb.CreateAccessTokenRequest{
+		Name:              "ci-copy",
+		ClientCertificate: certPEM,
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Сертификат заменяет токен в пределах его области действия
+	_, err = client.CreateData(context.Background(), &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_TEXT,
+		Name:          "note",
+		EncryptedData: []byte("secret"),
+	})
+	require.NoError(t, err)
+
+	listResp, err := client.ListData(context.Background(), &pb.ListDataRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.DataEntries, 1)
+
+	_, err = client.CreateData(context.Background(), &pb.CreateDataRequest{
+		Type:          pb.DataType_DATA_TYPE_CREDENTIALS,
+		Name:          "login",
+		EncryptedData: []byte("secret"),
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.ListAccessTokens(context.Background(), &pb.ListAccessTokensRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Отозванный токен больше не принимается по сертификату
+	_, err = client.RevokeAccessToken(ctx, &pb.RevokeAccessTokenRequest{Id: tokenResp.AccessToken.Id})
+	require.NoError(t, err)
+	_, err = client.ListData(context.Background(), &pb.ListDataRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Сертификат с тем же Common Name, но не привязанный к токену, отклоняется
+	otherCert, otherKey := ca.issue(t, "service-account", x509.ExtKeyUsageClientAuth)
+	other := dialMTLS(t, lis.Addr().String(), ca, otherCert, otherKey)
+	_, err = other.ListData(context.Background(), &pb.ListDataRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
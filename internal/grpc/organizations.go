@@ -0,0 +1,787 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// invitationTTL срок действия приглашения в организацию.
+const invitationTTL = 7 * 24 * time.Hour
+
+// CreateOrganization создает организацию. Создатель становится ее владельцем.
+func (s *Server) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.OrganizationResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if req.Name == "" || len(req.Name) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "organization name must be 1-100 characters")
+	}
+
+	org := &models.Organization{Name: req.Name}
+	if err := s.storage.CreateOrganization(ctx, org, userID); err != nil {
+		s.logger.Error("Failed to create organization", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create organization")
+	}
+
+	s.logger.Info("Organization created",
+		zap.String("organization_id", org.ID.String()),
+		zap.String("owner_id", userID.String()))
+
+	return &pb.OrganizationResponse{
+		Organization: convertToProtoOrganization(org),
+	}, nil
+}
+
+// ListOrganizations возвращает организации, в которых состоит пользователь.
+func (s *Server) ListOrganizations(ctx context.Context, req *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgs, err := s.storage.GetOrganizations(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get organizations", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get organizations")
+	}
+
+	protoOrgs := make([]*pb.Organization, len(orgs))
+	for i := range orgs {
+		protoOrgs[i] = convertToProtoOrganization(&orgs[i])
+	}
+
+	return &pb.ListOrganizationsResponse{
+		Organizations: protoOrgs,
+	}, nil
+}
+
+// ListOrganizationMembers возвращает участников организации. Доступно всем участникам.
+func (s *Server) ListOrganizationMembers(ctx context.Context, req *pb.ListOrganizationMembersRequest) (*pb.ListOrganizationMembersResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+	if _, err := s.requireOrganizationRole(ctx, orgID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := s.storage.GetOrganizationMembers(ctx, userID, orgID)
+	if err != nil {
+		s.logger.Error("Failed to get organization members", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get organization members")
+	}
+
+	protoMembers := make([]*pb.OrganizationMember, len(members))
+	for i := range members {
+		protoMembers[i] = convertToProtoOrganizationMember(&members[i])
+	}
+
+	return &pb.ListOrganizationMembersResponse{
+		Members: protoMembers,
+	}, nil
+}
+
+// InviteMember приглашает пользователя в организацию. Приглашать редакторов
+// и читателей могут администраторы, администраторов и владельцев - только владелец.
+func (s *Server) InviteMember(ctx context.Context, req *pb.InviteMemberRequest) (*pb.InvitationResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	role := convertProtoOrganizationRole(req.Role)
+	if !role.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization role")
+	}
+
+	actorRole, err := s.requireOrganizationRole(ctx, orgID, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !actorRole.CanManage(role) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+	}
+
+	invitee, err := s.storage.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if _, err := s.storage.GetOrganizationRole(ctx, orgID, invitee.ID); err == nil {
+		return nil, status.Error(codes.AlreadyExists, "user is already a member of the organization")
+	}
+
+	inviter, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	invitation := &models.OrganizationInvitation{
+		OrganizationID:  orgID,
+		InviteeID:       invitee.ID,
+		InviteeUsername: invitee.Username,
+		InvitedByName:   inviter.Username,
+		Role:            role,
+		ExpiresAt:       time.Now().Add(invitationTTL),
+	}
+	if err := s.storage.CreateInvitation(ctx, userID, invitation); err != nil {
+		s.logger.Error("Failed to create invitation", zap.Error(err))
+		if errors.Is(err, storage.ErrInsufficientRole) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+		}
+		return nil, status.Error(codes.Internal, "failed to create invitation")
+	}
+
+	s.logger.Info("Organization invitation created",
+		zap.String("organization_id", orgID.String()),
+		zap.String("invitee", invitee.Username),
+		zap.String("role", string(role)))
+
+	return &pb.InvitationResponse{
+		Invitation: convertToProtoInvitation(invitation),
+	}, nil
+}
+
+// ListInvitations возвращает действующие приглашения текущего пользователя.
+func (s *Server) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	invitations, err := s.storage.GetInvitations(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get invitations", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get invitations")
+	}
+
+	protoInvitations := make([]*pb.OrganizationInvitation, len(invitations))
+	for i := range invitations {
+		protoInvitations[i] = convertToProtoInvitation(&invitations[i])
+	}
+
+	return &pb.ListInvitationsResponse{
+		Invitations: protoInvitations,
+	}, nil
+}
+
+// AcceptInvitation принимает приглашение в организацию.
+func (s *Server) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.OrganizationResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	invitationID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid invitation ID")
+	}
+
+	org, err := s.storage.AcceptInvitation(ctx, userID, invitationID)
+	if err != nil {
+		s.logger.Error("Failed to accept invitation", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "invitation not found")
+	}
+
+	s.logger.Info("Organization invitation accepted",
+		zap.String("organization_id", org.ID.String()),
+		zap.String("user_id", userID.String()))
+
+	return &pb.OrganizationResponse{
+		Organization: convertToProtoOrganization(org),
+	}, nil
+}
+
+// DeclineInvitation отклоняет приглашение (приглашенный пользователь)
+// или отзывает его (администратор организации).
+func (s *Server) DeclineInvitation(ctx context.Context, req *pb.DeclineInvitationRequest) (*pb.DeclineInvitationResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	invitationID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid invitation ID")
+	}
+
+	if err := s.storage.DeleteInvitation(ctx, userID, invitationID); err != nil {
+		s.logger.Error("Failed to delete invitation", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "invitation not found")
+	}
+
+	return &pb.DeclineInvitationResponse{
+		Success: true,
+	}, nil
+}
+
+// UpdateMemberRole изменяет роль участника организации.
+func (s *Server) UpdateMemberRole(ctx context.Context, req *pb.UpdateMemberRoleRequest) (*pb.UpdateMemberRoleResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+	memberID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+	role := convertProtoOrganizationRole(req.Role)
+	if !role.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization role")
+	}
+
+	actorRole, err := s.requireOrganizationRole(ctx, orgID, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !actorRole.CanManage(role) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+	}
+
+	member, err := s.storage.UpdateOrganizationMemberRole(ctx, userID, orgID, memberID, role)
+	if err != nil {
+		s.logger.Error("Failed to update member role", zap.Error(err))
+		return nil, organizationMemberError(err)
+	}
+
+	s.logger.Info("Organization member role updated",
+		zap.String("organization_id", orgID.String()),
+		zap.String("member_id", memberID.String()),
+		zap.String("role", string(role)))
+
+	return &pb.UpdateMemberRoleResponse{
+		Member: convertToProtoOrganizationMember(member),
+	}, nil
+}
+
+// RemoveMember исключает участника из организации или позволяет участнику
+// покинуть ее. Исключенный участник мог сохранить ключи коллекций, поэтому
+// все коллекции организации помечаются как требующие смены ключа.
+func (s *Server) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+	memberID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	minRole := models.RoleAdmin
+	if memberID == userID {
+		minRole = models.RoleViewer
+	}
+	if _, err := s.requireOrganizationRole(ctx, orgID, userID, minRole); err != nil {
+		return nil, err
+	}
+
+	collectionIDs, err := s.storage.RemoveOrganizationMember(ctx, userID, orgID, memberID)
+	if err != nil {
+		s.logger.Error("Failed to remove organization member", zap.Error(err))
+		return nil, organizationMemberError(err)
+	}
+
+	s.logger.Info("Organization member removed",
+		zap.String("organization_id", orgID.String()),
+		zap.String("member_id", memberID.String()),
+		zap.Int("collections_to_rotate", len(collectionIDs)))
+
+	ids := make([]string, len(collectionIDs))
+	for i, id := range collectionIDs {
+		ids[i] = id.String()
+	}
+
+	return &pb.RemoveMemberResponse{
+		RotationRequiredCollectionIds: ids,
+	}, nil
+}
+
+// CreateCollection создает коллекцию организации. Ключ коллекции генерируется
+// клиентом и передается зашифрованным открытым ключом создателя.
+func (s *Server) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.CollectionResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+	if req.Name == "" || len(req.Name) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "collection name must be 1-100 characters")
+	}
+	if len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+	if err := validateRotationDays(req.RotationDays); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requireOrganizationRole(ctx, orgID, userID, models.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	collection := &models.Collection{
+		OrganizationID: orgID,
+		Name:           req.Name,
+		WrappedKey:     req.WrappedKey,
+		RotationDays:   int(req.RotationDays),
+	}
+	if err := s.storage.CreateCollection(ctx, userID, collection); err != nil {
+		s.logger.Error("Failed to create collection", zap.Error(err))
+		if errors.Is(err, storage.ErrInsufficientRole) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+		}
+		return nil, status.Error(codes.AlreadyExists, "collection with this name already exists")
+	}
+
+	return &pb.CollectionResponse{
+		Collection: convertToProtoCollection(collection),
+	}, nil
+}
+
+// ListCollections возвращает коллекции организации с ключом текущего пользователя.
+func (s *Server) ListCollections(ctx context.Context, req *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	orgID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid organization ID")
+	}
+	if _, err := s.requireOrganizationRole(ctx, orgID, userID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	collections, err := s.storage.GetCollections(ctx, userID, orgID)
+	if err != nil {
+		s.logger.Error("Failed to get collections", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get collections")
+	}
+
+	protoCollections := make([]*pb.Collection, len(collections))
+	for i := range collections {
+		protoCollections[i] = convertToProtoCollection(&collections[i])
+	}
+
+	return &pb.ListCollectionsResponse{
+		Collections: protoCollections,
+	}, nil
+}
+
+// GrantCollectionKey передает участнику ключ коллекции текущей версии,
+// зашифрованный его открытым ключом. Используется после принятия приглашения.
+func (s *Server) GrantCollectionKey(ctx context.Context, req *pb.GrantCollectionKeyRequest) (*pb.GrantCollectionKeyResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	memberID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+	if len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+
+	collection, err := s.requireCollectionRole(ctx, req.CollectionId, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if int(req.KeyVersion) != collection.KeyVersion {
+		return nil, status.Error(codes.FailedPrecondition, "collection key version mismatch")
+	}
+
+	key := &models.CollectionKey{
+		CollectionID: collection.ID,
+		UserID:       memberID,
+		KeyVersion:   collection.KeyVersion,
+		WrappedKey:   req.WrappedKey,
+	}
+	if err := s.storage.SetCollectionKey(ctx, userID, key); err != nil {
+		s.logger.Error("Failed to grant collection key", zap.Error(err))
+		if errors.Is(err, storage.ErrCollectionKeyMismatch) {
+			return nil, status.Error(codes.FailedPrecondition, "user is not a member or collection key version changed")
+		}
+		return nil, status.Error(codes.Internal, "failed to grant collection key")
+	}
+
+	return &pb.GrantCollectionKeyResponse{
+		Success: true,
+	}, nil
+}
+
+// RotateCollectionKey заменяет ключ коллекции. Клиент генерирует новый ключ,
+// шифрует его для каждого участника и перешифровывает все записи коллекции.
+func (s *Server) RotateCollectionKey(ctx context.Context, req *pb.RotateCollectionKeyRequest) (*pb.CollectionResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	collection, err := s.requireCollectionRole(ctx, req.CollectionId, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]models.CollectionKey, len(req.Keys))
+	for i, key := range req.Keys {
+		memberID, err := uuid.Parse(key.UserId)
+		if err != nil || len(key.WrappedKey) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid collection key")
+		}
+		keys[i] = models.CollectionKey{
+			CollectionID: collection.ID,
+			UserID:       memberID,
+			KeyVersion:   int(req.KeyVersion),
+			WrappedKey:   key.WrappedKey,
+		}
+	}
+
+	entries := make([]models.DataEntry, len(req.Entries))
+	for i, update := range req.Entries {
+		entryID, err := uuid.Parse(update.Id)
+		if err != nil || len(update.EncryptedData) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid collection entry")
+		}
+		entries[i] = models.DataEntry{
+			ID:            entryID,
+			EncryptedData: update.EncryptedData,
+			Version:       update.Version,
+		}
+	}
+
+	err = s.storage.RotateCollectionKey(ctx, userID, collection.ID, int(req.KeyVersion), keys, entries)
+	if err != nil {
+		s.logger.Error("Failed to rotate collection key", zap.Error(err))
+		switch {
+		case errors.Is(err, storage.ErrInsufficientRole):
+			return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+		case errors.Is(err, storage.ErrCollectionKeyMismatch):
+			return nil, status.Error(codes.FailedPrecondition, "rotation must cover all current members and entries with the next key version")
+		default:
+			return nil, status.Error(codes.Internal, "failed to rotate collection key")
+		}
+	}
+
+	s.logger.Info("Collection key rotated",
+		zap.String("collection_id", collection.ID.String()),
+		zap.Int32("key_version", req.KeyVersion))
+
+	collection, err = s.storage.GetCollection(ctx, userID, collection.ID)
+	if err != nil {
+		s.logger.Error("Failed to get collection", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get collection")
+	}
+
+	return &pb.CollectionResponse{
+		Collection: convertToProtoCollection(collection),
+	}, nil
+}
+
+// SetCollectionRotationPolicy изменяет политику смены паролей коллекции. Политика
+// действует на записи с паролями, для которых не задана собственная политика.
+// Доступно администраторам и владельцам.
+func (s *Server) SetCollectionRotationPolicy(ctx context.Context, req *pb.SetCollectionRotationPolicyRequest) (*pb.CollectionResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if err := validateRotationDays(req.RotationDays); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.requireCollectionRole(ctx, req.CollectionId, userID, models.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.SetCollectionRotationDays(ctx, userID, collection.ID, int(req.RotationDays)); err != nil {
+		s.logger.Error("Failed to set collection rotation policy", zap.Error(err))
+		if errors.Is(err, storage.ErrInsufficientRole) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+		}
+		return nil, status.Error(codes.Internal, "failed to set rotation policy")
+	}
+	collection.RotationDays = int(req.RotationDays)
+
+	return &pb.CollectionResponse{
+		Collection: convertToProtoCollection(collection),
+	}, nil
+}
+
+// CreateCollectionEntry создает запись в коллекции. Данные шифруются клиентом
+// ключом коллекции. Доступно редакторам и выше.
+func (s *Server) CreateCollectionEntry(ctx context.Context, req *pb.CreateCollectionEntryRequest) (*pb.DataEntryResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.EncryptedData) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "data is required")
+	}
+	dataType := convertProtoDataType(req.Type)
+	if dataType == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid data type")
+	}
+	if err := validateRotationDays(req.RotationDays); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.requireCollectionRole(ctx, req.CollectionId, userID, models.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.DataEntry{
+		CollectionID:           &collection.ID,
+		Type:                   models.DataType(dataType),
+		Name:                   req.Name,
+		Description:            req.Description,
+		EncryptedData:          req.EncryptedData,
+		Metadata:               req.Metadata,
+		RotationDays:           int(req.RotationDays),
+		CollectionRotationDays: collection.RotationDays,
+	}
+	if err := s.storage.CreateCollectionEntry(ctx, userID, entry); err != nil {
+		s.logger.Error("Failed to create collection entry", zap.Error(err))
+		if errors.Is(err, storage.ErrInsufficientRole) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient organization role")
+		}
+		return nil, status.Error(codes.AlreadyExists, "entry with this name already exists")
+	}
+
+	return &pb.DataEntryResponse{
+		DataEntry: convertToProtoDataEntry(entry),
+	}, nil
+}
+
+// ListCollectionEntries возвращает записи коллекции. Доступно всем участникам организации.
+func (s *Server) ListCollectionEntries(ctx context.Context, req *pb.ListCollectionEntriesRequest) (*pb.ListDataResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	collection, err := s.requireCollectionRole(ctx, req.CollectionId, userID, models.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.storage.GetCollectionEntries(ctx, userID, collection.ID)
+	if err != nil {
+		s.logger.Error("Failed to get collection entries", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get collection entries")
+	}
+	entries = filterAllowedEntries(ctx, entries)
+
+	protoEntries := make([]*pb.DataEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = convertToProtoDataEntry(&entry)
+	}
+
+	return &pb.ListDataResponse{
+		DataEntries: protoEntries,
+		Total:       int32(len(entries)),
+	}, nil
+}
+
+// updateCollectionEntry обновляет запись коллекции через UpdateData.
+func (s *Server) updateCollectionEntry(ctx context.Context, userID uuid.UUID, entry *models.DataEntry) (*pb.DataEntryResponse, error) {
+	if _, err := s.requireCollectionRole(ctx, entry.CollectionID.String(), userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.UpdateCollectionEntry(ctx, userID, entry); err != nil {
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			return nil, status.Error(codes.Aborted, "data entry was modified, reload it and retry")
+		}
+		s.logger.Error("Failed to update collection entry", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update data entry")
+	}
+
+	return &pb.DataEntryResponse{
+		DataEntry: convertToProtoDataEntry(entry),
+	}, nil
+}
+
+// deleteCollectionEntry удаляет запись коллекции через DeleteData.
+func (s *Server) deleteCollectionEntry(ctx context.Context, userID uuid.UUID, entry *models.DataEntry) (*pb.DeleteDataResponse, error) {
+	if _, err := s.requireCollectionRole(ctx, entry.CollectionID.String(), userID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.DeleteCollectionEntry(ctx, userID, entry.ID); err != nil {
+		s.logger.Error("Failed to delete collection entry", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete data entry")
+	}
+
+	return &pb.DeleteDataResponse{
+		Success: true,
+	}, nil
+}
+
+// requireOrganizationRole проверяет, что пользователь состоит в организации
+// с ролью не ниже minRole, и возвращает его роль.
+func (s *Server) requireOrganizationRole(ctx context.Context, orgID, userID uuid.UUID, minRole models.OrganizationRole) (models.OrganizationRole, error) {
+	role, err := s.storage.GetOrganizationRole(ctx, orgID, userID)
+	if err != nil {
+		return "", status.Error(codes.NotFound, "organization not found")
+	}
+	if !role.AtLeast(minRole) {
+		return "", status.Error(codes.PermissionDenied, "insufficient organization role")
+	}
+	return role, nil
+}
+
+// requireCollectionRole получает коллекцию и проверяет роль пользователя в ее организации.
+func (s *Server) requireCollectionRole(ctx context.Context, id string, userID uuid.UUID, minRole models.OrganizationRole) (*models.Collection, error) {
+	collectionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid collection ID")
+	}
+
+	collection, err := s.storage.GetCollection(ctx, userID, collectionID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	if _, err := s.requireOrganizationRole(ctx, collection.OrganizationID, userID, minRole); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// organizationMemberError преобразует ошибку изменения участника в gRPC статус.
+func organizationMemberError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrInsufficientRole):
+		return status.Error(codes.PermissionDenied, "insufficient organization role")
+	case errors.Is(err, storage.ErrLastOwner):
+		return status.Error(codes.FailedPrecondition, "organization must keep at least one owner")
+	default:
+		return status.Error(codes.NotFound, "organization member not found")
+	}
+}
+
+// convertProtoOrganizationRole преобразует proto роль в модель.
+func convertProtoOrganizationRole(role pb.OrganizationRole) models.OrganizationRole {
+	switch role {
+	case pb.OrganizationRole_ORGANIZATION_ROLE_OWNER:
+		return models.RoleOwner
+	case pb.OrganizationRole_ORGANIZATION_ROLE_ADMIN:
+		return models.RoleAdmin
+	case pb.OrganizationRole_ORGANIZATION_ROLE_EDITOR:
+		return models.RoleEditor
+	case pb.OrganizationRole_ORGANIZATION_ROLE_VIEWER:
+		return models.RoleViewer
+	default:
+		return ""
+	}
+}
+
+// convertToProtoOrganizationRole преобразует роль в proto.
+func convertToProtoOrganizationRole(role models.OrganizationRole) pb.OrganizationRole {
+	switch role {
+	case models.RoleOwner:
+		return pb.OrganizationRole_ORGANIZATION_ROLE_OWNER
+	case models.RoleAdmin:
+		return pb.OrganizationRole_ORGANIZATION_ROLE_ADMIN
+	case models.RoleEditor:
+		return pb.OrganizationRole_ORGANIZATION_ROLE_EDITOR
+	case models.RoleViewer:
+		return pb.OrganizationRole_ORGANIZATION_ROLE_VIEWER
+	default:
+		return pb.OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+	}
+}
+
+// convertToProtoOrganization преобразует модель Organization в proto.
+func convertToProtoOrganization(org *models.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:        org.ID.String(),
+		Name:      org.Name,
+		Role:      convertToProtoOrganizationRole(org.Role),
+		CreatedAt: timestamppb.New(org.CreatedAt),
+	}
+}
+
+// convertToProtoOrganizationMember преобразует модель OrganizationMember в proto.
+func convertToProtoOrganizationMember(member *models.OrganizationMember) *pb.OrganizationMember {
+	return &pb.OrganizationMember{
+		UserId:    member.UserID.String(),
+		Username:  member.Username,
+		Role:      convertToProtoOrganizationRole(member.Role),
+		CreatedAt: timestamppb.New(member.CreatedAt),
+	}
+}
+
+// convertToProtoInvitation преобразует модель OrganizationInvitation в proto.
+func convertToProtoInvitation(invitation *models.OrganizationInvitation) *pb.OrganizationInvitation {
+	return &pb.OrganizationInvitation{
+		Id:               invitation.ID.String(),
+		OrganizationId:   invitation.OrganizationID.String(),
+		OrganizationName: invitation.OrganizationName,
+		Username:         invitation.InviteeUsername,
+		InvitedBy:        invitation.InvitedByName,
+		Role:             convertToProtoOrganizationRole(invitation.Role),
+		CreatedAt:        timestamppb.New(invitation.CreatedAt),
+		ExpiresAt:        timestamppb.New(invitation.ExpiresAt),
+	}
+}
+
+// convertToProtoCollection преобразует модель Collection в proto.
+func convertToProtoCollection(collection *models.Collection) *pb.Collection {
+	return &pb.Collection{
+		Id:               collection.ID.String(),
+		OrganizationId:   collection.OrganizationID.String(),
+		Name:             collection.Name,
+		KeyVersion:       int32(collection.KeyVersion),
+		RotationRequired: collection.RotationRequired,
+		RotationDays:     int32(collection.RotationDays),
+		WrappedKey:       collection.WrappedKey,
+		CreatedAt:        timestamppb.New(collection.CreatedAt),
+	}
+}
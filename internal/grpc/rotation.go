@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateRotationDays проверяет период смены пароля политики; 0 означает отсутствие политики.
+func validateRotationDays(days int32) error {
+	if days < 0 || days > models.MaxRotationDays {
+		return status.Errorf(codes.InvalidArgument, "rotation days must be 0-%d", models.MaxRotationDays)
+	}
+	return nil
+}
+
+// ListDueForRotation возвращает записи пользователя и коллекций его организаций,
+// пароли которых пора сменить по политике записи или коллекции. С within_days
+// включаются и записи, срок смены которых наступит в течение этого числа дней.
+func (s *Server) ListDueForRotation(ctx context.Context, req *pb.ListDueForRotationRequest) (*pb.ListDataResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if err := validateRotationDays(req.WithinDays); err != nil {
+		return nil, err
+	}
+
+	dueBefore := time.Now().AddDate(0, 0, int(req.WithinDays))
+	entries, err := s.storage.GetEntriesDueForRotation(ctx, userID, dueBefore)
+	if err != nil {
+		s.logger.Error("Failed to get entries due for rotation", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get entries due for rotation")
+	}
+	entries = filterAllowedEntries(ctx, entries)
+
+	protoEntries := make([]*pb.DataEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = convertToProtoDataEntry(&entry)
+	}
+
+	return &pb.ListDataResponse{
+		DataEntries: protoEntries,
+		Total:       int32(len(entries)),
+	}, nil
+}
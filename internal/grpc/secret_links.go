@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Ограничения одноразовых ссылок на секреты.
+const (
+	defaultSecretLinkTTL = 24 * time.Hour
+	maxSecretLinkTTL     = 30 * 24 * time.Hour
+	maxSecretLinkViews   = 100
+	maxSecretLinkSize    = 64 << 10
+)
+
+// SecretLinkPurgeInterval период удаления истекших ссылок на секреты.
+const SecretLinkPurgeInterval = 10 * time.Minute
+
+// secretLinkPath возвращает путь публичной страницы ссылки.
+func secretLinkPath(id uuid.UUID) string {
+	return "/s/" + id.String()
+}
+
+// CreateSecretLink сохраняет зашифрованный клиентом секрет и возвращает путь
+// одноразовой ссылки. Ключ расшифровки клиент добавляет во фрагмент ссылки,
+// поэтому сервер не может прочитать секрет.
+func (s *Server) CreateSecretLink(ctx context.Context, req *pb.CreateSecretLinkRequest) (*pb.CreateSecretLinkResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(req.EncryptedData) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "encrypted data is required")
+	}
+	if len(req.EncryptedData) > maxSecretLinkSize {
+		return nil, status.Error(codes.InvalidArgument, "secret is too large")
+	}
+
+	maxViews := int(req.MaxViews)
+	if maxViews == 0 {
+		maxViews = 1
+	}
+	if maxViews < 1 || maxViews > maxSecretLinkViews {
+		return nil, status.Error(codes.InvalidArgument, "max views must be between 1 and 100")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(defaultSecretLinkTTL)
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.AsTime()
+	}
+	if !expiresAt.After(now) {
+		return nil, status.Error(codes.InvalidArgument, "expiration must be in the future")
+	}
+	if expiresAt.After(now.Add(maxSecretLinkTTL)) {
+		return nil, status.Error(codes.InvalidArgument, "expiration must be within 30 days")
+	}
+
+	link := &models.SecretLink{
+		UserID:        userID,
+		EncryptedData: req.EncryptedData,
+		MaxViews:      maxViews,
+		ExpiresAt:     expiresAt,
+	}
+	if err := s.storage.CreateSecretLink(ctx, link); err != nil {
+		s.logger.Error("Failed to create secret link", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create secret link")
+	}
+
+	s.logger.Info("Secret link created",
+		zap.String("user_id", userID.String()),
+		zap.String("link_id", link.ID.String()),
+		zap.Int("max_views", link.MaxViews),
+		zap.Time("expires_at", link.ExpiresAt))
+
+	return &pb.CreateSecretLinkResponse{
+		Link: &pb.SecretLink{
+			Id:        link.ID.String(),
+			Path:      secretLinkPath(link.ID),
+			MaxViews:  int32(link.MaxViews),
+			ExpiresAt: timestamppb.New(link.ExpiresAt),
+			CreatedAt: timestamppb.New(link.CreatedAt),
+		},
+	}, nil
+}
+
+// PurgeExpiredSecretLinks периодически удаляет истекшие ссылки на секреты
+// до отмены контекста.
+func (s *Server) PurgeExpiredSecretLinks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.storage.DeleteExpiredSecretLinks(ctx, time.Now())
+			if err != nil {
+				s.logger.Error("Failed to purge expired secret links", zap.Error(err))
+				continue
+			}
+			if purged > 0 {
+				s.logger.Info("Expired secret links purged", zap.Int64("count", purged))
+			}
+		}
+	}
+}
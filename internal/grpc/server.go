@@ -3,17 +3,25 @@ package grpc
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"time"
 
 	"github.com/GophKeeper/internal/auth"
 	"github.com/GophKeeper/internal/crypto"
+	"github.com/GophKeeper/internal/middleware"
 	"github.com/GophKeeper/internal/models"
 	"github.com/GophKeeper/internal/otp"
+	"github.com/GophKeeper/internal/payload"
 	"github.com/GophKeeper/internal/storage"
 	pb "github.com/GophKeeper/proto/gen/proto"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -33,8 +41,17 @@ type Server struct {
 	cryptoService *crypto.Service
 	otpService    *otp.Service
 
+	// Незавершенные рукопожатия SRP
+	srpLogins *srpLoginStore
+	// Секрет соли рукопожатий для неизвестных пользователей и аккаунтов без SRP,
+	// постоянный, чтобы соль не менялась после перезапуска
+	srpDecoySecret []byte
+
 	// Валидация
 	validator *validator.Validate
+
+	// Проверка открытого содержимого записей, nil при сквозном шифровании
+	payloadValidator *payload.Validator
 }
 
 // NewServer создает новый gRPC сервер.
@@ -46,29 +63,110 @@ func NewServer(
 	logger *zap.Logger,
 ) *Server {
 	return &Server{
-		storage:       storage,
-		authService:   authService,
-		cryptoService: cryptoService,
-		otpService:    otpService,
-		validator:     validator.New(),
-		logger:        logger,
+		storage:        storage,
+		authService:    authService,
+		cryptoService:  cryptoService,
+		otpService:     otpService,
+		srpLogins:      newSRPLoginStore(),
+		srpDecoySecret: cryptoService.DeriveSecret("srp-decoy-salt"),
+		validator:      validator.New(),
+		logger:         logger,
+	}
+}
+
+// SetTypedPayloads включает режим типизированного содержимого для развертываний
+// без сквозного шифрования: сервер разбирает содержимое записей как JSON
+// структуры пакета models и отклоняет некорректные поля.
+func (s *Server) SetTypedPayloads(enabled bool) {
+	if enabled {
+		s.payloadValidator = payload.NewValidator()
+	} else {
+		s.payloadValidator = nil
 	}
 }
 
-// NewGRPCServer создает новый gRPC сервер.
-func NewGRPCServer(server *Server) *grpc.Server {
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(AuthInterceptor(server.authService, server.logger)),
+// validatePayload проверяет содержимое записи в режиме типизированного содержимого
+// и возвращает InvalidArgument с описанием ошибок по полям.
+func (s *Server) validatePayload(dataType models.DataType, data []byte) error {
+	if s.payloadValidator == nil {
+		return nil
+	}
+	return s.payloadError(s.payloadValidator.Validate(dataType, data))
+}
+
+// validatePayloadUpdate проверяет новое содержимое изменяемой записи entry.
+// Правила, зависящие от текущей даты, применяются только к измененным полям,
+// поэтому запись с истекшей картой можно изменить, не меняя срок действия.
+func (s *Server) validatePayloadUpdate(entry *models.DataEntry, data []byte) error {
+	if s.payloadValidator == nil {
+		return nil
+	}
+
+	// HTTP обработчики сохраняют содержимое зашифрованным на сервере
+	previous := entry.EncryptedData
+	if !json.Valid(previous) {
+		if decrypted, err := s.cryptoService.DecryptLargeData(previous); err == nil {
+			previous = decrypted
+		}
+	}
+	return s.payloadError(s.payloadValidator.ValidateUpdate(entry.Type, previous, data))
+}
+
+// payloadError преобразует результат проверки содержимого в InvalidArgument
+// с описанием ошибок по полям.
+func (s *Server) payloadError(err error) error {
+	var verr *payload.ValidationError
+	if !errors.As(err, &verr) {
+		if err != nil {
+			s.logger.Error("Failed to validate payload", zap.Error(err))
+			return status.Error(codes.Internal, "failed to validate payload")
+		}
+		return nil
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range verr.Fields {
+		name := "encrypted_data"
+		if field.Field != "" {
+			name += "." + field.Field
+		}
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       name,
+			Description: field.Description,
+		})
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, "invalid payload").WithDetails(badRequest)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, verr.Error())
+	}
+	return st.Err()
+}
+
+// NewGRPCServer создает новый gRPC сервер. Дополнительные опции, например
+// учетные данные TLS, передаются в opts.
+func NewGRPCServer(server *Server, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.UnaryInterceptor(AuthInterceptor(server.authService, server.storage, server.logger)),
 	)
+	grpcServer := grpc.NewServer(opts...)
 	pb.RegisterGophKeeperServer(grpcServer, server)
 	return grpcServer
 }
 
-// validateAuthRequest валидирует запросы аутентификации
-func (s *Server) validateAuthRequest(username, password string) error {
+// validateUsername валидирует имя пользователя при регистрации
+func (s *Server) validateUsername(username string) error {
 	if username == "" || len(username) < 3 {
 		return status.Error(codes.InvalidArgument, "username must be at least 3 characters")
 	}
+	return nil
+}
+
+// validateAuthRequest валидирует запросы аутентификации
+func (s *Server) validateAuthRequest(username, password string) error {
+	if err := s.validateUsername(username); err != nil {
+		return err
+	}
 	if password == "" || len(password) < 6 {
 		return status.Error(codes.InvalidArgument, "password must be at least 6 characters")
 	}
@@ -96,22 +194,35 @@ func (s *Server) validateTokenRequest(token string) error {
 
 // Register регистрирует нового пользователя.
 func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.AuthResponse, error) {
-	// Валидируем запрос
-	if err := s.validateAuthRequest(req.Username, req.Password); err != nil {
-		return nil, err
+	// Создаем пользователя
+	user := &models.User{
+		Username:        req.Username,
+		WrappedVaultKey: req.WrappedVaultKey,
 	}
 
-	// Хешируем пароль
-	hashedPassword, err := s.authService.HashPassword(req.Password)
-	if err != nil {
-		s.logger.Error("Failed to hash password", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to process password")
-	}
+	if len(req.SrpVerifier) > 0 {
+		// Регистрация по SRP: пароль на сервер не передается
+		if err := s.validateUsername(req.Username); err != nil {
+			return nil, err
+		}
+		if err := validateSRPVerifier(req.SrpSalt, req.SrpVerifier); err != nil {
+			return nil, err
+		}
+		user.SRPSalt = req.SrpSalt
+		user.SRPVerifier = req.SrpVerifier
+	} else {
+		// Валидируем запрос
+		if err := s.validateAuthRequest(req.Username, req.Password); err != nil {
+			return nil, err
+		}
 
-	// Создаем пользователя
-	user := &models.User{
-		Username:     req.Username,
-		PasswordHash: hashedPassword,
+		// Хешируем пароль
+		hashedPassword, err := s.authService.HashPassword(req.Password)
+		if err != nil {
+			s.logger.Error("Failed to hash password", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to process password")
+		}
+		user.PasswordHash = hashedPassword
 	}
 
 	if err := s.storage.CreateUser(ctx, user); err != nil {
@@ -122,23 +233,7 @@ func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.Aut
 		return nil, status.Error(codes.Internal, "failed to create user")
 	}
 
-	// Генерируем токен
-	token, expiresAt, err := s.authService.GenerateToken(user.ID, user.Username)
-	if err != nil {
-		s.logger.Error("Failed to generate token", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to generate token")
-	}
-
-	return &pb.AuthResponse{
-		Token:     token,
-		ExpiresAt: timestamppb.New(expiresAt),
-		User: &pb.User{
-			Id:        user.ID.String(),
-			Username:  user.Username,
-			CreatedAt: timestamppb.New(user.CreatedAt),
-			UpdatedAt: timestamppb.New(user.UpdatedAt),
-		},
-	}, nil
+	return s.startSession(ctx, user)
 }
 
 // Login аутентифицирует пользователя.
@@ -161,23 +256,14 @@ func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthRespo
 		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 	}
 
-	// Генерируем токен
-	token, expiresAt, err := s.authService.GenerateToken(user.ID, user.Username)
+	resp, err := s.startSession(ctx, user)
 	if err != nil {
-		s.logger.Error("Failed to generate token", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to generate token")
+		return nil, err
 	}
-
-	return &pb.AuthResponse{
-		Token:     token,
-		ExpiresAt: timestamppb.New(expiresAt),
-		User: &pb.User{
-			Id:        user.ID.String(),
-			Username:  user.Username,
-			CreatedAt: timestamppb.New(user.CreatedAt),
-			UpdatedAt: timestamppb.New(user.UpdatedAt),
-		},
-	}, nil
+	// Вход по паролю возможен только для аккаунтов без SRP: клиент переводит их на SRP.
+	// Признак сообщается только после проверки пароля, чтобы не раскрывать состояние аккаунта.
+	resp.SrpUpgradeRequired = !user.UsesSRP()
+	return resp, nil
 }
 
 // RefreshToken обновляет JWT токен.
@@ -199,6 +285,16 @@ func (s *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest)
 		return nil, status.Error(codes.Internal, "failed to validate token")
 	}
 
+	// Отозванную сессию обновить нельзя
+	active, err := s.storage.IsSessionActive(ctx, claims.UserID, claims.SessionID)
+	if err != nil {
+		s.logger.Error("Failed to check session", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to check session")
+	}
+	if !active {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
 	// Получаем пользователя
 	user, err := s.storage.GetUserByID(ctx, claims.UserID)
 	if err != nil {
@@ -206,6 +302,263 @@ func (s *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest)
 		return nil, status.Error(codes.Internal, "failed to get user")
 	}
 
+	return newAuthResponse(user, token, expiresAt), nil
+}
+
+// ChangePassword меняет пароль пользователя и отзывает все остальные его сессии.
+// Для аккаунтов с SRP вместо паролей передаются новые соль и верификатор,
+// а знание текущего пароля подтверждается свежим доказательством SRP.
+// Если передан перешифрованный ключ хранилища, он сохраняется в той же транзакции,
+// поэтому записи не нужно загружать заново.
+func (s *Server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.AuthResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	sessionID, _ := ctx.Value(SessionIDKey).(uuid.UUID)
+
+	srpUpdate := len(req.SrpVerifier) > 0
+	if srpUpdate {
+		if err := validateSRPVerifier(req.SrpSalt, req.SrpVerifier); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if user.UsesSRP() {
+		// Пароль сервер не знает, поэтому требуем свежее доказательство SRP
+		if !srpUpdate {
+			return nil, status.Error(codes.InvalidArgument, "srp verifier is required")
+		}
+		// Свежего токена недостаточно: иначе похищенный токен позволил бы сменить пароль
+		if req.SrpLoginId == "" || len(req.SrpClientProof) == 0 {
+			return nil, status.Error(codes.PermissionDenied, "current password confirmation required")
+		}
+		if err := s.verifySRPProof(user, req.SrpLoginId, req.SrpClientProof); err != nil {
+			return nil, err
+		}
+	} else {
+		if req.OldPassword == "" {
+			return nil, status.Error(codes.InvalidArgument, "old password is required")
+		}
+		if !srpUpdate && len(req.NewPassword) < 6 {
+			return nil, status.Error(codes.InvalidArgument, "password must be at least 6 characters")
+		}
+		if !s.authService.CheckPassword(req.OldPassword, user.PasswordHash) {
+			s.logger.Warn("Invalid old password", zap.String("username", user.Username))
+			return nil, status.Error(codes.PermissionDenied, "invalid old password")
+		}
+	}
+
+	if srpUpdate {
+		// Переход на SRP отключает вход по паролю
+		user.PasswordHash = ""
+		user.SRPSalt = req.SrpSalt
+		user.SRPVerifier = req.SrpVerifier
+	} else {
+		hashedPassword, err := s.authService.HashPassword(req.NewPassword)
+		if err != nil {
+			s.logger.Error("Failed to hash password", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to process password")
+		}
+		user.PasswordHash = hashedPassword
+	}
+	if len(req.WrappedVaultKey) > 0 {
+		user.WrappedVaultKey = req.WrappedVaultKey
+	}
+
+	if err := s.storage.UpdateUserPassword(ctx, user, sessionID); err != nil {
+		s.logger.Error("Failed to update password", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to change password")
+	}
+
+	// Выдаем новый токен в рамках текущей сессии
+	token, expiresAt, err := s.authService.GenerateSessionToken(user.ID, user.Username, sessionID)
+	if err != nil {
+		s.logger.Error("Failed to generate token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+
+	s.logger.Info("Password changed", zap.String("username", user.Username))
+
+	return newAuthResponse(user, token, expiresAt), nil
+}
+
+// SetVaultKey сохраняет ключ хранилища аккаунта, зарегистрированного без него.
+// Сервер хранит ключ зашифрованным на клиенте и не может его прочитать; заменить
+// заданный ключ можно только вместе с паролем, иначе похищенный токен позволил бы
+// подменить ключ, которым клиент шифрует новые данные.
+func (s *Server) SetVaultKey(ctx context.Context, req *pb.SetVaultKeyRequest) (*pb.SetVaultKeyResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+	if len(req.WrappedVaultKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped vault key is required")
+	}
+
+	if err := s.storage.SetUserVaultKey(ctx, userID, req.WrappedVaultKey); err != nil {
+		if errors.Is(err, storage.ErrVaultKeyExists) {
+			return nil, status.Error(codes.FailedPrecondition, "vault key is already set")
+		}
+		s.logger.Error("Failed to set vault key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to set vault key")
+	}
+
+	return &pb.SetVaultKeyResponse{
+		Success: true,
+	}, nil
+}
+
+// DeleteAccount удаляет аккаунт пользователя после повторной проверки пароля
+// или свежего доказательства SRP. Записи, удаленные записи и сессии удаляются каскадно.
+func (s *Server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	user, err := s.reauthenticate(ctx, req.Password, req.SrpLoginId, req.SrpClientProof)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.DeleteUser(ctx, user.ID); err != nil {
+		if errors.Is(err, storage.ErrLastOwner) {
+			return nil, status.Error(codes.FailedPrecondition,
+				"account is the last owner of an organization with other members, transfer ownership first")
+		}
+		s.logger.Error("Failed to delete user", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete account")
+	}
+
+	s.logger.Info("Account deleted", zap.String("username", user.Username))
+
+	return &pb.DeleteAccountResponse{
+		Success: true,
+	}, nil
+}
+
+// ExportAccount выгружает все данные аккаунта в архив, зашифрованный ключом,
+// полученным из пароля пользователя. Сервер не знает пароль аккаунтов с SRP,
+// поэтому их данные возвращаются без шифрования архива и шифруются клиентом.
+func (s *Server) ExportAccount(ctx context.Context, req *pb.ExportAccountRequest) (*pb.ExportAccountResponse, error) {
+	user, err := s.reauthenticate(ctx, req.Password, req.SrpLoginId, req.SrpClientProof)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.storage.GetDataEntries(ctx, user.ID, nil)
+	if err != nil {
+		s.logger.Error("Failed to get data entries", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to export account")
+	}
+
+	deletedIDs, err := s.storage.GetDeletedEntriesAfter(ctx, user.ID, time.Time{})
+	if err != nil {
+		s.logger.Error("Failed to get deleted entries", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to export account")
+	}
+
+	attachments, err := s.storage.GetUserAttachments(ctx, user.ID)
+	if err != nil {
+		s.logger.Error("Failed to get attachments", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to export account")
+	}
+
+	exportedAt := time.Now()
+	export := &models.AccountExport{
+		Version:         1,
+		ExportedAt:      exportedAt,
+		Username:        user.Username,
+		CreatedAt:       user.CreatedAt,
+		WrappedVaultKey: user.WrappedVaultKey,
+		Entries:         entries,
+		DeletedIDs:      deletedIDs,
+		Attachments:     attachments,
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		s.logger.Error("Failed to marshal export", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to export account")
+	}
+
+	if user.UsesSRP() {
+		return &pb.ExportAccountResponse{
+			Export:     data,
+			ExportedAt: timestamppb.New(exportedAt),
+		}, nil
+	}
+
+	archive, err := crypto.EncryptWithPassword(data, req.Password)
+	if err != nil {
+		s.logger.Error("Failed to encrypt export", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to export account")
+	}
+
+	return &pb.ExportAccountResponse{
+		Archive:    archive,
+		ExportedAt: timestamppb.New(exportedAt),
+	}, nil
+}
+
+// reauthenticate повторно проверяет пароль текущего пользователя перед
+// необратимыми операциями. Аккаунты с SRP подтверждают пароль свежим
+// доказательством SRP, пароль для них не передается.
+func (s *Server) reauthenticate(ctx context.Context, password, srpLoginID string, srpProof []byte) (*models.User, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	user, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	if user.UsesSRP() {
+		if err := s.verifySRPProof(user, srpLoginID, srpProof); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	if password == "" {
+		return nil, status.Error(codes.InvalidArgument, "password is required")
+	}
+
+	if !s.authService.CheckPassword(password, user.PasswordHash) {
+		s.logger.Warn("Re-authentication failed", zap.String("username", user.Username))
+		return nil, status.Error(codes.PermissionDenied, "invalid password")
+	}
+
+	return user, nil
+}
+
+// startSession создает новую сессию пользователя и выдает для нее токен.
+func (s *Server) startSession(ctx context.Context, user *models.User) (*pb.AuthResponse, error) {
+	session := &models.Session{UserID: user.ID}
+	if err := s.storage.CreateSession(ctx, session); err != nil {
+		s.logger.Error("Failed to create session", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create session")
+	}
+
+	token, expiresAt, err := s.authService.GenerateSessionToken(user.ID, user.Username, session.ID)
+	if err != nil {
+		s.logger.Error("Failed to generate token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate token")
+	}
+
+	return newAuthResponse(user, token, expiresAt), nil
+}
+
+// newAuthResponse формирует ответ аутентификации.
+func newAuthResponse(user *models.User, token string, expiresAt time.Time) *pb.AuthResponse {
 	return &pb.AuthResponse{
 		Token:     token,
 		ExpiresAt: timestamppb.New(expiresAt),
@@ -215,11 +568,155 @@ func (s *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest)
 			CreatedAt: timestamppb.New(user.CreatedAt),
 			UpdatedAt: timestamppb.New(user.UpdatedAt),
 		},
+		WrappedVaultKey:   user.WrappedVaultKey,
+		WrappedPrivateKey: user.WrappedPrivateKey,
+	}
+}
+
+// Ограничения срока действия персональных токенов доступа.
+const (
+	defaultAccessTokenTTL = 30 * 24 * time.Hour
+	maxAccessTokenTTL     = 365 * 24 * time.Hour
+)
+
+// CreateAccessToken выпускает персональный токен доступа для автоматизации.
+// Сам токен возвращается только в ответе, на сервере хранится его хеш.
+func (s *Server) CreateAccessToken(ctx context.Context, req *pb.CreateAccessTokenRequest) (*pb.CreateAccessTokenResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	now := time.Now()
+	createReq := models.CreateAccessTokenRequest{
+		Name:      req.Name,
+		ReadOnly:  req.ReadOnly,
+		ExpiresAt: now.Add(defaultAccessTokenTTL),
+	}
+	if req.ExpiresAt != nil {
+		createReq.ExpiresAt = req.ExpiresAt.AsTime()
+	}
+	for _, protoType := range req.DataTypes {
+		dataType := convertProtoDataType(protoType)
+		if dataType == "" {
+			return nil, status.Error(codes.InvalidArgument, "invalid data type")
+		}
+		createReq.DataTypes = append(createReq.DataTypes, models.DataType(dataType))
+	}
+
+	if err := s.validator.Struct(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if !createReq.ExpiresAt.After(now) {
+		return nil, status.Error(codes.InvalidArgument, "expiration must be in the future")
+	}
+	if createReq.ExpiresAt.After(now.Add(maxAccessTokenTTL)) {
+		return nil, status.Error(codes.InvalidArgument, "expiration must be within one year")
+	}
+
+	accessToken := &models.AccessToken{
+		UserID:    userID,
+		Name:      createReq.Name,
+		ReadOnly:  createReq.ReadOnly,
+		DataTypes: createReq.DataTypes,
+		ExpiresAt: createReq.ExpiresAt,
+	}
+
+	// Токен, привязанный к сертификату, предъявляется только этим сертификатом
+	var token string
+	if len(req.ClientCertificate) > 0 {
+		fingerprint, err := clientCertificateFingerprint(req.ClientCertificate)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid client certificate")
+		}
+		accessToken.CertificateFingerprint = fingerprint
+	} else {
+		var tokenHash []byte
+		var err error
+		token, tokenHash, err = auth.GenerateAccessToken()
+		if err != nil {
+			s.logger.Error("Failed to generate access token", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to create access token")
+		}
+		accessToken.TokenHash = tokenHash
+	}
+
+	if err := s.storage.CreateAccessToken(ctx, accessToken); err != nil {
+		if err.Error() == "access token already exists" {
+			return nil, status.Error(codes.AlreadyExists, "client certificate is already bound to a token")
+		}
+		s.logger.Error("Failed to create access token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create access token")
+	}
+
+	s.logger.Info("Access token created",
+		zap.String("user_id", userID.String()),
+		zap.String("token_id", accessToken.ID.String()))
+
+	return &pb.CreateAccessTokenResponse{
+		Token:       token,
+		AccessToken: convertToProtoAccessToken(accessToken),
+	}, nil
+}
+
+// ListAccessTokens возвращает неотозванные персональные токены пользователя.
+func (s *Server) ListAccessTokens(ctx context.Context, req *pb.ListAccessTokensRequest) (*pb.ListAccessTokensResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	tokens, err := s.storage.ListAccessTokens(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to list access tokens", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list access tokens")
+	}
+
+	protoTokens := make([]*pb.AccessToken, len(tokens))
+	for i := range tokens {
+		protoTokens[i] = convertToProtoAccessToken(&tokens[i])
+	}
+
+	return &pb.ListAccessTokensResponse{
+		AccessTokens: protoTokens,
+	}, nil
+}
+
+// RevokeAccessToken отзывает персональный токен доступа.
+func (s *Server) RevokeAccessToken(ctx context.Context, req *pb.RevokeAccessTokenRequest) (*pb.RevokeAccessTokenResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	tokenID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid token ID")
+	}
+
+	if err := s.storage.RevokeAccessToken(ctx, userID, tokenID); err != nil {
+		s.logger.Error("Failed to revoke access token", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "access token not found")
+	}
+
+	s.logger.Info("Access token revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("token_id", tokenID.String()))
+
+	return &pb.RevokeAccessTokenResponse{
+		Success: true,
 	}, nil
 }
 
 // CreateData создает новую запись данных.
 func (s *Server) CreateData(ctx context.Context, req *pb.CreateDataRequest) (*pb.DataEntryResponse, error) {
+	return s.createData(ctx, req, req.EncryptedData)
+}
+
+// createData создает запись данных. plaintext - открытое содержимое записи
+// для проверки в режиме типизированного содержимого; HTTP обработчики
+// передают его до шифрования на сервере.
+func (s *Server) createData(ctx context.Context, req *pb.CreateDataRequest, plaintext []byte) (*pb.DataEntryResponse, error) {
 	// Получаем пользователя из контекста (добавляется middleware)
 	userID, ok := getUserIDFromContext(ctx)
 	if !ok {
@@ -239,6 +736,15 @@ func (s *Server) CreateData(ctx context.Context, req *pb.CreateDataRequest) (*pb
 	if dataType == "" {
 		return nil, status.Error(codes.InvalidArgument, "invalid data type")
 	}
+	if !allowsDataType(ctx, models.DataType(dataType)) {
+		return nil, status.Error(codes.PermissionDenied, "insufficient token scope")
+	}
+	if err := s.validatePayload(models.DataType(dataType), plaintext); err != nil {
+		return nil, err
+	}
+	if err := validateRotationDays(req.RotationDays); err != nil {
+		return nil, err
+	}
 
 	// Создаем запись
 	entry := &models.DataEntry{
@@ -248,6 +754,7 @@ func (s *Server) CreateData(ctx context.Context, req *pb.CreateDataRequest) (*pb
 		Description:   req.Description,
 		EncryptedData: req.EncryptedData,
 		Metadata:      req.Metadata,
+		RotationDays:  int(req.RotationDays),
 	}
 
 	if err := s.storage.CreateDataEntry(ctx, entry); err != nil {
@@ -275,11 +782,14 @@ func (s *Server) GetData(ctx context.Context, req *pb.GetDataRequest) (*pb.DataE
 		return nil, status.Error(codes.InvalidArgument, "invalid entry ID")
 	}
 
-	entry, err := s.storage.GetDataEntry(ctx, userID, entryID)
+	entry, err := s.getAccessibleEntry(ctx, userID, entryID)
 	if err != nil {
 		s.logger.Error("Failed to get data entry", zap.Error(err))
 		return nil, status.Error(codes.NotFound, "data entry not found")
 	}
+	if !allowsDataType(ctx, entry.Type) {
+		return nil, status.Error(codes.NotFound, "data entry not found")
+	}
 
 	return &pb.DataEntryResponse{
 		DataEntry: convertToProtoDataEntry(entry),
@@ -307,6 +817,7 @@ func (s *Server) ListData(ctx context.Context, req *pb.ListDataRequest) (*pb.Lis
 		s.logger.Error("Failed to get data entries", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to get data entries")
 	}
+	entries = filterAllowedEntries(ctx, entries)
 
 	protoEntries := make([]*pb.DataEntry, len(entries))
 	for i, entry := range entries {
@@ -321,6 +832,11 @@ func (s *Server) ListData(ctx context.Context, req *pb.ListDataRequest) (*pb.Lis
 
 // UpdateData обновляет запись данных.
 func (s *Server) UpdateData(ctx context.Context, req *pb.UpdateDataRequest) (*pb.DataEntryResponse, error) {
+	return s.updateData(ctx, req, req.EncryptedData)
+}
+
+// updateData обновляет запись данных, plaintext передается как в createData.
+func (s *Server) updateData(ctx context.Context, req *pb.UpdateDataRequest, plaintext []byte) (*pb.DataEntryResponse, error) {
 	userID, ok := getUserIDFromContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
@@ -331,11 +847,23 @@ func (s *Server) UpdateData(ctx context.Context, req *pb.UpdateDataRequest) (*pb
 		return nil, status.Error(codes.InvalidArgument, "invalid entry ID")
 	}
 
-	// Получаем существующую запись
-	entry, err := s.storage.GetDataEntry(ctx, userID, entryID)
-	if err != nil {
+	// Получаем существующую запись, в том числе предоставленную другим пользователем
+	entry, err := s.getAccessibleEntry(ctx, userID, entryID)
+	if err != nil || !allowsDataType(ctx, entry.Type) {
 		return nil, status.Error(codes.NotFound, "data entry not found")
 	}
+	if entry.Share != nil && !entry.Share.CanWrite() {
+		return nil, status.Error(codes.PermissionDenied, "entry is shared read-only")
+	}
+	if err := validateRotationDays(req.GetRotationDays()); err != nil {
+		return nil, err
+	}
+	// Записи коллекций всегда зашифрованы ключом коллекции
+	if entry.CollectionID == nil {
+		if err := s.validatePayloadUpdate(entry, plaintext); err != nil {
+			return nil, err
+		}
+	}
 
 	// Обновляем поля
 	entry.Name = req.Name
@@ -343,8 +871,23 @@ func (s *Server) UpdateData(ctx context.Context, req *pb.UpdateDataRequest) (*pb
 	entry.EncryptedData = req.EncryptedData
 	entry.Metadata = req.Metadata
 	entry.Version = req.Version
+	// Клиенты, не знающие о политиках смены паролей, не передают период
+	if req.RotationDays != nil {
+		entry.RotationDays = int(*req.RotationDays)
+	}
+	// Сервер не видит содержимое записи, о смене пароля сообщает клиент
+	if req.PasswordChanged {
+		entry.PasswordChangedAt = time.Now()
+	}
+
+	if entry.CollectionID != nil {
+		return s.updateCollectionEntry(ctx, userID, entry)
+	}
 
 	if err := s.storage.UpdateDataEntry(ctx, entry); err != nil {
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			return nil, status.Error(codes.Aborted, "data entry was modified, reload it and retry")
+		}
 		s.logger.Error("Failed to update data entry", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to update data entry")
 	}
@@ -366,6 +909,22 @@ func (s *Server) DeleteData(ctx context.Context, req *pb.DeleteDataRequest) (*pb
 		return nil, status.Error(codes.InvalidArgument, "invalid entry ID")
 	}
 
+	// Записи коллекций удаляются с проверкой роли в организации
+	if entry, err := s.storage.GetCollectionEntry(ctx, userID, entryID); err == nil {
+		if !allowsDataType(ctx, entry.Type) {
+			return nil, status.Error(codes.NotFound, "data entry not found")
+		}
+		return s.deleteCollectionEntry(ctx, userID, entry)
+	}
+
+	// Токен с ограниченным набором типов может удалять только доступные ему записи
+	if accessToken, ok := getAccessTokenFromContext(ctx); ok && len(accessToken.DataTypes) > 0 {
+		entry, err := s.storage.GetDataEntry(ctx, userID, entryID)
+		if err != nil || !accessToken.AllowsType(entry.Type) {
+			return nil, status.Error(codes.NotFound, "data entry not found")
+		}
+	}
+
 	if err := s.storage.DeleteDataEntry(ctx, userID, entryID); err != nil {
 		s.logger.Error("Failed to delete data entry", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to delete data entry")
@@ -392,6 +951,22 @@ func (s *Server) SyncData(ctx context.Context, req *pb.SyncDataRequest) (*pb.Syn
 		return nil, status.Error(codes.Internal, "failed to sync data")
 	}
 
+	// Записи других пользователей, доступ к которым предоставлен или изменен
+	sharedEntries, err := s.storage.GetSharedEntriesAfter(ctx, userID, lastSyncTime)
+	if err != nil {
+		s.logger.Error("Failed to get shared entries after sync time", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to sync data")
+	}
+
+	// Записи коллекций организаций, в которых состоит пользователь
+	collectionEntries, err := s.storage.GetCollectionEntriesAfter(ctx, userID, lastSyncTime)
+	if err != nil {
+		s.logger.Error("Failed to get collection entries after sync time", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to sync data")
+	}
+	entries = append(entries, sharedEntries...)
+	entries = filterAllowedEntries(ctx, append(entries, collectionEntries...))
+
 	// Получаем удаленные записи
 	deletedIDs, err := s.storage.GetDeletedEntriesAfter(ctx, userID, lastSyncTime)
 	if err != nil {
@@ -422,14 +997,16 @@ func (s *Server) GenerateOTP(ctx context.Context, req *pb.GenerateOTPRequest) (*
 		return nil, status.Error(codes.InvalidArgument, "secret is required")
 	}
 
-	code, err := s.otpService.GenerateCode(req.Secret)
+	params := otpParamsFromProto(req.Algorithm, req.Digits, req.Period)
+	now := time.Now()
+	code, err := otp.GenerateCodeAt(req.Secret, params, now)
 	if err != nil {
 		s.logger.Error("Failed to generate OTP code", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to generate OTP code")
+		return nil, status.Error(codes.InvalidArgument, "invalid OTP secret or parameters")
 	}
 
-	timeRemaining := s.otpService.GetTimeRemaining()
-	expiresAt := time.Now().Add(time.Duration(timeRemaining) * time.Second)
+	timeRemaining := params.TimeRemaining(now)
+	expiresAt := now.Add(time.Duration(timeRemaining) * time.Second)
 
 	return &pb.GenerateOTPResponse{
 		Code:          code,
@@ -453,10 +1030,17 @@ func (s *Server) CreateOTPSecret(ctx context.Context, req *pb.CreateOTPSecretReq
 		return nil, status.Error(codes.Internal, "failed to generate OTP secret")
 	}
 
-	qrCodeURL, err := s.otpService.GenerateQRCodeURL(secret, req.Issuer, req.AccountName)
+	params := otpParamsFromProto(req.Algorithm, req.Digits, req.Period)
+	qrCodeURL, err := s.otpService.GenerateQRCodeURLWithParams(secret, req.Issuer, req.AccountName, params)
 	if err != nil {
 		s.logger.Error("Failed to generate QR code URL", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to generate QR code URL")
+		return nil, status.Error(codes.InvalidArgument, "invalid OTP parameters")
+	}
+
+	qrCodePNG, err := otp.QRCodePNG(qrCodeURL, otpQRCodeSize)
+	if err != nil {
+		s.logger.Error("Failed to generate QR code image", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate QR code image")
 	}
 
 	backupCodes, err := s.otpService.GenerateBackupCodes(10)
@@ -469,9 +1053,22 @@ func (s *Server) CreateOTPSecret(ctx context.Context, req *pb.CreateOTPSecretReq
 		Secret:      secret,
 		QrCodeUrl:   qrCodeURL,
 		BackupCodes: backupCodes,
+		QrCodePng:   qrCodePNG,
 	}, nil
 }
 
+// otpQRCodeSize максимальный размер стороны PNG изображения QR кода в пикселях.
+const otpQRCodeSize = 256
+
+// otpParamsFromProto преобразует параметры TOTP из запроса.
+func otpParamsFromProto(algorithm string, digits, period int32) otp.Params {
+	return otp.Params{
+		Algorithm: algorithm,
+		Digits:    int(digits),
+		Period:    int(period),
+	}
+}
+
 // getUserIDFromContext извлекает ID пользователя из контекста.
 func getUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	userIDValue := ctx.Value(UserIDKey)
@@ -483,6 +1080,35 @@ func getUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	return userID, ok
 }
 
+// getAccessTokenFromContext извлекает персональный токен доступа из контекста.
+func getAccessTokenFromContext(ctx context.Context) (*models.AccessToken, bool) {
+	accessToken, ok := ctx.Value(AccessTokenKey).(*models.AccessToken)
+	return accessToken, ok
+}
+
+// allowsDataType проверяет, разрешен ли запросу доступ к типу данных.
+// Запросы с JWT токеном имеют доступ ко всем типам.
+func allowsDataType(ctx context.Context, dataType models.DataType) bool {
+	accessToken, ok := getAccessTokenFromContext(ctx)
+	return !ok || accessToken.AllowsType(dataType)
+}
+
+// filterAllowedEntries оставляет только записи, доступные персональному токену запроса.
+func filterAllowedEntries(ctx context.Context, entries []models.DataEntry) []models.DataEntry {
+	accessToken, ok := getAccessTokenFromContext(ctx)
+	if !ok || len(accessToken.DataTypes) == 0 {
+		return entries
+	}
+
+	allowed := make([]models.DataEntry, 0, len(entries))
+	for _, entry := range entries {
+		if accessToken.AllowsType(entry.Type) {
+			allowed = append(allowed, entry)
+		}
+	}
+	return allowed
+}
+
 // convertProtoDataType преобразует proto тип данных в строку.
 func convertProtoDataType(protoType pb.DataType) string {
 	switch protoType {
@@ -494,6 +1120,18 @@ func convertProtoDataType(protoType pb.DataType) string {
 		return string(models.DataTypeBinary)
 	case pb.DataType_DATA_TYPE_CARD:
 		return string(models.DataTypeCard)
+	case pb.DataType_DATA_TYPE_OTP:
+		return string(models.DataTypeOTP)
+	case pb.DataType_DATA_TYPE_SSH_KEY:
+		return string(models.DataTypeSSHKey)
+	case pb.DataType_DATA_TYPE_API_KEY:
+		return string(models.DataTypeAPIKey)
+	case pb.DataType_DATA_TYPE_IDENTITY:
+		return string(models.DataTypeIdentity)
+	case pb.DataType_DATA_TYPE_WIFI:
+		return string(models.DataTypeWiFi)
+	case pb.DataType_DATA_TYPE_SECURE_NOTE:
+		return string(models.DataTypeSecureNote)
 	default:
 		return ""
 	}
@@ -510,6 +1148,18 @@ func convertToProtoDataType(dataType models.DataType) pb.DataType {
 		return pb.DataType_DATA_TYPE_BINARY
 	case models.DataTypeCard:
 		return pb.DataType_DATA_TYPE_CARD
+	case models.DataTypeOTP:
+		return pb.DataType_DATA_TYPE_OTP
+	case models.DataTypeSSHKey:
+		return pb.DataType_DATA_TYPE_SSH_KEY
+	case models.DataTypeAPIKey:
+		return pb.DataType_DATA_TYPE_API_KEY
+	case models.DataTypeIdentity:
+		return pb.DataType_DATA_TYPE_IDENTITY
+	case models.DataTypeWiFi:
+		return pb.DataType_DATA_TYPE_WIFI
+	case models.DataTypeSecureNote:
+		return pb.DataType_DATA_TYPE_SECURE_NOTE
 	default:
 		return pb.DataType_DATA_TYPE_UNSPECIFIED
 	}
@@ -517,7 +1167,7 @@ func convertToProtoDataType(dataType models.DataType) pb.DataType {
 
 // convertToProtoDataEntry преобразует модель DataEntry в proto DataEntry.
 func convertToProtoDataEntry(entry *models.DataEntry) *pb.DataEntry {
-	return &pb.DataEntry{
+	protoEntry := &pb.DataEntry{
 		Id:            entry.ID.String(),
 		Type:          convertToProtoDataType(entry.Type),
 		Name:          entry.Name,
@@ -527,5 +1177,53 @@ func convertToProtoDataEntry(entry *models.DataEntry) *pb.DataEntry {
 		CreatedAt:     timestamppb.New(entry.CreatedAt),
 		UpdatedAt:     timestamppb.New(entry.UpdatedAt),
 		Version:       entry.Version,
+		RotationDays:  int32(entry.RotationDays),
+	}
+	if !entry.PasswordChangedAt.IsZero() {
+		protoEntry.PasswordChangedAt = timestamppb.New(entry.PasswordChangedAt)
+	}
+	if dueAt := entry.RotationDueAt(); dueAt != nil {
+		protoEntry.RotationDueAt = timestamppb.New(*dueAt)
+	}
+	if entry.Share != nil {
+		protoEntry.Share = convertToProtoEntryShare(entry.Share)
+	}
+	if entry.CollectionID != nil {
+		protoEntry.CollectionId = entry.CollectionID.String()
+	}
+	return protoEntry
+}
+
+// convertToProtoAccessToken преобразует модель AccessToken в proto AccessToken.
+func convertToProtoAccessToken(token *models.AccessToken) *pb.AccessToken {
+	protoToken := &pb.AccessToken{
+		Id:        token.ID.String(),
+		Name:      token.Name,
+		ReadOnly:  token.ReadOnly,
+		CreatedAt: timestamppb.New(token.CreatedAt),
+		ExpiresAt: timestamppb.New(token.ExpiresAt),
+	}
+	for _, dataType := range token.DataTypes {
+		protoToken.DataTypes = append(protoToken.DataTypes, convertToProtoDataType(dataType))
+	}
+	if token.LastUsedAt != nil {
+		protoToken.LastUsedAt = timestamppb.New(*token.LastUsedAt)
+	}
+	if len(token.CertificateFingerprint) > 0 {
+		protoToken.CertificateFingerprint = hex.EncodeToString(token.CertificateFingerprint)
+	}
+	return protoToken
+}
+
+// clientCertificateFingerprint разбирает клиентский сертификат в PEM
+// и возвращает его отпечаток, по которому сертификат сопоставляется токену.
+func clientCertificateFingerprint(certPEM []byte) ([]byte, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("certificate PEM block not found")
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return nil, err
 	}
+	return middleware.CertificateFingerprint(block.Bytes), nil
 }
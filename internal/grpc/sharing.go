@@ -0,0 +1,287 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/GophKeeper/internal/crypto"
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetPublicKey публикует открытый ключ пользователя, которым другие пользователи
+// шифруют ключи предоставляемых ему записей. Зашифрованный закрытый ключ
+// возвращается клиенту при входе.
+func (s *Server) SetPublicKey(ctx context.Context, req *pb.SetPublicKeyRequest) (*pb.SetPublicKeyResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(req.PublicKey) != crypto.SharingKeySize {
+		return nil, status.Error(codes.InvalidArgument, "invalid public key")
+	}
+
+	if err := s.storage.SetUserPublicKey(ctx, userID, req.PublicKey, req.WrappedPrivateKey); err != nil {
+		s.logger.Error("Failed to set public key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to set public key")
+	}
+
+	return &pb.SetPublicKeyResponse{
+		Success: true,
+	}, nil
+}
+
+// GetPublicKey возвращает открытый ключ пользователя для шифрования ключа записи.
+func (s *Server) GetPublicKey(ctx context.Context, req *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	user, err := s.storage.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if len(user.PublicKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "user has not published a public key")
+	}
+
+	return &pb.GetPublicKeyResponse{
+		UserId:    user.ID.String(),
+		Username:  user.Username,
+		PublicKey: user.PublicKey,
+	}, nil
+}
+
+// ShareEntry предоставляет другому пользователю доступ к записи. Ключ записи
+// шифруется клиентом открытым ключом получателя, сервер его не знает.
+// Повторный вызов для того же получателя меняет ключ и права доступа.
+func (s *Server) ShareEntry(ctx context.Context, req *pb.ShareEntryRequest) (*pb.ShareEntryResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	entryID, err := uuid.Parse(req.EntryId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid entry ID")
+	}
+	if req.RecipientUsername == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipient username is required")
+	}
+	if len(req.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+	permission := convertProtoSharePermission(req.Permission)
+	if permission == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid share permission")
+	}
+
+	// Делиться можно только собственными записями
+	if _, err := s.storage.GetDataEntry(ctx, userID, entryID); err != nil {
+		return nil, status.Error(codes.NotFound, "data entry not found")
+	}
+
+	recipient, err := s.storage.GetUserByUsername(ctx, req.RecipientUsername)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "recipient not found")
+	}
+	if recipient.ID == userID {
+		return nil, status.Error(codes.InvalidArgument, "cannot share an entry with yourself")
+	}
+	if len(recipient.PublicKey) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "recipient has not published a public key")
+	}
+
+	owner, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	share := &models.EntryShare{
+		EntryID:           entryID,
+		OwnerID:           userID,
+		OwnerUsername:     owner.Username,
+		RecipientID:       recipient.ID,
+		RecipientUsername: recipient.Username,
+		WrappedKey:        req.WrappedKey,
+		Permission:        permission,
+	}
+	if err := s.storage.CreateEntryShare(ctx, share); err != nil {
+		s.logger.Error("Failed to share data entry", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to share data entry")
+	}
+
+	s.logger.Info("Data entry shared",
+		zap.String("entry_id", entryID.String()),
+		zap.String("recipient", recipient.Username),
+		zap.String("permission", string(permission)))
+
+	return &pb.ShareEntryResponse{
+		Share: convertToProtoEntryShare(share),
+	}, nil
+}
+
+// ListEntryShares возвращает пользователей, которым владелец предоставил доступ к записи.
+func (s *Server) ListEntryShares(ctx context.Context, req *pb.ListEntrySharesRequest) (*pb.ListEntrySharesResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	entryID, err := uuid.Parse(req.EntryId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid entry ID")
+	}
+
+	if _, err := s.storage.GetDataEntry(ctx, userID, entryID); err != nil {
+		return nil, status.Error(codes.NotFound, "data entry not found")
+	}
+
+	shares, err := s.storage.GetEntryShares(ctx, userID, entryID)
+	if err != nil {
+		s.logger.Error("Failed to get entry shares", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get entry shares")
+	}
+
+	protoShares := make([]*pb.EntryShare, len(shares))
+	for i := range shares {
+		protoShares[i] = convertToProtoEntryShare(&shares[i])
+	}
+
+	return &pb.ListEntrySharesResponse{
+		Shares: protoShares,
+	}, nil
+}
+
+// RevokeShare отзывает доступ к записи. Владелец может отозвать доступ у любого
+// получателя, получатель - отказаться от записи. У получателя запись удаляется
+// при следующей синхронизации.
+func (s *Server) RevokeShare(ctx context.Context, req *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	if err := rejectAccessToken(ctx); err != nil {
+		return nil, err
+	}
+
+	shareID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid share ID")
+	}
+
+	if err := s.storage.DeleteEntryShare(ctx, userID, shareID); err != nil {
+		s.logger.Error("Failed to revoke entry share", zap.Error(err))
+		return nil, status.Error(codes.NotFound, "entry share not found")
+	}
+
+	s.logger.Info("Entry share revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("share_id", shareID.String()))
+
+	return &pb.RevokeShareResponse{
+		Success: true,
+	}, nil
+}
+
+// ListSharedWithMe возвращает записи других пользователей, доступ к которым
+// предоставлен текущему пользователю.
+func (s *Server) ListSharedWithMe(ctx context.Context, req *pb.ListSharedWithMeRequest) (*pb.ListDataResponse, error) {
+	userID, ok := getUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	entries, err := s.storage.GetSharedEntries(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get shared entries", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get shared entries")
+	}
+	entries = filterAllowedEntries(ctx, entries)
+
+	protoEntries := make([]*pb.DataEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = convertToProtoDataEntry(&entry)
+	}
+
+	return &pb.ListDataResponse{
+		DataEntries: protoEntries,
+		Total:       int32(len(entries)),
+	}, nil
+}
+
+// getAccessibleEntry получает собственную запись пользователя, запись, доступ
+// к которой ему предоставлен, или запись коллекции его организации. У общей
+// записи заполнено поле Share, у записи коллекции - CollectionID.
+func (s *Server) getAccessibleEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error) {
+	entry, err := s.storage.GetDataEntry(ctx, userID, entryID)
+	if err == nil {
+		return entry, nil
+	}
+	entry, err = s.storage.GetSharedEntry(ctx, userID, entryID)
+	if err == nil {
+		return entry, nil
+	}
+	return s.storage.GetCollectionEntry(ctx, userID, entryID)
+}
+
+// rejectAccessToken запрещает управление доступом к записям с персональным токеном.
+func rejectAccessToken(ctx context.Context) error {
+	if _, ok := getAccessTokenFromContext(ctx); ok {
+		return status.Error(codes.PermissionDenied, "insufficient token scope")
+	}
+	return nil
+}
+
+// convertProtoSharePermission преобразует proto права доступа в модель.
+// Неуказанные права означают доступ только для чтения.
+func convertProtoSharePermission(permission pb.SharePermission) models.SharePermission {
+	switch permission {
+	case pb.SharePermission_SHARE_PERMISSION_UNSPECIFIED, pb.SharePermission_SHARE_PERMISSION_READ:
+		return models.SharePermissionRead
+	case pb.SharePermission_SHARE_PERMISSION_WRITE:
+		return models.SharePermissionWrite
+	default:
+		return ""
+	}
+}
+
+// convertToProtoSharePermission преобразует права доступа в proto.
+func convertToProtoSharePermission(permission models.SharePermission) pb.SharePermission {
+	switch permission {
+	case models.SharePermissionRead:
+		return pb.SharePermission_SHARE_PERMISSION_READ
+	case models.SharePermissionWrite:
+		return pb.SharePermission_SHARE_PERMISSION_WRITE
+	default:
+		return pb.SharePermission_SHARE_PERMISSION_UNSPECIFIED
+	}
+}
+
+// convertToProtoEntryShare преобразует модель EntryShare в proto EntryShare.
+func convertToProtoEntryShare(share *models.EntryShare) *pb.EntryShare {
+	return &pb.EntryShare{
+		Id:                share.ID.String(),
+		EntryId:           share.EntryID.String(),
+		OwnerUsername:     share.OwnerUsername,
+		RecipientUsername: share.RecipientUsername,
+		Permission:        convertToProtoSharePermission(share.Permission),
+		WrappedKey:        share.WrappedKey,
+		CreatedAt:         timestamppb.New(share.CreatedAt),
+	}
+}
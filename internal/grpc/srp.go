@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/GophKeeper/internal/auth"
+	"github.com/GophKeeper/internal/models"
+	pb "github.com/GophKeeper/proto/gen/proto"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// srpLoginTTL время, за которое клиент должен завершить вход по SRP
+	srpLoginTTL = 2 * time.Minute
+	// maxPendingSRPLogins ограничивает число незавершенных рукопожатий
+	maxPendingSRPLogins = 10000
+	// maxSRPVerifierSize размер верификатора для группы 2048 бит
+	maxSRPVerifierSize = 256
+)
+
+// pendingSRPLogin хранит состояние сервера между шагами входа по SRP.
+type pendingSRPLogin struct {
+	userID    uuid.UUID
+	server    *auth.SRPServer
+	expiresAt time.Time
+}
+
+// srpLoginStore хранит незавершенные рукопожатия SRP в памяти.
+type srpLoginStore struct {
+	mu     sync.Mutex
+	logins map[string]*pendingSRPLogin
+}
+
+// newSRPLoginStore создает хранилище рукопожатий SRP.
+func newSRPLoginStore() *srpLoginStore {
+	return &srpLoginStore{
+		logins: make(map[string]*pendingSRPLogin),
+	}
+}
+
+// put сохраняет рукопожатие и возвращает его идентификатор.
+func (st *srpLoginStore) put(login *pendingSRPLogin) (string, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	if len(st.logins) >= maxPendingSRPLogins {
+		for id, pending := range st.logins {
+			if now.After(pending.expiresAt) {
+				delete(st.logins, id)
+			}
+		}
+		if len(st.logins) >= maxPendingSRPLogins {
+			return "", false
+		}
+	}
+
+	id := uuid.NewString()
+	st.logins[id] = login
+	return id, true
+}
+
+// take извлекает рукопожатие. Каждое рукопожатие можно использовать один раз.
+func (st *srpLoginStore) take(id string) (*pendingSRPLogin, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	login, ok := st.logins[id]
+	if !ok {
+		return nil, false
+	}
+	delete(st.logins, id)
+
+	if time.Now().After(login.expiresAt) {
+		return nil, false
+	}
+	return login, true
+}
+
+// BeginSRPLogin начинает вход по SRP-6a: возвращает соль и открытый ключ сервера.
+// Для неизвестных пользователей и аккаунтов без SRP возвращается неотличимый ответ
+// с постоянной солью, а ошибка возникает только при проверке доказательства, чтобы
+// по ответам нельзя было перебирать имена пользователей и состояние аккаунтов.
+func (s *Server) BeginSRPLogin(ctx context.Context, req *pb.BeginSRPLoginRequest) (*pb.BeginSRPLoginResponse, error) {
+	if req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "username is required")
+	}
+	if len(req.ClientPublicKey) == 0 || len(req.ClientPublicKey) > maxSRPVerifierSize {
+		return nil, status.Error(codes.InvalidArgument, "invalid client public key")
+	}
+
+	var userID uuid.UUID
+	var srpServer *auth.SRPServer
+	user, err := s.storage.GetUserByUsername(ctx, req.Username)
+	if err == nil && user.UsesSRP() {
+		userID = user.ID
+		srpServer, err = auth.NewSRPServer(user.Username, user.SRPSalt, user.SRPVerifier, req.ClientPublicKey)
+	} else {
+		s.logger.Warn("SRP login for unknown or legacy account", zap.String("username", req.Username))
+		srpServer, err = auth.NewSRPDecoyServer(s.srpDecoySecret, req.Username, req.ClientPublicKey)
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid client public key")
+	}
+
+	loginID, ok := s.srpLogins.put(&pendingSRPLogin{
+		userID:    userID,
+		server:    srpServer,
+		expiresAt: time.Now().Add(srpLoginTTL),
+	})
+	if !ok {
+		return nil, status.Error(codes.ResourceExhausted, "too many pending logins")
+	}
+
+	return &pb.BeginSRPLoginResponse{
+		LoginId:         loginID,
+		Salt:            srpServer.Salt(),
+		ServerPublicKey: srpServer.PublicKey(),
+	}, nil
+}
+
+// FinishSRPLogin завершает вход по SRP-6a: проверяет доказательство клиента,
+// создает сессию и возвращает доказательство сервера.
+func (s *Server) FinishSRPLogin(ctx context.Context, req *pb.FinishSRPLoginRequest) (*pb.FinishSRPLoginResponse, error) {
+	if req.LoginId == "" || len(req.ClientProof) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "login id and proof are required")
+	}
+
+	login, ok := s.srpLogins.take(req.LoginId)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "login expired")
+	}
+
+	serverProof, err := login.server.VerifyClient(req.ClientProof)
+	if err != nil {
+		s.logger.Warn("Invalid SRP proof", zap.String("user_id", login.userID.String()))
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	user, err := s.storage.GetUserByID(ctx, login.userID)
+	if err != nil {
+		s.logger.Error("Failed to get user", zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	authResp, err := s.startSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FinishSRPLoginResponse{
+		Auth:        authResp,
+		ServerProof: serverProof,
+	}, nil
+}
+
+// verifySRPProof проверяет доказательство клиента в рукопожатии, начатом
+// BeginSRPLogin для того же пользователя. Рукопожатие расходуется.
+func (s *Server) verifySRPProof(user *models.User, loginID string, proof []byte) error {
+	if loginID == "" || len(proof) == 0 {
+		return status.Error(codes.InvalidArgument, "srp proof is required")
+	}
+
+	login, ok := s.srpLogins.take(loginID)
+	if !ok || login.userID != user.ID {
+		return status.Error(codes.PermissionDenied, "invalid password")
+	}
+
+	if _, err := login.server.VerifyClient(proof); err != nil {
+		s.logger.Warn("Re-authentication failed", zap.String("username", user.Username))
+		return status.Error(codes.PermissionDenied, "invalid password")
+	}
+	return nil
+}
+
+// validateSRPVerifier проверяет соль и верификатор, присланные клиентом.
+func validateSRPVerifier(salt, verifier []byte) error {
+	if len(salt) < auth.SRPSaltSize || len(salt) > 64 {
+		return status.Error(codes.InvalidArgument, "invalid srp salt")
+	}
+	if len(verifier) == 0 || len(verifier) > maxSRPVerifierSize {
+		return status.Error(codes.InvalidArgument, "invalid srp verifier")
+	}
+	return nil
+}
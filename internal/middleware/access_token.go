@@ -0,0 +1,47 @@
+// Package middleware содержит HTTP middleware для сервера.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GophKeeper/internal/auth"
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
+)
+
+// AccessTokenKey ключ для хранения персонального токена доступа в контексте.
+type AccessTokenKey struct{}
+
+// ErrAccessTokenInactive возвращается для отозванных и истекших токенов.
+var ErrAccessTokenInactive = errors.New("access token is revoked or expired")
+
+// AuthenticateAccessToken проверяет персональный токен доступа и отмечает его использование.
+func AuthenticateAccessToken(ctx context.Context, tokens storage.AccessTokenRepository, token string) (*models.AccessToken, error) {
+	if tokens == nil {
+		return nil, errors.New("access tokens are not supported")
+	}
+
+	accessToken, err := tokens.GetAccessTokenByHash(ctx, auth.HashAccessToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find access token: %w", err)
+	}
+
+	if !accessToken.IsActive(time.Now()) {
+		return nil, ErrAccessTokenInactive
+	}
+
+	if err := tokens.TouchAccessToken(ctx, accessToken.ID); err != nil {
+		return nil, fmt.Errorf("failed to update access token: %w", err)
+	}
+
+	return accessToken, nil
+}
+
+// GetAccessTokenFromContext извлекает персональный токен доступа из контекста.
+func GetAccessTokenFromContext(ctx context.Context) (*models.AccessToken, bool) {
+	accessToken, ok := ctx.Value(AccessTokenKey{}).(*models.AccessToken)
+	return accessToken, ok
+}
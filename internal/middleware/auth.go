@@ -3,11 +3,14 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/GophKeeper/internal/auth"
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -15,12 +18,28 @@ import (
 // UserIDKey ключ для хранения ID пользователя в контексте.
 type UserIDKey struct{}
 
-// AuthMiddleware создает middleware для проверки JWT токенов.
-func AuthMiddleware(authService *auth.Service, logger *zap.Logger) func(http.Handler) http.Handler {
+// SessionIDKey ключ для хранения ID сессии в контексте.
+type SessionIDKey struct{}
+
+// AuthMiddleware создает middleware для проверки JWT токенов и персональных токенов доступа.
+// Если credentials не nil, дополнительно проверяется, что сессия JWT токена не отозвана,
+// а запросы без заголовка Authorization принимаются по клиентскому сертификату mTLS,
+// привязанному к персональному токену доступа, с ограничениями этого токена.
+func AuthMiddleware(authService *auth.Service, credentials storage.CredentialRepository, logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
+				// Клиентский сертификат предъявляет привязанный к нему токен доступа
+				if accessToken, err := AuthenticateClientCert(r.Context(), credentials, r.TLS); err == nil {
+					serveAccessToken(next, w, r, accessToken, logger)
+					return
+				} else if !errors.Is(err, ErrNoClientCertificate) {
+					logger.Warn("Client certificate rejected", zap.Error(err))
+					http.Error(w, "Unknown client certificate", http.StatusUnauthorized)
+					return
+				}
+
 				logger.Warn("Missing Authorization header")
 				http.Error(w, "Authorization header required", http.StatusUnauthorized)
 				return
@@ -35,6 +54,23 @@ func AuthMiddleware(authService *auth.Service, logger *zap.Logger) func(http.Han
 			}
 
 			token := parts[1]
+
+			// Персональные токены доступа разрешены только для работы с данными
+			if auth.IsAccessToken(token) {
+				if credentials == nil {
+					http.Error(w, "Invalid token", http.StatusUnauthorized)
+					return
+				}
+				accessToken, err := AuthenticateAccessToken(r.Context(), credentials, token)
+				if err != nil {
+					logger.Warn("Invalid access token", zap.Error(err))
+					http.Error(w, "Invalid token", http.StatusUnauthorized)
+					return
+				}
+				serveAccessToken(next, w, r, accessToken, logger)
+				return
+			}
+
 			claims, err := authService.ValidateToken(token)
 			if err != nil {
 				logger.Warn("Invalid token", zap.Error(err))
@@ -42,8 +78,23 @@ func AuthMiddleware(authService *auth.Service, logger *zap.Logger) func(http.Han
 				return
 			}
 
-			// Добавляем ID пользователя в контекст
+			if credentials != nil {
+				active, err := credentials.IsSessionActive(r.Context(), claims.UserID, claims.SessionID)
+				if err != nil {
+					logger.Error("Failed to check session", zap.Error(err))
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+					return
+				}
+				if !active {
+					logger.Warn("Session is revoked or unknown")
+					http.Error(w, "Invalid token", http.StatusUnauthorized)
+					return
+				}
+			}
+
+			// Добавляем ID пользователя и сессии в контекст
 			ctx := context.WithValue(r.Context(), UserIDKey{}, claims.UserID)
+			ctx = context.WithValue(ctx, SessionIDKey{}, claims.SessionID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -55,6 +106,43 @@ func GetUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	return userID, ok
 }
 
+// serveAccessToken проверяет область действия токена доступа для запроса
+// и передает запрос дальше от имени владельца токена.
+func serveAccessToken(next http.Handler, w http.ResponseWriter, r *http.Request, accessToken *models.AccessToken, logger *zap.Logger) {
+	if !accessTokenAllowsRequest(accessToken, r) {
+		logger.Warn("Access token scope violation",
+			zap.String("token_id", accessToken.ID.String()),
+			zap.String("path", r.URL.Path))
+		http.Error(w, "Insufficient token scope", http.StatusForbidden)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserIDKey{}, accessToken.UserID)
+	ctx = context.WithValue(ctx, AccessTokenKey{}, accessToken)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// accessTokenAllowsRequest проверяет, что персональный токен может выполнить HTTP запрос:
+// доступны только маршруты данных и синхронизации, а токену только для чтения - лишь чтение.
+func accessTokenAllowsRequest(accessToken *models.AccessToken, r *http.Request) bool {
+	path := r.URL.Path
+	isSync := path == "/sync"
+	isData := path == "/data" || strings.HasPrefix(path, "/data/")
+	if !isSync && !isData {
+		return false
+	}
+	if accessToken.ReadOnly {
+		return isSync || r.Method == http.MethodGet
+	}
+	return true
+}
+
+// GetSessionIDFromContext извлекает ID сессии из контекста.
+func GetSessionIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	sessionID, ok := ctx.Value(SessionIDKey{}).(uuid.UUID)
+	return sessionID, ok
+}
+
 // LoggingMiddleware создает middleware для логирования HTTP запросов.
 func LoggingMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/storage"
+)
+
+// ErrNoClientCertificate возвращается, если клиент не предъявил проверенный сертификат.
+var ErrNoClientCertificate = errors.New("no verified client certificate")
+
+// CertificateFingerprint возвращает отпечаток SHA-256 сертификата в DER.
+func CertificateFingerprint(der []byte) []byte {
+	sum := sha256.Sum256(der)
+	return sum[:]
+}
+
+// ClientCertFingerprint возвращает отпечаток проверенного клиентского сертификата.
+func ClientCertFingerprint(state *tls.ConnectionState) ([]byte, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	return CertificateFingerprint(state.VerifiedChains[0][0].Raw), true
+}
+
+// AuthenticateClientCert сопоставляет проверенный клиентский сертификат токену доступа.
+// Сертификат, выданный доверенным CA, принимается только если владелец аккаунта явно
+// привязал его к токену; права запроса ограничены областью действия этого токена.
+func AuthenticateClientCert(ctx context.Context, tokens storage.AccessTokenRepository, state *tls.ConnectionState) (*models.AccessToken, error) {
+	fingerprint, ok := ClientCertFingerprint(state)
+	if !ok {
+		return nil, ErrNoClientCertificate
+	}
+	if tokens == nil {
+		return nil, errors.New("client certificates are not supported")
+	}
+
+	accessToken, err := tokens.GetAccessTokenByCertificate(ctx, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map client certificate to access token: %w", err)
+	}
+
+	if !accessToken.IsActive(time.Now()) {
+		return nil, ErrAccessTokenInactive
+	}
+
+	if err := tokens.TouchAccessToken(ctx, accessToken.ID); err != nil {
+		return nil, fmt.Errorf("failed to update access token: %w", err)
+	}
+
+	return accessToken, nil
+}
@@ -23,7 +23,7 @@ func TestAuthMiddleware(t *testing.T) {
 	require.NoError(t, err)
 
 	// Создаем middleware
-	middleware := AuthMiddleware(authService, logger)
+	middleware := AuthMiddleware(authService, nil, logger)
 
 	// Создаем тестовый handler
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -15,15 +15,118 @@ const (
 	DataTypeText        DataType = "text"        // произвольные текстовые данные
 	DataTypeBinary      DataType = "binary"      // произвольные бинарные данные
 	DataTypeCard        DataType = "card"        // данные банковских карт
+	DataTypeOTP         DataType = "otp"         // секреты одноразовых паролей
+	DataTypeSSHKey      DataType = "ssh_key"     // пары ключей SSH
+	DataTypeAPIKey      DataType = "api_key"     // ключи и токены API
+	DataTypeIdentity    DataType = "identity"    // документы, удостоверяющие личность
+	DataTypeWiFi        DataType = "wifi"        // параметры сетей Wi-Fi
+	DataTypeSecureNote  DataType = "secure_note" // защищенные заметки в Markdown
 )
 
+// MaxRotationDays ограничивает период смены пароля в политиках записей и коллекций.
+const MaxRotationDays = 3650
+
+// Rotatable проверяет, содержат ли записи типа пароль или ключ, который
+// нужно регулярно менять. На такие записи действует политика коллекции.
+func (t DataType) Rotatable() bool {
+	switch t {
+	case DataTypeCredentials, DataTypeWiFi, DataTypeAPIKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// RotatableTypes возвращает типы данных, на которые действует политика смены паролей коллекции.
+func RotatableTypes() []DataType {
+	return []DataType{DataTypeCredentials, DataTypeWiFi, DataTypeAPIKey}
+}
+
+// SharePermission представляет права получателя общей записи.
+type SharePermission string
+
+const (
+	SharePermissionRead  SharePermission = "read"  // только чтение
+	SharePermissionWrite SharePermission = "write" // чтение и изменение
+)
+
+// OrganizationRole представляет роль участника организации.
+type OrganizationRole string
+
+const (
+	RoleOwner  OrganizationRole = "owner"  // полный доступ, управление администраторами
+	RoleAdmin  OrganizationRole = "admin"  // управление участниками и коллекциями
+	RoleEditor OrganizationRole = "editor" // чтение и изменение записей
+	RoleViewer OrganizationRole = "viewer" // только чтение записей
+)
+
+// organizationRoleRanks упорядочивает роли по убыванию прав.
+var organizationRoleRanks = map[OrganizationRole]int{
+	RoleOwner:  4,
+	RoleAdmin:  3,
+	RoleEditor: 2,
+	RoleViewer: 1,
+}
+
+// Valid проверяет, что роль известна.
+func (r OrganizationRole) Valid() bool {
+	return organizationRoleRanks[r] > 0
+}
+
+// AtLeast проверяет, что роль дает не меньше прав, чем min.
+func (r OrganizationRole) AtLeast(min OrganizationRole) bool {
+	return r.Valid() && organizationRoleRanks[r] >= organizationRoleRanks[min]
+}
+
+// CanManage проверяет, может ли участник с ролью r назначать роль target
+// и управлять участниками с этой ролью. Владельцами и администраторами
+// управляет только владелец, редакторами и читателями - также администратор.
+func (r OrganizationRole) CanManage(target OrganizationRole) bool {
+	if !target.Valid() {
+		return false
+	}
+	if target.AtLeast(RoleAdmin) {
+		return r == RoleOwner
+	}
+	return r.AtLeast(RoleAdmin)
+}
+
+// RolesAtLeast возвращает все роли, дающие не меньше прав, чем min.
+func RolesAtLeast(min OrganizationRole) []OrganizationRole {
+	var roles []OrganizationRole
+	for _, role := range []OrganizationRole{RoleOwner, RoleAdmin, RoleEditor, RoleViewer} {
+		if role.AtLeast(min) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
 // User представляет пользователя в системе.
 type User struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	Username     string    `json:"username" db:"username" validate:"required,min=3,max=50"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID                uuid.UUID `json:"id" db:"id"`
+	Username          string    `json:"username" db:"username" validate:"required,min=3,max=50"`
+	PasswordHash      string    `json:"-" db:"password_hash"`
+	WrappedVaultKey   []byte    `json:"-" db:"wrapped_vault_key"`
+	SRPSalt           []byte    `json:"-" db:"srp_salt"`
+	SRPVerifier       []byte    `json:"-" db:"srp_verifier"`
+	PublicKey         []byte    `json:"-" db:"public_key"`
+	WrappedPrivateKey []byte    `json:"-" db:"wrapped_private_key"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UsesSRP проверяет, входит ли пользователь по протоколу SRP вместо пароля.
+func (u *User) UsesSRP() bool {
+	return len(u.SRPVerifier) > 0
+}
+
+// Session представляет сессию пользователя, к которой привязан JWT токен.
+type Session struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
 }
 
 // DataEntry представляет запись сохраненных данных.
@@ -38,6 +141,280 @@ type DataEntry struct {
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
 	Version       int64     `json:"version" db:"version"`
+	// Share заполняется, если запись принадлежит другому пользователю
+	// и доступ к ней предоставлен текущему
+	Share *EntryShare `json:"share,omitempty" db:"-"`
+	// CollectionID заполняется для записей коллекции организации
+	CollectionID *uuid.UUID `json:"collection_id,omitempty" db:"collection_id"`
+	// PasswordChangedAt время последней смены пароля, о которой сообщил клиент
+	PasswordChangedAt time.Time `json:"password_changed_at" db:"password_changed_at"`
+	// RotationDays период смены пароля записи в днях, 0 - политика не задана
+	RotationDays int `json:"rotation_days,omitempty" db:"rotation_days"`
+	// CollectionRotationDays политика смены паролей коллекции записи
+	CollectionRotationDays int `json:"-" db:"-"`
+}
+
+// RotationPolicyDays возвращает действующий период смены пароля в днях:
+// политику записи, а если она не задана - политику коллекции для записей
+// с паролями. 0 означает, что пароль менять не требуется.
+func (e *DataEntry) RotationPolicyDays() int {
+	if e.RotationDays > 0 {
+		return e.RotationDays
+	}
+	if e.Type.Rotatable() {
+		return e.CollectionRotationDays
+	}
+	return 0
+}
+
+// RotationDueAt возвращает срок смены пароля или nil, если политика не задана.
+func (e *DataEntry) RotationDueAt() *time.Time {
+	days := e.RotationPolicyDays()
+	if days <= 0 {
+		return nil
+	}
+	dueAt := e.PasswordChangedAt.AddDate(0, 0, days)
+	return &dueAt
+}
+
+// EntryShare представляет доступ к записи, предоставленный другому пользователю.
+type EntryShare struct {
+	ID                uuid.UUID       `json:"id" db:"id"`
+	EntryID           uuid.UUID       `json:"entry_id" db:"entry_id"`
+	OwnerID           uuid.UUID       `json:"owner_id" db:"owner_id"`
+	OwnerUsername     string          `json:"owner_username" db:"-"`
+	RecipientID       uuid.UUID       `json:"recipient_id" db:"recipient_id"`
+	RecipientUsername string          `json:"recipient_username" db:"-"`
+	WrappedKey        []byte          `json:"wrapped_key" db:"wrapped_key"`
+	Permission        SharePermission `json:"permission" db:"permission"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+}
+
+// CanWrite проверяет, может ли получатель изменять запись.
+func (s *EntryShare) CanWrite() bool {
+	return s.Permission == SharePermissionWrite
+}
+
+// Ограничения вложений записей. Размер считается по зашифрованному содержимому
+// и не превышает ограничения сообщения gRPC по умолчанию (4 МБ).
+const (
+	MaxAttachmentSize       = 3 << 20  // размер одного вложения
+	MaxEntryAttachmentsSize = 20 << 20 // суммарный размер вложений записи
+	MaxEntryAttachments     = 20       // количество вложений записи
+)
+
+// Attachment представляет файл, прикрепленный к записи. Содержимое зашифровано
+// собственным ключом вложения, ключ хранится зашифрованным ключом записи.
+type Attachment struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	EntryID       uuid.UUID `json:"entry_id" db:"entry_id"`
+	UploadedBy    uuid.UUID `json:"uploaded_by" db:"uploaded_by"`
+	Name          string    `json:"name" db:"name"`
+	MimeType      string    `json:"mime_type" db:"mime_type"`
+	Size          int64     `json:"size" db:"size"`
+	WrappedKey    []byte    `json:"wrapped_key" db:"wrapped_key"`
+	EncryptedData []byte    `json:"encrypted_data,omitempty" db:"encrypted_data"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// Organization представляет организацию с общими коллекциями записей.
+type Organization struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	// Role роль текущего пользователя в организации
+	Role OrganizationRole `json:"role" db:"-"`
+}
+
+// OrganizationMember представляет участника организации.
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id" db:"organization_id"`
+	UserID         uuid.UUID        `json:"user_id" db:"user_id"`
+	Username       string           `json:"username" db:"-"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+// OrganizationInvitation представляет приглашение пользователя в организацию.
+type OrganizationInvitation struct {
+	ID               uuid.UUID        `json:"id" db:"id"`
+	OrganizationID   uuid.UUID        `json:"organization_id" db:"organization_id"`
+	OrganizationName string           `json:"organization_name" db:"-"`
+	InviteeID        uuid.UUID        `json:"invitee_id" db:"invitee_id"`
+	InviteeUsername  string           `json:"invitee_username" db:"-"`
+	InvitedBy        uuid.UUID        `json:"invited_by" db:"invited_by"`
+	InvitedByName    string           `json:"invited_by_username" db:"-"`
+	Role             OrganizationRole `json:"role" db:"role"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	ExpiresAt        time.Time        `json:"expires_at" db:"expires_at"`
+}
+
+// Collection представляет коллекцию записей организации. Записи шифруются
+// ключом коллекции, который хранится зашифрованным для каждого участника.
+type Collection struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	Name           string    `json:"name" db:"name"`
+	KeyVersion     int       `json:"key_version" db:"key_version"`
+	// RotationRequired устанавливается при исключении участника
+	RotationRequired bool `json:"rotation_required" db:"rotation_required"`
+	// RotationDays период смены паролей записей коллекции в днях, 0 - политика не задана
+	RotationDays int       `json:"rotation_days,omitempty" db:"rotation_days"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	// WrappedKey ключ коллекции, зашифрованный для текущего пользователя
+	WrappedKey []byte `json:"wrapped_key,omitempty" db:"-"`
+}
+
+// CollectionKey представляет ключ коллекции, зашифрованный открытым ключом участника.
+type CollectionKey struct {
+	CollectionID uuid.UUID `json:"collection_id" db:"collection_id"`
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	KeyVersion   int       `json:"key_version" db:"key_version"`
+	WrappedKey   []byte    `json:"wrapped_key" db:"wrapped_key"`
+}
+
+// SecretLink представляет одноразовую ссылку на секрет для передачи
+// человеку без аккаунта. Ключ расшифровки хранится только во фрагменте ссылки.
+type SecretLink struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	UserID        uuid.UUID `json:"user_id" db:"user_id"`
+	EncryptedData []byte    `json:"encrypted_data" db:"encrypted_data"`
+	MaxViews      int       `json:"max_views" db:"max_views"`
+	Views         int       `json:"views" db:"views"`
+	ExpiresAt     time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// ViewsLeft возвращает количество оставшихся просмотров ссылки.
+func (l *SecretLink) ViewsLeft() int {
+	if l.Views >= l.MaxViews {
+		return 0
+	}
+	return l.MaxViews - l.Views
+}
+
+// EmergencyAccessStatus определяет состояние экстренного доступа.
+type EmergencyAccessStatus string
+
+const (
+	// EmergencyAccessIdle - контакт назначен, доступ не запрошен
+	EmergencyAccessIdle EmergencyAccessStatus = "idle"
+	// EmergencyAccessRequested - контакт запросил доступ, идет период ожидания
+	EmergencyAccessRequested EmergencyAccessStatus = "requested"
+	// EmergencyAccessApproved - доступ предоставлен
+	EmergencyAccessApproved EmergencyAccessStatus = "approved"
+)
+
+// EmergencyAccess представляет экстренный доступ контакта к хранилищу владельца.
+// Ключ хранилища зашифрован открытым ключом контакта и выдается только после
+// одобрения владельцем или истечения периода ожидания без отказа.
+type EmergencyAccess struct {
+	ID              uuid.UUID             `json:"id" db:"id"`
+	GrantorID       uuid.UUID             `json:"grantor_id" db:"grantor_id"`
+	GrantorUsername string                `json:"grantor_username" db:"grantor_username"`
+	GranteeID       uuid.UUID             `json:"grantee_id" db:"grantee_id"`
+	GranteeUsername string                `json:"grantee_username" db:"grantee_username"`
+	WaitDays        int                   `json:"wait_days" db:"wait_days"`
+	WrappedKey      []byte                `json:"-" db:"wrapped_key"`
+	Status          EmergencyAccessStatus `json:"status" db:"status"`
+	RequestedAt     *time.Time            `json:"requested_at,omitempty" db:"requested_at"`
+	ApprovedAt      *time.Time            `json:"approved_at,omitempty" db:"approved_at"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// AvailableAt возвращает момент автоматического предоставления запрошенного доступа.
+func (a *EmergencyAccess) AvailableAt() *time.Time {
+	if a.Status != EmergencyAccessRequested || a.RequestedAt == nil {
+		return nil
+	}
+	availableAt := a.RequestedAt.Add(time.Duration(a.WaitDays) * 24 * time.Hour)
+	return &availableAt
+}
+
+// WaitElapsed проверяет, истек ли период ожидания запрошенного доступа.
+func (a *EmergencyAccess) WaitElapsed(now time.Time) bool {
+	availableAt := a.AvailableAt()
+	return availableAt != nil && !now.Before(*availableAt)
+}
+
+// EmergencyAccessEventType определяет тип события журнала экстренного доступа.
+type EmergencyAccessEventType string
+
+const (
+	EmergencyEventDesignated    EmergencyAccessEventType = "designated"
+	EmergencyEventRequested     EmergencyAccessEventType = "requested"
+	EmergencyEventApproved      EmergencyAccessEventType = "approved"
+	EmergencyEventDenied        EmergencyAccessEventType = "denied"
+	EmergencyEventGranted       EmergencyAccessEventType = "granted"
+	EmergencyEventVaultAccessed EmergencyAccessEventType = "vault_accessed"
+	EmergencyEventRevoked       EmergencyAccessEventType = "revoked"
+)
+
+// EmergencyAccessEvent представляет запись журнала экстренного доступа.
+// ActorID не задан для событий, выполненных сервером автоматически.
+type EmergencyAccessEvent struct {
+	ID                uuid.UUID                `json:"id" db:"id"`
+	EmergencyAccessID uuid.UUID                `json:"emergency_access_id" db:"emergency_access_id"`
+	ActorID           *uuid.UUID               `json:"actor_id,omitempty" db:"actor_id"`
+	ActorUsername     string                   `json:"actor_username,omitempty" db:"actor_username"`
+	Event             EmergencyAccessEventType `json:"event" db:"event"`
+	CreatedAt         time.Time                `json:"created_at" db:"created_at"`
+}
+
+// Notification представляет уведомление пользователя.
+type Notification struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Message   string    `json:"message" db:"message"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PayloadSchemaVersion текущая версия схемы содержимого записей.
+// Версия 1 (поле отсутствует) - содержимое без пользовательских полей,
+// версия 2 добавила пользовательские поля.
+const PayloadSchemaVersion = 2
+
+// MaxCustomFields ограничивает количество пользовательских полей записи.
+const MaxCustomFields = 50
+
+// CustomFieldType представляет тип пользовательского поля записи.
+type CustomFieldType string
+
+const (
+	CustomFieldText    CustomFieldType = "text"    // произвольный текст
+	CustomFieldHidden  CustomFieldType = "hidden"  // скрываемое значение
+	CustomFieldURL     CustomFieldType = "url"     // абсолютный URL
+	CustomFieldEmail   CustomFieldType = "email"   // адрес электронной почты
+	CustomFieldDate    CustomFieldType = "date"    // дата в формате YYYY-MM-DD
+	CustomFieldBoolean CustomFieldType = "boolean" // true или false
+)
+
+// CustomFieldTypes перечисляет типы пользовательских полей в порядке выбора.
+var CustomFieldTypes = []CustomFieldType{
+	CustomFieldText, CustomFieldHidden, CustomFieldURL,
+	CustomFieldEmail, CustomFieldDate, CustomFieldBoolean,
+}
+
+// CustomField представляет пользовательское поле записи.
+type CustomField struct {
+	Name  string          `json:"name" validate:"required,max=100"`
+	Type  CustomFieldType `json:"type" validate:"required,oneof=text hidden url email date boolean"`
+	Value string          `json:"value"`
+}
+
+// CustomFields содержит версию схемы и пользовательские поля. Встраивается
+// в содержимое записей всех типов и шифруется вместе с ним.
+type CustomFields struct {
+	SchemaVersion int           `json:"schema_version,omitempty"`
+	Fields        []CustomField `json:"fields,omitempty" validate:"omitempty,max=50,dive"`
+}
+
+// SupportsSchema проверяет, что содержимое записано известной версией схемы
+// и может быть перезаписано без потери полей.
+func (c CustomFields) SupportsSchema() bool {
+	return c.SchemaVersion <= PayloadSchemaVersion
 }
 
 // Credentials представляет пары логин/пароль.
@@ -46,12 +423,14 @@ type Credentials struct {
 	Password string `json:"password" validate:"required"`
 	URL      string `json:"url,omitempty"`
 	Notes    string `json:"notes,omitempty"`
+	CustomFields
 }
 
 // TextData представляет произвольные текстовые данные.
 type TextData struct {
 	Content string `json:"content" validate:"required"`
 	Notes   string `json:"notes,omitempty"`
+	CustomFields
 }
 
 // BinaryData представляет произвольные бинарные данные.
@@ -60,16 +439,88 @@ type BinaryData struct {
 	Content  []byte `json:"content" validate:"required"`
 	MimeType string `json:"mime_type,omitempty"`
 	Notes    string `json:"notes,omitempty"`
+	CustomFields
 }
 
-// CardData представляет данные банковских карт.
+// CardData представляет данные банковских карт. Номер по алгоритму Луна,
+// срок действия, CVV и PIN проверяет пакет payload.
 type CardData struct {
-	Number     string `json:"number" validate:"required,creditcard"`
+	Number     string `json:"number" validate:"required"`
 	ExpiryDate string `json:"expiry_date" validate:"required"`
 	Holder     string `json:"holder" validate:"required"`
-	CVV        string `json:"cvv" validate:"required,len=3"`
+	CVV        string `json:"cvv" validate:"required"`
 	PIN        string `json:"pin,omitempty"`
 	Notes      string `json:"notes,omitempty"`
+	CustomFields
+}
+
+// OTPData представляет секрет TOTP или HOTP для вычисления одноразовых кодов
+// на клиенте. Пустые Type, Algorithm, Digits и Period означают TOTP, SHA1,
+// 6 цифр и 30 секунд. Counter хранит следующее значение счетчика HOTP.
+type OTPData struct {
+	Type        string `json:"type,omitempty" validate:"omitempty,oneof=totp hotp"`
+	Secret      string `json:"secret" validate:"required"`
+	Issuer      string `json:"issuer,omitempty"`
+	AccountName string `json:"account_name,omitempty"`
+	Algorithm   string `json:"algorithm,omitempty" validate:"omitempty,oneof=SHA1 SHA256 SHA512"`
+	Digits      int    `json:"digits,omitempty" validate:"omitempty,oneof=6 8"`
+	Period      int    `json:"period,omitempty" validate:"omitempty,min=1,max=300"`
+	Counter     uint64 `json:"counter,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+	CustomFields
+}
+
+// SSHKeyData представляет пару ключей SSH. Закрытый ключ хранится в формате
+// PEM или OpenSSH, открытый - в формате authorized_keys. Если открытый ключ
+// не указан, клиент вычисляет его отпечаток по закрытому.
+type SSHKeyData struct {
+	PrivateKey string `json:"private_key" validate:"required"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+	CustomFields
+}
+
+// APIKeyData представляет ключ или токен API. ExpiresAt - дата окончания
+// действия в формате YYYY-MM-DD, пустая для бессрочных ключей.
+type APIKeyData struct {
+	Key       string `json:"key" validate:"required"`
+	Secret    string `json:"secret,omitempty"`
+	URL       string `json:"url,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+	CustomFields
+}
+
+// IdentityData представляет документ, удостоверяющий личность. Даты хранятся
+// в формате YYYY-MM-DD, страна - двухбуквенным кодом ISO 3166-1.
+type IdentityData struct {
+	DocumentType string `json:"document_type" validate:"required,oneof=passport driver_license id_card other"`
+	Number       string `json:"number" validate:"required"`
+	FullName     string `json:"full_name" validate:"required"`
+	BirthDate    string `json:"birth_date,omitempty"`
+	Country      string `json:"country,omitempty" validate:"omitempty,len=2,alpha"`
+	IssuedBy     string `json:"issued_by,omitempty"`
+	IssuedAt     string `json:"issued_at,omitempty"`
+	ExpiresAt    string `json:"expires_at,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+	CustomFields
+}
+
+// WiFiData представляет параметры сети Wi-Fi. Пустая защита означает WPA2.
+type WiFiData struct {
+	SSID     string `json:"ssid" validate:"required,max=32"`
+	Password string `json:"password,omitempty"`
+	Security string `json:"security,omitempty" validate:"omitempty,oneof=open wep wpa wpa2 wpa3"`
+	Hidden   bool   `json:"hidden,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+	CustomFields
+}
+
+// SecureNoteData представляет защищенную заметку с текстом в формате Markdown.
+type SecureNoteData struct {
+	Content string `json:"content" validate:"required"`
+	CustomFields
 }
 
 // AuthRequest представляет запрос на аутентификацию.
@@ -87,17 +538,181 @@ type AuthResponse struct {
 
 // RegisterRequest представляет запрос на регистрацию пользователя.
 type RegisterRequest struct {
-	Username string `json:"username" validate:"required,min=3,max=50"`
-	Password string `json:"password" validate:"required,min=6"`
+	Username    string `json:"username" validate:"required,min=3,max=50"`
+	Password    string `json:"password" validate:"required_without=SRPVerifier,omitempty,min=6"`
+	SRPSalt     []byte `json:"srp_salt,omitempty" validate:"required_with=SRPVerifier"`
+	SRPVerifier []byte `json:"srp_verifier,omitempty"`
+}
+
+// BeginSRPLoginRequest представляет первый шаг входа по SRP-6a.
+type BeginSRPLoginRequest struct {
+	Username        string `json:"username" validate:"required"`
+	ClientPublicKey []byte `json:"client_public_key" validate:"required"`
+}
+
+// FinishSRPLoginRequest представляет второй шаг входа по SRP-6a.
+type FinishSRPLoginRequest struct {
+	LoginID     string `json:"login_id" validate:"required"`
+	ClientProof []byte `json:"client_proof" validate:"required"`
+}
+
+// AccessToken представляет персональный токен доступа для автоматизации.
+type AccessToken struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Name      string    `json:"name" db:"name"`
+	TokenHash []byte    `json:"-" db:"token_hash"`
+	// CertificateFingerprint отпечаток клиентского сертификата, которым предъявляется токен
+	CertificateFingerprint []byte     `json:"certificate_fingerprint,omitempty" db:"certificate_fingerprint"`
+	ReadOnly               bool       `json:"read_only" db:"read_only"`
+	DataTypes              []DataType `json:"data_types" db:"data_types"`
+	ExpiresAt              time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt              time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt             *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt              *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// IsActive проверяет, что токен не отозван и не истек.
+func (t *AccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// AllowsType проверяет, разрешен ли токену доступ к типу данных.
+// Пустой список типов означает доступ ко всем типам.
+func (t *AccessToken) AllowsType(dataType DataType) bool {
+	if len(t.DataTypes) == 0 {
+		return true
+	}
+	for _, allowed := range t.DataTypes {
+		if allowed == dataType {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAccessTokenRequest представляет запрос на создание токена доступа.
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name" validate:"required,min=1,max=100"`
+	ReadOnly  bool       `json:"read_only"`
+	DataTypes []DataType `json:"data_types" validate:"dive,oneof=credentials text binary card otp ssh_key api_key identity wifi secure_note"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	// ClientCertificate привязывает токен к клиентскому сертификату mTLS (PEM)
+	ClientCertificate string `json:"client_certificate"`
+}
+
+// SetVaultKeyRequest представляет запрос на сохранение ключа хранилища.
+type SetVaultKeyRequest struct {
+	WrappedVaultKey []byte `json:"wrapped_vault_key" validate:"required"`
+}
+
+// SetPublicKeyRequest представляет запрос на публикацию открытого ключа.
+type SetPublicKeyRequest struct {
+	PublicKey         []byte `json:"public_key" validate:"required"`
+	WrappedPrivateKey []byte `json:"wrapped_private_key"`
+}
+
+// ShareEntryRequest представляет запрос на предоставление доступа к записи.
+type ShareEntryRequest struct {
+	RecipientUsername string          `json:"recipient_username" validate:"required"`
+	WrappedKey        []byte          `json:"wrapped_key" validate:"required"`
+	Permission        SharePermission `json:"permission" validate:"omitempty,oneof=read write"`
+}
+
+// AddAttachmentRequest представляет запрос на добавление вложения к записи.
+type AddAttachmentRequest struct {
+	Name          string `json:"name" validate:"required,max=255"`
+	MimeType      string `json:"mime_type" validate:"max=255"`
+	WrappedKey    []byte `json:"wrapped_key" validate:"required"`
+	EncryptedData []byte `json:"encrypted_data" validate:"required"`
+}
+
+// CreateOrganizationRequest представляет запрос на создание организации.
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// InviteMemberRequest представляет запрос на приглашение в организацию.
+type InviteMemberRequest struct {
+	Username string           `json:"username" validate:"required"`
+	Role     OrganizationRole `json:"role" validate:"required,oneof=owner admin editor viewer"`
+}
+
+// UpdateMemberRoleRequest представляет запрос на изменение роли участника.
+type UpdateMemberRoleRequest struct {
+	Role OrganizationRole `json:"role" validate:"required,oneof=owner admin editor viewer"`
+}
+
+// CreateCollectionRequest представляет запрос на создание коллекции.
+type CreateCollectionRequest struct {
+	Name         string `json:"name" validate:"required,min=1,max=100"`
+	WrappedKey   []byte `json:"wrapped_key" validate:"required"`
+	RotationDays int    `json:"rotation_days" validate:"omitempty,min=1,max=3650"`
+}
+
+// SetRotationPolicyRequest представляет запрос на изменение политики смены паролей коллекции.
+type SetRotationPolicyRequest struct {
+	RotationDays int `json:"rotation_days" validate:"omitempty,min=1,max=3650"`
+}
+
+// GrantCollectionKeyRequest представляет запрос на передачу ключа коллекции участнику.
+type GrantCollectionKeyRequest struct {
+	WrappedKey []byte `json:"wrapped_key" validate:"required"`
+	KeyVersion int    `json:"key_version" validate:"required,min=1"`
+}
+
+// RotateCollectionKeyRequest представляет запрос на смену ключа коллекции.
+type RotateCollectionKeyRequest struct {
+	KeyVersion int             `json:"key_version" validate:"required,min=2"`
+	Keys       []CollectionKey `json:"keys" validate:"required,min=1"`
+	Entries    []DataEntry     `json:"entries"`
+}
+
+// CreateCollectionEntryRequest представляет запрос на создание записи в коллекции.
+// Данные передаются уже зашифрованными ключом коллекции.
+type CreateCollectionEntryRequest struct {
+	Type          DataType `json:"type" validate:"required,oneof=credentials text binary card otp ssh_key api_key identity wifi secure_note"`
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	Description   string   `json:"description"`
+	EncryptedData []byte   `json:"encrypted_data" validate:"required"`
+	Metadata      string   `json:"metadata"`
+	RotationDays  int      `json:"rotation_days" validate:"omitempty,min=1,max=3650"`
+}
+
+// CreateSecretLinkRequest представляет запрос на создание одноразовой ссылки.
+type CreateSecretLinkRequest struct {
+	EncryptedData []byte    `json:"encrypted_data" validate:"required"`
+	MaxViews      int       `json:"max_views" validate:"omitempty,min=1,max=100"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// AddEmergencyContactRequest представляет запрос на назначение контакта для экстренного доступа.
+type AddEmergencyContactRequest struct {
+	Username   string `json:"username" validate:"required"`
+	WaitDays   int    `json:"wait_days" validate:"omitempty,min=1,max=90"`
+	WrappedKey []byte `json:"wrapped_key" validate:"required"`
+}
+
+// ChangePasswordRequest представляет запрос на смену пароля.
+type ChangePasswordRequest struct {
+	OldPassword     string `json:"old_password,omitempty"`
+	NewPassword     string `json:"new_password" validate:"required_without=SRPVerifier,omitempty,min=6"`
+	WrappedVaultKey []byte `json:"wrapped_vault_key,omitempty"`
+	SRPSalt         []byte `json:"srp_salt,omitempty" validate:"required_with=SRPVerifier"`
+	SRPVerifier     []byte `json:"srp_verifier,omitempty"`
+	// SRPLoginID и SRPClientProof подтверждают текущий пароль аккаунта с SRP
+	SRPLoginID     string `json:"srp_login_id,omitempty"`
+	SRPClientProof []byte `json:"srp_client_proof,omitempty" validate:"required_with=SRPLoginID"`
 }
 
 // CreateDataRequest представляет запрос на создание данных.
 type CreateDataRequest struct {
-	Type        DataType    `json:"type" validate:"required,oneof=credentials text binary card"`
-	Name        string      `json:"name" validate:"required,min=1,max=100"`
-	Description string      `json:"description"`
-	Data        interface{} `json:"data" validate:"required"`
-	Metadata    string      `json:"metadata"`
+	Type         DataType    `json:"type" validate:"required,oneof=credentials text binary card otp ssh_key api_key identity wifi secure_note"`
+	Name         string      `json:"name" validate:"required,min=1,max=100"`
+	Description  string      `json:"description"`
+	Data         interface{} `json:"data" validate:"required"`
+	Metadata     string      `json:"metadata"`
+	RotationDays int         `json:"rotation_days" validate:"omitempty,min=1,max=3650"`
 }
 
 // UpdateDataRequest представляет запрос на обновление данных.
@@ -108,6 +723,10 @@ type UpdateDataRequest struct {
 	Data        interface{} `json:"data" validate:"required"`
 	Metadata    string      `json:"metadata"`
 	Version     int64       `json:"version" validate:"required"`
+	// RotationDays заменяет политику записи, 0 снимает ее; nil сохраняет текущую
+	RotationDays *int `json:"rotation_days,omitempty" validate:"omitempty,min=0,max=3650"`
+	// PasswordChanged сообщает, что пароль записи изменен
+	PasswordChanged bool `json:"password_changed"`
 }
 
 // DataResponse представляет ответ с данными.
@@ -135,9 +754,33 @@ type SyncResponse struct {
 	LastSyncTime time.Time      `json:"last_sync_time"`
 }
 
+// AccountExport представляет полный экспорт данных аккаунта.
+type AccountExport struct {
+	Version         int         `json:"version"`
+	ExportedAt      time.Time   `json:"exported_at"`
+	Username        string      `json:"username"`
+	CreatedAt       time.Time   `json:"created_at"`
+	WrappedVaultKey []byte      `json:"wrapped_vault_key,omitempty"`
+	Entries         []DataEntry `json:"entries"`
+	DeletedIDs      []uuid.UUID `json:"deleted_ids"`
+	// Attachments вложения записей с содержимым и ключами, зашифрованными на клиенте
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// AccountPasswordRequest представляет запрос, требующий повторного ввода пароля.
+// Аккаунты с SRP вместо пароля передают свежее доказательство SRP.
+type AccountPasswordRequest struct {
+	Password       string `json:"password" validate:"required_without=SRPLoginID"`
+	SRPLoginID     string `json:"srp_login_id" validate:"required_without=Password"`
+	SRPClientProof []byte `json:"srp_client_proof" validate:"required_with=SRPLoginID"`
+}
+
 // OTPRequest представляет запрос на генерацию OTP.
 type OTPRequest struct {
-	Secret string `json:"secret" validate:"required"`
+	Secret    string `json:"secret" validate:"required"`
+	Algorithm string `json:"algorithm,omitempty" validate:"omitempty,oneof=SHA1 SHA256 SHA512"`
+	Digits    int    `json:"digits,omitempty" validate:"omitempty,oneof=6 8"`
+	Period    int    `json:"period,omitempty" validate:"omitempty,min=1,max=300"`
 }
 
 // OTPResponse представляет ответ с OTP кодом.
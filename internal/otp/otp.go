@@ -5,7 +5,6 @@ import (
 	"crypto/rand"
 	"encoding/base32"
 	"fmt"
-	"net/url"
 	"strings"
 	"time"
 
@@ -13,6 +12,94 @@ import (
 	"github.com/pquerna/otp/totp"
 )
 
+// Параметры TOTP по умолчанию, которые используют большинство сервисов.
+const (
+	DefaultAlgorithm = "SHA1"
+	DefaultDigits    = 6
+	DefaultPeriod    = 30
+)
+
+// Params описывает параметры генерации TOTP кодов.
+// Нулевые значения заменяются параметрами по умолчанию.
+type Params struct {
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// withDefaults возвращает параметры с подставленными значениями по умолчанию.
+func (p Params) withDefaults() Params {
+	if p.Algorithm == "" {
+		p.Algorithm = DefaultAlgorithm
+	}
+	if p.Digits == 0 {
+		p.Digits = DefaultDigits
+	}
+	if p.Period == 0 {
+		p.Period = DefaultPeriod
+	}
+	return p
+}
+
+// TimeRemaining возвращает количество секунд до смены кода, действующего в момент t.
+func (p Params) TimeRemaining(t time.Time) int {
+	period := int64(p.withDefaults().Period)
+	return int(period - t.Unix()%period)
+}
+
+// validateOpts преобразует параметры в настройки библиотеки otp.
+func (p Params) validateOpts() (totp.ValidateOpts, error) {
+	p = p.withDefaults()
+
+	var algorithm otp.Algorithm
+	switch strings.ToUpper(p.Algorithm) {
+	case "SHA1":
+		algorithm = otp.AlgorithmSHA1
+	case "SHA256":
+		algorithm = otp.AlgorithmSHA256
+	case "SHA512":
+		algorithm = otp.AlgorithmSHA512
+	default:
+		return totp.ValidateOpts{}, fmt.Errorf("unsupported OTP algorithm: %s", p.Algorithm)
+	}
+
+	var digits otp.Digits
+	switch p.Digits {
+	case 6:
+		digits = otp.DigitsSix
+	case 8:
+		digits = otp.DigitsEight
+	default:
+		return totp.ValidateOpts{}, fmt.Errorf("unsupported OTP digits: %d", p.Digits)
+	}
+
+	if p.Period <= 0 {
+		return totp.ValidateOpts{}, fmt.Errorf("invalid OTP period: %d", p.Period)
+	}
+
+	return totp.ValidateOpts{
+		Period:    uint(p.Period),
+		Digits:    digits,
+		Algorithm: algorithm,
+	}, nil
+}
+
+// GenerateCodeAt вычисляет TOTP код для момента t с заданными параметрами.
+// Используется клиентом для локального вычисления кодов из сохраненных записей.
+func GenerateCodeAt(secret string, params Params, t time.Time) (string, error) {
+	opts, err := params.validateOpts()
+	if err != nil {
+		return "", err
+	}
+
+	code, err := totp.GenerateCodeCustom(NormalizeSecret(secret), t, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate TOTP code: %w", err)
+	}
+
+	return code, nil
+}
+
 // Service предоставляет методы для работы с OTP.
 type Service struct{}
 
@@ -32,14 +119,14 @@ func (s *Service) GenerateSecret() (string, error) {
 	return base32.StdEncoding.EncodeToString(secret), nil
 }
 
-// GenerateCode генерирует текущий TOTP код из секрета.
+// GenerateCode генерирует текущий TOTP код из секрета с параметрами по умолчанию.
 func (s *Service) GenerateCode(secret string) (string, error) {
-	code, err := totp.GenerateCode(secret, time.Now())
-	if err != nil {
-		return "", fmt.Errorf("failed to generate TOTP code: %w", err)
-	}
+	return s.GenerateCodeWithParams(secret, Params{})
+}
 
-	return code, nil
+// GenerateCodeWithParams генерирует текущий TOTP код с заданными параметрами.
+func (s *Service) GenerateCodeWithParams(secret string, params Params) (string, error) {
+	return GenerateCodeAt(secret, params, time.Now())
 }
 
 // ValidateCode проверяет TOTP код.
@@ -50,22 +137,31 @@ func (s *Service) ValidateCode(secret, code string) (bool, error) {
 
 // GenerateQRCodeURL генерирует URL для QR кода для настройки приложения аутентификатора.
 func (s *Service) GenerateQRCodeURL(secret, issuer, accountName string) (string, error) {
-	key, err := otp.NewKeyFromURL(fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s&algorithm=SHA1&digits=6&period=30",
-		url.QueryEscape(issuer),
-		url.QueryEscape(accountName),
-		secret,
-		url.QueryEscape(issuer)))
+	return s.GenerateQRCodeURLWithParams(secret, issuer, accountName, Params{})
+}
+
+// GenerateQRCodeURLWithParams генерирует otpauth://totp URI с заданными параметрами.
+func (s *Service) GenerateQRCodeURLWithParams(secret, issuer, accountName string, params Params) (string, error) {
+	key := &Key{
+		Type:        TypeTOTP,
+		Secret:      secret,
+		Issuer:      issuer,
+		AccountName: accountName,
+		Params:      params,
+	}
+
+	uri, err := key.URI()
 	if err != nil {
 		return "", fmt.Errorf("failed to create OTP key: %w", err)
 	}
 
-	return key.URL(), nil
+	return uri, nil
 }
 
-// GetTimeRemaining возвращает количество секунд до истечения текущего кода.
+// GetTimeRemaining возвращает количество секунд до истечения текущего кода
+// с периодом по умолчанию.
 func (s *Service) GetTimeRemaining() int {
-	period := 30 // TOTP период в секундах
-	return period - int(time.Now().Unix()%int64(period))
+	return Params{}.TimeRemaining(time.Now())
 }
 
 // GenerateBackupCodes генерирует резервные коды для восстановления доступа.
@@ -1,8 +1,10 @@
 package otp
 
 import (
+	"encoding/base32"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -61,3 +63,40 @@ func TestGetTimeRemaining(t *testing.T) {
 	require.GreaterOrEqual(t, rem, 0)
 	require.LessOrEqual(t, rem, 30)
 }
+
+func TestGenerateCodeAt(t *testing.T) {
+	// Тестовые векторы RFC 6238 для момента T = 59
+	seed := "12345678901234567890"
+	at := time.Unix(59, 0)
+
+	tests := []struct {
+		name      string
+		secret    string
+		algorithm string
+		want      string
+	}{
+		{"SHA1", seed, "SHA1", "94287082"},
+		{"SHA256", strings.Repeat(seed, 2)[:32], "SHA256", "46119246"},
+		{"SHA512", strings.Repeat(seed, 4)[:64], "SHA512", "90693936"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := base32.StdEncoding.EncodeToString([]byte(tt.secret))
+			code, err := GenerateCodeAt(secret, Params{Algorithm: tt.algorithm, Digits: 8}, at)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, code)
+		})
+	}
+
+	// Параметры по умолчанию совпадают с GenerateCode
+	secret := base32.StdEncoding.EncodeToString([]byte(seed))
+	code, err := GenerateCodeAt(secret, Params{}, at)
+	require.NoError(t, err)
+	require.Equal(t, "287082", code)
+
+	_, err = GenerateCodeAt(secret, Params{Algorithm: "MD5"}, at)
+	require.Error(t, err)
+	_, err = GenerateCodeAt(secret, Params{Digits: 7}, at)
+	require.Error(t, err)
+}
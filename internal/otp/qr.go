@@ -0,0 +1,90 @@
+package otp
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/boombuler/barcode/qr"
+	"github.com/makiuchi-d/gozxing"
+	zxingqr "github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// qrQuietZone ширина обязательного светлого поля вокруг QR кода в модулях.
+const qrQuietZone = 4
+
+// QRCodeMatrix кодирует содержимое в QR код и возвращает матрицу модулей
+// вместе со светлым полем по краям. true соответствует темному модулю.
+func QRCodeMatrix(content string) ([][]bool, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	size := code.Bounds().Dx()
+	matrix := make([][]bool, size+2*qrQuietZone)
+	for y := range matrix {
+		matrix[y] = make([]bool, size+2*qrQuietZone)
+	}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			matrix[y+qrQuietZone][x+qrQuietZone] = code.At(x, y) == color.Black
+		}
+	}
+
+	return matrix, nil
+}
+
+// QRCodePNG возвращает QR код в виде PNG изображения. Размер модуля
+// подбирается так, чтобы сторона изображения не превышала size пикселей.
+func QRCodePNG(content string, size int) ([]byte, error) {
+	matrix, err := QRCodeMatrix(content)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := size / len(matrix)
+	if scale < 1 {
+		scale = 1
+	}
+
+	side := len(matrix) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			if matrix[y/scale][x/scale] {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeQRImage распознает QR код на изображении, например на снимке экрана
+// с настройкой двухфакторной аутентификации, и возвращает его содержимое.
+func DecodeQRImage(img image.Image) (string, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_TRY_HARDER: true,
+	}
+	result, err := zxingqr.NewQRCodeReader().Decode(bitmap, hints)
+	if err != nil {
+		return "", fmt.Errorf("QR code not found: %w", err)
+	}
+
+	return result.GetText(), nil
+}
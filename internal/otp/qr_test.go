@@ -0,0 +1,49 @@
+package otp
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRCodePNGRoundTrip(t *testing.T) {
+	key := &Key{Type: TypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Issuer: "GophKeeper", AccountName: "alice@example.com",
+		Params: Params{Algorithm: "SHA256", Digits: 8, Period: 60}}
+	uri, err := key.URI()
+	require.NoError(t, err)
+
+	data, err := QRCodePNG(uri, 256)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.LessOrEqual(t, img.Bounds().Dx(), 256)
+	require.Equal(t, img.Bounds().Dx(), img.Bounds().Dy())
+
+	decoded, err := DecodeQRImage(img)
+	require.NoError(t, err)
+	require.Equal(t, uri, decoded)
+}
+
+func TestQRCodeMatrix(t *testing.T) {
+	matrix, err := QRCodeMatrix("otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	require.NotEmpty(t, matrix)
+	require.Len(t, matrix[0], len(matrix))
+
+	// Светлое поле по краям
+	for i := range matrix {
+		require.False(t, matrix[0][i])
+		require.False(t, matrix[i][0])
+	}
+	// Угловой поисковый узор начинается сразу за светлым полем
+	require.True(t, matrix[qrQuietZone][qrQuietZone])
+}
+
+func TestDecodeQRImageWithoutCode(t *testing.T) {
+	_, err := DecodeQRImage(image.NewGray(image.Rect(0, 0, 64, 64)))
+	require.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package otp
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pquerna/otp/hotp"
+)
+
+// Типы одноразовых паролей в otpauth:// URI.
+const (
+	TypeTOTP = "totp" // коды по времени (RFC 6238)
+	TypeHOTP = "hotp" // коды по счетчику (RFC 4226)
+)
+
+// ErrInvalidURI возвращается при разборе некорректного otpauth:// URI.
+var ErrInvalidURI = errors.New("invalid otpauth URI")
+
+// Key описывает секрет одноразовых паролей со всеми параметрами,
+// которые передаются в otpauth:// URI.
+type Key struct {
+	Type        string
+	Secret      string
+	Issuer      string
+	AccountName string
+	Params
+	// Counter используется только для HOTP и указывает следующее значение счетчика
+	Counter uint64
+}
+
+// NormalizeSecret приводит секрет к каноническому виду base32 без пробелов
+// и выравнивания, в котором его ожидают приложения аутентификаторы.
+func NormalizeSecret(secret string) string {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	return strings.TrimRight(secret, "=")
+}
+
+// Validate проверяет тип, секрет и параметры ключа.
+func (k *Key) Validate() error {
+	if k.Type != TypeTOTP && k.Type != TypeHOTP {
+		return fmt.Errorf("unsupported OTP type: %s", k.Type)
+	}
+	if k.Secret == "" {
+		return errors.New("OTP secret is required")
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(NormalizeSecret(k.Secret)); err != nil {
+		return fmt.Errorf("OTP secret is not valid base32: %w", err)
+	}
+	if _, err := k.Params.validateOpts(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// URI формирует otpauth:// URI ключа для импорта в приложение аутентификатор.
+func (k *Key) URI() (string, error) {
+	if err := k.Validate(); err != nil {
+		return "", err
+	}
+
+	params := k.Params.withDefaults()
+	query := url.Values{}
+	query.Set("secret", NormalizeSecret(k.Secret))
+	if k.Issuer != "" {
+		query.Set("issuer", k.Issuer)
+	}
+	query.Set("algorithm", strings.ToUpper(params.Algorithm))
+	query.Set("digits", strconv.Itoa(params.Digits))
+	if k.Type == TypeHOTP {
+		query.Set("counter", strconv.FormatUint(k.Counter, 10))
+	} else {
+		query.Set("period", strconv.Itoa(params.Period))
+	}
+
+	label := k.AccountName
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.AccountName
+	}
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     k.Type,
+		Path:     "/" + label,
+		RawQuery: query.Encode(),
+	}
+	return uri.String(), nil
+}
+
+// ParseURI разбирает otpauth://totp и otpauth://hotp URI. Издатель берется
+// из параметра issuer, а при его отсутствии - из префикса метки.
+func ParseURI(raw string) (*Key, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURI, err)
+	}
+	if u.Scheme != "otpauth" {
+		return nil, fmt.Errorf("%w: unexpected scheme %q", ErrInvalidURI, u.Scheme)
+	}
+
+	key := &Key{Type: strings.ToLower(u.Host)}
+	query := u.Query()
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		key.Issuer = strings.TrimSpace(issuer)
+		key.AccountName = strings.TrimSpace(account)
+	} else {
+		key.AccountName = label
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		key.Issuer = issuer
+	}
+
+	key.Secret = NormalizeSecret(query.Get("secret"))
+	key.Algorithm = strings.ToUpper(query.Get("algorithm"))
+
+	if digits := query.Get("digits"); digits != "" {
+		if key.Digits, err = strconv.Atoi(digits); err != nil {
+			return nil, fmt.Errorf("%w: invalid digits %q", ErrInvalidURI, digits)
+		}
+	}
+
+	switch key.Type {
+	case TypeTOTP:
+		if period := query.Get("period"); period != "" {
+			if key.Period, err = strconv.Atoi(period); err != nil || key.Period <= 0 {
+				return nil, fmt.Errorf("%w: invalid period %q", ErrInvalidURI, period)
+			}
+		}
+	case TypeHOTP:
+		counter := query.Get("counter")
+		if counter == "" {
+			return nil, fmt.Errorf("%w: counter is required for HOTP", ErrInvalidURI)
+		}
+		if key.Counter, err = strconv.ParseUint(counter, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: invalid counter %q", ErrInvalidURI, counter)
+		}
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURI, err)
+	}
+
+	return key, nil
+}
+
+// GenerateHOTP вычисляет HOTP код для значения счетчика counter.
+// Сохранение увеличенного счетчика остается на вызывающей стороне.
+func GenerateHOTP(secret string, params Params, counter uint64) (string, error) {
+	opts, err := params.validateOpts()
+	if err != nil {
+		return "", err
+	}
+
+	code, err := hotp.GenerateCodeCustom(NormalizeSecret(secret), counter, hotp.ValidateOpts{
+		Digits:    opts.Digits,
+		Algorithm: opts.Algorithm,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate HOTP code: %w", err)
+	}
+
+	return code, nil
+}
@@ -0,0 +1,85 @@
+package otp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURI(t *testing.T) {
+	key, err := ParseURI("otpauth://totp/ACME%20Co:john.doe@email.com?secret=hxdm vjec jjws rb3h wizr 4ifu gftm xboz&issuer=ACME%20Co&algorithm=SHA256&digits=8&period=60")
+	require.NoError(t, err)
+	require.Equal(t, TypeTOTP, key.Type)
+	require.Equal(t, "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ", key.Secret)
+	require.Equal(t, "ACME Co", key.Issuer)
+	require.Equal(t, "john.doe@email.com", key.AccountName)
+	require.Equal(t, "SHA256", key.Algorithm)
+	require.Equal(t, 8, key.Digits)
+	require.Equal(t, 60, key.Period)
+
+	// Издатель из метки, параметры по умолчанию
+	key, err = ParseURI("otpauth://hotp/Example:alice?secret=JBSWY3DPEHPK3PXP&counter=42")
+	require.NoError(t, err)
+	require.Equal(t, TypeHOTP, key.Type)
+	require.Equal(t, "Example", key.Issuer)
+	require.Equal(t, "alice", key.AccountName)
+	require.Equal(t, uint64(42), key.Counter)
+	require.Empty(t, key.Algorithm)
+
+	invalid := []string{
+		"https://example.com/?secret=JBSWY3DPEHPK3PXP",
+		"otpauth://motp/alice?secret=JBSWY3DPEHPK3PXP",
+		"otpauth://totp/alice",
+		"otpauth://totp/alice?secret=not-base32!",
+		"otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP&digits=7",
+		"otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP&period=0",
+		"otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+		"otpauth://hotp/alice?secret=JBSWY3DPEHPK3PXP",
+	}
+	for _, uri := range invalid {
+		_, err := ParseURI(uri)
+		require.ErrorIs(t, err, ErrInvalidURI, uri)
+	}
+}
+
+func TestKeyURIRoundTrip(t *testing.T) {
+	keys := []*Key{
+		{Type: TypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Issuer: "Big Corp", AccountName: "bob@example.com",
+			Params: Params{Algorithm: "SHA512", Digits: 8, Period: 45}},
+		{Type: TypeHOTP, Secret: "JBSWY3DPEHPK3PXP", AccountName: "bob", Counter: 7},
+	}
+
+	for _, key := range keys {
+		uri, err := key.URI()
+		require.NoError(t, err)
+
+		parsed, err := ParseURI(uri)
+		require.NoError(t, err)
+		require.Equal(t, key.Type, parsed.Type)
+		require.Equal(t, key.Secret, parsed.Secret)
+		require.Equal(t, key.Issuer, parsed.Issuer)
+		require.Equal(t, key.AccountName, parsed.AccountName)
+		require.Equal(t, key.Params.withDefaults(), parsed.Params.withDefaults())
+		require.Equal(t, key.Counter, parsed.Counter)
+	}
+}
+
+func TestGenerateHOTP(t *testing.T) {
+	// Тестовые векторы RFC 4226
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	want := []string{"755224", "287082", "359152", "969429"}
+	for counter, expected := range want {
+		code, err := GenerateHOTP(secret, Params{}, uint64(counter))
+		require.NoError(t, err)
+		require.Equal(t, expected, code)
+	}
+}
+
+func TestParamsTimeRemaining(t *testing.T) {
+	at := time.Unix(100, 0)
+	require.Equal(t, 20, Params{}.TimeRemaining(at))
+	require.Equal(t, 20, Params{Period: 60}.TimeRemaining(at))
+	require.Equal(t, 1, Params{Period: 7}.TimeRemaining(time.Unix(97, 0)))
+	require.Equal(t, 7, Params{Period: 7}.TimeRemaining(time.Unix(98, 0)))
+}
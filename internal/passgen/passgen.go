@@ -0,0 +1,237 @@
+// Package passgen генерирует случайные пароли и парольные фразы.
+package passgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Ограничения длины пароля.
+const (
+	MinLength     = 4
+	MaxLength     = 128
+	DefaultLength = 20
+)
+
+// Наборы символов пароля.
+const (
+	lowerChars = "abcdefghijklmnopqrstuvwxyz"
+	upperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars = "0123456789"
+	// Кавычки, обратная косая черта и пробел не используются, чтобы пароль
+	// можно было вставить в командную строку и конфигурационные файлы.
+	symbolChars = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+	// Символы, которые легко спутать при чтении с экрана или бумаги.
+	ambiguousChars = "Il1|O0o"
+
+	// Буквы для произносимых паролей.
+	consonantChars = "bcdfghjklmnprstvz"
+	vowelChars     = "aeiou"
+)
+
+// ErrNoCharClasses возвращается, если в параметрах не выбран ни один класс символов.
+var ErrNoCharClasses = errors.New("at least one character class must be enabled")
+
+// Options описывает параметры генерации пароля.
+type Options struct {
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool // не использовать символы из ambiguousChars
+	Pronounceable    bool // чередовать согласные и гласные, цифры и символы добавляются в конец
+}
+
+// DefaultOptions возвращает параметры по умолчанию: 20 символов всех классов.
+func DefaultOptions() Options {
+	return Options{
+		Length:  DefaultLength,
+		Lower:   true,
+		Upper:   true,
+		Digits:  true,
+		Symbols: true,
+	}
+}
+
+// validate проверяет параметры генерации.
+func (o Options) validate() error {
+	if o.Length < MinLength || o.Length > MaxLength {
+		return fmt.Errorf("password length must be between %d and %d", MinLength, MaxLength)
+	}
+	if !o.Lower && !o.Upper && !o.Digits && !o.Symbols {
+		return ErrNoCharClasses
+	}
+	if o.Pronounceable && !o.Lower && !o.Upper {
+		return errors.New("pronounceable passwords require letters")
+	}
+	return nil
+}
+
+// classes возвращает наборы символов выбранных классов.
+func (o Options) classes() []string {
+	var classes []string
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{o.Lower, lowerChars},
+		{o.Upper, upperChars},
+		{o.Digits, digitChars},
+		{o.Symbols, symbolChars},
+	} {
+		if class.enabled {
+			classes = append(classes, o.filter(class.chars))
+		}
+	}
+	return classes
+}
+
+// filter удаляет неоднозначные символы, если они исключены.
+func (o Options) filter(chars string) string {
+	if !o.ExcludeAmbiguous {
+		return chars
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(ambiguousChars, r) {
+			return -1
+		}
+		return r
+	}, chars)
+}
+
+// Generate создает пароль. Пароль содержит хотя бы один символ каждого
+// выбранного класса, остальные символы выбираются равномерно из всех классов.
+func Generate(opts Options) (string, error) {
+	if err := opts.validate(); err != nil {
+		return "", err
+	}
+	if opts.Pronounceable {
+		return generatePronounceable(opts)
+	}
+
+	classes := opts.classes()
+	if len(classes) > opts.Length {
+		return "", fmt.Errorf("password length must be at least %d for the selected character classes", len(classes))
+	}
+
+	password := make([]byte, 0, opts.Length)
+	for _, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	all := strings.Join(classes, "")
+	for len(password) < opts.Length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	if err := shuffle(password); err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// generatePronounceable создает пароль из чередующихся согласных и гласных.
+// Заглавной становится одна случайная буква (все, если строчные не выбраны),
+// выбранные цифры и символы занимают последние позиции, чтобы не разрывать слоги.
+func generatePronounceable(opts Options) (string, error) {
+	var tail []string
+	if opts.Digits {
+		tail = append(tail, opts.filter(digitChars))
+	}
+	if opts.Symbols {
+		tail = append(tail, opts.filter(symbolChars))
+	}
+
+	letters := opts.Length - len(tail)
+	consonants, vowels := consonantChars, vowelChars
+	if !opts.Lower {
+		consonants, vowels = strings.ToUpper(consonants), strings.ToUpper(vowels)
+	}
+	consonants, vowels = opts.filter(consonants), opts.filter(vowels)
+	startVowel, err := randomInt(2)
+	if err != nil {
+		return "", err
+	}
+
+	password := make([]byte, 0, opts.Length)
+	for i := 0; i < letters; i++ {
+		chars := consonants
+		if (i+startVowel)%2 == 1 {
+			chars = vowels
+		}
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	if opts.Upper && opts.Lower {
+		// Заглавной становится буква, чья заглавная форма не исключена как неоднозначная
+		var candidates []int
+		for i, c := range password {
+			if opts.filter(strings.ToUpper(string(c))) != "" {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) > 0 {
+			pos, err := randomInt(len(candidates))
+			if err != nil {
+				return "", err
+			}
+			i := candidates[pos]
+			password[i] = strings.ToUpper(string(password[i]))[0]
+		}
+	}
+
+	for _, chars := range tail {
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	return string(password), nil
+}
+
+// randomChar возвращает случайный символ набора.
+func randomChar(chars string) (byte, error) {
+	i, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+// randomInt возвращает равномерно распределенное число из [0, n).
+func randomInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read random data: %w", err)
+	}
+	return int(v.Int64()), nil
+}
+
+// shuffle перемешивает символы алгоритмом Фишера-Йетса.
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return err
+		}
+		b[i], b[j] = b[j], b[i]
+	}
+	return nil
+}
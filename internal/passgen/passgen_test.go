@@ -0,0 +1,133 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		password, err := Generate(DefaultOptions())
+		require.NoError(t, err)
+		require.Len(t, password, DefaultLength)
+
+		// Каждый выбранный класс присутствует
+		require.True(t, strings.ContainsAny(password, lowerChars))
+		require.True(t, strings.ContainsAny(password, upperChars))
+		require.True(t, strings.ContainsAny(password, digitChars))
+		require.True(t, strings.ContainsAny(password, symbolChars))
+	}
+}
+
+func TestGenerate_Classes(t *testing.T) {
+	password, err := Generate(Options{Length: 64, Digits: true})
+	require.NoError(t, err)
+	require.Len(t, password, 64)
+	require.Empty(t, strings.Trim(password, digitChars))
+
+	opts := DefaultOptions()
+	opts.Length = MaxLength
+	opts.ExcludeAmbiguous = true
+	for i := 0; i < 20; i++ {
+		password, err = Generate(opts)
+		require.NoError(t, err)
+		require.False(t, strings.ContainsAny(password, ambiguousChars), password)
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	_, err := Generate(Options{Length: 16})
+	require.ErrorIs(t, err, ErrNoCharClasses)
+
+	opts := DefaultOptions()
+	opts.Length = MinLength - 1
+	_, err = Generate(opts)
+	require.Error(t, err)
+
+	opts.Length = MaxLength + 1
+	_, err = Generate(opts)
+	require.Error(t, err)
+
+	_, err = Generate(Options{Length: 16, Digits: true, Pronounceable: true})
+	require.Error(t, err)
+}
+
+func TestGenerate_Pronounceable(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Length = 16
+	opts.Pronounceable = true
+	opts.ExcludeAmbiguous = true
+
+	for i := 0; i < 20; i++ {
+		password, err := Generate(opts)
+		require.NoError(t, err)
+		require.Len(t, password, 16)
+
+		// Цифра и символ в конце, перед ними чередуются согласные и гласные
+		require.Contains(t, digitChars, string(password[14]))
+		require.Contains(t, symbolChars, string(password[15]))
+		letters := strings.ToLower(password[:14])
+		require.NotEqual(t, letters, password[:14], "one letter must be uppercase")
+		for j := 1; j < len(letters); j++ {
+			require.NotEqual(t, strings.ContainsRune(vowelChars, rune(letters[j])),
+				strings.ContainsRune(vowelChars, rune(letters[j-1])), password)
+		}
+		require.False(t, strings.ContainsAny(password, ambiguousChars), password)
+	}
+}
+
+func TestEFFWordlist(t *testing.T) {
+	words, err := EFFWordlist()
+	require.NoError(t, err)
+	require.Len(t, words, 7776)
+	require.Equal(t, "abacus", words[0])
+	require.Equal(t, "zoom", words[len(words)-1])
+}
+
+func TestParseWordlist(t *testing.T) {
+	words, err := ParseWordlist(strings.NewReader("11111\talpha\n\n11112\tbravo\ncharlie\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"alpha", "bravo", "charlie"}, words)
+
+	_, err = ParseWordlist(strings.NewReader("alpha\nalpha\n"))
+	require.ErrorContains(t, err, "duplicate")
+
+	_, err = ParseWordlist(strings.NewReader("11111 two words\n"))
+	require.Error(t, err)
+
+	_, err = ParseWordlist(strings.NewReader("alpha\n"))
+	require.Error(t, err)
+}
+
+func TestPassphrase(t *testing.T) {
+	phrase, err := Passphrase(DefaultPassphraseOptions())
+	require.NoError(t, err)
+	require.Len(t, strings.Split(phrase, "-"), DefaultWords)
+
+	wordlist := []string{"alpha", "bravo"}
+	phrase, err = Passphrase(PassphraseOptions{
+		Words:      4,
+		Separator:  " ",
+		Capitalize: true,
+		Number:     true,
+		Wordlist:   wordlist,
+	})
+	require.NoError(t, err)
+
+	words := strings.Split(phrase, " ")
+	require.Len(t, words, 4)
+	digits := 0
+	for _, word := range words {
+		trimmed := strings.TrimRight(word, digitChars)
+		digits += len(word) - len(trimmed)
+		require.Contains(t, []string{"Alpha", "Bravo"}, trimmed)
+	}
+	require.Equal(t, 1, digits)
+
+	_, err = Passphrase(PassphraseOptions{Words: MinWords - 1})
+	require.Error(t, err)
+	_, err = Passphrase(PassphraseOptions{Words: MaxWords + 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package passgen
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ограничения количества слов парольной фразы.
+const (
+	MinWords     = 3
+	MaxWords     = 20
+	DefaultWords = 6
+)
+
+// Длинный список слов EFF для diceware: 7776 слов, каждое соответствует пяти
+// броскам кубика, то есть около 12,9 бита энтропии на слово.
+//
+//go:embed eff_large_wordlist.txt
+var effWordlistData string
+
+var (
+	effWordlistOnce sync.Once
+	effWordlist     []string
+	effWordlistErr  error
+)
+
+// EFFWordlist возвращает встроенный длинный список слов EFF.
+func EFFWordlist() ([]string, error) {
+	effWordlistOnce.Do(func() {
+		effWordlist, effWordlistErr = ParseWordlist(strings.NewReader(effWordlistData))
+	})
+	return effWordlist, effWordlistErr
+}
+
+// ParseWordlist читает список слов: по одному слову в строке, перед словом
+// может стоять номер броска кубиков, как в списках EFF. Пустые строки
+// пропускаются, повторяющиеся слова считаются ошибкой, так как уменьшают энтропию.
+func ParseWordlist(r io.Reader) ([]string, error) {
+	var words []string
+	seen := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		word := fields[len(fields)-1]
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("wordlist line %d: expected a word", line)
+		}
+		if _, ok := seen[word]; ok {
+			return nil, fmt.Errorf("wordlist line %d: duplicate word %q", line, word)
+		}
+		seen[word] = struct{}{}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read wordlist: %w", err)
+	}
+	if len(words) < 2 {
+		return nil, fmt.Errorf("wordlist must contain at least 2 words")
+	}
+
+	return words, nil
+}
+
+// PassphraseOptions описывает параметры генерации парольной фразы.
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool     // начинать каждое слово с заглавной буквы
+	Number     bool     // добавить цифру к случайному слову
+	Wordlist   []string // список слов, по умолчанию встроенный список EFF
+}
+
+// DefaultPassphraseOptions возвращает параметры по умолчанию: шесть слов
+// через дефис, около 77 бит энтропии.
+func DefaultPassphraseOptions() PassphraseOptions {
+	return PassphraseOptions{
+		Words:     DefaultWords,
+		Separator: "-",
+	}
+}
+
+// Passphrase создает парольную фразу из случайных слов списка.
+func Passphrase(opts PassphraseOptions) (string, error) {
+	if opts.Words < MinWords || opts.Words > MaxWords {
+		return "", fmt.Errorf("passphrase must contain between %d and %d words", MinWords, MaxWords)
+	}
+
+	wordlist := opts.Wordlist
+	if wordlist == nil {
+		var err error
+		if wordlist, err = EFFWordlist(); err != nil {
+			return "", err
+		}
+	}
+
+	words := make([]string, opts.Words)
+	for i := range words {
+		n, err := randomInt(len(wordlist))
+		if err != nil {
+			return "", err
+		}
+		words[i] = wordlist[n]
+		if opts.Capitalize {
+			words[i] = capitalize(words[i])
+		}
+	}
+
+	if opts.Number {
+		pos, err := randomInt(len(words))
+		if err != nil {
+			return "", err
+		}
+		digit, err := randomChar(digitChars)
+		if err != nil {
+			return "", err
+		}
+		words[pos] += string(digit)
+	}
+
+	return strings.Join(words, opts.Separator), nil
+}
+
+// capitalize делает заглавной первую букву слова.
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if size == 0 {
+		return word
+	}
+	return string(unicode.ToUpper(r)) + word[size:]
+}
@@ -0,0 +1,534 @@
+// Package payload проверяет открытое содержимое записей по типу данных.
+// Используется сервером в развертываниях без сквозного шифрования, когда
+// содержимое записей передается в виде JSON структур из пакета models.
+package payload
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/GophKeeper/internal/otp"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ограничения содержимого записей.
+const (
+	maxFilenameLength = 255
+	minCardDigits     = 12
+	maxCardDigits     = 19
+	minWPAPassword    = 8
+	maxWPAPassword    = 63
+)
+
+// FieldError описывает ошибку в одном поле содержимого записи.
+type FieldError struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// ValidationError содержит все ошибки содержимого записи по полям.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error реализует интерфейс error.
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		parts[i] = field.Field + ": " + field.Description
+	}
+	return "invalid payload: " + strings.Join(parts, "; ")
+}
+
+// add добавляет ошибку поля.
+func (e *ValidationError) add(field, description string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Description: description})
+}
+
+// Validator проверяет содержимое записей по типу данных.
+type Validator struct {
+	validate *validator.Validate
+	now      func() time.Time
+}
+
+// NewValidator создает валидатор содержимого записей.
+func NewValidator() *Validator {
+	validate := validator.New()
+	// Ошибки адресуются по именам полей JSON, которые видит клиент
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return &Validator{validate: validate, now: time.Now}
+}
+
+// Validate разбирает содержимое записи типа dataType и проверяет его поля.
+// Возвращает *ValidationError с ошибками по полям или nil. Для типов без
+// структуры содержимого проверка не выполняется.
+func (v *Validator) Validate(dataType models.DataType, data []byte) error {
+	return v.validatePayload(dataType, data, nil, false)
+}
+
+// ValidateUpdate проверяет новое содержимое записи так же, как Validate, но
+// правила, зависящие от текущей даты, применяет только к измененным полям:
+// карта с истекшим сроком остается в хранилище, и запись можно переименовать,
+// не меняя срок действия. previous - текущее содержимое записи.
+func (v *Validator) ValidateUpdate(dataType models.DataType, previous, data []byte) error {
+	return v.validatePayload(dataType, data, previous, true)
+}
+
+// validatePayload проверяет содержимое записи; previous учитывается только
+// при изменении записи.
+func (v *Validator) validatePayload(dataType models.DataType, data, previous []byte, update bool) error {
+	var target interface{}
+	var check func(*ValidationError)
+
+	switch dataType {
+	case models.DataTypeCredentials:
+		var creds models.Credentials
+		target, check = &creds, func(e *ValidationError) { v.checkCredentials(&creds, e) }
+	case models.DataTypeCard:
+		var card models.CardData
+		target, check = &card, func(e *ValidationError) { v.checkCard(&card, previousCard(previous, update), e) }
+	case models.DataTypeText:
+		var text models.TextData
+		target, check = &text, func(*ValidationError) {}
+	case models.DataTypeBinary:
+		var binary models.BinaryData
+		target, check = &binary, func(e *ValidationError) { v.checkBinary(&binary, e) }
+	case models.DataTypeOTP:
+		var otpData models.OTPData
+		target, check = &otpData, func(e *ValidationError) { v.checkOTP(&otpData, e) }
+	case models.DataTypeSSHKey:
+		var key models.SSHKeyData
+		target, check = &key, func(e *ValidationError) { v.checkSSHKey(&key, e) }
+	case models.DataTypeAPIKey:
+		var key models.APIKeyData
+		target, check = &key, func(e *ValidationError) { v.checkAPIKey(&key, e) }
+	case models.DataTypeIdentity:
+		var identity models.IdentityData
+		target, check = &identity, func(e *ValidationError) { v.checkIdentity(&identity, e) }
+	case models.DataTypeWiFi:
+		var wifi models.WiFiData
+		target, check = &wifi, func(e *ValidationError) { v.checkWiFi(&wifi, e) }
+	case models.DataTypeSecureNote:
+		var note models.SecureNoteData
+		target, check = &note, func(*ValidationError) {}
+	default:
+		return nil
+	}
+
+	verr := &ValidationError{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		verr.add("", fmt.Sprintf("payload must be a JSON object of type %s: %v", dataType, err))
+		return verr
+	}
+	// Данные после первого значения не сохраняются как часть записи незамеченными
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		verr.add("", fmt.Sprintf("payload must be a single JSON object of type %s", dataType))
+		return verr
+	}
+
+	if err := v.validate.Struct(target); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return fmt.Errorf("failed to validate payload: %w", err)
+		}
+		for _, fieldErr := range fieldErrs {
+			verr.add(fieldPath(fieldErr), describeTag(fieldErr))
+		}
+	}
+
+	check(verr)
+	v.checkCustomFields(data, verr)
+	if len(verr.Fields) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// describeTag возвращает понятное описание нарушенного правила валидации.
+func describeTag(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required":
+		return "is required"
+	case "url":
+		return "must be an absolute URL"
+	case "oneof":
+		return "must be one of: " + err.Param()
+	case "min", "max", "len":
+		return fmt.Sprintf("must satisfy %s=%s", err.Tag(), err.Param())
+	case "alpha":
+		return "must contain only letters"
+	default:
+		return "failed " + err.Tag() + " check"
+	}
+}
+
+// fieldPath возвращает путь к полю в JSON без имени корневой структуры,
+// например fields[0].value. Встроенные структуры не имеют имени в JSON.
+func fieldPath(err validator.FieldError) string {
+	parts := strings.Split(err.Namespace(), ".")
+	path := make([]string, 0, len(parts))
+	for _, part := range parts[1:] {
+		if part != "CustomFields" {
+			path = append(path, part)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// hasField проверяет, есть ли уже ошибка для поля.
+func (e *ValidationError) hasField(field string) bool {
+	for _, f := range e.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCredentials проверяет адрес сайта в учетных данных.
+func (v *Validator) checkCredentials(creds *models.Credentials, verr *ValidationError) {
+	if creds.URL == "" || verr.hasField("url") {
+		return
+	}
+	if u, err := url.Parse(creds.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		verr.add("url", "must be an absolute URL")
+	}
+}
+
+// previousCard разбирает текущее содержимое изменяемой записи карты.
+// Возвращает nil при создании записи.
+func previousCard(previous []byte, update bool) *models.CardData {
+	if !update {
+		return nil
+	}
+	var card models.CardData
+	// Неразобранное содержимое считается отличающимся от нового
+	_ = json.Unmarshal(previous, &card)
+	return &card
+}
+
+// checkCard проверяет номер по алгоритму Луна, срок действия, CVV и PIN.
+// При изменении записи (previous не nil) истечение срока проверяется, только
+// если срок действия изменен.
+func (v *Validator) checkCard(card, previous *models.CardData, verr *ValidationError) {
+	if card.Number != "" {
+		number := strings.NewReplacer(" ", "", "-", "").Replace(card.Number)
+		switch {
+		case !isDigits(number):
+			verr.add("number", "must contain only digits, spaces and dashes")
+		case len(number) < minCardDigits || len(number) > maxCardDigits:
+			verr.add("number", fmt.Sprintf("must contain %d-%d digits", minCardDigits, maxCardDigits))
+		case !LuhnValid(number):
+			verr.add("number", "fails Luhn checksum")
+		}
+	}
+
+	if card.ExpiryDate != "" {
+		expiresAt, err := ParseCardExpiry(card.ExpiryDate)
+		if err != nil {
+			verr.add("expiry_date", "must be in MM/YY or MM/YYYY format")
+		} else if (previous == nil || previous.ExpiryDate != card.ExpiryDate) && !expiresAt.After(v.now()) {
+			verr.add("expiry_date", "card has expired")
+		}
+	}
+
+	if card.CVV != "" && (!isDigits(card.CVV) || len(card.CVV) < 3 || len(card.CVV) > 4) {
+		verr.add("cvv", "must be 3 or 4 digits")
+	}
+
+	if card.PIN != "" && (!isDigits(card.PIN) || len(card.PIN) < 4 || len(card.PIN) > 12) {
+		verr.add("pin", "must be 4 to 12 digits")
+	}
+}
+
+// checkBinary проверяет имя файла и соответствие MIME типа содержимому.
+func (v *Validator) checkBinary(binary *models.BinaryData, verr *ValidationError) {
+	if binary.Filename != "" {
+		switch {
+		case len(binary.Filename) > maxFilenameLength:
+			verr.add("filename", fmt.Sprintf("must be at most %d bytes", maxFilenameLength))
+		case strings.ContainsAny(binary.Filename, `/\`) || filepath.Base(binary.Filename) != binary.Filename ||
+			binary.Filename == "." || binary.Filename == "..":
+			verr.add("filename", "must be a file name without directories")
+		case strings.IndexFunc(binary.Filename, unicode.IsControl) >= 0:
+			verr.add("filename", "must not contain control characters")
+		}
+	}
+
+	if binary.MimeType != "" && len(binary.Content) > 0 {
+		detected := mimetype.Detect(binary.Content)
+		if !mimeMatches(detected, binary.MimeType) {
+			verr.add("mime_type", fmt.Sprintf("declared %s but content is %s", binary.MimeType, detected.String()))
+		}
+	}
+}
+
+// checkOTP проверяет тип, секрет и параметры одноразовых паролей.
+func (v *Validator) checkOTP(data *models.OTPData, verr *ValidationError) {
+	if data.Secret == "" {
+		return
+	}
+	key := &otp.Key{
+		Type:   data.Type,
+		Secret: data.Secret,
+		Params: otp.Params{Algorithm: data.Algorithm, Digits: data.Digits, Period: data.Period},
+	}
+	if key.Type == "" {
+		key.Type = otp.TypeTOTP
+	}
+	if err := key.Validate(); err != nil && !verr.hasField("algorithm") && !verr.hasField("digits") {
+		verr.add("secret", err.Error())
+	}
+}
+
+// checkSSHKey проверяет, что ключи разбираются и открытый ключ соответствует
+// закрытому. Зашифрованный закрытый ключ без парольной фразы допускается,
+// но тогда его соответствие открытому не проверяется.
+func (v *Validator) checkSSHKey(key *models.SSHKeyData, verr *ValidationError) {
+	var public ssh.PublicKey
+	if key.PublicKey != "" {
+		parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.PublicKey))
+		if err != nil {
+			verr.add("public_key", "must be in authorized_keys format")
+		}
+		public = parsed
+	}
+
+	if key.PrivateKey == "" {
+		return
+	}
+	signer, err := parseSSHPrivateKey(key)
+	var missing *ssh.PassphraseMissingError
+	switch {
+	case errors.As(err, &missing):
+		return
+	case errors.Is(err, x509.IncorrectPasswordError):
+		verr.add("passphrase", "does not decrypt private_key")
+	case err != nil:
+		verr.add("private_key", "must be a PEM or OpenSSH private key")
+	case public != nil && !bytes.Equal(public.Marshal(), signer.PublicKey().Marshal()):
+		verr.add("public_key", "does not match private_key")
+	}
+}
+
+// checkAPIKey проверяет адрес сервиса и дату окончания действия ключа API.
+// Истекшие ключи допускаются: клиент предупреждает о них при просмотре.
+func (v *Validator) checkAPIKey(key *models.APIKeyData, verr *ValidationError) {
+	if key.URL != "" {
+		if u, err := url.Parse(key.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			verr.add("url", "must be an absolute URL")
+		}
+	}
+	checkDate("expires_at", key.ExpiresAt, verr)
+}
+
+// checkIdentity проверяет даты документа: дата рождения не в будущем,
+// документ выдан раньше окончания срока действия.
+func (v *Validator) checkIdentity(identity *models.IdentityData, verr *ValidationError) {
+	birthDate := checkDate("birth_date", identity.BirthDate, verr)
+	issuedAt := checkDate("issued_at", identity.IssuedAt, verr)
+	expiresAt := checkDate("expires_at", identity.ExpiresAt, verr)
+
+	if !birthDate.IsZero() && birthDate.After(v.now()) {
+		verr.add("birth_date", "must not be in the future")
+	}
+	if !issuedAt.IsZero() && !expiresAt.IsZero() && !expiresAt.After(issuedAt) {
+		verr.add("expires_at", "must be after issued_at")
+	}
+}
+
+// checkWiFi проверяет пароль сети по требованиям выбранной защиты.
+func (v *Validator) checkWiFi(wifi *models.WiFiData, verr *ValidationError) {
+	if verr.hasField("security") {
+		return
+	}
+
+	password := wifi.Password
+	switch wifi.Security {
+	case "open":
+		if password != "" {
+			verr.add("password", "must be empty for an open network")
+		}
+	case "wep":
+		if !(len(password) == 5 || len(password) == 13 || (isHex(password) && (len(password) == 10 || len(password) == 26))) {
+			verr.add("password", "must be 5 or 13 characters or 10 or 26 hex digits")
+		}
+	default:
+		if password == "" {
+			verr.add("password", "is required")
+		} else if !(len(password) == 64 && isHex(password)) &&
+			(len(password) < minWPAPassword || len(password) > maxWPAPassword) {
+			verr.add("password", fmt.Sprintf("must be %d-%d characters or 64 hex digits", minWPAPassword, maxWPAPassword))
+		}
+	}
+}
+
+// checkDate проверяет дату в формате YYYY-MM-DD и возвращает ее.
+// Для пустой или неверной даты возвращается нулевое время.
+func checkDate(field, value string, verr *ValidationError) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		verr.add(field, "must be a date in YYYY-MM-DD format")
+		return time.Time{}
+	}
+	return date
+}
+
+// checkCustomFields проверяет версию схемы, уникальность имен и значения
+// пользовательских полей по их типам.
+func (v *Validator) checkCustomFields(data []byte, verr *ValidationError) {
+	var extras models.CustomFields
+	if err := json.Unmarshal(data, &extras); err != nil {
+		return
+	}
+
+	if !extras.SupportsSchema() {
+		verr.add("schema_version", fmt.Sprintf("must be at most %d", models.PayloadSchemaVersion))
+	}
+
+	seen := make(map[string]int, len(extras.Fields))
+	for i, field := range extras.Fields {
+		path := fmt.Sprintf("fields[%d]", i)
+		if prev, ok := seen[field.Name]; ok && field.Name != "" {
+			verr.add(path+".name", fmt.Sprintf("duplicates fields[%d].name", prev))
+		} else {
+			seen[field.Name] = i
+		}
+
+		if field.Value == "" || verr.hasField(path+".type") {
+			continue
+		}
+		if err := checkCustomFieldValue(field.Type, field.Value); err != nil {
+			verr.add(path+".value", err.Error())
+		}
+	}
+}
+
+// checkCustomFieldValue проверяет значение пользовательского поля по его типу.
+func checkCustomFieldValue(fieldType models.CustomFieldType, value string) error {
+	switch fieldType {
+	case models.CustomFieldURL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("must be an absolute URL")
+		}
+	case models.CustomFieldEmail:
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return errors.New("must be an email address")
+		}
+	case models.CustomFieldDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return errors.New("must be a date in YYYY-MM-DD format")
+		}
+	case models.CustomFieldBoolean:
+		if value != "true" && value != "false" {
+			return errors.New("must be true or false")
+		}
+	}
+	return nil
+}
+
+// mimeMatches проверяет, что заявленный MIME тип совпадает с определенным
+// по содержимому или является его более общим родителем (например text/plain для JSON).
+func mimeMatches(detected *mimetype.MIME, declared string) bool {
+	declared, _, _ = strings.Cut(declared, ";")
+	declared = strings.TrimSpace(declared)
+	for m := detected; m != nil; m = m.Parent() {
+		if m.Is(declared) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCardExpiry возвращает момент окончания срока действия карты:
+// карта действует до конца указанного месяца включительно.
+func ParseCardExpiry(value string) (time.Time, error) {
+	monthPart, yearPart, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok || len(monthPart) != 2 || (len(yearPart) != 2 && len(yearPart) != 4) ||
+		!isDigits(monthPart) || !isDigits(yearPart) {
+		return time.Time{}, fmt.Errorf("invalid expiry date %q", value)
+	}
+
+	month, _ := strconv.Atoi(monthPart)
+	year, _ := strconv.Atoi(yearPart)
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("invalid expiry month %q", monthPart)
+	}
+	if len(yearPart) == 2 {
+		year += 2000
+	}
+
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+// LuhnValid проверяет контрольную сумму номера карты по алгоритму Луна.
+// Номер передается без пробелов и разделителей.
+func LuhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// isDigits проверяет, что строка непустая и состоит только из цифр ASCII.
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isHex проверяет, что строка непустая и состоит из шестнадцатеричных цифр.
+func isHex(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
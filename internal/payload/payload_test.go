@@ -0,0 +1,292 @@
+package payload
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestValidator() *Validator {
+	v := NewValidator()
+	v.now = func() time.Time { return time.Date(2025, time.June, 15, 12, 0, 0, 0, time.UTC) }
+	return v
+}
+
+func mustJSON(t *testing.T, value interface{}) []byte {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	return data
+}
+
+// fieldNames возвращает имена полей с ошибками.
+func fieldNames(t *testing.T, err error) []string {
+	verr, ok := err.(*ValidationError)
+	require.True(t, ok, "expected *ValidationError, got %v", err)
+	names := make([]string, len(verr.Fields))
+	for i, field := range verr.Fields {
+		names[i] = field.Field
+	}
+	return names
+}
+
+func TestValidateValidPayloads(t *testing.T) {
+	v := newTestValidator()
+
+	payloads := map[models.DataType]interface{}{
+		models.DataTypeCredentials: models.Credentials{Login: "alice", Password: "secret", URL: "https://example.com/login"},
+		models.DataTypeCard: models.CardData{
+			Number: "4111 1111 1111 1111", ExpiryDate: "06/25", Holder: "ALICE", CVV: "123", PIN: "0000",
+		},
+		models.DataTypeText:   models.TextData{Content: "заметка"},
+		models.DataTypeBinary: models.BinaryData{Filename: "note.txt", MimeType: "text/plain; charset=utf-8", Content: []byte("hello")},
+		models.DataTypeOTP:    models.OTPData{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA256", Digits: 8, Period: 60},
+		models.DataTypeAPIKey: models.APIKeyData{Key: "sk_live_123", URL: "https://api.example.com", ExpiresAt: "2024-01-01"},
+		models.DataTypeIdentity: models.IdentityData{
+			DocumentType: "passport", Number: "4510 123456", FullName: "Alice Smith", Country: "RU",
+			BirthDate: "1990-05-01", IssuedAt: "2015-05-20", ExpiresAt: "2025-05-20",
+		},
+		models.DataTypeWiFi:       models.WiFiData{SSID: "Home", Password: "correct horse", Security: "wpa3"},
+		models.DataTypeSecureNote: models.SecureNoteData{Content: "# Восстановление\n\n- шаг 1"},
+	}
+	for dataType, value := range payloads {
+		require.NoError(t, v.Validate(dataType, mustJSON(t, value)), dataType)
+	}
+
+	// Типы без структуры содержимого не проверяются
+	require.NoError(t, v.Validate(models.DataType("unknown"), []byte("opaque")))
+}
+
+func TestValidateCard(t *testing.T) {
+	v := newTestValidator()
+
+	err := v.Validate(models.DataTypeCard, mustJSON(t, models.CardData{
+		Number: "4111 1111 1111 1112", ExpiryDate: "05/2025", Holder: "ALICE", CVV: "12345", PIN: "12",
+	}))
+	require.ElementsMatch(t, []string{"number", "expiry_date", "cvv", "pin"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeCard, mustJSON(t, models.CardData{
+		Number: "4111-1111-1111-111a", ExpiryDate: "13/25", Holder: "ALICE", CVV: "1234",
+	}))
+	require.ElementsMatch(t, []string{"number", "expiry_date"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeCard, mustJSON(t, models.CardData{Number: "4111111111111111"}))
+	require.ElementsMatch(t, []string{"expiry_date", "holder", "cvv"}, fieldNames(t, err))
+}
+
+func TestValidateUpdateExpiredCard(t *testing.T) {
+	v := newTestValidator()
+	expired := models.CardData{Number: "4111111111111111", ExpiryDate: "05/2025", Holder: "ALICE", CVV: "123"}
+	previous := mustJSON(t, expired)
+
+	// Истекшую карту можно изменить, не меняя срок действия
+	renamed := expired
+	renamed.Holder = "ALICE SMITH"
+	require.NoError(t, v.ValidateUpdate(models.DataTypeCard, previous, mustJSON(t, renamed)))
+
+	// Новый срок действия должен быть в будущем, формат проверяется всегда
+	renamed.ExpiryDate = "04/2025"
+	require.Equal(t, []string{"expiry_date"}, fieldNames(t, v.ValidateUpdate(models.DataTypeCard, previous, mustJSON(t, renamed))))
+	renamed.ExpiryDate = "12/2027"
+	require.NoError(t, v.ValidateUpdate(models.DataTypeCard, previous, mustJSON(t, renamed)))
+
+	bad := expired
+	bad.CVV = "1"
+	require.Equal(t, []string{"cvv"}, fieldNames(t, v.ValidateUpdate(models.DataTypeCard, previous, mustJSON(t, bad))))
+
+	// Создание истекшей карты отклоняется
+	require.Equal(t, []string{"expiry_date"}, fieldNames(t, v.Validate(models.DataTypeCard, previous)))
+}
+
+func TestValidateBinary(t *testing.T) {
+	v := newTestValidator()
+
+	err := v.Validate(models.DataTypeBinary, mustJSON(t, models.BinaryData{
+		Filename: "../etc/passwd", MimeType: "image/png", Content: []byte("plain text"),
+	}))
+	require.ElementsMatch(t, []string{"filename", "mime_type"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeBinary, mustJSON(t, models.BinaryData{
+		Filename: "bad\x00name", MimeType: "application/json", Content: []byte(`{"a":1}`),
+	}))
+	require.Equal(t, []string{"filename"}, fieldNames(t, err))
+}
+
+func TestValidateMalformedPayload(t *testing.T) {
+	v := newTestValidator()
+
+	err := v.Validate(models.DataTypeCredentials, []byte(`{"login":"alice","password":"x","extra":1}`))
+	require.Equal(t, []string{""}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeText, []byte("not json"))
+	require.Equal(t, []string{""}, fieldNames(t, err))
+
+	// Данные после первого объекта отклоняются
+	err = v.Validate(models.DataTypeText, []byte(`{"content":"a"}{"content":"b"}`))
+	require.Equal(t, []string{""}, fieldNames(t, err))
+	err = v.Validate(models.DataTypeText, []byte(`{"content":"a"} trailing`))
+	require.Equal(t, []string{""}, fieldNames(t, err))
+	require.NoError(t, v.Validate(models.DataTypeText, []byte("{\"content\":\"a\"}\n")))
+
+	err = v.Validate(models.DataTypeCredentials, mustJSON(t, models.Credentials{Login: "alice", Password: "x", URL: "example.com"}))
+	require.Equal(t, []string{"url"}, fieldNames(t, err))
+}
+
+func TestValidateOTP(t *testing.T) {
+	v := newTestValidator()
+
+	err := v.Validate(models.DataTypeOTP, mustJSON(t, models.OTPData{Secret: "not-base32!"}))
+	require.Equal(t, []string{"secret"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeOTP, mustJSON(t, models.OTPData{Secret: "JBSWY3DPEHPK3PXP", Digits: 7}))
+	require.Equal(t, []string{"digits"}, fieldNames(t, err))
+}
+
+func TestLuhnValid(t *testing.T) {
+	require.True(t, LuhnValid("79927398713"))
+	require.True(t, LuhnValid("4111111111111111"))
+	require.False(t, LuhnValid("79927398710"))
+}
+
+func TestValidateCustomFields(t *testing.T) {
+	v := newTestValidator()
+
+	valid := models.TextData{Content: "note", CustomFields: models.CustomFields{
+		SchemaVersion: models.PayloadSchemaVersion,
+		Fields: []models.CustomField{
+			{Name: "Recovery", Type: models.CustomFieldHidden, Value: "secret"},
+			{Name: "Portal", Type: models.CustomFieldURL, Value: "https://example.com"},
+			{Name: "Owner", Type: models.CustomFieldEmail, Value: "alice@example.com"},
+			{Name: "Renewal", Type: models.CustomFieldDate, Value: "2026-01-31"},
+			{Name: "Shared", Type: models.CustomFieldBoolean, Value: "false"},
+			{Name: "Empty", Type: models.CustomFieldDate},
+		},
+	}}
+	require.NoError(t, v.Validate(models.DataTypeText, mustJSON(t, valid)))
+
+	invalid := models.Credentials{Login: "alice", Password: "x", CustomFields: models.CustomFields{
+		SchemaVersion: models.PayloadSchemaVersion + 1,
+		Fields: []models.CustomField{
+			{Name: "Portal", Type: models.CustomFieldURL, Value: "example.com"},
+			{Name: "Portal", Type: models.CustomFieldEmail, Value: "Alice <alice@example.com>"},
+			{Name: "Renewal", Type: models.CustomFieldDate, Value: "31.01.2026"},
+			{Name: "Shared", Type: models.CustomFieldBoolean, Value: "yes"},
+			{Type: "color", Value: "red"},
+		},
+	}}
+	err := v.Validate(models.DataTypeCredentials, mustJSON(t, invalid))
+	require.ElementsMatch(t, []string{
+		"schema_version",
+		"fields[0].value",
+		"fields[1].name", "fields[1].value",
+		"fields[2].value",
+		"fields[3].value",
+		"fields[4].name", "fields[4].type",
+	}, fieldNames(t, err))
+}
+
+// newSSHKey создает пару ключей ed25519: закрытый ключ в формате OpenSSH,
+// зашифрованный passphrase, если она задана, и открытый в формате authorized_keys.
+func newSSHKey(t *testing.T, passphrase string) (string, string) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var block *pem.Block
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(private, "test", []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(private, "test")
+	}
+	require.NoError(t, err)
+
+	sshPublic, err := ssh.NewPublicKey(public)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(block)), string(ssh.MarshalAuthorizedKey(sshPublic))
+}
+
+func TestValidateSSHKey(t *testing.T) {
+	v := newTestValidator()
+	private, public := newSSHKey(t, "")
+	encrypted, encryptedPublic := newSSHKey(t, "s3cret")
+
+	valid := []models.SSHKeyData{
+		{PrivateKey: private, PublicKey: public},
+		{PrivateKey: private},
+		{PrivateKey: encrypted, PublicKey: encryptedPublic, Passphrase: "s3cret"},
+		// Без парольной фразы соответствие ключей не проверить
+		{PrivateKey: encrypted, PublicKey: public},
+	}
+	for _, key := range valid {
+		require.NoError(t, v.Validate(models.DataTypeSSHKey, mustJSON(t, key)))
+	}
+
+	err := v.Validate(models.DataTypeSSHKey, mustJSON(t, models.SSHKeyData{PrivateKey: private, PublicKey: encryptedPublic}))
+	require.Equal(t, []string{"public_key"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeSSHKey, mustJSON(t, models.SSHKeyData{PrivateKey: encrypted, Passphrase: "wrong"}))
+	require.Equal(t, []string{"passphrase"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeSSHKey, mustJSON(t, models.SSHKeyData{PrivateKey: "not a key", PublicKey: "ssh-rsa AAAA"}))
+	require.ElementsMatch(t, []string{"private_key", "public_key"}, fieldNames(t, err))
+}
+
+func TestSSHPublicKey(t *testing.T) {
+	private, public := newSSHKey(t, "")
+	encrypted, encryptedPublic := newSSHKey(t, "s3cret")
+
+	fromPrivate, err := SSHPublicKey(&models.SSHKeyData{PrivateKey: private})
+	require.NoError(t, err)
+	fromPublic, err := SSHPublicKey(&models.SSHKeyData{PublicKey: public})
+	require.NoError(t, err)
+	require.Equal(t, ssh.FingerprintSHA256(fromPublic), ssh.FingerprintSHA256(fromPrivate))
+
+	// Открытый ключ зашифрованного ключа OpenSSH доступен без парольной фразы
+	fromEncrypted, err := SSHPublicKey(&models.SSHKeyData{PrivateKey: encrypted})
+	require.NoError(t, err)
+	require.Equal(t, encryptedPublic, string(ssh.MarshalAuthorizedKey(fromEncrypted)))
+}
+
+func TestValidateIdentityAndAPIKey(t *testing.T) {
+	v := newTestValidator()
+
+	err := v.Validate(models.DataTypeIdentity, mustJSON(t, models.IdentityData{
+		DocumentType: "visa", Number: "1", FullName: "Alice", Country: "RUS",
+		BirthDate: "2030-01-01", IssuedAt: "2020-01-01", ExpiresAt: "2019-01-01",
+	}))
+	require.ElementsMatch(t, []string{"document_type", "country", "birth_date", "expires_at"}, fieldNames(t, err))
+
+	err = v.Validate(models.DataTypeAPIKey, mustJSON(t, models.APIKeyData{Key: "k", URL: "api.example.com", ExpiresAt: "01.01.2026"}))
+	require.ElementsMatch(t, []string{"url", "expires_at"}, fieldNames(t, err))
+}
+
+func TestValidateWiFi(t *testing.T) {
+	v := newTestValidator()
+
+	cases := []struct {
+		wifi   models.WiFiData
+		fields []string
+	}{
+		{models.WiFiData{SSID: "Cafe", Security: "open"}, nil},
+		{models.WiFiData{SSID: "Cafe", Security: "open", Password: "x"}, []string{"password"}},
+		{models.WiFiData{SSID: "Old", Security: "wep", Password: "0123456789"}, nil},
+		{models.WiFiData{SSID: "Old", Security: "wep", Password: "123"}, []string{"password"}},
+		{models.WiFiData{SSID: "Home", Password: "short"}, []string{"password"}},
+		{models.WiFiData{SSID: "Home"}, []string{"password"}},
+		{models.WiFiData{SSID: "a very long network name over 32 bytes", Password: "password", Security: "wpa5"},
+			[]string{"ssid", "security"}},
+	}
+	for _, tc := range cases {
+		err := v.Validate(models.DataTypeWiFi, mustJSON(t, tc.wifi))
+		if tc.fields == nil {
+			require.NoError(t, err, tc.wifi)
+			continue
+		}
+		require.ElementsMatch(t, tc.fields, fieldNames(t, err), tc.wifi)
+	}
+}
@@ -0,0 +1,43 @@
+package payload
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GophKeeper/internal/models"
+	"golang.org/x/crypto/ssh"
+)
+
+// parseSSHPrivateKey разбирает закрытый ключ SSH. Парольная фраза используется
+// только для зашифрованных ключей.
+func parseSSHPrivateKey(key *models.SSHKeyData) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(key.PrivateKey))
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) && key.Passphrase != "" {
+		return ssh.ParsePrivateKeyWithPassphrase([]byte(key.PrivateKey), []byte(key.Passphrase))
+	}
+	return signer, err
+}
+
+// SSHPublicKey возвращает открытый ключ пары: указанный в записи или
+// вычисленный по закрытому ключу. Для зашифрованного ключа OpenSSH открытый
+// ключ доступен и без парольной фразы.
+func SSHPublicKey(key *models.SSHKeyData) (ssh.PublicKey, error) {
+	if key.PublicKey != "" {
+		public, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return public, nil
+	}
+
+	signer, err := parseSSHPrivateKey(key)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) && missing.PublicKey != nil {
+		return missing.PublicKey, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return signer.PublicKey(), nil
+}
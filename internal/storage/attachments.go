@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrAttachmentLimit возвращается, если новое вложение превысит количество
+// или суммарный размер вложений записи.
+var ErrAttachmentLimit = errors.New("entry attachment limit exceeded")
+
+// ErrAttachmentNotFound возвращается, если вложение не найдено у записи.
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// CreateAttachment сохраняет вложение записи. Строка записи блокируется на время
+// проверки ограничений, поэтому одновременные загрузки не могут их превысить.
+func (s *PostgresStorage) CreateAttachment(ctx context.Context, attachment *models.Attachment) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT id FROM data_entries WHERE id = $1 FOR UPDATE`, attachment.EntryID).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("data entry not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock data entry: %w", err)
+	}
+
+	var count int
+	var totalSize int64
+	err = tx.QueryRow(ctx,
+		`SELECT COUNT(*), COALESCE(SUM(size), 0) FROM entry_attachments WHERE entry_id = $1`,
+		attachment.EntryID,
+	).Scan(&count, &totalSize)
+	if err != nil {
+		return fmt.Errorf("failed to get entry attachments size: %w", err)
+	}
+	if count >= models.MaxEntryAttachments || totalSize+attachment.Size > models.MaxEntryAttachmentsSize {
+		return ErrAttachmentLimit
+	}
+
+	query := `
+		INSERT INTO entry_attachments (id, entry_id, uploaded_by, name, mime_type, size, wrapped_key, encrypted_data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	attachment.ID, attachment.CreatedAt, _ = s.prepareNewEntity()
+
+	_, err = tx.Exec(ctx, query,
+		attachment.ID, attachment.EntryID, attachment.UploadedBy, attachment.Name, attachment.MimeType,
+		attachment.Size, attachment.WrappedKey, attachment.EncryptedData, attachment.CreatedAt,
+	)
+	if err := s.handleExecError(err, "attachment already exists", "failed to create attachment"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachments возвращает вложения записи без содержимого.
+func (s *PostgresStorage) GetAttachments(ctx context.Context, entryID uuid.UUID) ([]models.Attachment, error) {
+	query := `
+		SELECT id, entry_id, uploaded_by, name, COALESCE(mime_type, ''), size, wrapped_key, created_at
+		FROM entry_attachments
+		WHERE entry_id = $1
+		ORDER BY created_at`
+
+	rows, err := s.pool.Query(ctx, query, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []models.Attachment
+	for rows.Next() {
+		var attachment models.Attachment
+		if err := rows.Scan(
+			&attachment.ID, &attachment.EntryID, &attachment.UploadedBy, &attachment.Name,
+			&attachment.MimeType, &attachment.Size, &attachment.WrappedKey, &attachment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// GetAttachment возвращает вложение записи вместе с зашифрованным содержимым.
+func (s *PostgresStorage) GetAttachment(ctx context.Context, entryID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	query := `
+		SELECT id, entry_id, uploaded_by, name, COALESCE(mime_type, ''), size, wrapped_key, encrypted_data, created_at
+		FROM entry_attachments
+		WHERE id = $1 AND entry_id = $2`
+
+	var attachment models.Attachment
+	err := s.pool.QueryRow(ctx, query, attachmentID, entryID).Scan(
+		&attachment.ID, &attachment.EntryID, &attachment.UploadedBy, &attachment.Name, &attachment.MimeType,
+		&attachment.Size, &attachment.WrappedKey, &attachment.EncryptedData, &attachment.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// GetUserAttachments возвращает вложения всех собственных записей пользователя
+// вместе с зашифрованным содержимым для экспорта аккаунта.
+func (s *PostgresStorage) GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]models.Attachment, error) {
+	query := `
+		SELECT a.id, a.entry_id, a.uploaded_by, a.name, COALESCE(a.mime_type, ''), a.size, a.wrapped_key, a.encrypted_data, a.created_at
+		FROM entry_attachments a
+		JOIN data_entries e ON e.id = a.entry_id
+		WHERE e.user_id = $1
+		ORDER BY a.entry_id, a.created_at`
+
+	rows, err := s.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []models.Attachment
+	for rows.Next() {
+		var attachment models.Attachment
+		if err := rows.Scan(
+			&attachment.ID, &attachment.EntryID, &attachment.UploadedBy, &attachment.Name, &attachment.MimeType,
+			&attachment.Size, &attachment.WrappedKey, &attachment.EncryptedData, &attachment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+// DeleteAttachment удаляет вложение записи.
+func (s *PostgresStorage) DeleteAttachment(ctx context.Context, entryID, attachmentID uuid.UUID) error {
+	result, err := s.pool.Exec(ctx, `DELETE FROM entry_attachments WHERE id = $1 AND entry_id = $2`, attachmentID, entryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAttachmentNotFound
+	}
+
+	return nil
+}
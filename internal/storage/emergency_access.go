@@ -0,0 +1,318 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrEmergencyAccessState возвращается, если текущее состояние экстренного
+// доступа не допускает запрошенный переход.
+var ErrEmergencyAccessState = errors.New("emergency access is not in the expected state")
+
+// maxNotifications ограничивает количество возвращаемых уведомлений.
+const maxNotifications = 100
+
+// emergencyAccessSelect выбирает экстренный доступ вместе с именами участников.
+const emergencyAccessSelect = `
+	SELECT a.id, a.grantor_id, g.username, a.grantee_id, t.username, a.wait_days, a.wrapped_key,
+		a.status, a.requested_at, a.approved_at, a.created_at, a.updated_at
+	FROM emergency_access a
+	JOIN users g ON g.id = a.grantor_id
+	JOIN users t ON t.id = a.grantee_id`
+
+// scanEmergencyAccess сканирует строку результата emergencyAccessSelect.
+func scanEmergencyAccess(row pgx.Row) (*models.EmergencyAccess, error) {
+	var access models.EmergencyAccess
+	err := row.Scan(
+		&access.ID, &access.GrantorID, &access.GrantorUsername, &access.GranteeID, &access.GranteeUsername,
+		&access.WaitDays, &access.WrappedKey, &access.Status, &access.RequestedAt, &access.ApprovedAt,
+		&access.CreatedAt, &access.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &access, nil
+}
+
+// queryEmergencyAccess выполняет запрос списка экстренных доступов.
+func (s *PostgresStorage) queryEmergencyAccess(ctx context.Context, query string, args ...interface{}) ([]models.EmergencyAccess, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err := s.handleQueryError(err, "failed to query emergency access"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.EmergencyAccess
+	for rows.Next() {
+		access, err := scanEmergencyAccess(rows)
+		if err := s.handleScanError(err, "failed to scan emergency access"); err != nil {
+			return nil, err
+		}
+		result = append(result, *access)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error iterating emergency access"); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// recordEmergencyEvent записывает событие в журнал и уведомляет участников,
+// кроме выполнившего действие. События сервера получают оба участника.
+func recordEmergencyEvent(ctx context.Context, tx pgx.Tx, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error {
+	event.ID = uuid.New()
+	event.EmergencyAccessID = access.ID
+	event.CreatedAt = time.Now()
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO emergency_access_events (id, emergency_access_id, grantor_id, grantee_id, actor_id, event, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		event.ID, event.EmergencyAccessID, access.GrantorID, access.GranteeID, event.ActorID, event.Event, event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record emergency access event: %w", err)
+	}
+
+	for _, userID := range []uuid.UUID{access.GrantorID, access.GranteeID} {
+		if event.ActorID != nil && *event.ActorID == userID {
+			continue
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO notifications (id, user_id, message, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			uuid.New(), userID, message, event.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CreateEmergencyAccess назначает контакт для экстренного доступа и уведомляет его.
+func (s *PostgresStorage) CreateEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, message string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	access.ID, access.CreatedAt, access.UpdatedAt = s.prepareNewEntity()
+	access.Status = models.EmergencyAccessIdle
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO emergency_access (id, grantor_id, grantee_id, wait_days, wrapped_key, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		access.ID, access.GrantorID, access.GranteeID, access.WaitDays, access.WrappedKey,
+		access.Status, access.CreatedAt, access.UpdatedAt,
+	)
+	if err := s.handleExecError(err, "emergency contact already exists", "failed to create emergency access"); err != nil {
+		return err
+	}
+
+	event := &models.EmergencyAccessEvent{ActorID: &access.GrantorID, Event: models.EmergencyEventDesignated}
+	if err := recordEmergencyEvent(ctx, tx, access, event, message); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmergencyAccess получает экстренный доступ, в котором пользователь
+// является владельцем или контактом.
+func (s *PostgresStorage) GetEmergencyAccess(ctx context.Context, userID, accessID uuid.UUID) (*models.EmergencyAccess, error) {
+	query := emergencyAccessSelect + `
+		WHERE a.id = $2 AND (a.grantor_id = $1 OR a.grantee_id = $1)`
+
+	access, err := scanEmergencyAccess(s.pool.QueryRow(ctx, query, userID, accessID))
+	if err := s.handleQueryRowError(err, "emergency access not found", "failed to get emergency access"); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// GetEmergencyAccessList получает все экстренные доступы, в которых участвует пользователь.
+func (s *PostgresStorage) GetEmergencyAccessList(ctx context.Context, userID uuid.UUID) ([]models.EmergencyAccess, error) {
+	query := emergencyAccessSelect + `
+		WHERE a.grantor_id = $1 OR a.grantee_id = $1
+		ORDER BY a.created_at`
+
+	return s.queryEmergencyAccess(ctx, query, userID)
+}
+
+// GetElapsedEmergencyRequests получает запросы доступа, период ожидания которых истек.
+func (s *PostgresStorage) GetElapsedEmergencyRequests(ctx context.Context, now time.Time) ([]models.EmergencyAccess, error) {
+	query := emergencyAccessSelect + `
+		WHERE a.status = 'requested' AND a.requested_at + a.wait_days * INTERVAL '1 day' <= $1`
+
+	return s.queryEmergencyAccess(ctx, query, now)
+}
+
+// TransitionEmergencyAccess переводит экстренный доступ из одного из состояний from
+// в состояние to, записывает событие в журнал и уведомляет участников.
+func (s *PostgresStorage) TransitionEmergencyAccess(ctx context.Context, accessID uuid.UUID, from []models.EmergencyAccessStatus, to models.EmergencyAccessStatus, event *models.EmergencyAccessEvent, message string) (*models.EmergencyAccess, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	fromStrings := make([]string, len(from))
+	for i, status := range from {
+		fromStrings[i] = string(status)
+	}
+
+	// Время запроса и одобрения соответствуют текущему состоянию
+	query := `
+		UPDATE emergency_access
+		SET status = $3::varchar,
+			requested_at = CASE WHEN $3 = 'requested' THEN $4::timestamptz WHEN $3 = 'idle' THEN NULL ELSE requested_at END,
+			approved_at = CASE WHEN $3 = 'approved' THEN $4::timestamptz ELSE NULL END
+		WHERE id = $1 AND status = ANY($2)`
+
+	result, err := tx.Exec(ctx, query, accessID, fromStrings, string(to), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update emergency access: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrEmergencyAccessState
+	}
+
+	access, err := scanEmergencyAccess(tx.QueryRow(ctx, emergencyAccessSelect+` WHERE a.id = $1`, accessID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency access: %w", err)
+	}
+
+	if err := recordEmergencyEvent(ctx, tx, access, event, message); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return access, nil
+}
+
+// RecordEmergencyAccessEvent записывает событие без изменения состояния доступа.
+func (s *PostgresStorage) RecordEmergencyAccessEvent(ctx context.Context, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := recordEmergencyEvent(ctx, tx, access, event, message); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEmergencyAccess удаляет экстренный доступ. Журнал событий сохраняется.
+func (s *PostgresStorage) DeleteEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `DELETE FROM emergency_access WHERE id = $1`, access.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete emergency access: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("emergency access not found")
+	}
+
+	if err := recordEmergencyEvent(ctx, tx, access, event, message); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmergencyAccessEvents получает журнал экстренного доступа для его участника.
+func (s *PostgresStorage) GetEmergencyAccessEvents(ctx context.Context, userID, accessID uuid.UUID) ([]models.EmergencyAccessEvent, error) {
+	query := `
+		SELECT e.id, e.emergency_access_id, e.actor_id, COALESCE(u.username, ''), e.event, e.created_at
+		FROM emergency_access_events e
+		LEFT JOIN users u ON u.id = e.actor_id
+		WHERE e.emergency_access_id = $2 AND (e.grantor_id = $1 OR e.grantee_id = $1)
+		ORDER BY e.created_at`
+
+	rows, err := s.pool.Query(ctx, query, userID, accessID)
+	if err := s.handleQueryError(err, "failed to query emergency access events"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.EmergencyAccessEvent
+	for rows.Next() {
+		var event models.EmergencyAccessEvent
+		err := rows.Scan(&event.ID, &event.EmergencyAccessID, &event.ActorID, &event.ActorUsername, &event.Event, &event.CreatedAt)
+		if err := s.handleScanError(err, "failed to scan emergency access event"); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error iterating emergency access events"); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetNotifications получает последние уведомления пользователя.
+func (s *PostgresStorage) GetNotifications(ctx context.Context, userID uuid.UUID) ([]models.Notification, error) {
+	query := `
+		SELECT id, user_id, message, created_at
+		FROM notifications
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := s.pool.Query(ctx, query, userID, maxNotifications)
+	if err := s.handleQueryError(err, "failed to query notifications"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		var notification models.Notification
+		err := rows.Scan(&notification.ID, &notification.UserID, &notification.Message, &notification.CreatedAt)
+		if err := s.handleScanError(err, "failed to scan notification"); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error iterating notifications"); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,1006 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Ошибки проверки прав в организациях.
+var (
+	// ErrInsufficientRole возвращается, если роли пользователя недостаточно для операции
+	ErrInsufficientRole = errors.New("insufficient organization role")
+	// ErrLastOwner возвращается при попытке исключить или понизить последнего владельца
+	ErrLastOwner = errors.New("organization must keep at least one owner")
+	// ErrCollectionKeyMismatch возвращается, если версия ключа, получатели ключей
+	// или перешифрованные записи не соответствуют текущему состоянию коллекции
+	ErrCollectionKeyMismatch = errors.New("collection key does not match current members, entries or key version")
+)
+
+// rolesToStrings преобразует роли для параметра ANY($n).
+func rolesToStrings(roles []models.OrganizationRole) []string {
+	result := make([]string, len(roles))
+	for i, role := range roles {
+		result[i] = string(role)
+	}
+	return result
+}
+
+// managerRoles возвращает роли, которые могут назначать роль target.
+func managerRoles(target models.OrganizationRole) []string {
+	var roles []string
+	for _, role := range models.RolesAtLeast(models.RoleViewer) {
+		if role.CanManage(target) {
+			roles = append(roles, string(role))
+		}
+	}
+	return roles
+}
+
+// lockMembers блокирует участников организации до конца транзакции и возвращает их роли.
+// Блокировка нужна, чтобы одновременные изменения не оставили организацию без владельца.
+func (s *PostgresStorage) lockMembers(ctx context.Context, tx pgx.Tx, orgID uuid.UUID) (map[uuid.UUID]models.OrganizationRole, error) {
+	query := `
+		SELECT user_id, role
+		FROM organization_members
+		WHERE organization_id = $1
+		FOR UPDATE`
+
+	rows, err := tx.Query(ctx, query, orgID)
+	if err := s.handleQueryError(err, "failed to query organization members"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make(map[uuid.UUID]models.OrganizationRole)
+	for rows.Next() {
+		var userID uuid.UUID
+		var role models.OrganizationRole
+		if err := rows.Scan(&userID, &role); err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members[userID] = role
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// countOwners считает владельцев среди участников.
+func countOwners(members map[uuid.UUID]models.OrganizationRole) int {
+	owners := 0
+	for _, role := range members {
+		if role == models.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+// ownedOrganizationsToDelete блокирует участников организаций пользователя и возвращает
+// организации, в которых он единственный участник. Если пользователь - последний
+// владелец организации с другими участниками, возвращается ErrLastOwner.
+func (s *PostgresStorage) ownedOrganizationsToDelete(ctx context.Context, tx pgx.Tx, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT organization_id
+		FROM organization_members
+		WHERE user_id = $1
+		ORDER BY organization_id`
+
+	rows, err := tx.Query(ctx, query, userID)
+	if err := s.handleQueryError(err, "failed to query organizations"); err != nil {
+		return nil, err
+	}
+	var orgIDs []uuid.UUID
+	for rows.Next() {
+		var orgID uuid.UUID
+		if err := rows.Scan(&orgID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgIDs = append(orgIDs, orgID)
+	}
+	rows.Close()
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	var orphaned []uuid.UUID
+	for _, orgID := range orgIDs {
+		members, err := s.lockMembers(ctx, tx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) == 1 {
+			orphaned = append(orphaned, orgID)
+			continue
+		}
+		if members[userID] == models.RoleOwner && countOwners(members) == 1 {
+			return nil, ErrLastOwner
+		}
+	}
+
+	return orphaned, nil
+}
+
+// CreateOrganization создает организацию, создатель становится ее владельцем.
+func (s *PostgresStorage) CreateOrganization(ctx context.Context, org *models.Organization, ownerID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	org.ID, org.CreatedAt, _ = s.prepareNewEntity()
+
+	orgQuery := `INSERT INTO organizations (id, name, created_at, updated_at) VALUES ($1, $2, $3, $3)`
+	_, err = tx.Exec(ctx, orgQuery, org.ID, org.Name, org.CreatedAt)
+	if err := s.handleExecError(err, "", "failed to create organization"); err != nil {
+		return err
+	}
+
+	memberQuery := `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)`
+	_, err = tx.Exec(ctx, memberQuery, org.ID, ownerID, models.RoleOwner, org.CreatedAt)
+	if err := s.handleExecError(err, "", "failed to add organization owner"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	org.Role = models.RoleOwner
+	return nil
+}
+
+// GetOrganizations получает организации, в которых состоит пользователь, вместе с его ролью.
+func (s *PostgresStorage) GetOrganizations(ctx context.Context, userID uuid.UUID) ([]models.Organization, error) {
+	query := `
+		SELECT o.id, o.name, o.created_at, m.role
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name ASC`
+
+	rows, err := s.pool.Query(ctx, query, userID)
+	if err := s.handleQueryError(err, "failed to query organizations"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []models.Organization
+	for rows.Next() {
+		var org models.Organization
+		err := rows.Scan(&org.ID, &org.Name, &org.CreatedAt, &org.Role)
+		if err := s.handleScanError(err, "failed to scan organization"); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// GetOrganizationRole получает роль пользователя в организации.
+func (s *PostgresStorage) GetOrganizationRole(ctx context.Context, orgID, userID uuid.UUID) (models.OrganizationRole, error) {
+	query := `SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	var role models.OrganizationRole
+	err := s.pool.QueryRow(ctx, query, orgID, userID).Scan(&role)
+	if err := s.handleQueryRowError(err, "organization member not found", "failed to get organization role"); err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+// GetOrganizationMembers получает участников организации. Список доступен только участникам.
+func (s *PostgresStorage) GetOrganizationMembers(ctx context.Context, userID, orgID uuid.UUID) ([]models.OrganizationMember, error) {
+	query := `
+		SELECT m.organization_id, m.user_id, u.username, m.role, m.created_at
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND EXISTS (
+			SELECT 1 FROM organization_members a
+			WHERE a.organization_id = $1 AND a.user_id = $2
+		)
+		ORDER BY m.created_at ASC`
+
+	rows, err := s.pool.Query(ctx, query, orgID, userID)
+	if err := s.handleQueryError(err, "failed to query organization members"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.OrganizationMember
+	for rows.Next() {
+		var member models.OrganizationMember
+		err := rows.Scan(&member.OrganizationID, &member.UserID, &member.Username, &member.Role, &member.CreatedAt)
+		if err := s.handleScanError(err, "failed to scan organization member"); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// UpdateOrganizationMemberRole изменяет роль участника. Действующий пользователь
+// должен иметь право управлять как текущей, так и новой ролью участника.
+func (s *PostgresStorage) UpdateOrganizationMemberRole(ctx context.Context, actorID, orgID, memberID uuid.UUID, role models.OrganizationRole) (*models.OrganizationMember, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	members, err := s.lockMembers(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorRole, ok := members[actorID]
+	if !ok {
+		return nil, fmt.Errorf("organization member not found")
+	}
+	currentRole, ok := members[memberID]
+	if !ok {
+		return nil, fmt.Errorf("organization member not found")
+	}
+	if !actorRole.CanManage(currentRole) || !actorRole.CanManage(role) {
+		return nil, ErrInsufficientRole
+	}
+	if currentRole == models.RoleOwner && role != models.RoleOwner && countOwners(members) == 1 {
+		return nil, ErrLastOwner
+	}
+
+	query := `
+		UPDATE organization_members m
+		SET role = $3
+		FROM users u
+		WHERE m.organization_id = $1 AND m.user_id = $2 AND u.id = m.user_id
+		RETURNING u.username, m.created_at`
+
+	member := &models.OrganizationMember{
+		OrganizationID: orgID,
+		UserID:         memberID,
+		Role:           role,
+	}
+	err = tx.QueryRow(ctx, query, orgID, memberID, role).Scan(&member.Username, &member.CreatedAt)
+	if err := s.handleQueryRowError(err, "organization member not found", "failed to update member role"); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return member, nil
+}
+
+// RemoveOrganizationMember исключает участника из организации. Участник может
+// покинуть организацию сам. Ключи коллекций исключенного участника удаляются,
+// его записи коллекций помечаются удаленными для синхронизации, а все коллекции
+// организации помечаются как требующие смены ключа. Возвращает ID этих коллекций.
+func (s *PostgresStorage) RemoveOrganizationMember(ctx context.Context, actorID, orgID, memberID uuid.UUID) ([]uuid.UUID, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	members, err := s.lockMembers(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorRole, ok := members[actorID]
+	if !ok {
+		return nil, fmt.Errorf("organization member not found")
+	}
+	memberRole, ok := members[memberID]
+	if !ok {
+		return nil, fmt.Errorf("organization member not found")
+	}
+	if actorID != memberID && !actorRole.CanManage(memberRole) {
+		return nil, ErrInsufficientRole
+	}
+	if memberRole == models.RoleOwner && countOwners(members) == 1 {
+		return nil, ErrLastOwner
+	}
+
+	deleteQuery := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	_, err = tx.Exec(ctx, deleteQuery, orgID, memberID)
+	if err := s.handleExecError(err, "", "failed to remove organization member"); err != nil {
+		return nil, err
+	}
+
+	keysQuery := `
+		DELETE FROM collection_keys k
+		USING collections c
+		WHERE k.collection_id = c.id AND c.organization_id = $1 AND k.user_id = $2`
+	_, err = tx.Exec(ctx, keysQuery, orgID, memberID)
+	if err := s.handleExecError(err, "", "failed to remove collection keys"); err != nil {
+		return nil, err
+	}
+
+	tombstoneQuery := `
+		INSERT INTO deleted_entries (id, user_id, deleted_at)
+		SELECT e.id, $2, NOW()
+		FROM collection_entries e
+		JOIN collections c ON c.id = e.collection_id
+		WHERE c.organization_id = $1
+		ON CONFLICT (id, user_id) DO UPDATE SET deleted_at = EXCLUDED.deleted_at`
+	_, err = tx.Exec(ctx, tombstoneQuery, orgID, memberID)
+	if err := s.handleExecError(err, "", "failed to insert deleted collection entries"); err != nil {
+		return nil, err
+	}
+
+	// Исключенный участник мог сохранить ключи коллекций, поэтому их нужно сменить
+	rotationQuery := `
+		UPDATE collections SET rotation_required = TRUE
+		WHERE organization_id = $1
+		RETURNING id`
+	rows, err := tx.Query(ctx, rotationQuery, orgID)
+	if err := s.handleQueryError(err, "failed to mark collections for key rotation"); err != nil {
+		return nil, err
+	}
+	var collectionIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan collection ID: %w", err)
+		}
+		collectionIDs = append(collectionIDs, id)
+	}
+	rows.Close()
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return collectionIDs, nil
+}
+
+// CreateInvitation создает приглашение в организацию. Повторное приглашение того же
+// пользователя заменяет роль и срок действия. Приглашение создается, только если
+// действующий пользователь может назначать приглашаемую роль.
+func (s *PostgresStorage) CreateInvitation(ctx context.Context, actorID uuid.UUID, invitation *models.OrganizationInvitation) error {
+	query := `
+		INSERT INTO organization_invitations (id, organization_id, invitee_id, invited_by, role, created_at, expires_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		FROM organization_members a
+		WHERE a.organization_id = $2 AND a.user_id = $4 AND a.role = ANY($8)
+			AND NOT EXISTS (
+				SELECT 1 FROM organization_members m
+				WHERE m.organization_id = $2 AND m.user_id = $3
+			)
+		ON CONFLICT (organization_id, invitee_id) DO UPDATE
+		SET invited_by = EXCLUDED.invited_by,
+			role = EXCLUDED.role,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		RETURNING id`
+
+	newID, createdAt, _ := s.prepareNewEntity()
+	invitation.InvitedBy = actorID
+	err := s.pool.QueryRow(ctx, query,
+		newID, invitation.OrganizationID, invitation.InviteeID, actorID,
+		invitation.Role, createdAt, invitation.ExpiresAt, managerRoles(invitation.Role),
+	).Scan(&invitation.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInsufficientRole
+	}
+	if err := s.handleQueryRowError(err, "organization not found", "failed to create invitation"); err != nil {
+		return err
+	}
+	invitation.CreatedAt = createdAt
+
+	return nil
+}
+
+// GetInvitations получает действующие приглашения пользователя.
+func (s *PostgresStorage) GetInvitations(ctx context.Context, inviteeID uuid.UUID) ([]models.OrganizationInvitation, error) {
+	query := `
+		SELECT i.id, i.organization_id, o.name, i.invitee_id, u.username,
+			i.invited_by, b.username, i.role, i.created_at, i.expires_at
+		FROM organization_invitations i
+		JOIN organizations o ON o.id = i.organization_id
+		JOIN users u ON u.id = i.invitee_id
+		JOIN users b ON b.id = i.invited_by
+		WHERE i.invitee_id = $1 AND i.expires_at > NOW()
+		ORDER BY i.created_at DESC`
+
+	rows, err := s.pool.Query(ctx, query, inviteeID)
+	if err := s.handleQueryError(err, "failed to query invitations"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []models.OrganizationInvitation
+	for rows.Next() {
+		var inv models.OrganizationInvitation
+		err := rows.Scan(
+			&inv.ID, &inv.OrganizationID, &inv.OrganizationName,
+			&inv.InviteeID, &inv.InviteeUsername,
+			&inv.InvitedBy, &inv.InvitedByName,
+			&inv.Role, &inv.CreatedAt, &inv.ExpiresAt,
+		)
+		if err := s.handleScanError(err, "failed to scan invitation"); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation принимает приглашение и добавляет пользователя в организацию.
+// Ключи коллекций новому участнику передает администратор отдельно.
+func (s *PostgresStorage) AcceptInvitation(ctx context.Context, inviteeID, invitationID uuid.UUID) (*models.Organization, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deleteQuery := `
+		DELETE FROM organization_invitations
+		WHERE id = $1 AND invitee_id = $2 AND expires_at > NOW()
+		RETURNING organization_id, role`
+
+	var orgID uuid.UUID
+	var role models.OrganizationRole
+	err = tx.QueryRow(ctx, deleteQuery, invitationID, inviteeID).Scan(&orgID, &role)
+	if err := s.handleQueryRowError(err, "invitation not found", "failed to accept invitation"); err != nil {
+		return nil, err
+	}
+
+	memberQuery := `
+		INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (organization_id, user_id) DO NOTHING`
+	_, err = tx.Exec(ctx, memberQuery, orgID, inviteeID, role)
+	if err := s.handleExecError(err, "", "failed to add organization member"); err != nil {
+		return nil, err
+	}
+
+	// Записи снова появятся у участника, если ранее он был исключен
+	tombstoneQuery := `
+		DELETE FROM deleted_entries d
+		USING collection_entries e, collections c
+		WHERE d.id = e.id AND d.user_id = $2 AND c.id = e.collection_id AND c.organization_id = $1`
+	_, err = tx.Exec(ctx, tombstoneQuery, orgID, inviteeID)
+	if err := s.handleExecError(err, "", "failed to clear deleted collection entries"); err != nil {
+		return nil, err
+	}
+
+	orgQuery := `
+		SELECT o.id, o.name, o.created_at, m.role
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE o.id = $1 AND m.user_id = $2`
+	var org models.Organization
+	err = tx.QueryRow(ctx, orgQuery, orgID, inviteeID).Scan(&org.ID, &org.Name, &org.CreatedAt, &org.Role)
+	if err := s.handleQueryRowError(err, "organization not found", "failed to get organization"); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &org, nil
+}
+
+// DeleteInvitation удаляет приглашение. Отклонить приглашение может приглашенный
+// пользователь, отозвать - администратор или владелец организации.
+func (s *PostgresStorage) DeleteInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	query := `
+		DELETE FROM organization_invitations i
+		WHERE i.id = $1 AND (
+			i.invitee_id = $2 OR EXISTS (
+				SELECT 1 FROM organization_members a
+				WHERE a.organization_id = i.organization_id AND a.user_id = $2 AND a.role = ANY($3)
+			)
+		)`
+
+	result, err := s.pool.Exec(ctx, query, invitationID, userID, rolesToStrings(models.RolesAtLeast(models.RoleAdmin)))
+	if err := s.handleExecError(err, "", "failed to delete invitation"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("invitation not found")
+	}
+
+	return nil
+}
+
+// collectionSelect выбирает коллекции, доступные участнику ($1), вместе с ключом
+// коллекции текущей версии, зашифрованным для этого участника.
+const collectionSelect = `
+	SELECT c.id, c.organization_id, c.name, c.key_version, c.rotation_required,
+		COALESCE(c.rotation_days, 0), c.created_at, c.updated_at, k.wrapped_key
+	FROM collections c
+	JOIN organization_members m ON m.organization_id = c.organization_id
+	LEFT JOIN collection_keys k
+		ON k.collection_id = c.id AND k.user_id = m.user_id AND k.key_version = c.key_version
+	WHERE m.user_id = $1`
+
+// scanCollection сканирует строку результата collectionSelect.
+func scanCollection(row pgx.Row) (*models.Collection, error) {
+	var collection models.Collection
+	err := row.Scan(
+		&collection.ID, &collection.OrganizationID, &collection.Name,
+		&collection.KeyVersion, &collection.RotationRequired, &collection.RotationDays,
+		&collection.CreatedAt, &collection.UpdatedAt, &collection.WrappedKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// CreateCollection создает коллекцию организации. Создавать коллекции могут
+// администраторы и владельцы; ключ коллекции сохраняется для создателя.
+func (s *PostgresStorage) CreateCollection(ctx context.Context, actorID uuid.UUID, collection *models.Collection) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	collection.ID, collection.CreatedAt, collection.UpdatedAt = s.prepareNewEntity()
+	collection.KeyVersion = 1
+	collection.RotationRequired = false
+
+	query := `
+		INSERT INTO collections (id, organization_id, name, key_version, created_at, updated_at, rotation_days)
+		SELECT $1, $2, $3, $4, $5, $6, $9
+		FROM organization_members a
+		WHERE a.organization_id = $2 AND a.user_id = $7 AND a.role = ANY($8)`
+	result, err := tx.Exec(ctx, query,
+		collection.ID, collection.OrganizationID, collection.Name, collection.KeyVersion,
+		collection.CreatedAt, collection.UpdatedAt,
+		actorID, rolesToStrings(models.RolesAtLeast(models.RoleAdmin)),
+		nullRotationDays(collection.RotationDays),
+	)
+	if err := s.handleExecError(err, "collection with this name already exists", "failed to create collection"); err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrInsufficientRole
+	}
+
+	keyQuery := `
+		INSERT INTO collection_keys (collection_id, user_id, key_version, wrapped_key, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.Exec(ctx, keyQuery, collection.ID, actorID, collection.KeyVersion, collection.WrappedKey, collection.CreatedAt)
+	if err := s.handleExecError(err, "", "failed to store collection key"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollections получает коллекции организации, доступные участнику.
+func (s *PostgresStorage) GetCollections(ctx context.Context, userID, orgID uuid.UUID) ([]models.Collection, error) {
+	query := collectionSelect + `
+		AND c.organization_id = $2
+		ORDER BY c.name ASC`
+
+	rows, err := s.pool.Query(ctx, query, userID, orgID)
+	if err := s.handleQueryError(err, "failed to query collections"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collections []models.Collection
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err := s.handleScanError(err, "failed to scan collection"); err != nil {
+			return nil, err
+		}
+		collections = append(collections, *collection)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// GetCollection получает коллекцию, если пользователь состоит в ее организации.
+func (s *PostgresStorage) GetCollection(ctx context.Context, userID, collectionID uuid.UUID) (*models.Collection, error) {
+	query := collectionSelect + `
+		AND c.id = $2`
+
+	collection, err := scanCollection(s.pool.QueryRow(ctx, query, userID, collectionID))
+	if err := s.handleQueryRowError(err, "collection not found", "failed to get collection"); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// SetCollectionRotationDays изменяет политику смены паролей записей коллекции.
+// Изменять политику могут администраторы и владельцы, 0 снимает политику.
+func (s *PostgresStorage) SetCollectionRotationDays(ctx context.Context, actorID, collectionID uuid.UUID, days int) error {
+	query := `
+		UPDATE collections c
+		SET rotation_days = $2, updated_at = NOW()
+		FROM organization_members a
+		WHERE c.id = $1 AND a.organization_id = c.organization_id
+			AND a.user_id = $3 AND a.role = ANY($4)`
+
+	result, err := s.pool.Exec(ctx, query,
+		collectionID, nullRotationDays(days),
+		actorID, rolesToStrings(models.RolesAtLeast(models.RoleAdmin)),
+	)
+	if err := s.handleExecError(err, "", "failed to update collection rotation policy"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrInsufficientRole
+	}
+
+	return nil
+}
+
+// SetCollectionKey сохраняет ключ коллекции текущей версии для участника организации.
+// Передавать ключи могут администраторы и владельцы.
+func (s *PostgresStorage) SetCollectionKey(ctx context.Context, actorID uuid.UUID, key *models.CollectionKey) error {
+	query := `
+		INSERT INTO collection_keys (collection_id, user_id, key_version, wrapped_key, created_at)
+		SELECT c.id, $2, c.key_version, $3, NOW()
+		FROM collections c
+		WHERE c.id = $1 AND c.key_version = $4
+			AND EXISTS (
+				SELECT 1 FROM organization_members a
+				WHERE a.organization_id = c.organization_id AND a.user_id = $5 AND a.role = ANY($6)
+			)
+			AND EXISTS (
+				SELECT 1 FROM organization_members m
+				WHERE m.organization_id = c.organization_id AND m.user_id = $2
+			)
+		ON CONFLICT (collection_id, user_id) DO UPDATE
+		SET key_version = EXCLUDED.key_version,
+			wrapped_key = EXCLUDED.wrapped_key,
+			created_at = EXCLUDED.created_at`
+
+	result, err := s.pool.Exec(ctx, query,
+		key.CollectionID, key.UserID, key.WrappedKey, key.KeyVersion,
+		actorID, rolesToStrings(models.RolesAtLeast(models.RoleAdmin)),
+	)
+	if err := s.handleExecError(err, "", "failed to store collection key"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrCollectionKeyMismatch
+	}
+
+	return nil
+}
+
+// RotateCollectionKey заменяет ключ коллекции новым. Новый ключ должен быть
+// зашифрован для каждого текущего участника организации, а все записи коллекции
+// перешифрованы им. Проверки и замена выполняются в одной транзакции.
+func (s *PostgresStorage) RotateCollectionKey(ctx context.Context, actorID, collectionID uuid.UUID, keyVersion int, keys []models.CollectionKey, entries []models.DataEntry) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var orgID uuid.UUID
+	var currentVersion int
+	lockQuery := `SELECT organization_id, key_version FROM collections WHERE id = $1 FOR UPDATE`
+	err = tx.QueryRow(ctx, lockQuery, collectionID).Scan(&orgID, &currentVersion)
+	if err := s.handleQueryRowError(err, "collection not found", "failed to get collection"); err != nil {
+		return err
+	}
+
+	members, err := s.lockMembers(ctx, tx, orgID)
+	if err != nil {
+		return err
+	}
+	actorRole, ok := members[actorID]
+	if !ok {
+		return fmt.Errorf("collection not found")
+	}
+	if !actorRole.AtLeast(models.RoleAdmin) {
+		return ErrInsufficientRole
+	}
+
+	if keyVersion != currentVersion+1 || len(keys) != len(members) {
+		return ErrCollectionKeyMismatch
+	}
+	seen := make(map[uuid.UUID]bool, len(keys))
+	for _, key := range keys {
+		if _, ok := members[key.UserID]; !ok || seen[key.UserID] {
+			return ErrCollectionKeyMismatch
+		}
+		seen[key.UserID] = true
+	}
+
+	var entryCount int
+	countQuery := `SELECT COUNT(*) FROM collection_entries WHERE collection_id = $1`
+	if err := tx.QueryRow(ctx, countQuery, collectionID).Scan(&entryCount); err != nil {
+		return fmt.Errorf("failed to count collection entries: %w", err)
+	}
+	if entryCount != len(entries) {
+		return ErrCollectionKeyMismatch
+	}
+
+	entryQuery := `
+		UPDATE collection_entries
+		SET encrypted_data = $1, version = version + 1
+		WHERE id = $2 AND collection_id = $3 AND version = $4`
+	for _, entry := range entries {
+		result, err := tx.Exec(ctx, entryQuery, entry.EncryptedData, entry.ID, collectionID, entry.Version)
+		if err := s.handleExecError(err, "", "failed to update collection entry"); err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return ErrCollectionKeyMismatch
+		}
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM collection_keys WHERE collection_id = $1`, collectionID)
+	if err := s.handleExecError(err, "", "failed to delete collection keys"); err != nil {
+		return err
+	}
+
+	keyQuery := `
+		INSERT INTO collection_keys (collection_id, user_id, key_version, wrapped_key, created_at)
+		VALUES ($1, $2, $3, $4, NOW())`
+	for _, key := range keys {
+		_, err := tx.Exec(ctx, keyQuery, collectionID, key.UserID, keyVersion, key.WrappedKey)
+		if err := s.handleExecError(err, "", "failed to store collection key"); err != nil {
+			return err
+		}
+	}
+
+	updateQuery := `UPDATE collections SET key_version = $2, rotation_required = FALSE WHERE id = $1`
+	_, err = tx.Exec(ctx, updateQuery, collectionID, keyVersion)
+	if err := s.handleExecError(err, "", "failed to update collection"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// collectionEntrySelect выбирает записи коллекций организаций, в которых состоит участник ($1).
+const collectionEntrySelect = `
+	SELECT e.id, e.created_by, e.collection_id, e.type, e.name, e.description,
+		e.encrypted_data, e.metadata, e.created_at, e.updated_at, e.version,
+		e.password_changed_at, COALESCE(e.rotation_days, 0), COALESCE(c.rotation_days, 0)
+	FROM collection_entries e
+	JOIN collections c ON c.id = e.collection_id
+	JOIN organization_members m ON m.organization_id = c.organization_id
+	WHERE m.user_id = $1`
+
+// scanCollectionEntry сканирует строку результата collectionEntrySelect.
+func scanCollectionEntry(row pgx.Row) (*models.DataEntry, error) {
+	var entry models.DataEntry
+	var createdBy *uuid.UUID
+	var collectionID uuid.UUID
+	err := row.Scan(
+		&entry.ID, &createdBy, &collectionID, &entry.Type, &entry.Name,
+		&entry.Description, &entry.EncryptedData, &entry.Metadata,
+		&entry.CreatedAt, &entry.UpdatedAt, &entry.Version,
+		&entry.PasswordChangedAt, &entry.RotationDays, &entry.CollectionRotationDays,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Автор мог удалить аккаунт, запись при этом остается в коллекции
+	if createdBy != nil {
+		entry.UserID = *createdBy
+	}
+	entry.CollectionID = &collectionID
+	return &entry, nil
+}
+
+// queryCollectionEntries выполняет запрос записей коллекций.
+func (s *PostgresStorage) queryCollectionEntries(ctx context.Context, query string, args ...interface{}) ([]models.DataEntry, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err := s.handleQueryError(err, "failed to query collection entries"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.DataEntry
+	for rows.Next() {
+		entry, err := scanCollectionEntry(rows)
+		if err := s.handleScanError(err, "failed to scan collection entry"); err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CreateCollectionEntry создает запись в коллекции. Создавать записи могут
+// участники с ролью редактора и выше.
+func (s *PostgresStorage) CreateCollectionEntry(ctx context.Context, actorID uuid.UUID, entry *models.DataEntry) error {
+	if entry.CollectionID == nil {
+		return fmt.Errorf("collection is required")
+	}
+
+	query := `
+		INSERT INTO collection_entries (id, collection_id, created_by, type, name, description, encrypted_data, metadata,
+			created_at, updated_at, version, password_changed_at, rotation_days)
+		SELECT $1, c.id, $3, $4, $5, $6, $7, $8, $9, $10, $11, $13, $14
+		FROM collections c
+		JOIN organization_members a ON a.organization_id = c.organization_id
+		WHERE c.id = $2 AND a.user_id = $3 AND a.role = ANY($12)`
+
+	entry.ID, entry.CreatedAt, entry.UpdatedAt, entry.Version = s.prepareNewDataEntry()
+	entry.PasswordChangedAt = entry.CreatedAt
+	entry.UserID = actorID
+
+	result, err := s.pool.Exec(ctx, query,
+		entry.ID, *entry.CollectionID, actorID, entry.Type, entry.Name,
+		entry.Description, entry.EncryptedData, entry.Metadata,
+		entry.CreatedAt, entry.UpdatedAt, entry.Version,
+		rolesToStrings(models.RolesAtLeast(models.RoleEditor)),
+		entry.PasswordChangedAt, nullRotationDays(entry.RotationDays),
+	)
+	if err := s.handleExecError(err, "entry with this name already exists", "failed to create collection entry"); err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrInsufficientRole
+	}
+
+	return nil
+}
+
+// GetCollectionEntry получает запись коллекции, доступную участнику.
+func (s *PostgresStorage) GetCollectionEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error) {
+	query := collectionEntrySelect + `
+		AND e.id = $2`
+
+	entry, err := scanCollectionEntry(s.pool.QueryRow(ctx, query, userID, entryID))
+	if err := s.handleQueryRowError(err, "data entry not found", "failed to get collection entry"); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetCollectionEntries получает все записи коллекции.
+func (s *PostgresStorage) GetCollectionEntries(ctx context.Context, userID, collectionID uuid.UUID) ([]models.DataEntry, error) {
+	query := collectionEntrySelect + `
+		AND e.collection_id = $2
+		ORDER BY e.created_at DESC`
+
+	return s.queryCollectionEntries(ctx, query, userID, collectionID)
+}
+
+// GetCollectionEntriesAfter получает записи коллекций, измененные после указанного
+// времени или ставшие доступными участнику после вступления в организацию.
+func (s *PostgresStorage) GetCollectionEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
+	query := collectionEntrySelect + `
+		AND GREATEST(e.updated_at, m.created_at) > $2
+		ORDER BY e.updated_at ASC`
+
+	return s.queryCollectionEntries(ctx, query, userID, after)
+}
+
+// UpdateCollectionEntry обновляет запись коллекции с проверкой версии.
+// Изменять записи могут участники с ролью редактора и выше.
+func (s *PostgresStorage) UpdateCollectionEntry(ctx context.Context, actorID uuid.UUID, entry *models.DataEntry) error {
+	query := `
+		UPDATE collection_entries e
+		SET name = $1, description = $2, encrypted_data = $3, metadata = $4, version = e.version + 1,
+			password_changed_at = $9, rotation_days = $10
+		FROM collections c
+		JOIN organization_members a ON a.organization_id = c.organization_id
+		WHERE e.id = $5 AND e.version = $6 AND c.id = e.collection_id
+			AND a.user_id = $7 AND a.role = ANY($8)`
+
+	result, err := s.pool.Exec(ctx, query,
+		entry.Name, entry.Description, entry.EncryptedData, entry.Metadata,
+		entry.ID, entry.Version,
+		actorID, rolesToStrings(models.RolesAtLeast(models.RoleEditor)),
+		entry.PasswordChangedAt, nullRotationDays(entry.RotationDays),
+	)
+	if err := s.handleExecError(err, "entry with this name already exists", "failed to update collection entry"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrVersionMismatch
+	}
+
+	entry.Version++
+	entry.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// DeleteCollectionEntry удаляет запись коллекции. Удалять записи могут участники
+// с ролью редактора и выше; запись удаляется у всех участников при синхронизации.
+func (s *PostgresStorage) DeleteCollectionEntry(ctx context.Context, actorID, entryID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deleteQuery := `
+		DELETE FROM collection_entries e
+		USING collections c, organization_members a
+		WHERE e.id = $1 AND c.id = e.collection_id
+			AND a.organization_id = c.organization_id AND a.user_id = $2 AND a.role = ANY($3)
+		RETURNING c.organization_id`
+
+	var orgID uuid.UUID
+	err = tx.QueryRow(ctx, deleteQuery, entryID, actorID, rolesToStrings(models.RolesAtLeast(models.RoleEditor))).Scan(&orgID)
+	if err := s.handleQueryRowError(err, "data entry not found", "failed to delete collection entry"); err != nil {
+		return err
+	}
+
+	tombstoneQuery := `
+		INSERT INTO deleted_entries (id, user_id, deleted_at)
+		SELECT $1, m.user_id, NOW()
+		FROM organization_members m
+		WHERE m.organization_id = $2
+		ON CONFLICT (id, user_id) DO UPDATE SET deleted_at = EXCLUDED.deleted_at`
+	_, err = tx.Exec(ctx, tombstoneQuery, entryID, orgID)
+	if err := s.handleExecError(err, "", "failed to insert deleted entry"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
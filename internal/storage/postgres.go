@@ -16,11 +16,43 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrVersionMismatch возвращается при обновлении записи, если она была удалена
+// или изменена после того, как клиент получил ее версию.
+var ErrVersionMismatch = errors.New("data entry not found or version mismatch")
+
+// ErrVaultKeyExists возвращается при попытке заменить уже заданный ключ хранилища
+var ErrVaultKeyExists = errors.New("vault key is already set")
+
 // UserRepository определяет интерфейс для работы с пользователями
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	UpdateUserPassword(ctx context.Context, user *models.User, keepSessionID uuid.UUID) error
+	SetUserVaultKey(ctx context.Context, userID uuid.UUID, wrappedVaultKey []byte) error
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// SessionRepository определяет интерфейс для работы с сессиями
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *models.Session) error
+	IsSessionActive(ctx context.Context, userID, sessionID uuid.UUID) (bool, error)
+}
+
+// AccessTokenRepository определяет интерфейс для работы с персональными токенами доступа
+type AccessTokenRepository interface {
+	CreateAccessToken(ctx context.Context, token *models.AccessToken) error
+	GetAccessTokenByHash(ctx context.Context, tokenHash []byte) (*models.AccessToken, error)
+	GetAccessTokenByCertificate(ctx context.Context, fingerprint []byte) (*models.AccessToken, error)
+	ListAccessTokens(ctx context.Context, userID uuid.UUID) ([]models.AccessToken, error)
+	RevokeAccessToken(ctx context.Context, userID, tokenID uuid.UUID) error
+	TouchAccessToken(ctx context.Context, tokenID uuid.UUID) error
+}
+
+// CredentialRepository объединяет репозитории, необходимые для проверки учетных данных запроса
+type CredentialRepository interface {
+	SessionRepository
+	AccessTokenRepository
 }
 
 // DataRepository определяет интерфейс для работы с данными
@@ -38,6 +70,84 @@ type SyncRepository interface {
 	GetDeletedEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]uuid.UUID, error)
 }
 
+// ShareRepository определяет интерфейс для обмена записями между пользователями
+type ShareRepository interface {
+	SetUserPublicKey(ctx context.Context, userID uuid.UUID, publicKey, wrappedPrivateKey []byte) error
+	CreateEntryShare(ctx context.Context, share *models.EntryShare) error
+	GetEntryShares(ctx context.Context, ownerID, entryID uuid.UUID) ([]models.EntryShare, error)
+	DeleteEntryShare(ctx context.Context, userID, shareID uuid.UUID) error
+	GetSharedEntry(ctx context.Context, recipientID, entryID uuid.UUID) (*models.DataEntry, error)
+	GetSharedEntries(ctx context.Context, recipientID uuid.UUID) ([]models.DataEntry, error)
+	GetSharedEntriesAfter(ctx context.Context, recipientID uuid.UUID, after time.Time) ([]models.DataEntry, error)
+}
+
+// OrganizationRepository определяет интерфейс для работы с организациями и их участниками.
+// Методы, изменяющие состав организации, проверяют роль действующего пользователя.
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, org *models.Organization, ownerID uuid.UUID) error
+	GetOrganizations(ctx context.Context, userID uuid.UUID) ([]models.Organization, error)
+	GetOrganizationRole(ctx context.Context, orgID, userID uuid.UUID) (models.OrganizationRole, error)
+	GetOrganizationMembers(ctx context.Context, userID, orgID uuid.UUID) ([]models.OrganizationMember, error)
+	UpdateOrganizationMemberRole(ctx context.Context, actorID, orgID, memberID uuid.UUID, role models.OrganizationRole) (*models.OrganizationMember, error)
+	RemoveOrganizationMember(ctx context.Context, actorID, orgID, memberID uuid.UUID) ([]uuid.UUID, error)
+	CreateInvitation(ctx context.Context, actorID uuid.UUID, invitation *models.OrganizationInvitation) error
+	GetInvitations(ctx context.Context, inviteeID uuid.UUID) ([]models.OrganizationInvitation, error)
+	AcceptInvitation(ctx context.Context, inviteeID, invitationID uuid.UUID) (*models.Organization, error)
+	DeleteInvitation(ctx context.Context, userID, invitationID uuid.UUID) error
+}
+
+// CollectionRepository определяет интерфейс для работы с коллекциями организаций и их записями.
+type CollectionRepository interface {
+	CreateCollection(ctx context.Context, actorID uuid.UUID, collection *models.Collection) error
+	GetCollections(ctx context.Context, userID, orgID uuid.UUID) ([]models.Collection, error)
+	GetCollection(ctx context.Context, userID, collectionID uuid.UUID) (*models.Collection, error)
+	SetCollectionKey(ctx context.Context, actorID uuid.UUID, key *models.CollectionKey) error
+	SetCollectionRotationDays(ctx context.Context, actorID, collectionID uuid.UUID, days int) error
+	RotateCollectionKey(ctx context.Context, actorID, collectionID uuid.UUID, keyVersion int, keys []models.CollectionKey, entries []models.DataEntry) error
+	CreateCollectionEntry(ctx context.Context, actorID uuid.UUID, entry *models.DataEntry) error
+	GetCollectionEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error)
+	GetCollectionEntries(ctx context.Context, userID, collectionID uuid.UUID) ([]models.DataEntry, error)
+	GetCollectionEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]models.DataEntry, error)
+	UpdateCollectionEntry(ctx context.Context, actorID uuid.UUID, entry *models.DataEntry) error
+	DeleteCollectionEntry(ctx context.Context, actorID, entryID uuid.UUID) error
+}
+
+// SecretLinkRepository определяет интерфейс для работы с одноразовыми ссылками на секреты
+type SecretLinkRepository interface {
+	CreateSecretLink(ctx context.Context, link *models.SecretLink) error
+	ConsumeSecretLink(ctx context.Context, linkID uuid.UUID, now time.Time) (*models.SecretLink, error)
+	DeleteExpiredSecretLinks(ctx context.Context, now time.Time) (int64, error)
+}
+
+// AttachmentRepository определяет интерфейс для работы с вложениями записей.
+// Права доступа к записи проверяет вызывающий код.
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *models.Attachment) error
+	GetAttachments(ctx context.Context, entryID uuid.UUID) ([]models.Attachment, error)
+	GetAttachment(ctx context.Context, entryID, attachmentID uuid.UUID) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, entryID, attachmentID uuid.UUID) error
+	GetUserAttachments(ctx context.Context, userID uuid.UUID) ([]models.Attachment, error)
+}
+
+// RotationRepository определяет интерфейс для напоминаний о смене паролей
+type RotationRepository interface {
+	GetEntriesDueForRotation(ctx context.Context, userID uuid.UUID, dueBefore time.Time) ([]models.DataEntry, error)
+}
+
+// EmergencyAccessRepository определяет интерфейс для работы с экстренным доступом.
+// Изменения записываются в журнал и создают уведомления участникам в той же транзакции.
+type EmergencyAccessRepository interface {
+	CreateEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, message string) error
+	GetEmergencyAccess(ctx context.Context, userID, accessID uuid.UUID) (*models.EmergencyAccess, error)
+	GetEmergencyAccessList(ctx context.Context, userID uuid.UUID) ([]models.EmergencyAccess, error)
+	GetElapsedEmergencyRequests(ctx context.Context, now time.Time) ([]models.EmergencyAccess, error)
+	TransitionEmergencyAccess(ctx context.Context, accessID uuid.UUID, from []models.EmergencyAccessStatus, to models.EmergencyAccessStatus, event *models.EmergencyAccessEvent, message string) (*models.EmergencyAccess, error)
+	RecordEmergencyAccessEvent(ctx context.Context, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error
+	DeleteEmergencyAccess(ctx context.Context, access *models.EmergencyAccess, event *models.EmergencyAccessEvent, message string) error
+	GetEmergencyAccessEvents(ctx context.Context, userID, accessID uuid.UUID) ([]models.EmergencyAccessEvent, error)
+	GetNotifications(ctx context.Context, userID uuid.UUID) ([]models.Notification, error)
+}
+
 // ConnectionManager определяет интерфейс для управления соединением
 type ConnectionManager interface {
 	Close()
@@ -46,8 +156,17 @@ type ConnectionManager interface {
 // Storage объединяет все репозитории в один интерфейс
 type Storage interface {
 	UserRepository
+	SessionRepository
+	AccessTokenRepository
 	DataRepository
 	SyncRepository
+	ShareRepository
+	OrganizationRepository
+	CollectionRepository
+	SecretLinkRepository
+	EmergencyAccessRepository
+	AttachmentRepository
+	RotationRepository
 	ConnectionManager
 }
 
@@ -174,25 +293,31 @@ func (s *PostgresStorage) handleExecError(err error, uniqueViolationMsg string,
 // CreateUser создает нового пользователя.
 func (s *PostgresStorage) CreateUser(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (id, username, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO users (id, username, password_hash, wrapped_vault_key, srp_salt, srp_verifier, public_key, wrapped_private_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	user.ID, user.CreatedAt, user.UpdatedAt = s.prepareNewEntity()
 
-	_, err := s.pool.Exec(ctx, query, user.ID, user.Username, user.PasswordHash, user.CreatedAt, user.UpdatedAt)
+	_, err := s.pool.Exec(ctx, query,
+		user.ID, user.Username, user.PasswordHash, user.WrappedVaultKey,
+		user.SRPSalt, user.SRPVerifier, user.PublicKey, user.WrappedPrivateKey,
+		user.CreatedAt, user.UpdatedAt,
+	)
 	return s.handleExecError(err, "username already exists", "failed to create user")
 }
 
 // GetUserByUsername получает пользователя по имени.
 func (s *PostgresStorage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
-		SELECT id, username, password_hash, created_at, updated_at
+		SELECT id, username, password_hash, wrapped_vault_key, srp_salt, srp_verifier,
+			public_key, wrapped_private_key, created_at, updated_at
 		FROM users 
 		WHERE username = $1`
 
 	var user models.User
 	err := s.pool.QueryRow(ctx, query, username).Scan(
-		&user.ID, &user.Username, &user.PasswordHash,
+		&user.ID, &user.Username, &user.PasswordHash, &user.WrappedVaultKey,
+		&user.SRPSalt, &user.SRPVerifier, &user.PublicKey, &user.WrappedPrivateKey,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 
@@ -206,13 +331,15 @@ func (s *PostgresStorage) GetUserByUsername(ctx context.Context, username string
 // GetUserByID получает пользователя по ID.
 func (s *PostgresStorage) GetUserByID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, username, password_hash, created_at, updated_at
+		SELECT id, username, password_hash, wrapped_vault_key, srp_salt, srp_verifier,
+			public_key, wrapped_private_key, created_at, updated_at
 		FROM users 
 		WHERE id = $1`
 
 	var user models.User
 	err := s.pool.QueryRow(ctx, query, userID).Scan(
-		&user.ID, &user.Username, &user.PasswordHash,
+		&user.ID, &user.Username, &user.PasswordHash, &user.WrappedVaultKey,
+		&user.SRPSalt, &user.SRPVerifier, &user.PublicKey, &user.WrappedPrivateKey,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 
@@ -223,18 +350,342 @@ func (s *PostgresStorage) GetUserByID(ctx context.Context, userID uuid.UUID) (*m
 	return &user, nil
 }
 
-// CreateDataEntry создает новую запись данных.
+// UpdateUserPassword обновляет хеш пароля, верификатор SRP и ключ хранилища пользователя
+// и отзывает все сессии, кроме keepSessionID, в одной транзакции.
+// Если WrappedVaultKey пуст, ключ не меняется.
+func (s *PostgresStorage) UpdateUserPassword(ctx context.Context, user *models.User, keepSessionID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	updateQuery := `
+		UPDATE users
+		SET password_hash = $1,
+			srp_salt = $2,
+			srp_verifier = $3,
+			wrapped_vault_key = COALESCE($4, wrapped_vault_key),
+			password_changed_at = NOW()
+		WHERE id = $5`
+
+	var vaultKey interface{}
+	if len(user.WrappedVaultKey) > 0 {
+		vaultKey = user.WrappedVaultKey
+	}
+
+	result, err := tx.Exec(ctx, updateQuery, user.PasswordHash, user.SRPSalt, user.SRPVerifier, vaultKey, user.ID)
+	if err := s.handleExecError(err, "", "failed to update password"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	// Отзываем остальные сессии пользователя
+	revokeQuery := `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL`
+
+	_, err = tx.Exec(ctx, revokeQuery, user.ID, keepSessionID)
+	if err := s.handleExecError(err, "", "failed to revoke sessions"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetUserVaultKey сохраняет ключ хранилища пользователя, если он еще не задан.
+// Заданный ключ меняется только вместе с паролем в UpdateUserPassword.
+func (s *PostgresStorage) SetUserVaultKey(ctx context.Context, userID uuid.UUID, wrappedVaultKey []byte) error {
+	query := `UPDATE users SET wrapped_vault_key = $1 WHERE id = $2 AND wrapped_vault_key IS NULL`
+
+	result, err := s.pool.Exec(ctx, query, wrappedVaultKey, userID)
+	if err := s.handleExecError(err, "", "failed to set vault key"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrVaultKeyExists
+	}
+
+	return nil
+}
+
+// SetUserPublicKey сохраняет открытый ключ пользователя для общих записей
+// и его закрытый ключ, зашифрованный на стороне клиента.
+func (s *PostgresStorage) SetUserPublicKey(ctx context.Context, userID uuid.UUID, publicKey, wrappedPrivateKey []byte) error {
+	query := `UPDATE users SET public_key = $1, wrapped_private_key = $2 WHERE id = $3`
+
+	result, err := s.pool.Exec(ctx, query, publicKey, wrappedPrivateKey, userID)
+	if err := s.handleExecError(err, "", "failed to update public key"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// DeleteUser удаляет пользователя. Записи, удаленные записи и сессии
+// удаляются каскадно внешними ключами. Организации, в которых пользователь
+// единственный участник, удаляются вместе с ним; если он последний владелец
+// организации с другими участниками, возвращается ErrLastOwner.
+func (s *PostgresStorage) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	orphaned, err := s.ownedOrganizationsToDelete(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(orphaned) > 0 {
+		orgQuery := `DELETE FROM organizations WHERE id = ANY($1)`
+		_, err = tx.Exec(ctx, orgQuery, orphaned)
+		if err := s.handleExecError(err, "", "failed to delete organizations"); err != nil {
+			return err
+		}
+	}
+
+	query := `DELETE FROM users WHERE id = $1`
+
+	result, err := tx.Exec(ctx, query, userID)
+	if err := s.handleExecError(err, "", "failed to delete user"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSession создает новую сессию пользователя.
+func (s *PostgresStorage) CreateSession(ctx context.Context, session *models.Session) error {
+	query := `
+		INSERT INTO sessions (id, user_id, created_at)
+		VALUES ($1, $2, $3)`
+
+	if session.ID == uuid.Nil {
+		session.ID = uuid.New()
+	}
+	session.CreatedAt = time.Now()
+
+	_, err := s.pool.Exec(ctx, query, session.ID, session.UserID, session.CreatedAt)
+	return s.handleExecError(err, "session already exists", "failed to create session")
+}
+
+// IsSessionActive проверяет, что сессия принадлежит пользователю и не отозвана.
+func (s *PostgresStorage) IsSessionActive(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM sessions
+			WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+		)`
+
+	var active bool
+	err := s.pool.QueryRow(ctx, query, sessionID, userID).Scan(&active)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+
+	return active, nil
+}
+
+// CreateAccessToken сохраняет новый персональный токен доступа.
+func (s *PostgresStorage) CreateAccessToken(ctx context.Context, token *models.AccessToken) error {
+	query := `
+		INSERT INTO access_tokens (id, user_id, name, token_hash, certificate_fingerprint, read_only, data_types, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	token.ID, token.CreatedAt, _ = s.prepareNewEntity()
+
+	_, err := s.pool.Exec(ctx, query,
+		token.ID, token.UserID, token.Name, token.TokenHash, token.CertificateFingerprint,
+		token.ReadOnly, dataTypesToStrings(token.DataTypes), token.ExpiresAt, token.CreatedAt,
+	)
+
+	return s.handleExecError(err, "access token already exists", "failed to create access token")
+}
+
+// GetAccessTokenByHash получает персональный токен доступа по хешу.
+func (s *PostgresStorage) GetAccessTokenByHash(ctx context.Context, tokenHash []byte) (*models.AccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, certificate_fingerprint, read_only, data_types, expires_at, created_at, last_used_at, revoked_at
+		FROM access_tokens
+		WHERE token_hash = $1`
+
+	token, err := scanAccessToken(s.pool.QueryRow(ctx, query, tokenHash))
+	if err := s.handleQueryRowError(err, "access token not found", "failed to get access token"); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// GetAccessTokenByCertificate получает токен доступа по отпечатку клиентского сертификата.
+func (s *PostgresStorage) GetAccessTokenByCertificate(ctx context.Context, fingerprint []byte) (*models.AccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, certificate_fingerprint, read_only, data_types, expires_at, created_at, last_used_at, revoked_at
+		FROM access_tokens
+		WHERE certificate_fingerprint = $1`
+
+	token, err := scanAccessToken(s.pool.QueryRow(ctx, query, fingerprint))
+	if err := s.handleQueryRowError(err, "access token not found", "failed to get access token"); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ListAccessTokens получает все неотозванные персональные токены пользователя.
+func (s *PostgresStorage) ListAccessTokens(ctx context.Context, userID uuid.UUID) ([]models.AccessToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, certificate_fingerprint, read_only, data_types, expires_at, created_at, last_used_at, revoked_at
+		FROM access_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC`
+
+	rows, err := s.pool.Query(ctx, query, userID)
+	if err := s.handleQueryError(err, "failed to query access tokens"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []models.AccessToken
+	for rows.Next() {
+		token, err := scanAccessToken(rows)
+		if err := s.handleScanError(err, "failed to scan access token"); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RevokeAccessToken отзывает персональный токен пользователя.
+func (s *PostgresStorage) RevokeAccessToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	query := `
+		UPDATE access_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := s.pool.Exec(ctx, query, tokenID, userID)
+	if err := s.handleExecError(err, "", "failed to revoke access token"); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("access token not found")
+	}
+
+	return nil
+}
+
+// TouchAccessToken обновляет время последнего использования токена.
+func (s *PostgresStorage) TouchAccessToken(ctx context.Context, tokenID uuid.UUID) error {
+	query := `UPDATE access_tokens SET last_used_at = NOW() WHERE id = $1`
+
+	_, err := s.pool.Exec(ctx, query, tokenID)
+	return s.handleExecError(err, "", "failed to update access token")
+}
+
+// scanAccessToken сканирует строку результата в персональный токен доступа.
+func scanAccessToken(row pgx.Row) (*models.AccessToken, error) {
+	var token models.AccessToken
+	var dataTypes []string
+	err := row.Scan(
+		&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.CertificateFingerprint,
+		&token.ReadOnly, &dataTypes, &token.ExpiresAt, &token.CreatedAt,
+		&token.LastUsedAt, &token.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dataType := range dataTypes {
+		token.DataTypes = append(token.DataTypes, models.DataType(dataType))
+	}
+
+	return &token, nil
+}
+
+// dataTypesToStrings преобразует типы данных в строки для хранения в массиве.
+func dataTypesToStrings(dataTypes []models.DataType) []string {
+	result := make([]string, len(dataTypes))
+	for i, dataType := range dataTypes {
+		result[i] = string(dataType)
+	}
+	return result
+}
+
+// dataEntrySelect выбирает записи данных пользователя.
+const dataEntrySelect = `
+	SELECT id, user_id, type, name, description, encrypted_data, metadata,
+		created_at, updated_at, version, password_changed_at, COALESCE(rotation_days, 0)
+	FROM data_entries`
+
+// scanDataEntry сканирует строку результата dataEntrySelect.
+func scanDataEntry(row pgx.Row) (*models.DataEntry, error) {
+	var entry models.DataEntry
+	err := row.Scan(
+		&entry.ID, &entry.UserID, &entry.Type, &entry.Name,
+		&entry.Description, &entry.EncryptedData, &entry.Metadata,
+		&entry.CreatedAt, &entry.UpdatedAt, &entry.Version,
+		&entry.PasswordChangedAt, &entry.RotationDays,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// nullRotationDays преобразует период смены пароля для хранения: 0 хранится как NULL.
+func nullRotationDays(days int) *int {
+	if days <= 0 {
+		return nil
+	}
+	return &days
+}
+
+// CreateDataEntry создает новую запись данных. Время смены пароля совпадает
+// со временем создания записи.
 func (s *PostgresStorage) CreateDataEntry(ctx context.Context, entry *models.DataEntry) error {
 	query := `
-		INSERT INTO data_entries (id, user_id, type, name, description, encrypted_data, metadata, created_at, updated_at, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		INSERT INTO data_entries (id, user_id, type, name, description, encrypted_data, metadata,
+			created_at, updated_at, version, password_changed_at, rotation_days)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	entry.ID, entry.CreatedAt, entry.UpdatedAt, entry.Version = s.prepareNewDataEntry()
+	entry.PasswordChangedAt = entry.CreatedAt
 
 	_, err := s.pool.Exec(ctx, query,
 		entry.ID, entry.UserID, entry.Type, entry.Name,
 		entry.Description, entry.EncryptedData, entry.Metadata,
 		entry.CreatedAt, entry.UpdatedAt, entry.Version,
+		entry.PasswordChangedAt, nullRotationDays(entry.RotationDays),
 	)
 
 	return s.handleExecError(err, "entry with this name already exists", "failed to create data entry")
@@ -242,23 +693,15 @@ func (s *PostgresStorage) CreateDataEntry(ctx context.Context, entry *models.Dat
 
 // GetDataEntry получает запись данных по ID.
 func (s *PostgresStorage) GetDataEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.DataEntry, error) {
-	query := `
-		SELECT id, user_id, type, name, description, encrypted_data, metadata, created_at, updated_at, version
-		FROM data_entries 
+	query := dataEntrySelect + `
 		WHERE id = $1 AND user_id = $2`
 
-	var entry models.DataEntry
-	err := s.pool.QueryRow(ctx, query, entryID, userID).Scan(
-		&entry.ID, &entry.UserID, &entry.Type, &entry.Name,
-		&entry.Description, &entry.EncryptedData, &entry.Metadata,
-		&entry.CreatedAt, &entry.UpdatedAt, &entry.Version,
-	)
-
+	entry, err := scanDataEntry(s.pool.QueryRow(ctx, query, entryID, userID))
 	if err := s.handleQueryRowError(err, "data entry not found", "failed to get data entry"); err != nil {
 		return nil, err
 	}
 
-	return &entry, nil
+	return entry, nil
 }
 
 // GetDataEntries получает все записи данных пользователя.
@@ -267,21 +710,22 @@ func (s *PostgresStorage) GetDataEntries(ctx context.Context, userID uuid.UUID,
 	var args []interface{}
 
 	if dataType != nil {
-		query = `
-			SELECT id, user_id, type, name, description, encrypted_data, metadata, created_at, updated_at, version
-			FROM data_entries 
+		query = dataEntrySelect + `
 			WHERE user_id = $1 AND type = $2
 			ORDER BY created_at DESC`
 		args = []interface{}{userID, *dataType}
 	} else {
-		query = `
-			SELECT id, user_id, type, name, description, encrypted_data, metadata, created_at, updated_at, version
-			FROM data_entries 
+		query = dataEntrySelect + `
 			WHERE user_id = $1
 			ORDER BY created_at DESC`
 		args = []interface{}{userID}
 	}
 
+	return s.queryDataEntries(ctx, query, args...)
+}
+
+// queryDataEntries выполняет запрос записей данных пользователя.
+func (s *PostgresStorage) queryDataEntries(ctx context.Context, query string, args ...interface{}) ([]models.DataEntry, error) {
 	rows, err := s.pool.Query(ctx, query, args...)
 	if err := s.handleQueryError(err, "failed to query data entries"); err != nil {
 		return nil, err
@@ -290,16 +734,11 @@ func (s *PostgresStorage) GetDataEntries(ctx context.Context, userID uuid.UUID,
 
 	var entries []models.DataEntry
 	for rows.Next() {
-		var entry models.DataEntry
-		err := rows.Scan(
-			&entry.ID, &entry.UserID, &entry.Type, &entry.Name,
-			&entry.Description, &entry.EncryptedData, &entry.Metadata,
-			&entry.CreatedAt, &entry.UpdatedAt, &entry.Version,
-		)
+		entry, err := scanDataEntry(rows)
 		if err := s.handleScanError(err, "failed to scan data entry"); err != nil {
 			return nil, err
 		}
-		entries = append(entries, entry)
+		entries = append(entries, *entry)
 	}
 
 	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
@@ -309,16 +748,19 @@ func (s *PostgresStorage) GetDataEntries(ctx context.Context, userID uuid.UUID,
 	return entries, nil
 }
 
-// UpdateDataEntry обновляет запись данных с проверкой версии.
+// UpdateDataEntry обновляет запись данных с проверкой версии. Время смены
+// пароля и политика записи сохраняются из entry.
 func (s *PostgresStorage) UpdateDataEntry(ctx context.Context, entry *models.DataEntry) error {
 	query := `
 		UPDATE data_entries 
-		SET name = $1, description = $2, encrypted_data = $3, metadata = $4, version = version + 1
+		SET name = $1, description = $2, encrypted_data = $3, metadata = $4, version = version + 1,
+			password_changed_at = $8, rotation_days = $9
 		WHERE id = $5 AND user_id = $6 AND version = $7`
 
 	result, err := s.pool.Exec(ctx, query,
 		entry.Name, entry.Description, entry.EncryptedData, entry.Metadata,
 		entry.ID, entry.UserID, entry.Version,
+		entry.PasswordChangedAt, nullRotationDays(entry.RotationDays),
 	)
 
 	if err := s.handleExecError(err, "entry with this name already exists", "failed to update data entry"); err != nil {
@@ -326,7 +768,7 @@ func (s *PostgresStorage) UpdateDataEntry(ctx context.Context, entry *models.Dat
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("data entry not found or version mismatch")
+		return ErrVersionMismatch
 	}
 
 	// Обновляем версию в объекте
@@ -344,6 +786,20 @@ func (s *PostgresStorage) DeleteDataEntry(ctx context.Context, userID, entryID u
 	}
 	defer tx.Rollback(ctx)
 
+	// Получатели общей записи тоже должны удалить ее при синхронизации.
+	// Доступы удаляются каскадно вместе с записью.
+	recipientsQuery := `
+		INSERT INTO deleted_entries (id, user_id, deleted_at)
+		SELECT s.entry_id, s.recipient_id, NOW()
+		FROM entry_shares s
+		JOIN data_entries e ON e.id = s.entry_id
+		WHERE e.id = $1 AND e.user_id = $2
+		ON CONFLICT (id, user_id) DO UPDATE SET deleted_at = EXCLUDED.deleted_at`
+	_, err = tx.Exec(ctx, recipientsQuery, entryID, userID)
+	if err := s.handleExecError(err, "", "failed to insert deleted shared entries"); err != nil {
+		return err
+	}
+
 	// Удаляем запись
 	deleteQuery := `DELETE FROM data_entries WHERE id = $1 AND user_id = $2`
 	result, err := tx.Exec(ctx, deleteQuery, entryID, userID)
@@ -371,67 +827,247 @@ func (s *PostgresStorage) DeleteDataEntry(ctx context.Context, userID, entryID u
 
 // GetDataEntriesAfter получает записи данных, измененные после указанного времени.
 func (s *PostgresStorage) GetDataEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
-	query := `
-		SELECT id, user_id, type, name, description, encrypted_data, metadata, created_at, updated_at, version
-		FROM data_entries 
+	query := dataEntrySelect + `
 		WHERE user_id = $1 AND updated_at > $2
 		ORDER BY updated_at ASC`
 
+	return s.queryDataEntries(ctx, query, userID, after)
+}
+
+// GetDeletedEntriesAfter получает ID записей, удаленных после указанного времени.
+func (s *PostgresStorage) GetDeletedEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]uuid.UUID, error) {
+	query := `
+		SELECT id 
+		FROM deleted_entries 
+		WHERE user_id = $1 AND deleted_at > $2
+		ORDER BY deleted_at ASC`
+
 	rows, err := s.pool.Query(ctx, query, userID, after)
-	if err := s.handleQueryError(err, "failed to query data entries"); err != nil {
+	if err := s.handleQueryError(err, "failed to query deleted entries"); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var entries []models.DataEntry
+	var deletedIDs []uuid.UUID
 	for rows.Next() {
-		var entry models.DataEntry
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted entry ID: %w", err)
+		}
+		deletedIDs = append(deletedIDs, id)
+	}
+
+	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
+		return nil, err
+	}
+
+	return deletedIDs, nil
+}
+
+// sharedEntrySelect выбирает общую запись вместе с данными о доступе к ней.
+const sharedEntrySelect = `
+	SELECT e.id, e.user_id, e.type, e.name, e.description, e.encrypted_data, e.metadata,
+		e.created_at, e.updated_at, e.version, e.password_changed_at, COALESCE(e.rotation_days, 0),
+		s.id, s.owner_id, o.username, s.recipient_id, r.username, s.wrapped_key, s.permission, s.created_at
+	FROM entry_shares s
+	JOIN data_entries e ON e.id = s.entry_id
+	JOIN users o ON o.id = s.owner_id
+	JOIN users r ON r.id = s.recipient_id`
+
+// CreateEntryShare предоставляет получателю доступ к записи владельца.
+// Повторный вызов для того же получателя заменяет ключ и права доступа.
+func (s *PostgresStorage) CreateEntryShare(ctx context.Context, share *models.EntryShare) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `
+		INSERT INTO entry_shares (id, entry_id, owner_id, recipient_id, wrapped_key, permission, created_at)
+		SELECT $1, e.id, e.user_id, $2, $3, $4, $5
+		FROM data_entries e
+		WHERE e.id = $6 AND e.user_id = $7
+		ON CONFLICT (entry_id, recipient_id) DO UPDATE
+		SET wrapped_key = EXCLUDED.wrapped_key,
+			permission = EXCLUDED.permission,
+			created_at = EXCLUDED.created_at
+		RETURNING id`
+
+	newID, createdAt, _ := s.prepareNewEntity()
+	err = tx.QueryRow(ctx, insertQuery,
+		newID, share.RecipientID, share.WrappedKey, share.Permission, createdAt,
+		share.EntryID, share.OwnerID,
+	).Scan(&share.ID)
+	if err := s.handleQueryRowError(err, "data entry not found", "failed to create entry share"); err != nil {
+		return err
+	}
+	share.CreatedAt = createdAt
+
+	// Запись снова появится у получателя, если доступ был отозван ранее
+	tombstoneQuery := `DELETE FROM deleted_entries WHERE id = $1 AND user_id = $2`
+	_, err = tx.Exec(ctx, tombstoneQuery, share.EntryID, share.RecipientID)
+	if err := s.handleExecError(err, "", "failed to clear deleted entry"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetEntryShares получает всех получателей записи владельца.
+func (s *PostgresStorage) GetEntryShares(ctx context.Context, ownerID, entryID uuid.UUID) ([]models.EntryShare, error) {
+	query := `
+		SELECT s.id, s.entry_id, s.owner_id, o.username, s.recipient_id, r.username,
+			s.wrapped_key, s.permission, s.created_at
+		FROM entry_shares s
+		JOIN users o ON o.id = s.owner_id
+		JOIN users r ON r.id = s.recipient_id
+		WHERE s.entry_id = $1 AND s.owner_id = $2
+		ORDER BY s.created_at ASC`
+
+	rows, err := s.pool.Query(ctx, query, entryID, ownerID)
+	if err := s.handleQueryError(err, "failed to query entry shares"); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shares []models.EntryShare
+	for rows.Next() {
+		var share models.EntryShare
 		err := rows.Scan(
-			&entry.ID, &entry.UserID, &entry.Type, &entry.Name,
-			&entry.Description, &entry.EncryptedData, &entry.Metadata,
-			&entry.CreatedAt, &entry.UpdatedAt, &entry.Version,
+			&share.ID, &share.EntryID, &share.OwnerID, &share.OwnerUsername,
+			&share.RecipientID, &share.RecipientUsername,
+			&share.WrappedKey, &share.Permission, &share.CreatedAt,
 		)
-		if err := s.handleScanError(err, "failed to scan data entry"); err != nil {
+		if err := s.handleScanError(err, "failed to scan entry share"); err != nil {
 			return nil, err
 		}
-		entries = append(entries, entry)
+		shares = append(shares, share)
 	}
 
 	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
 		return nil, err
 	}
 
-	return entries, nil
+	return shares, nil
 }
 
-// GetDeletedEntriesAfter получает ID записей, удаленных после указанного времени.
-func (s *PostgresStorage) GetDeletedEntriesAfter(ctx context.Context, userID uuid.UUID, after time.Time) ([]uuid.UUID, error) {
-	query := `
-		SELECT id 
-		FROM deleted_entries 
-		WHERE user_id = $1 AND deleted_at > $2
-		ORDER BY deleted_at ASC`
+// DeleteEntryShare отзывает доступ к записи. Отозвать доступ может владелец
+// или сам получатель. Для получателя создается запись об удалении,
+// чтобы запись исчезла из его хранилища при синхронизации.
+func (s *PostgresStorage) DeleteEntryShare(ctx context.Context, userID, shareID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
-	rows, err := s.pool.Query(ctx, query, userID, after)
-	if err := s.handleQueryError(err, "failed to query deleted entries"); err != nil {
+	deleteQuery := `
+		DELETE FROM entry_shares
+		WHERE id = $1 AND (owner_id = $2 OR recipient_id = $2)
+		RETURNING entry_id, recipient_id`
+
+	var entryID, recipientID uuid.UUID
+	err = tx.QueryRow(ctx, deleteQuery, shareID, userID).Scan(&entryID, &recipientID)
+	if err := s.handleQueryRowError(err, "entry share not found", "failed to delete entry share"); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO deleted_entries (id, user_id, deleted_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (id, user_id) DO UPDATE SET deleted_at = EXCLUDED.deleted_at`
+	_, err = tx.Exec(ctx, insertQuery, entryID, recipientID)
+	if err := s.handleExecError(err, "", "failed to insert deleted entry"); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetSharedEntry получает запись другого пользователя, доступ к которой предоставлен получателю.
+func (s *PostgresStorage) GetSharedEntry(ctx context.Context, recipientID, entryID uuid.UUID) (*models.DataEntry, error) {
+	query := sharedEntrySelect + `
+		WHERE s.recipient_id = $1 AND s.entry_id = $2`
+
+	entry, err := scanSharedEntry(s.pool.QueryRow(ctx, query, recipientID, entryID))
+	if err := s.handleQueryRowError(err, "data entry not found", "failed to get shared entry"); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetSharedEntries получает все записи, доступ к которым предоставлен получателю.
+func (s *PostgresStorage) GetSharedEntries(ctx context.Context, recipientID uuid.UUID) ([]models.DataEntry, error) {
+	query := sharedEntrySelect + `
+		WHERE s.recipient_id = $1
+		ORDER BY s.created_at DESC`
+
+	return s.querySharedEntries(ctx, query, recipientID)
+}
+
+// GetSharedEntriesAfter получает общие записи, измененные или предоставленные
+// получателю после указанного времени.
+func (s *PostgresStorage) GetSharedEntriesAfter(ctx context.Context, recipientID uuid.UUID, after time.Time) ([]models.DataEntry, error) {
+	query := sharedEntrySelect + `
+		WHERE s.recipient_id = $1 AND GREATEST(e.updated_at, s.created_at) > $2
+		ORDER BY e.updated_at ASC`
+
+	return s.querySharedEntries(ctx, query, recipientID, after)
+}
+
+// querySharedEntries выполняет запрос общих записей.
+func (s *PostgresStorage) querySharedEntries(ctx context.Context, query string, args ...interface{}) ([]models.DataEntry, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err := s.handleQueryError(err, "failed to query shared entries"); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var deletedIDs []uuid.UUID
+	var entries []models.DataEntry
 	for rows.Next() {
-		var id uuid.UUID
-		if err := rows.Scan(&id); err != nil {
-			return nil, fmt.Errorf("failed to scan deleted entry ID: %w", err)
+		entry, err := scanSharedEntry(rows)
+		if err := s.handleScanError(err, "failed to scan shared entry"); err != nil {
+			return nil, err
 		}
-		deletedIDs = append(deletedIDs, id)
+		entries = append(entries, *entry)
 	}
 
 	if err := s.handleRowsError(rows.Err(), "error during rows iteration"); err != nil {
 		return nil, err
 	}
 
-	return deletedIDs, nil
+	return entries, nil
+}
+
+// scanSharedEntry сканирует строку результата sharedEntrySelect.
+func scanSharedEntry(row pgx.Row) (*models.DataEntry, error) {
+	var entry models.DataEntry
+	var share models.EntryShare
+	err := row.Scan(
+		&entry.ID, &entry.UserID, &entry.Type, &entry.Name,
+		&entry.Description, &entry.EncryptedData, &entry.Metadata,
+		&entry.CreatedAt, &entry.UpdatedAt, &entry.Version,
+		&entry.PasswordChangedAt, &entry.RotationDays,
+		&share.ID, &share.OwnerID, &share.OwnerUsername,
+		&share.RecipientID, &share.RecipientUsername,
+		&share.WrappedKey, &share.Permission, &share.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	share.EntryID = entry.ID
+	entry.Share = &share
+	return &entry, nil
 }
 
 // Close закрывает соединение с базой данных.
@@ -218,3 +218,366 @@ func TestIntegrationSyncData(t *testing.T) {
 	require.Len(t, deletedEntries, 1)
 	require.Equal(t, entry1.ID, deletedEntries[0])
 }
+
+func TestIntegrationEntryShares(t *testing.T) {
+	s := setupIntegrationTestStorage(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	owner := &models.User{Username: "integration_share_owner_" + uuid.NewString(), PasswordHash: "hash"}
+	recipient := &models.User{Username: "integration_share_recipient_" + uuid.NewString(), PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, owner))
+	require.NoError(t, s.CreateUser(ctx, recipient))
+	require.NoError(t, s.SetUserPublicKey(ctx, recipient.ID, []byte("public"), []byte("private")))
+
+	entry := &models.DataEntry{
+		UserID:        owner.ID,
+		Type:          models.DataTypeCredentials,
+		Name:          "Shared",
+		EncryptedData: []byte("secret"),
+	}
+	require.NoError(t, s.CreateDataEntry(ctx, entry))
+
+	share := &models.EntryShare{
+		EntryID:     entry.ID,
+		OwnerID:     owner.ID,
+		RecipientID: recipient.ID,
+		WrappedKey:  []byte("wrapped"),
+		Permission:  models.SharePermissionRead,
+	}
+	require.NoError(t, s.CreateEntryShare(ctx, share))
+
+	// Получатель не может делиться чужой записью
+	require.Error(t, s.CreateEntryShare(ctx, &models.EntryShare{
+		EntryID:     entry.ID,
+		OwnerID:     recipient.ID,
+		RecipientID: owner.ID,
+		WrappedKey:  []byte("wrapped"),
+		Permission:  models.SharePermissionRead,
+	}))
+
+	shared, err := s.GetSharedEntry(ctx, recipient.ID, entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, owner.Username, shared.Share.OwnerUsername)
+	require.Equal(t, []byte("wrapped"), shared.Share.WrappedKey)
+
+	synced, err := s.GetSharedEntriesAfter(ctx, recipient.ID, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, synced, 1)
+
+	revokedAt := time.Now().Add(-time.Second)
+	require.NoError(t, s.DeleteEntryShare(ctx, owner.ID, share.ID))
+
+	_, err = s.GetSharedEntry(ctx, recipient.ID, entry.ID)
+	require.Error(t, err)
+	deleted, err := s.GetDeletedEntriesAfter(ctx, recipient.ID, revokedAt)
+	require.NoError(t, err)
+	require.Contains(t, deleted, entry.ID)
+
+	// Удаление записи владельцем создает запись об удалении и у получателя
+	require.NoError(t, s.CreateEntryShare(ctx, share))
+	require.NoError(t, s.DeleteDataEntry(ctx, owner.ID, entry.ID))
+	deleted, err = s.GetDeletedEntriesAfter(ctx, owner.ID, revokedAt)
+	require.NoError(t, err)
+	require.Contains(t, deleted, entry.ID)
+	deleted, err = s.GetDeletedEntriesAfter(ctx, recipient.ID, revokedAt)
+	require.NoError(t, err)
+	require.Contains(t, deleted, entry.ID)
+}
+
+func TestIntegrationOrganizationRoles(t *testing.T) {
+	s := setupIntegrationTestStorage(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	owner := &models.User{Username: "integration_org_owner_" + uuid.NewString(), PasswordHash: "hash"}
+	viewer := &models.User{Username: "integration_org_viewer_" + uuid.NewString(), PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, owner))
+	require.NoError(t, s.CreateUser(ctx, viewer))
+
+	org := &models.Organization{Name: "Integration org"}
+	require.NoError(t, s.CreateOrganization(ctx, org, owner.ID))
+
+	invitation := &models.OrganizationInvitation{
+		OrganizationID: org.ID,
+		InviteeID:      viewer.ID,
+		Role:           models.RoleViewer,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	require.NoError(t, s.CreateInvitation(ctx, owner.ID, invitation))
+	_, err := s.AcceptInvitation(ctx, viewer.ID, invitation.ID)
+	require.NoError(t, err)
+
+	collection := &models.Collection{OrganizationID: org.ID, Name: "Servers", WrappedKey: []byte("owner-key")}
+	require.NoError(t, s.CreateCollection(ctx, owner.ID, collection))
+	require.ErrorIs(t, s.CreateCollection(ctx, viewer.ID, &models.Collection{OrganizationID: org.ID, Name: "Other", WrappedKey: []byte("k")}), ErrInsufficientRole)
+
+	// Роль проверяется в запросе: читатель не может создать запись
+	entry := &models.DataEntry{
+		CollectionID:  &collection.ID,
+		Type:          models.DataTypeText,
+		Name:          "Note",
+		EncryptedData: []byte("secret"),
+	}
+	require.ErrorIs(t, s.CreateCollectionEntry(ctx, viewer.ID, entry), ErrInsufficientRole)
+	require.NoError(t, s.CreateCollectionEntry(ctx, owner.ID, entry))
+
+	entries, err := s.GetCollectionEntries(ctx, viewer.ID, collection.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	_, err = s.RemoveOrganizationMember(ctx, owner.ID, org.ID, owner.ID)
+	require.ErrorIs(t, err, ErrLastOwner)
+
+	removedAt := time.Now().Add(-time.Second)
+	rotate, err := s.RemoveOrganizationMember(ctx, owner.ID, org.ID, viewer.ID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{collection.ID}, rotate)
+
+	deleted, err := s.GetDeletedEntriesAfter(ctx, viewer.ID, removedAt)
+	require.NoError(t, err)
+	require.Contains(t, deleted, entry.ID)
+
+	// Смена ключа должна охватывать всех участников и все записи
+	require.ErrorIs(t, s.RotateCollectionKey(ctx, owner.ID, collection.ID, 2,
+		[]models.CollectionKey{{UserID: owner.ID, WrappedKey: []byte("owner-key-2")}}, nil), ErrCollectionKeyMismatch)
+	require.NoError(t, s.RotateCollectionKey(ctx, owner.ID, collection.ID, 2,
+		[]models.CollectionKey{{UserID: owner.ID, WrappedKey: []byte("owner-key-2")}},
+		[]models.DataEntry{{ID: entry.ID, EncryptedData: []byte("secret-2"), Version: entry.Version}}))
+
+	rotated, err := s.GetCollection(ctx, owner.ID, collection.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, rotated.KeyVersion)
+	require.False(t, rotated.RotationRequired)
+	require.Equal(t, []byte("owner-key-2"), rotated.WrappedKey)
+}
+
+func TestIntegrationPasswordRotation(t *testing.T) {
+	s := setupIntegrationTestStorage(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	user := &models.User{Username: "integration_rotation_" + uuid.NewString(), PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, user))
+
+	entry := &models.DataEntry{
+		UserID:        user.ID,
+		Type:          models.DataTypeCredentials,
+		Name:          "Mail",
+		EncryptedData: []byte("secret"),
+		RotationDays:  90,
+	}
+	require.NoError(t, s.CreateDataEntry(ctx, entry))
+	require.Equal(t, entry.CreatedAt, entry.PasswordChangedAt)
+
+	due, err := s.GetEntriesDueForRotation(ctx, user.ID, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	// Пароль сменен 120 дней назад, срок смены истек 30 дней назад
+	entry.PasswordChangedAt = time.Now().AddDate(0, 0, -120)
+	require.NoError(t, s.UpdateDataEntry(ctx, entry))
+	due, err = s.GetEntriesDueForRotation(ctx, user.ID, time.Now())
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, 90, due[0].RotationDays)
+
+	// Политика коллекции действует только на записи с паролями
+	org := &models.Organization{Name: "Integration rotation org"}
+	require.NoError(t, s.CreateOrganization(ctx, org, user.ID))
+	collection := &models.Collection{OrganizationID: org.ID, Name: "Servers", WrappedKey: []byte("key"), RotationDays: 30}
+	require.NoError(t, s.CreateCollection(ctx, user.ID, collection))
+
+	for _, dataType := range []models.DataType{models.DataTypeCredentials, models.DataTypeText} {
+		teamEntry := &models.DataEntry{
+			CollectionID:  &collection.ID,
+			Type:          dataType,
+			Name:          string(dataType),
+			EncryptedData: []byte("secret"),
+		}
+		require.NoError(t, s.CreateCollectionEntry(ctx, user.ID, teamEntry))
+		teamEntry.PasswordChangedAt = time.Now().AddDate(0, 0, -40)
+		require.NoError(t, s.UpdateCollectionEntry(ctx, user.ID, teamEntry))
+	}
+
+	due, err = s.GetEntriesDueForRotation(ctx, user.ID, time.Now())
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, "Mail", due[0].Name)
+	require.Equal(t, string(models.DataTypeCredentials), due[1].Name)
+	require.Equal(t, 30, due[1].CollectionRotationDays)
+
+	require.NoError(t, s.SetCollectionRotationDays(ctx, user.ID, collection.ID, 0))
+	due, err = s.GetEntriesDueForRotation(ctx, user.ID, time.Now())
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+}
+
+func TestIntegrationSecretLinks(t *testing.T) {
+	s := setupIntegrationTestStorage(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	user := &models.User{Username: "integration_secret_link_" + uuid.NewString(), PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, user))
+
+	link := &models.SecretLink{
+		UserID:        user.ID,
+		EncryptedData: []byte("secret"),
+		MaxViews:      2,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, s.CreateSecretLink(ctx, link))
+
+	// Каждый просмотр расходуется, после последнего ссылка удаляется
+	opened, err := s.ConsumeSecretLink(ctx, link.ID, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), opened.EncryptedData)
+	require.Equal(t, 1, opened.ViewsLeft())
+
+	opened, err = s.ConsumeSecretLink(ctx, link.ID, time.Now())
+	require.NoError(t, err)
+	require.Zero(t, opened.ViewsLeft())
+
+	_, err = s.ConsumeSecretLink(ctx, link.ID, time.Now())
+	require.ErrorIs(t, err, ErrSecretLinkGone)
+
+	// Истекшая ссылка недоступна и удаляется очисткой
+	expired := &models.SecretLink{
+		UserID:        user.ID,
+		EncryptedData: []byte("secret"),
+		MaxViews:      1,
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	require.NoError(t, s.CreateSecretLink(ctx, expired))
+
+	later := time.Now().Add(2 * time.Minute)
+	_, err = s.ConsumeSecretLink(ctx, expired.ID, later)
+	require.ErrorIs(t, err, ErrSecretLinkGone)
+
+	purged, err := s.DeleteExpiredSecretLinks(ctx, later)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, purged, int64(1))
+}
+
+func TestIntegrationAttachments(t *testing.T) {
+	s := setupIntegrationTestStorage(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	user := &models.User{Username: "integration_attachment_" + uuid.NewString(), PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, user))
+
+	entry := &models.DataEntry{
+		UserID:        user.ID,
+		Type:          models.DataTypeText,
+		Name:          "Passport",
+		EncryptedData: []byte("secret"),
+	}
+	require.NoError(t, s.CreateDataEntry(ctx, entry))
+
+	attachment := &models.Attachment{
+		EntryID:       entry.ID,
+		UploadedBy:    user.ID,
+		Name:          "scan.pdf",
+		MimeType:      "application/pdf",
+		Size:          int64(len("encrypted")),
+		WrappedKey:    []byte("wrapped"),
+		EncryptedData: []byte("encrypted"),
+	}
+	require.NoError(t, s.CreateAttachment(ctx, attachment))
+
+	// Список не содержит данных вложений
+	attachments, err := s.GetAttachments(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	require.Equal(t, "scan.pdf", attachments[0].Name)
+	require.Empty(t, attachments[0].EncryptedData)
+
+	stored, err := s.GetAttachment(ctx, entry.ID, attachment.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("encrypted"), stored.EncryptedData)
+
+	// Суммарный размер вложений записи ограничен
+	tooLarge := *attachment
+	tooLarge.Size = models.MaxEntryAttachmentsSize
+	require.ErrorIs(t, s.CreateAttachment(ctx, &tooLarge), ErrAttachmentLimit)
+
+	require.NoError(t, s.DeleteAttachment(ctx, entry.ID, attachment.ID))
+	require.ErrorIs(t, s.DeleteAttachment(ctx, entry.ID, attachment.ID), ErrAttachmentNotFound)
+
+	// Вложения удаляются вместе с записью
+	require.NoError(t, s.CreateAttachment(ctx, attachment))
+	require.NoError(t, s.DeleteDataEntry(ctx, user.ID, entry.ID))
+	_, err = s.GetAttachment(ctx, entry.ID, attachment.ID)
+	require.ErrorIs(t, err, ErrAttachmentNotFound)
+}
+
+func TestIntegrationEmergencyAccess(t *testing.T) {
+	s := setupIntegrationTestStorage(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	grantor := &models.User{Username: "integration_emergency_grantor_" + uuid.NewString(), PasswordHash: "hash"}
+	grantee := &models.User{Username: "integration_emergency_grantee_" + uuid.NewString(), PasswordHash: "hash"}
+	require.NoError(t, s.CreateUser(ctx, grantor))
+	require.NoError(t, s.CreateUser(ctx, grantee))
+
+	access := &models.EmergencyAccess{
+		GrantorID:  grantor.ID,
+		GranteeID:  grantee.ID,
+		WaitDays:   1,
+		WrappedKey: []byte("wrapped"),
+	}
+	require.NoError(t, s.CreateEmergencyAccess(ctx, access, "designated"))
+
+	// Отклонить можно только запрошенный доступ
+	denied := &models.EmergencyAccessEvent{ActorID: &grantor.ID, Event: models.EmergencyEventDenied}
+	_, err := s.TransitionEmergencyAccess(ctx, access.ID,
+		[]models.EmergencyAccessStatus{models.EmergencyAccessRequested}, models.EmergencyAccessIdle, denied, "denied")
+	require.ErrorIs(t, err, ErrEmergencyAccessState)
+
+	requested := &models.EmergencyAccessEvent{ActorID: &grantee.ID, Event: models.EmergencyEventRequested}
+	updated, err := s.TransitionEmergencyAccess(ctx, access.ID,
+		[]models.EmergencyAccessStatus{models.EmergencyAccessIdle}, models.EmergencyAccessRequested, requested, "requested")
+	require.NoError(t, err)
+	require.Equal(t, models.EmergencyAccessRequested, updated.Status)
+	require.NotNil(t, updated.RequestedAt)
+	require.Equal(t, grantor.Username, updated.GrantorUsername)
+
+	// Запрос попадает в выборку только после истечения ожидания
+	elapsed, err := s.GetElapsedEmergencyRequests(ctx, time.Now())
+	require.NoError(t, err)
+	for _, item := range elapsed {
+		require.NotEqual(t, access.ID, item.ID)
+	}
+
+	elapsed, err = s.GetElapsedEmergencyRequests(ctx, time.Now().Add(25*time.Hour))
+	require.NoError(t, err)
+	found := false
+	for _, item := range elapsed {
+		found = found || item.ID == access.ID
+	}
+	require.True(t, found)
+
+	granted := &models.EmergencyAccessEvent{Event: models.EmergencyEventGranted}
+	updated, err = s.TransitionEmergencyAccess(ctx, access.ID,
+		[]models.EmergencyAccessStatus{models.EmergencyAccessRequested}, models.EmergencyAccessApproved, granted, "granted")
+	require.NoError(t, err)
+	require.NotNil(t, updated.ApprovedAt)
+
+	// Событие сервера уведомляет обоих участников, журнал сохраняется после удаления
+	revoked := &models.EmergencyAccessEvent{ActorID: &grantor.ID, Event: models.EmergencyEventRevoked}
+	require.NoError(t, s.DeleteEmergencyAccess(ctx, updated, revoked, "revoked"))
+
+	events, err := s.GetEmergencyAccessEvents(ctx, grantee.ID, access.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	require.Equal(t, grantor.Username, events[0].ActorUsername)
+	require.Nil(t, events[2].ActorID)
+
+	notifications, err := s.GetNotifications(ctx, grantor.ID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	require.Equal(t, "granted", notifications[0].Message)
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/google/uuid"
+)
+
+// collectionEntryRotationDays вычисляет действующий период смены пароля записи
+// коллекции так же, как models.DataEntry.RotationPolicyDays; $3 - типы записей,
+// на которые действует политика коллекции.
+const collectionEntryRotationDays = `
+	COALESCE(e.rotation_days, CASE WHEN e.type = ANY($3) THEN c.rotation_days END)`
+
+// GetEntriesDueForRotation получает записи пользователя и коллекций его организаций,
+// срок смены пароля которых по политике записи или коллекции наступает не позже
+// dueBefore. Записи упорядочены по сроку смены пароля.
+func (s *PostgresStorage) GetEntriesDueForRotation(ctx context.Context, userID uuid.UUID, dueBefore time.Time) ([]models.DataEntry, error) {
+	query := dataEntrySelect + `
+		WHERE user_id = $1 AND rotation_days IS NOT NULL
+			AND password_changed_at + rotation_days * INTERVAL '1 day' <= $2`
+
+	entries, err := s.queryDataEntries(ctx, query, userID, dueBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionQuery := collectionEntrySelect + `
+		AND ` + collectionEntryRotationDays + ` IS NOT NULL
+		AND e.password_changed_at + ` + collectionEntryRotationDays + ` * INTERVAL '1 day' <= $2`
+
+	rotatable := dataTypesToStrings(models.RotatableTypes())
+	collectionEntries, err := s.queryCollectionEntries(ctx, collectionQuery, userID, dueBefore, rotatable)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, collectionEntries...)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].RotationDueAt().Before(*entries[j].RotationDueAt())
+	})
+
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GophKeeper/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSecretLinkGone возвращается, если ссылка не существует, истекла
+// или уже исчерпала допустимое количество просмотров.
+var ErrSecretLinkGone = errors.New("secret link not found, expired or already viewed")
+
+// CreateSecretLink сохраняет одноразовую ссылку на зашифрованный секрет.
+func (s *PostgresStorage) CreateSecretLink(ctx context.Context, link *models.SecretLink) error {
+	query := `
+		INSERT INTO secret_links (id, user_id, encrypted_data, max_views, views, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, 0, $5, $6)`
+
+	link.ID, link.CreatedAt, _ = s.prepareNewEntity()
+	link.Views = 0
+
+	_, err := s.pool.Exec(ctx, query,
+		link.ID, link.UserID, link.EncryptedData, link.MaxViews, link.ExpiresAt, link.CreatedAt,
+	)
+
+	return s.handleExecError(err, "secret link already exists", "failed to create secret link")
+}
+
+// ConsumeSecretLink засчитывает просмотр ссылки и возвращает секрет.
+// После последнего допустимого просмотра ссылка удаляется в той же транзакции,
+// поэтому одновременные запросы не могут получить секрет сверх лимита.
+func (s *PostgresStorage) ConsumeSecretLink(ctx context.Context, linkID uuid.UUID, now time.Time) (*models.SecretLink, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE secret_links
+		SET views = views + 1
+		WHERE id = $1 AND views < max_views AND expires_at > $2
+		RETURNING id, user_id, encrypted_data, max_views, views, expires_at, created_at`
+
+	var link models.SecretLink
+	err = tx.QueryRow(ctx, query, linkID, now).Scan(
+		&link.ID, &link.UserID, &link.EncryptedData, &link.MaxViews,
+		&link.Views, &link.ExpiresAt, &link.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrSecretLinkGone
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume secret link: %w", err)
+	}
+
+	if link.ViewsLeft() == 0 {
+		if _, err := tx.Exec(ctx, `DELETE FROM secret_links WHERE id = $1`, linkID); err != nil {
+			return nil, fmt.Errorf("failed to burn secret link: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &link, nil
+}
+
+// DeleteExpiredSecretLinks удаляет истекшие ссылки и возвращает их количество.
+func (s *PostgresStorage) DeleteExpiredSecretLinks(ctx context.Context, now time.Time) (int64, error) {
+	result, err := s.pool.Exec(ctx, `DELETE FROM secret_links WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired secret links: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,123 @@
+package strength
+
+import (
+	"math"
+	"unicode"
+)
+
+// Подсказки, которые показываются для слабых паролей.
+const (
+	suggestionDefaultWords   = "Используйте несколько слов, избегайте распространенных фраз"
+	suggestionNoSymbolsNeed  = "Символы, цифры и заглавные буквы не обязательны"
+	suggestionAddWord        = "Добавьте еще одно-два слова, лучше редких"
+	suggestionLongerKeyboard = "Используйте более длинный клавиатурный шаблон с поворотами"
+	suggestionAvoidRepeats   = "Избегайте повторяющихся слов и символов"
+	suggestionAvoidSequences = "Избегайте последовательностей"
+	suggestionAvoidYears     = "Избегайте недавних годов и годов, связанных с вами"
+	suggestionAvoidDates     = "Избегайте дат, связанных с вами"
+	suggestionCapitalization = "Заглавная первая буква мало помогает"
+	suggestionAllUppercase   = "Все заглавные буквы подобрать почти так же легко, как все строчные"
+	suggestionReversed       = "Слова задом наперед подобрать легко"
+	suggestionL33t           = "Замены вроде '@' вместо 'a' мало помогают"
+)
+
+// feedback возвращает предупреждение и подсказки по самому длинному шаблону пароля.
+func feedback(score int, sequence []Match) (string, []string) {
+	if len(sequence) == 0 {
+		return "", []string{suggestionDefaultWords, suggestionNoSymbolsNeed}
+	}
+	if score > 2 {
+		return "", nil
+	}
+
+	longest := sequence[0]
+	for _, match := range sequence[1:] {
+		if len([]rune(match.Token)) > len([]rune(longest.Token)) {
+			longest = match
+		}
+	}
+
+	warning, suggestions := matchFeedback(longest, len(sequence) == 1)
+	suggestions = append([]string{suggestionAddWord}, suggestions...)
+	return warning, suggestions
+}
+
+// matchFeedback объясняет слабость конкретного шаблона.
+func matchFeedback(match Match, sole bool) (string, []string) {
+	switch match.Pattern {
+	case PatternDictionary:
+		return dictionaryFeedback(match, sole)
+	case PatternSpatial:
+		if match.Turns == 1 {
+			return "Ряды клавиш вроде qwerty легко подобрать", []string{suggestionLongerKeyboard}
+		}
+		return "Короткие клавиатурные шаблоны легко подобрать", []string{suggestionLongerKeyboard}
+	case PatternRepeat:
+		if len([]rune(match.BaseToken)) == 1 {
+			return "Повторы вида \"aaa\" легко подобрать", []string{suggestionAvoidRepeats}
+		}
+		return "Повторы вида \"abcabc\" лишь немного сложнее подобрать, чем \"abc\"", []string{suggestionAvoidRepeats}
+	case PatternSequence:
+		return "Последовательности вида abc или 6543 легко подобрать", []string{suggestionAvoidSequences}
+	case PatternYear:
+		return "Недавние годы легко подобрать", []string{suggestionAvoidYears}
+	case PatternDate:
+		return "Даты часто легко подобрать", []string{suggestionAvoidDates}
+	default:
+		return "", nil
+	}
+}
+
+// dictionaryFeedback объясняет слабость словарного слова.
+func dictionaryFeedback(match Match, sole bool) (string, []string) {
+	var warning string
+	switch match.Dictionary {
+	case DictionaryPasswords:
+		switch {
+		case sole && !match.L33t && !match.Reversed && match.Rank <= 10:
+			warning = "Это один из 10 самых распространенных паролей"
+		case sole && !match.L33t && !match.Reversed && match.Rank <= 100:
+			warning = "Это один из 100 самых распространенных паролей"
+		case sole && !match.L33t && !match.Reversed:
+			warning = "Это очень распространенный пароль"
+		case math.Log10(match.Guesses) <= 4:
+			warning = "Пароль похож на распространенный"
+		}
+	case DictionaryWords:
+		if sole {
+			warning = "Одно слово легко подобрать"
+		}
+	case DictionaryUserInputs:
+		warning = "Пароль содержит название записи, логин или адрес сайта"
+	}
+
+	var suggestions []string
+	token := []rune(match.Token)
+	switch {
+	case onlyFirstUpper(token):
+		suggestions = append(suggestions, suggestionCapitalization)
+	case allUpper(token):
+		suggestions = append(suggestions, suggestionAllUppercase)
+	}
+	if match.Reversed && len(token) >= 4 {
+		suggestions = append(suggestions, suggestionReversed)
+	}
+	if match.L33t {
+		suggestions = append(suggestions, suggestionL33t)
+	}
+	return warning, suggestions
+}
+
+// allUpper проверяет, что все буквы токена заглавные.
+func allUpper(token []rune) bool {
+	letters := 0
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			letters++
+			if !isUpper(r) {
+				return false
+			}
+		}
+	}
+	return letters > 0
+}
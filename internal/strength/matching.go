@@ -0,0 +1,723 @@
+package strength
+
+import (
+	"bufio"
+	_ "embed"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/GophKeeper/internal/passgen"
+)
+
+// Pattern определяет вид шаблона, найденного в пароле.
+type Pattern string
+
+// Виды шаблонов.
+const (
+	PatternDictionary Pattern = "dictionary" // слово из словаря, возможно перевернутое или с заменами l33t
+	PatternSpatial    Pattern = "spatial"    // соседние клавиши: qwerty, asdf, 1qaz
+	PatternRepeat     Pattern = "repeat"     // повтор: aaa, abcabc
+	PatternSequence   Pattern = "sequence"   // последовательность: abcd, 9876
+	PatternYear       Pattern = "year"       // год: 1987, 2024
+	PatternDate       Pattern = "date"       // дата: 13.05.1987, 870513
+	PatternBruteforce Pattern = "bruteforce" // участок без шаблона
+)
+
+// Названия словарей.
+const (
+	DictionaryPasswords  = "passwords"   // распространенные пароли
+	DictionaryWords      = "words"       // слова списка EFF
+	DictionaryUserInputs = "user_inputs" // данные пользователя
+)
+
+// Match описывает шаблон, занимающий символы пароля с I по J включительно.
+type Match struct {
+	Pattern Pattern
+	I, J    int // позиции в рунах
+	Token   string
+	Guesses float64
+
+	// PatternDictionary
+	Dictionary  string
+	MatchedWord string
+	Rank        int
+	Reversed    bool
+	L33t        bool
+	Sub         map[rune]rune // замены l33t: символ пароля -> буква слова
+
+	// PatternSpatial
+	Turns        int
+	ShiftedCount int
+
+	// PatternRepeat
+	BaseToken   string
+	BaseGuesses float64
+	RepeatCount int
+
+	// PatternSequence
+	Ascending bool
+
+	// PatternYear и PatternDate
+	Year      int
+	Separator string
+}
+
+// Список распространенных паролей, упорядоченный по частоте.
+//
+//go:embed common_passwords.txt
+var commonPasswordsData string
+
+var (
+	dictionariesOnce sync.Once
+	dictionaries     map[string]map[string]int
+)
+
+// rankedDictionaries возвращает встроенные словари: слово -> ранг (1 — самое частое).
+func rankedDictionaries() map[string]map[string]int {
+	dictionariesOnce.Do(func() {
+		dictionaries = make(map[string]map[string]int)
+
+		passwords := make(map[string]int)
+		scanner := bufio.NewScanner(strings.NewReader(commonPasswordsData))
+		for scanner.Scan() {
+			word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+			if _, ok := passwords[word]; word != "" && !ok {
+				passwords[word] = len(passwords) + 1
+			}
+		}
+		dictionaries[DictionaryPasswords] = passwords
+
+		// Список EFF не упорядочен по частоте, поэтому все слова имеют одинаковый
+		// ранг: атакующему придется перебрать весь список.
+		words := make(map[string]int)
+		if list, err := passgen.EFFWordlist(); err == nil {
+			for _, word := range list {
+				words[word] = len(list)
+			}
+		}
+		dictionaries[DictionaryWords] = words
+	})
+	return dictionaries
+}
+
+// l33tTable содержит замены букв похожими символами.
+var l33tTable = map[rune][]rune{
+	'a': {'4', '@'},
+	'b': {'8'},
+	'c': {'(', '{', '[', '<'},
+	'e': {'3'},
+	'g': {'6', '9'},
+	'i': {'1', '!', '|'},
+	'l': {'1', '|', '7'},
+	'o': {'0'},
+	's': {'$', '5'},
+	't': {'+', '7'},
+	'x': {'%'},
+	'z': {'2'},
+}
+
+// maxL33tSubs ограничивает число проверяемых вариантов замен.
+const maxL33tSubs = 32
+
+// referenceYear — год, относительно которого оцениваются годы и даты в паролях.
+var referenceYear = time.Now().Year()
+
+// matcher ищет шаблоны в пароле.
+type matcher struct {
+	dictionaries map[string]map[string]int
+}
+
+// newMatcher создает поиск шаблонов со встроенными словарями и данными пользователя.
+func newMatcher(userInputs []string) *matcher {
+	m := &matcher{dictionaries: make(map[string]map[string]int)}
+	for name, dict := range rankedDictionaries() {
+		m.dictionaries[name] = dict
+	}
+
+	inputs := make(map[string]int)
+	for _, input := range userInputs {
+		for _, word := range normalizeInput(input) {
+			if _, ok := inputs[word]; !ok {
+				inputs[word] = len(inputs) + 1
+			}
+		}
+	}
+	if len(inputs) > 0 {
+		m.dictionaries[DictionaryUserInputs] = inputs
+	}
+	return m
+}
+
+// omnimatch возвращает все найденные шаблоны, упорядоченные по позиции.
+func (m *matcher) omnimatch(password []rune) []Match {
+	var matches []Match
+	matches = append(matches, m.dictionaryMatch(password)...)
+	matches = append(matches, m.reverseDictionaryMatch(password)...)
+	matches = append(matches, m.l33tMatch(password)...)
+	matches = append(matches, spatialMatch(password)...)
+	matches = append(matches, m.repeatMatch(password)...)
+	matches = append(matches, sequenceMatch(password)...)
+	matches = append(matches, yearMatch(password)...)
+	matches = append(matches, dateMatch(password)...)
+	sortMatches(matches)
+	return matches
+}
+
+// sortMatches упорядочивает шаблоны по началу, затем по концу.
+func sortMatches(matches []Match) {
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].I != matches[b].I {
+			return matches[a].I < matches[b].I
+		}
+		return matches[a].J < matches[b].J
+	})
+}
+
+// dictionaryMatch ищет подстроки пароля в словарях без учета регистра.
+func (m *matcher) dictionaryMatch(password []rune) []Match {
+	lower := []rune(strings.ToLower(string(password)))
+	if len(lower) != len(password) {
+		// Смена регистра изменила число рун, позиции не сопоставить
+		lower = password
+	}
+
+	var matches []Match
+	names := make([]string, 0, len(m.dictionaries))
+	for name := range m.dictionaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dict := m.dictionaries[name]
+		for i := range lower {
+			for j := i; j < len(lower); j++ {
+				word := string(lower[i : j+1])
+				rank, ok := dict[word]
+				if !ok {
+					continue
+				}
+				matches = append(matches, Match{
+					Pattern:     PatternDictionary,
+					I:           i,
+					J:           j,
+					Token:       string(password[i : j+1]),
+					Dictionary:  name,
+					MatchedWord: word,
+					Rank:        rank,
+				})
+			}
+		}
+	}
+	return matches
+}
+
+// reverseDictionaryMatch ищет словарные слова, записанные задом наперед.
+func (m *matcher) reverseDictionaryMatch(password []rune) []Match {
+	reversed := reverseRunes(password)
+	matches := m.dictionaryMatch(reversed)
+	for k := range matches {
+		match := &matches[k]
+		match.Token = string(reverseRunes([]rune(match.Token)))
+		match.Reversed = true
+		match.I, match.J = len(password)-1-match.J, len(password)-1-match.I
+	}
+	return matches
+}
+
+// l33tMatch ищет словарные слова, в которых буквы заменены похожими символами.
+func (m *matcher) l33tMatch(password []rune) []Match {
+	var matches []Match
+	for _, sub := range l33tSubstitutions(password) {
+		translated := make([]rune, len(password))
+		for k, r := range password {
+			if letter, ok := sub[r]; ok {
+				translated[k] = letter
+			} else {
+				translated[k] = r
+			}
+		}
+
+		for _, match := range m.dictionaryMatch(translated) {
+			token := password[match.I : match.J+1]
+			// Замены, которые действительно встречаются в найденном слове
+			used := make(map[rune]rune)
+			for _, r := range token {
+				if letter, ok := sub[r]; ok {
+					used[r] = letter
+				}
+			}
+			// Одиночные символы вроде "1" вместо "i" не считаются словом
+			if len(used) == 0 || len(token) <= 1 {
+				continue
+			}
+			match.Token = string(token)
+			match.L33t = true
+			match.Sub = used
+			matches = append(matches, match)
+		}
+	}
+	return dedupMatches(matches)
+}
+
+// l33tSubstitutions перечисляет варианты замен для символов l33t, встречающихся
+// в пароле. Символ может заменять несколько букв ("1" — "i" или "l"), поэтому
+// перебираются все сочетания, но не больше maxL33tSubs.
+func l33tSubstitutions(password []rune) []map[rune]rune {
+	candidates := make(map[rune][]rune)
+	for letter, subs := range l33tTable {
+		for _, sub := range subs {
+			if containsRune(password, sub) {
+				candidates[sub] = append(candidates[sub], letter)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	chars := make([]rune, 0, len(candidates))
+	for char, letters := range candidates {
+		sort.Slice(letters, func(a, b int) bool { return letters[a] < letters[b] })
+		chars = append(chars, char)
+	}
+	sort.Slice(chars, func(a, b int) bool { return chars[a] < chars[b] })
+
+	subs := []map[rune]rune{{}}
+	for _, char := range chars {
+		var next []map[rune]rune
+		for _, sub := range subs {
+			for _, letter := range candidates[char] {
+				if len(next) >= maxL33tSubs {
+					break
+				}
+				extended := make(map[rune]rune, len(sub)+1)
+				for k, v := range sub {
+					extended[k] = v
+				}
+				extended[char] = letter
+				next = append(next, extended)
+			}
+		}
+		subs = next
+	}
+	return subs
+}
+
+// dedupMatches удаляет шаблоны, найденные несколькими вариантами замен.
+func dedupMatches(matches []Match) []Match {
+	seen := make(map[string]struct{})
+	result := matches[:0]
+	for _, match := range matches {
+		key := strconv.Itoa(match.I) + ":" + strconv.Itoa(match.J) + ":" + match.Dictionary + ":" + match.MatchedWord
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, match)
+	}
+	return result
+}
+
+// Раскладка qwerty: ряды без Shift и с Shift. Каждый следующий ряд сдвинут
+// вправо на полклавиши, поэтому у клавиши (r, c) соседи сверху — (r-1, c) и
+// (r-1, c+1), снизу — (r+1, c-1) и (r+1, c).
+var (
+	qwertyRows = []string{
+		"`1234567890-=",
+		"qwertyuiop[]\\",
+		"asdfghjkl;'",
+		"zxcvbnm,./",
+	}
+	qwertyShiftedRows = []string{
+		"~!@#$%^&*()_+",
+		"QWERTYUIOP{}|",
+		"ASDFGHJKL:\"",
+		"ZXCVBNM<>?",
+	}
+)
+
+var (
+	keyboardOnce sync.Once
+	// keyboardGraph: клавиша -> соседние клавиши по направлениям. Каждый сосед —
+	// пара рун (без Shift, с Shift) или пустое значение, если соседа нет.
+	keyboardGraph map[rune][6][2]rune
+	// shiftedKeys — символы, набираемые с Shift.
+	shiftedKeys map[rune]bool
+	// keyboardStartingPositions и keyboardAverageDegree используются при оценке
+	// числа попыток для клавиатурных шаблонов.
+	keyboardStartingPositions float64
+	keyboardAverageDegree     float64
+)
+
+// buildKeyboardGraph строит граф соседства клавиш раскладки qwerty.
+func buildKeyboardGraph() {
+	keyboardOnce.Do(func() {
+		keyboardGraph = make(map[rune][6][2]rune)
+		shiftedKeys = make(map[rune]bool)
+
+		key := func(r, c int) [2]rune {
+			if r < 0 || r >= len(qwertyRows) || c < 0 || c >= len(qwertyRows[r]) {
+				return [2]rune{}
+			}
+			return [2]rune{rune(qwertyRows[r][c]), rune(qwertyShiftedRows[r][c])}
+		}
+
+		degrees := 0
+		for r, row := range qwertyRows {
+			for c := range row {
+				neighbors := [6][2]rune{
+					key(r, c-1),   // влево
+					key(r-1, c),   // вверх-влево
+					key(r-1, c+1), // вверх-вправо
+					key(r, c+1),   // вправо
+					key(r+1, c),   // вниз-вправо
+					key(r+1, c-1), // вниз-влево
+				}
+				for _, neighbor := range neighbors {
+					if neighbor[0] != 0 {
+						degrees++
+					}
+				}
+				keys := key(r, c)
+				keyboardGraph[keys[0]] = neighbors
+				keyboardGraph[keys[1]] = neighbors
+				shiftedKeys[keys[1]] = true
+			}
+		}
+
+		keys := len(keyboardGraph) / 2
+		keyboardStartingPositions = float64(len(keyboardGraph))
+		keyboardAverageDegree = float64(degrees) / float64(keys)
+	})
+}
+
+// spatialMatch ищет участки из соседних клавиш длиной не меньше трех символов.
+func spatialMatch(password []rune) []Match {
+	buildKeyboardGraph()
+
+	var matches []Match
+	for i := 0; i < len(password)-1; {
+		j := i + 1
+		lastDirection := -1
+		turns := 0
+		shifted := 0
+		if shiftedKeys[password[i]] {
+			shifted++
+		}
+
+		for {
+			found := false
+			if j < len(password) {
+				neighbors := keyboardGraph[password[j-1]]
+				for direction, neighbor := range neighbors {
+					if neighbor[0] == 0 {
+						continue
+					}
+					if password[j] != neighbor[0] && password[j] != neighbor[1] {
+						continue
+					}
+					found = true
+					if password[j] == neighbor[1] && neighbor[0] != neighbor[1] {
+						shifted++
+					}
+					if direction != lastDirection {
+						turns++
+						lastDirection = direction
+					}
+					break
+				}
+			}
+
+			if found {
+				j++
+				continue
+			}
+			if j-i > 2 {
+				matches = append(matches, Match{
+					Pattern:      PatternSpatial,
+					I:            i,
+					J:            j - 1,
+					Token:        string(password[i:j]),
+					Turns:        turns,
+					ShiftedCount: shifted,
+				})
+			}
+			i = j
+			break
+		}
+	}
+	return matches
+}
+
+// repeatMatch ищет повторы подстроки: "aaa", "abcabcabc". Для каждой позиции
+// выбирается повтор, покрывающий больше всего символов.
+func (m *matcher) repeatMatch(password []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(password); {
+		bestEnd, bestBase := -1, 0
+		for base := 1; i+2*base <= len(password); base++ {
+			end := i + base
+			for end+base <= len(password) && equalRunes(password[end:end+base], password[i:i+base]) {
+				end += base
+			}
+			if end-i >= 2*base && end > bestEnd {
+				bestEnd, bestBase = end, base
+			}
+		}
+		if bestEnd < 0 {
+			i++
+			continue
+		}
+
+		base := password[i : i+bestBase]
+		baseGuesses, _ := mostGuessableSequence(base, m.omnimatch(base), true)
+		matches = append(matches, Match{
+			Pattern:     PatternRepeat,
+			I:           i,
+			J:           bestEnd - 1,
+			Token:       string(password[i:bestEnd]),
+			BaseToken:   string(base),
+			BaseGuesses: baseGuesses,
+			RepeatCount: (bestEnd - i) / bestBase,
+		})
+		i = bestEnd
+	}
+	return matches
+}
+
+// maxSequenceDelta — наибольший шаг между кодами символов последовательности.
+const maxSequenceDelta = 5
+
+// sequenceMatch ищет последовательности символов с постоянным шагом: "abcd",
+// "9753", "zyx". Последовательности из двух символов учитываются только с шагом 1.
+func sequenceMatch(password []rune) []Match {
+	if len(password) < 2 {
+		return nil
+	}
+
+	var matches []Match
+	emit := func(i, j, delta int) {
+		if j-i <= 1 && abs(delta) != 1 {
+			return
+		}
+		if delta == 0 || abs(delta) > maxSequenceDelta {
+			return
+		}
+		matches = append(matches, Match{
+			Pattern:   PatternSequence,
+			I:         i,
+			J:         j,
+			Token:     string(password[i : j+1]),
+			Ascending: delta > 0,
+		})
+	}
+
+	i := 0
+	lastDelta := 0
+	for k := 1; k < len(password); k++ {
+		delta := int(password[k]) - int(password[k-1])
+		if k == 1 {
+			lastDelta = delta
+		}
+		if delta == lastDelta {
+			continue
+		}
+		j := k - 1
+		emit(i, j, lastDelta)
+		i = j
+		lastDelta = delta
+	}
+	emit(i, len(password)-1, lastDelta)
+	return matches
+}
+
+// Допустимый диапазон годов в паролях.
+const (
+	minYear = 1900
+	maxYear = 2099
+)
+
+// yearMatch ищет четырехзначные годы.
+func yearMatch(password []rune) []Match {
+	var matches []Match
+	for i := 0; i+4 <= len(password); i++ {
+		token := string(password[i : i+4])
+		year, ok := parseDigits(token)
+		if !ok || year < minYear || year > maxYear {
+			continue
+		}
+		matches = append(matches, Match{
+			Pattern: PatternYear,
+			I:       i,
+			J:       i + 3,
+			Token:   token,
+			Year:    year,
+		})
+	}
+	return matches
+}
+
+// dateSeparators — допустимые разделители дат.
+const dateSeparators = "./-_ \\"
+
+// dateMatch ищет даты: с разделителями ("13.05.1987", "5/13/87") и без них
+// ("130587", "19870513").
+func dateMatch(password []rune) []Match {
+	var matches []Match
+	for i := range password {
+		for j := i + 5; j < len(password) && j-i < 10; j++ {
+			token := string(password[i : j+1])
+			year, separator, ok := parseDate(token)
+			if !ok {
+				continue
+			}
+			matches = append(matches, Match{
+				Pattern:   PatternDate,
+				I:         i,
+				J:         j,
+				Token:     token,
+				Year:      year,
+				Separator: separator,
+			})
+		}
+	}
+	return matches
+}
+
+// parseDate распознает дату и возвращает ее год и разделитель.
+func parseDate(token string) (int, string, bool) {
+	for _, sep := range dateSeparators {
+		parts := strings.Split(token, string(sep))
+		if len(parts) != 3 {
+			continue
+		}
+		numbers := make([]int, 3)
+		for k, part := range parts {
+			n, ok := parseDigits(part)
+			if !ok || len(part) > 4 || (len(part) > 2 && k == 1) {
+				return 0, "", false
+			}
+			numbers[k] = n
+		}
+		year, ok := dateYear(numbers, []int{len(parts[0]), len(parts[1]), len(parts[2])})
+		return year, string(sep), ok
+	}
+
+	if len(token) != 6 && len(token) != 8 {
+		return 0, "", false
+	}
+	if _, ok := parseDigits(token); !ok {
+		return 0, "", false
+	}
+	yearLen := len(token) - 4
+	splits := [][3]int{
+		{2, 2, yearLen}, // ДДММГГ(ГГ) или ММДДГГ(ГГ)
+		{yearLen, 2, 2}, // ГГ(ГГ)ММДД
+	}
+	for _, split := range splits {
+		parts := []string{token[:split[0]], token[split[0] : split[0]+split[1]], token[split[0]+split[1]:]}
+		numbers := make([]int, 3)
+		for k, part := range parts {
+			numbers[k], _ = parseDigits(part)
+		}
+		if year, ok := dateYear(numbers, split[:]); ok {
+			return year, "", true
+		}
+	}
+	return 0, "", false
+}
+
+// dateYear проверяет, что числа образуют дату в одном из порядков ДМГ, МДГ,
+// ГМД, и возвращает год. Двузначный год дополняется до ближайшего к текущему.
+func dateYear(numbers, lengths []int) (int, bool) {
+	orders := [][3]int{
+		{0, 1, 2}, // день, месяц, год
+		{1, 0, 2}, // месяц, день, год
+		{2, 1, 0}, // год, месяц, день
+	}
+	for _, order := range orders {
+		day, month, year := numbers[order[0]], numbers[order[1]], numbers[order[2]]
+		yearLen := lengths[order[2]]
+		if yearLen != 2 && yearLen != 4 {
+			continue
+		}
+		if day < 1 || day > 31 || month < 1 || month > 12 {
+			continue
+		}
+		if yearLen == 2 {
+			year += 1900
+			if year+100 <= referenceYear+10 {
+				year += 100
+			}
+		}
+		if year < minYear || year > maxYear {
+			continue
+		}
+		return year, true
+	}
+	return 0, false
+}
+
+// parseDigits разбирает строку из одних цифр.
+func parseDigits(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// reverseRunes возвращает руны в обратном порядке.
+func reverseRunes(runes []rune) []rune {
+	reversed := make([]rune, len(runes))
+	for i, r := range runes {
+		reversed[len(runes)-1-i] = r
+	}
+	return reversed
+}
+
+// equalRunes сравнивает два среза рун.
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsRune проверяет, содержит ли срез руну.
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// isUpper проверяет, является ли руна заглавной буквой.
+func isUpper(r rune) bool {
+	return unicode.IsUpper(r)
+}
+
+// abs возвращает модуль числа.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
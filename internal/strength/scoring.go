@@ -0,0 +1,337 @@
+package strength
+
+import (
+	"math"
+	"unicode"
+)
+
+// Параметры оценки числа попыток, как в zxcvbn.
+const (
+	// bruteforceCardinality — число вариантов на символ участка без шаблона.
+	bruteforceCardinality = 10
+	// Наименьшее число попыток для шаблона, который покрывает не весь пароль.
+	minSubmatchGuessesSingleChar = 10
+	minSubmatchGuessesMultiChar  = 50
+	// minYearSpace — наименьший разброс годов, чтобы недавние годы не оценивались
+	// слишком низко.
+	minYearSpace = 20
+	// sequencePenalty штрафует последовательности из многих шаблонов: атакующий
+	// сначала перебирает короткие сочетания.
+	sequencePenalty = 10000
+)
+
+// mostGuessableSequence находит разбиение пароля на шаблоны с наименьшим числом
+// попыток (динамическим программированием, как в zxcvbn). Участки без шаблонов
+// заполняются перебором. excludeAdditive отключает штраф за число шаблонов,
+// что нужно при оценке основы повтора.
+func mostGuessableSequence(password []rune, matches []Match, excludeAdditive bool) (float64, []Match) {
+	n := len(password)
+	if n == 0 {
+		return 1, nil
+	}
+
+	matchesByEnd := make([][]Match, n)
+	for _, match := range matches {
+		matchesByEnd[match.J] = append(matchesByEnd[match.J], match)
+	}
+
+	// Для каждой позиции k и длины l: последний шаблон лучшей последовательности
+	// из l шаблонов, покрывающей символы 0..k, произведение попыток и итоговая оценка.
+	type state struct {
+		match Match
+		pi    float64
+		g     float64
+	}
+	optimal := make([]map[int]state, n)
+	for k := range optimal {
+		optimal[k] = make(map[int]state)
+	}
+
+	update := func(match Match, l int) {
+		k := match.J
+		match.Guesses = estimateGuesses(match, n)
+		pi := match.Guesses
+		if l > 1 {
+			pi *= optimal[match.I-1][l-1].pi
+		}
+		g := factorial(l) * pi
+		if !excludeAdditive {
+			g += math.Pow(sequencePenalty, float64(l-1))
+		}
+		// Последовательность из большего числа шаблонов с не меньшей оценкой не нужна
+		for competingL, competing := range optimal[k] {
+			if competingL > l {
+				continue
+			}
+			if competing.g <= g {
+				return
+			}
+		}
+		optimal[k][l] = state{match: match, pi: pi, g: g}
+	}
+
+	bruteforce := func(i, j int) Match {
+		return Match{
+			Pattern: PatternBruteforce,
+			I:       i,
+			J:       j,
+			Token:   string(password[i : j+1]),
+		}
+	}
+
+	bruteforceUpdate := func(k int) {
+		update(bruteforce(0, k), 1)
+		for i := 1; i <= k; i++ {
+			match := bruteforce(i, k)
+			for l, last := range optimal[i-1] {
+				// Два участка перебора подряд хуже одного общего
+				if last.match.Pattern == PatternBruteforce {
+					continue
+				}
+				update(match, l+1)
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for _, match := range matchesByEnd[k] {
+			if match.I > 0 {
+				for l := range optimal[match.I-1] {
+					update(match, l+1)
+				}
+			} else {
+				update(match, 1)
+			}
+		}
+		bruteforceUpdate(k)
+	}
+
+	// Восстановление лучшей последовательности с конца пароля
+	bestL, bestG := 0, math.Inf(1)
+	for l, candidate := range optimal[n-1] {
+		if candidate.g < bestG || (candidate.g == bestG && l < bestL) {
+			bestL, bestG = l, candidate.g
+		}
+	}
+	sequence := make([]Match, bestL)
+	for k, l := n-1, bestL; k >= 0 && l > 0; l-- {
+		match := optimal[k][l].match
+		sequence[l-1] = match
+		k = match.I - 1
+	}
+
+	return bestG, sequence
+}
+
+// estimateGuesses оценивает число попыток для шаблона в пароле длиной n.
+func estimateGuesses(match Match, n int) float64 {
+	token := []rune(match.Token)
+	minGuesses := 1.0
+	if len(token) < n {
+		minGuesses = minSubmatchGuessesMultiChar
+		if len(token) == 1 {
+			minGuesses = minSubmatchGuessesSingleChar
+		}
+	}
+
+	var guesses float64
+	switch match.Pattern {
+	case PatternDictionary:
+		guesses = dictionaryGuesses(match)
+	case PatternSpatial:
+		guesses = spatialGuesses(match)
+	case PatternRepeat:
+		guesses = match.BaseGuesses * float64(match.RepeatCount)
+	case PatternSequence:
+		guesses = sequenceGuesses(match)
+	case PatternYear:
+		guesses = yearSpace(match.Year)
+	case PatternDate:
+		guesses = yearSpace(match.Year) * 365
+		if match.Separator != "" {
+			guesses *= 4
+		}
+	default:
+		guesses = bruteforceGuesses(len(token))
+	}
+	return math.Max(guesses, minGuesses)
+}
+
+// bruteforceGuesses оценивает перебор участка длиной length.
+func bruteforceGuesses(length int) float64 {
+	guesses := math.Pow(bruteforceCardinality, float64(length))
+	if math.IsInf(guesses, 1) {
+		guesses = math.MaxFloat64
+	}
+	// На единицу больше минимума, чтобы одиночный шаблон был предпочтительнее перебора
+	minGuesses := float64(minSubmatchGuessesMultiChar + 1)
+	if length == 1 {
+		minGuesses = minSubmatchGuessesSingleChar + 1
+	}
+	return math.Max(guesses, minGuesses)
+}
+
+// dictionaryGuesses учитывает ранг слова и его искажения: регистр, замены l33t
+// и обратный порядок.
+func dictionaryGuesses(match Match) float64 {
+	guesses := float64(match.Rank) * uppercaseVariations(match.Token) * l33tVariations(match)
+	if match.Reversed {
+		guesses *= 2
+	}
+	return guesses
+}
+
+// uppercaseVariations оценивает число вариантов регистра слова. Заглавная первая
+// или последняя буква и все заглавные — самые частые варианты и стоят вдвое.
+func uppercaseVariations(token string) float64 {
+	runes := []rune(token)
+	upper, lower := 0, 0
+	for _, r := range runes {
+		switch {
+		case isUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	if lower == 0 || onlyFirstUpper(runes) || onlyLastUpper(runes) {
+		return 2
+	}
+
+	variations := 0.0
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return variations
+}
+
+// onlyFirstUpper проверяет, что заглавная только первая буква.
+func onlyFirstUpper(runes []rune) bool {
+	for i, r := range runes {
+		if (i == 0) != isUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// onlyLastUpper проверяет, что заглавная только последняя буква.
+func onlyLastUpper(runes []rune) bool {
+	for i, r := range runes {
+		if (i == len(runes)-1) != isUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// l33tVariations оценивает число вариантов замен: атакующий пробует заменять
+// часть букв, поэтому пароль со всеми заменами стоит лишь вдвое дороже.
+func l33tVariations(match Match) float64 {
+	if !match.L33t {
+		return 1
+	}
+
+	variations := 1.0
+	token := []rune(match.Token)
+	for subbed, letter := range match.Sub {
+		s, u := 0, 0
+		for _, r := range token {
+			switch unicode.ToLower(r) {
+			case subbed:
+				s++
+			case letter:
+				u++
+			}
+		}
+		if s == 0 || u == 0 {
+			variations *= 2
+			continue
+		}
+		possibilities := 0.0
+		for i := 1; i <= min(s, u); i++ {
+			possibilities += binomial(s+u, i)
+		}
+		variations *= possibilities
+	}
+	return variations
+}
+
+// spatialGuesses оценивает клавиатурный шаблон по длине, числу поворотов и
+// числу символов, набранных с Shift.
+func spatialGuesses(match Match) float64 {
+	buildKeyboardGraph()
+
+	length := len([]rune(match.Token))
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(match.Turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * keyboardStartingPositions * math.Pow(keyboardAverageDegree, float64(j))
+		}
+	}
+
+	shifted := match.ShiftedCount
+	unshifted := length - shifted
+	if shifted > 0 {
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			variations := 0.0
+			for i := 1; i <= min(shifted, unshifted); i++ {
+				variations += binomial(shifted+unshifted, i)
+			}
+			guesses *= variations
+		}
+	}
+	return guesses
+}
+
+// sequenceGuesses оценивает последовательность. Последовательности, начинающиеся
+// с очевидных символов ("a", "1", "z"), перебираются первыми.
+func sequenceGuesses(match Match) float64 {
+	token := []rune(match.Token)
+	first := token[0]
+
+	var base float64
+	switch {
+	case first == 'a' || first == 'A' || first == 'z' || first == 'Z' || first == '0' || first == '1' || first == '9':
+		base = 4
+	case unicode.IsDigit(first):
+		base = 10
+	default:
+		base = 26
+	}
+	if !match.Ascending {
+		base *= 2
+	}
+	return base * float64(len(token))
+}
+
+// yearSpace оценивает число годов, которые нужно перебрать, чтобы дойти до year.
+func yearSpace(year int) float64 {
+	return math.Max(math.Abs(float64(year-referenceYear)), minYearSpace)
+}
+
+// binomial возвращает биномиальный коэффициент C(n, k).
+func binomial(n, k int) float64 {
+	if k > n || k < 0 {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+// factorial возвращает n!.
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+// Package strength оценивает стойкость паролей по методике zxcvbn: пароль
+// раскладывается на известные шаблоны (словарные слова, клавиатурные ряды,
+// последовательности, повторы, даты), и стойкость определяется числом попыток,
+// которое понадобится атакующему, перебирающему пароли по этим шаблонам.
+package strength
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxLength ограничивает число анализируемых символов: поиск шаблонов
+// квадратичен по длине, а более длинные пароли и так получают максимальную оценку.
+const MaxLength = 100
+
+// MaxScore — оценка самых стойких паролей.
+const MaxScore = 4
+
+// Пороги числа попыток для оценок 0–3.
+var scoreThresholds = [...]float64{1e3, 1e6, 1e8, 1e10}
+
+// offlineGuessesPerSecond — скорость перебора при утечке базы, хешированной
+// медленной функцией (bcrypt, argon2).
+const offlineGuessesPerSecond = 1e4
+
+// Result содержит оценку стойкости пароля.
+type Result struct {
+	Password    string
+	Score       int     // от 0 (очень слабый) до MaxScore (очень стойкий)
+	Guesses     float64 // оценка числа попыток для подбора
+	Warning     string  // объяснение главной слабости, пусто для стойких паролей
+	Suggestions []string
+	Sequence    []Match // шаблоны, из которых состоит пароль
+}
+
+// Weak сообщает, что пароль стоит заменить.
+func (r Result) Weak() bool {
+	return r.Score < 3
+}
+
+// CrackTime возвращает оценку времени подбора при офлайн-атаке.
+func (r Result) CrackTime() time.Duration {
+	seconds := r.Guesses / offlineGuessesPerSecond
+	if seconds >= math.MaxInt64/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// CrackTimeDisplay возвращает время подбора в удобном для чтения виде.
+func (r Result) CrackTimeDisplay() string {
+	const (
+		minute  = 60.0
+		hour    = 60 * minute
+		day     = 24 * hour
+		month   = 31 * day
+		year    = 12 * month
+		century = 100 * year
+	)
+
+	seconds := r.Guesses / offlineGuessesPerSecond
+	switch {
+	case seconds < 1:
+		return "мгновенно"
+	case seconds < minute:
+		return fmt.Sprintf("%.0f сек", seconds)
+	case seconds < hour:
+		return fmt.Sprintf("%.0f мин", seconds/minute)
+	case seconds < day:
+		return fmt.Sprintf("%.0f ч", seconds/hour)
+	case seconds < month:
+		return fmt.Sprintf("%.0f сут", seconds/day)
+	case seconds < year:
+		return fmt.Sprintf("%.0f мес", seconds/month)
+	case seconds < century:
+		return fmt.Sprintf("%.0f г", seconds/year)
+	default:
+		return "столетия"
+	}
+}
+
+// ScoreLabel возвращает название оценки для интерфейса.
+func ScoreLabel(score int) string {
+	switch score {
+	case 0:
+		return "очень слабый"
+	case 1:
+		return "слабый"
+	case 2:
+		return "средний"
+	case 3:
+		return "надежный"
+	default:
+		return "очень надежный"
+	}
+}
+
+// Estimate оценивает стойкость пароля. userInputs — строки, которые атакующий
+// может знать о владельце: имя записи, логин, адрес сайта. Пароли, содержащие
+// их, оцениваются как словарные.
+func Estimate(password string, userInputs ...string) Result {
+	runes := []rune(password)
+	if len(runes) > MaxLength {
+		runes = runes[:MaxLength]
+	}
+
+	m := newMatcher(userInputs)
+	matches := m.omnimatch(runes)
+	guesses, sequence := mostGuessableSequence(runes, matches, false)
+
+	result := Result{
+		Password: password,
+		Score:    scoreFromGuesses(guesses),
+		Guesses:  guesses,
+		Sequence: sequence,
+	}
+	result.Warning, result.Suggestions = feedback(result.Score, sequence)
+	return result
+}
+
+// scoreFromGuesses переводит число попыток в оценку от 0 до MaxScore.
+func scoreFromGuesses(guesses float64) int {
+	// Небольшой запас, чтобы пароли на границе порога не получали более высокую оценку
+	const delta = 5
+	for score, threshold := range scoreThresholds {
+		if guesses < threshold+delta {
+			return score
+		}
+	}
+	return MaxScore
+}
+
+// normalizeInput приводит пользовательские данные к словарной форме и делит
+// их на слова: из "john.doe@example.com" получаются "john", "doe" и "example".
+func normalizeInput(input string) []string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil
+	}
+	words := []string{input}
+	parts := strings.FieldsFunc(input, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, part := range parts {
+		if part != input && len([]rune(part)) >= 3 {
+			words = append(words, part)
+		}
+	}
+	return words
+}
@@ -0,0 +1,118 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate_Scores(t *testing.T) {
+	tests := []struct {
+		password string
+		maxScore int
+		minScore int
+	}{
+		{"", 0, 0},
+		{"password", 0, 0},
+		{"123456", 0, 0},
+		{"qwerty", 0, 0},
+		{"P@ssw0rd", 0, 0},
+		{"drowssap", 1, 0},
+		{"aaaaaaaaaaaa", 1, 0},
+		{"abcdefghijk", 1, 0},
+		{"zxcvbnm,./", 1, 0},
+		{"1987", 0, 0},
+		{"13.05.1987", 1, 0},
+		{"Summer2023!", 2, 1},
+		{"correct-horse-battery-staple", 4, 4},
+		{"kX9#vL2$qW7!mZ4&", 4, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			result := Estimate(tt.password)
+			assert.LessOrEqual(t, result.Score, tt.maxScore, "guesses: %g", result.Guesses)
+			assert.GreaterOrEqual(t, result.Score, tt.minScore, "guesses: %g", result.Guesses)
+		})
+	}
+}
+
+func TestEstimate_Patterns(t *testing.T) {
+	tests := []struct {
+		password string
+		pattern  Pattern
+	}{
+		{"password", PatternDictionary},
+		{"wertyuiop", PatternSpatial},
+		{"abababab", PatternRepeat},
+		{"98765", PatternSequence},
+		{"2019", PatternYear},
+		{"05/13/1987", PatternDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			result := Estimate(tt.password)
+			require.Len(t, result.Sequence, 1)
+			assert.Equal(t, tt.pattern, result.Sequence[0].Pattern)
+		})
+	}
+}
+
+func TestEstimate_Variations(t *testing.T) {
+	result := Estimate("P4ssw0rd")
+	require.Len(t, result.Sequence, 1)
+	match := result.Sequence[0]
+	assert.True(t, match.L33t)
+	assert.Equal(t, "password", match.MatchedWord)
+	assert.Contains(t, result.Suggestions, suggestionL33t)
+	assert.Contains(t, result.Suggestions, suggestionCapitalization)
+
+	result = Estimate("drowssap")
+	require.Len(t, result.Sequence, 1)
+	assert.True(t, result.Sequence[0].Reversed)
+
+	// Искажения увеличивают число попыток, но ненамного
+	assert.Greater(t, Estimate("P4ssw0rd").Guesses, Estimate("password").Guesses)
+	assert.Less(t, Estimate("P4ssw0rd").Guesses, 1e4)
+}
+
+func TestEstimate_UserInputs(t *testing.T) {
+	without := Estimate("gophkeeper2024")
+	with := Estimate("gophkeeper2024", "GophKeeper", "admin@gophkeeper.dev")
+
+	assert.Less(t, with.Guesses, without.Guesses)
+	assert.Less(t, with.Score, 3)
+	assert.Equal(t, DictionaryUserInputs, with.Sequence[0].Dictionary)
+	assert.NotEmpty(t, with.Warning)
+}
+
+func TestEstimate_Feedback(t *testing.T) {
+	result := Estimate("123456")
+	assert.Equal(t, "Это один из 10 самых распространенных паролей", result.Warning)
+	assert.Equal(t, suggestionAddWord, result.Suggestions[0])
+	assert.True(t, result.Weak())
+
+	result = Estimate("correct-horse-battery-staple")
+	assert.Empty(t, result.Warning)
+	assert.Empty(t, result.Suggestions)
+	assert.False(t, result.Weak())
+
+	result = Estimate("")
+	assert.Equal(t, 0, result.Score)
+	assert.Len(t, result.Suggestions, 2)
+}
+
+func TestEstimate_LongPassword(t *testing.T) {
+	long := make([]byte, 10*MaxLength)
+	for i := range long {
+		long[i] = 'a' + byte(i*7%26)
+	}
+	result := Estimate(string(long))
+	assert.Equal(t, MaxScore, result.Score)
+}
+
+func TestResult_CrackTimeDisplay(t *testing.T) {
+	assert.Equal(t, "мгновенно", Result{Guesses: 10}.CrackTimeDisplay())
+	assert.Equal(t, "5 мин", Result{Guesses: 300 * offlineGuessesPerSecond}.CrackTimeDisplay())
+	assert.Equal(t, "столетия", Result{Guesses: 1e20}.CrackTimeDisplay())
+}
@@ -11,13 +11,15 @@ import (
 const HelpTemplate = `GophKeeper - Secure Password Manager
 
 Usage:
-  gophkeeper-client [options]
+  gophkeeper-client [options] [command]
 
 Options:
   -server string     Server address (default "{{.DefaultServer}}")
   -grpc string       gRPC server address (default "{{.DefaultGRPC}}")
   -tls               Enable TLS connection
   -cert string       TLS certificate file
+  -breach-dir string Local breached passwords corpus (HIBP range files)
+  -breach-url string Breached passwords mirror URL (HIBP range API)
   -config string     Configuration file path (default "{{.DefaultConfig}}")
   -log string        Log level (default "{{.DefaultLogLevel}}")
   -v, --version      Show version information
@@ -26,6 +28,13 @@ Options:
 Commands:
   version            Show version information
   help               Show this help message
+  otp-import <image> [name]
+                     Import a TOTP/HOTP secret from a QR code screenshot
+                     (PNG, JPEG or GIF); requires GOPHKEEPER_TOKEN
+  generate [flags]   Generate passwords locally: -length, -no-lower, -no-upper,
+                     -no-digits, -no-symbols, -exclude-ambiguous, -pronounceable;
+                     passphrases: -passphrase, -words, -separator, -capitalize,
+                     -number, -wordlist; -count prints several values
 
 Interactive Mode:
   Run without arguments to start the interactive TUI interface
@@ -0,0 +1,91 @@
+package templates
+
+// SecretLinkPage определяет публичную страницу одноразовой ссылки на секрет.
+// Секрет запрашивается только по нажатию кнопки, чтобы предпросмотр ссылки
+// в мессенджерах не расходовал просмотры. Ключ берется из фрагмента адреса,
+// который браузер не отправляет на сервер, и секрет расшифровывается локально.
+const SecretLinkPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex, nofollow">
+<title>GophKeeper - Shared secret</title>
+<style>
+body { font-family: sans-serif; max-width: 40rem; margin: 3rem auto; padding: 0 1rem; }
+pre { background: #f4f4f4; padding: 1rem; white-space: pre-wrap; word-break: break-all; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>Shared secret</h1>
+<p id="notice">This secret can be viewed a limited number of times and will be destroyed afterwards.</p>
+<button id="reveal">Reveal secret</button>
+<pre id="secret" hidden></pre>
+<p id="status"></p>
+<script>
+(function () {
+  var statusEl = document.getElementById("status");
+  var button = document.getElementById("reveal");
+
+  function fail(message) {
+    statusEl.textContent = message;
+    statusEl.className = "error";
+    button.hidden = true;
+  }
+
+  function decodeBase64(value) {
+    value = value.replace(/-/g, "+").replace(/_/g, "/");
+    while (value.length % 4) {
+      value += "=";
+    }
+    var binary = atob(value);
+    var bytes = new Uint8Array(binary.length);
+    for (var i = 0; i < binary.length; i++) {
+      bytes[i] = binary.charCodeAt(i);
+    }
+    return bytes;
+  }
+
+  var fragment = window.location.hash.slice(1);
+  if (!fragment || !window.crypto || !window.crypto.subtle) {
+    fail("This link is incomplete or your browser cannot decrypt it.");
+    return;
+  }
+
+  button.addEventListener("click", function () {
+    button.disabled = true;
+    fetch(window.location.pathname, { method: "POST", cache: "no-store" })
+      .then(function (response) {
+        if (!response.ok) {
+          throw new Error("This secret has expired or has already been viewed.");
+        }
+        return response.json();
+      })
+      .then(function (payload) {
+        var data = decodeBase64(payload.encrypted_data);
+        return window.crypto.subtle.importKey("raw", decodeBase64(fragment), "AES-GCM", false, ["decrypt"])
+          .then(function (key) {
+            return window.crypto.subtle.decrypt({ name: "AES-GCM", iv: data.slice(0, 12) }, key, data.slice(12));
+          })
+          .then(function (plaintext) {
+            var secret = document.getElementById("secret");
+            secret.textContent = new TextDecoder().decode(plaintext);
+            secret.hidden = false;
+            button.hidden = true;
+            statusEl.textContent = payload.views_left > 0
+              ? "Views left: " + payload.views_left
+              : "This secret has now been destroyed.";
+          }, function () {
+            throw new Error("The secret could not be decrypted. Check that the link is complete.");
+          });
+      })
+      .catch(function (err) {
+        fail(err.message);
+      });
+  });
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,156 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startTLSServer запускает TLS сервер, завершающий рукопожатие и закрывающий соединение.
+func startTLSServer(t *testing.T, certFile, keyFile string) string {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// handshake подключается к серверу с конфигурацией клиента.
+func handshake(address string, cfg *tls.Config) error {
+	conn, err := tls.Dial("tcp", address, cfg)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// certSPKIHash возвращает пин сертификата из PEM файла.
+func certSPKIHash(t *testing.T, certFile string) string {
+	data, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	block, _ := pem.Decode(data)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return SPKIHash(cert)
+}
+
+func TestServerNameFromAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"localhost:8081", "localhost"},
+		{"keeper.example.com:443", "keeper.example.com"},
+		{":8081", "localhost"},
+		{"dns:///keeper.example.com:8081", "keeper.example.com"},
+		{"[::1]:8081", "::1"},
+		{"keeper.example.com", "keeper.example.com"},
+	}
+
+	for _, tt := range tests {
+		got, err := ServerNameFromAddress(tt.address)
+		require.NoError(t, err, tt.address)
+		require.Equal(t, tt.want, got, tt.address)
+	}
+}
+
+func TestClientTLSConfigVerifiesServer(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	writeSelfSignedCert(t, certFile, keyFile, "server")
+	address := startTLSServer(t, certFile, keyFile)
+	_, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+
+	// Имя выводится из адреса: сертификат выдан на localhost
+	cfg, err := NewClientTLSConfig(ClientOptions{Address: "localhost:" + port, CAFile: certFile})
+	require.NoError(t, err)
+	require.Equal(t, "localhost", cfg.ServerName)
+	require.NoError(t, handshake(address, cfg))
+
+	// Имя из адреса не совпадает с сертификатом
+	cfg, err = NewClientTLSConfig(ClientOptions{Address: address, CAFile: certFile})
+	require.NoError(t, err)
+	err = handshake(address, cfg)
+	require.ErrorContains(t, err, `server certificate is not valid for "127.0.0.1"`)
+
+	// Сертификат не подписан доверенным CA
+	otherCA := filepath.Join(dir, "other.crt")
+	writeSelfSignedCert(t, otherCA, filepath.Join(dir, "other.key"), "other")
+	cfg, err = NewClientTLSConfig(ClientOptions{Address: address, ServerName: "localhost", CAFile: otherCA})
+	require.NoError(t, err)
+	err = handshake(address, cfg)
+	require.ErrorContains(t, err, "is not signed by the CA from")
+
+	_, err = NewClientTLSConfig(ClientOptions{Address: address, CAFile: keyFile})
+	require.ErrorContains(t, err, "contains no PEM certificates")
+}
+
+func TestClientTLSConfigPinning(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	writeSelfSignedCert(t, certFile, keyFile, "server")
+	address := startTLSServer(t, certFile, keyFile)
+
+	pin := certSPKIHash(t, certFile)
+	cfg, err := NewClientTLSConfig(ClientOptions{
+		Address:    address,
+		ServerName: "localhost",
+		CAFile:     certFile,
+		PinnedSPKI: []string{"sha256/" + pin},
+	})
+	require.NoError(t, err)
+	require.NoError(t, handshake(address, cfg))
+
+	otherCert := filepath.Join(dir, "other.crt")
+	writeSelfSignedCert(t, otherCert, filepath.Join(dir, "other.key"), "other")
+	cfg, err = NewClientTLSConfig(ClientOptions{
+		Address:    address,
+		ServerName: "localhost",
+		CAFile:     certFile,
+		PinnedSPKI: []string{certSPKIHash(t, otherCert)},
+	})
+	require.NoError(t, err)
+	err = handshake(address, cfg)
+	require.ErrorContains(t, err, ErrPinMismatch.Error())
+	require.ErrorContains(t, err, pin)
+
+	_, err = NewClientTLSConfig(ClientOptions{Address: address, PinnedSPKI: []string{"not-a-pin"}})
+	require.ErrorContains(t, err, "invalid SPKI pin")
+}
+
+func TestClientTLSConfigClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	writeSelfSignedCert(t, certFile, keyFile, "alice")
+
+	cfg, err := NewClientTLSConfig(ClientOptions{Address: "localhost:8081", CAFile: certFile, CertFile: certFile, KeyFile: keyFile})
+	require.NoError(t, err)
+	require.Len(t, cfg.Certificates, 1)
+
+	_, err = NewClientTLSConfig(ClientOptions{Address: "localhost:8081", CAFile: certFile, CertFile: certFile})
+	require.Error(t, err)
+}
@@ -0,0 +1,183 @@
+// Package tlsutil настраивает TLS для серверов GophKeeper с перезагрузкой сертификатов.
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultReloadInterval период проверки изменения файлов сертификатов.
+const DefaultReloadInterval = 10 * time.Second
+
+// Options описывает файлы сертификатов сервера.
+type Options struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile включает проверку клиентских сертификатов (mTLS)
+	ClientCAFile string
+	// RequireClientCert запрещает подключения без клиентского сертификата
+	RequireClientCert bool
+}
+
+// Reloader хранит сертификат сервера и пул доверенных CA клиентов
+// и перечитывает их при изменении файлов без перезапуска сервера.
+type Reloader struct {
+	opts   Options
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+// NewReloader загружает сертификаты и создает Reloader.
+func NewReloader(opts Options, logger *zap.Logger) (*Reloader, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.New("certificate and key files are required")
+	}
+	if opts.RequireClientCert && opts.ClientCAFile == "" {
+		return nil, errors.New("client CA file is required when client certificates are required")
+	}
+
+	r := &Reloader{
+		opts:   opts,
+		logger: logger,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// load читает сертификат, ключ и CA клиентов с диска.
+func (r *Reloader) load() error {
+	modTimes, err := r.currentModTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.opts.ClientCAFile != "" {
+		pemData, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pemData) {
+			return errors.New("client CA file contains no certificates")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// currentModTimes возвращает время изменения всех отслеживаемых файлов.
+func (r *Reloader) currentModTimes() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+	for _, path := range []string{r.opts.CertFile, r.opts.KeyFile, r.opts.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// changed проверяет, изменился ли какой-либо из файлов с момента загрузки.
+func (r *Reloader) changed() bool {
+	modTimes, err := r.currentModTimes()
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for path, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload перечитывает файлы, если они изменились. При ошибке продолжает
+// использоваться предыдущий сертификат.
+func (r *Reloader) Reload() error {
+	if !r.changed() {
+		return nil
+	}
+	if err := r.load(); err != nil {
+		return err
+	}
+
+	r.logger.Info("TLS certificates reloaded", zap.String("cert_file", r.opts.CertFile))
+	return nil
+}
+
+// Watch периодически проверяет файлы сертификатов до отмены контекста.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(); err != nil {
+				r.logger.Error("Failed to reload TLS certificates", zap.Error(err))
+			}
+		}
+	}
+}
+
+// TLSConfig возвращает конфигурацию TLS, которая при каждом подключении
+// использует актуальные сертификат и пул CA клиентов.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+			if r.clientCAs != nil {
+				cfg.ClientCAs = r.clientCAs
+				cfg.ClientAuth = tls.VerifyClientCertIfGiven
+				if r.opts.RequireClientCert {
+					cfg.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+			return cfg, nil
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeSelfSignedCert записывает самоподписанный сертификат и ключ в файлы.
+func writeSelfSignedCert(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		DNSNames:              []string{"localhost"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
+
+// servedCommonName возвращает CN сертификата, который сервер отдаст клиенту.
+func servedCommonName(t *testing.T, cfg *tls.Config) string {
+	serverCfg, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(serverCfg.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestReloaderReloadsChangedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	writeSelfSignedCert(t, certFile, keyFile, "first")
+
+	reloader, err := NewReloader(Options{CertFile: certFile, KeyFile: keyFile}, zap.NewNop())
+	require.NoError(t, err)
+	cfg := reloader.TLSConfig()
+	require.Equal(t, "first", servedCommonName(t, cfg))
+
+	// Файлы не менялись - сертификат остается прежним
+	require.NoError(t, reloader.Reload())
+	require.Equal(t, "first", servedCommonName(t, cfg))
+
+	writeSelfSignedCert(t, certFile, keyFile, "second")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	require.NoError(t, os.Chtimes(keyFile, future, future))
+
+	require.NoError(t, reloader.Reload())
+	require.Equal(t, "second", servedCommonName(t, cfg))
+
+	// Поврежденный файл не заменяет рабочий сертификат
+	require.NoError(t, os.WriteFile(certFile, []byte("broken"), 0o600))
+	later := future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.Error(t, reloader.Reload())
+	require.Equal(t, "second", servedCommonName(t, cfg))
+}
+
+func TestReloaderClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	writeSelfSignedCert(t, certFile, keyFile, "server")
+	writeSelfSignedCert(t, caFile, filepath.Join(dir, "ca.key"), "ca")
+
+	_, err := NewReloader(Options{CertFile: certFile, KeyFile: keyFile, RequireClientCert: true}, zap.NewNop())
+	require.Error(t, err)
+
+	reloader, err := NewReloader(Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}, zap.NewNop())
+	require.NoError(t, err)
+	serverCfg, err := reloader.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Equal(t, tls.VerifyClientCertIfGiven, serverCfg.ClientAuth)
+	require.NotNil(t, serverCfg.ClientCAs)
+
+	reloader, err = NewReloader(Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true}, zap.NewNop())
+	require.NoError(t, err)
+	serverCfg, err = reloader.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, serverCfg.ClientAuth)
+}
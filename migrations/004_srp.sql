@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Соль и верификатор SRP-6a для входа без передачи пароля на сервер.
+-- У аккаунтов с SRP password_hash пуст и вход по паролю невозможен.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS srp_salt BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS srp_verifier BYTEA;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users DROP COLUMN IF EXISTS srp_verifier;
+ALTER TABLE users DROP COLUMN IF EXISTS srp_salt;
+
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Открытый ключ X25519 для получения общих записей и закрытый ключ,
+-- зашифрованный ключом хранилища на стороне клиента
+ALTER TABLE users ADD COLUMN IF NOT EXISTS public_key BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS wrapped_private_key BYTEA;
+
+-- Создание таблицы доступа к записям других пользователей.
+-- Ключ записи хранится зашифрованным открытым ключом получателя.
+CREATE TABLE IF NOT EXISTS entry_shares (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_id UUID NOT NULL REFERENCES data_entries(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    permission VARCHAR(10) NOT NULL CHECK (permission IN ('read', 'write')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT unique_entry_recipient UNIQUE(entry_id, recipient_id),
+    CONSTRAINT share_not_self CHECK (owner_id <> recipient_id)
+);
+
+-- Создание индекса для синхронизации записей получателя
+CREATE INDEX IF NOT EXISTS idx_entry_shares_recipient_id ON entry_shares(recipient_id);
+
+-- Отзыв доступа создает запись об удалении для получателя, поэтому одна
+-- запись может быть удалена у нескольких пользователей
+ALTER TABLE deleted_entries DROP CONSTRAINT IF EXISTS deleted_entries_pkey;
+ALTER TABLE deleted_entries ADD PRIMARY KEY (id, user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM deleted_entries a USING deleted_entries b
+    WHERE a.id = b.id AND a.deleted_at < b.deleted_at;
+ALTER TABLE deleted_entries DROP CONSTRAINT IF EXISTS deleted_entries_pkey;
+ALTER TABLE deleted_entries ADD PRIMARY KEY (id);
+
+DROP TABLE IF EXISTS entry_shares;
+ALTER TABLE users DROP COLUMN IF EXISTS wrapped_private_key;
+ALTER TABLE users DROP COLUMN IF EXISTS public_key;
+
+-- +goose StatementEnd
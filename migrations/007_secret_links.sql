@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Создание таблицы одноразовых ссылок на секреты. Секрет зашифрован ключом,
+-- который передается только во фрагменте ссылки и на сервер не попадает
+CREATE TABLE IF NOT EXISTS secret_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    encrypted_data BYTEA NOT NULL,
+    max_views INTEGER NOT NULL DEFAULT 1 CHECK (max_views > 0),
+    views INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_links_expires_at ON secret_links(expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS secret_links;
+
+-- +goose StatementEnd
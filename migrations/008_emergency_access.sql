@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Создание таблицы экстренного доступа. Ключ хранилища владельца зашифрован
+-- открытым ключом контакта и выдается только после одобрения или истечения ожидания
+CREATE TABLE IF NOT EXISTS emergency_access (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    grantor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wait_days INTEGER NOT NULL CHECK (wait_days BETWEEN 1 AND 90),
+    wrapped_key BYTEA NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'idle' CHECK (status IN ('idle', 'requested', 'approved')),
+    requested_at TIMESTAMP WITH TIME ZONE,
+    approved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT unique_emergency_contact UNIQUE(grantor_id, grantee_id),
+    CONSTRAINT emergency_contact_not_self CHECK (grantor_id <> grantee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_access_grantee_id ON emergency_access(grantee_id);
+CREATE INDEX IF NOT EXISTS idx_emergency_access_requested ON emergency_access(requested_at) WHERE status = 'requested';
+
+-- Журнал экстренного доступа. Сохраняется после удаления доступа,
+-- поэтому не ссылается на emergency_access
+CREATE TABLE IF NOT EXISTS emergency_access_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    emergency_access_id UUID NOT NULL,
+    grantor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grantee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    event VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_access_events_access_id ON emergency_access_events(emergency_access_id, created_at);
+
+-- Создание таблицы уведомлений пользователей
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at);
+
+CREATE TRIGGER update_emergency_access_updated_at
+    BEFORE UPDATE ON emergency_access
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS update_emergency_access_updated_at ON emergency_access;
+
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS emergency_access_events;
+DROP TABLE IF EXISTS emergency_access;
+
+-- +goose StatementEnd
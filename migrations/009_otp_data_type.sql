@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Добавление типа записей с секретами одноразовых паролей (TOTP)
+ALTER TABLE data_entries DROP CONSTRAINT IF EXISTS data_entries_type_check;
+ALTER TABLE data_entries ADD CONSTRAINT data_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card', 'otp'));
+
+ALTER TABLE collection_entries DROP CONSTRAINT IF EXISTS collection_entries_type_check;
+ALTER TABLE collection_entries ADD CONSTRAINT collection_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card', 'otp'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Ограничение восстанавливается без проверки существующих строк,
+-- чтобы откат не требовал удаления сохраненных секретов
+ALTER TABLE collection_entries DROP CONSTRAINT IF EXISTS collection_entries_type_check;
+ALTER TABLE collection_entries ADD CONSTRAINT collection_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card')) NOT VALID;
+
+ALTER TABLE data_entries DROP CONSTRAINT IF EXISTS data_entries_type_check;
+ALTER TABLE data_entries ADD CONSTRAINT data_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card')) NOT VALID;
+
+-- +goose StatementEnd
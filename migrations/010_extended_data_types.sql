@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Добавление типов записей: ключи SSH и API, документы, сети Wi-Fi и защищенные заметки
+ALTER TABLE data_entries DROP CONSTRAINT IF EXISTS data_entries_type_check;
+ALTER TABLE data_entries ADD CONSTRAINT data_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card', 'otp',
+                    'ssh_key', 'api_key', 'identity', 'wifi', 'secure_note'));
+
+ALTER TABLE collection_entries DROP CONSTRAINT IF EXISTS collection_entries_type_check;
+ALTER TABLE collection_entries ADD CONSTRAINT collection_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card', 'otp',
+                    'ssh_key', 'api_key', 'identity', 'wifi', 'secure_note'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Ограничение восстанавливается без проверки существующих строк,
+-- чтобы откат не требовал удаления сохраненных записей
+ALTER TABLE collection_entries DROP CONSTRAINT IF EXISTS collection_entries_type_check;
+ALTER TABLE collection_entries ADD CONSTRAINT collection_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card', 'otp')) NOT VALID;
+
+ALTER TABLE data_entries DROP CONSTRAINT IF EXISTS data_entries_type_check;
+ALTER TABLE data_entries ADD CONSTRAINT data_entries_type_check
+    CHECK (type IN ('credentials', 'text', 'binary', 'card', 'otp')) NOT VALID;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Создание таблицы вложений записей. Каждое вложение зашифровано собственным
+-- ключом, который клиент шифрует ключом хранилища или ключом общей записи:
+-- сервер не видит ни ключ, ни содержимое. mime_type заполняют только клиенты
+-- API, которые сами решили раскрыть тип файла
+CREATE TABLE IF NOT EXISTS entry_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_id UUID NOT NULL REFERENCES data_entries(id) ON DELETE CASCADE,
+    uploaded_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(255),
+    size BIGINT NOT NULL CHECK (size > 0),
+    wrapped_key BYTEA NOT NULL,
+    encrypted_data BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_entry_attachments_entry_id ON entry_attachments(entry_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS entry_attachments;
+
+-- +goose StatementEnd
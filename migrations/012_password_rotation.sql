@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Время последней смены пароля и период его смены в днях. Сервер не видит
+-- содержимое записей, о смене пароля сообщает клиент при обновлении.
+-- Для существующих записей время смены неизвестно, берется время изменения.
+-- Триггеры updated_at на время заполнения отключаются, иначе заполнение
+-- сдвинуло бы время изменения всех записей и сбило синхронизацию клиентов
+ALTER TABLE data_entries
+    ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS rotation_days INTEGER CHECK (rotation_days > 0);
+ALTER TABLE data_entries DISABLE TRIGGER update_data_entries_updated_at;
+UPDATE data_entries SET password_changed_at = COALESCE(updated_at, created_at, NOW());
+ALTER TABLE data_entries ENABLE TRIGGER update_data_entries_updated_at;
+ALTER TABLE data_entries ALTER COLUMN password_changed_at SET DEFAULT NOW();
+ALTER TABLE data_entries ALTER COLUMN password_changed_at SET NOT NULL;
+
+ALTER TABLE collection_entries
+    ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS rotation_days INTEGER CHECK (rotation_days > 0);
+ALTER TABLE collection_entries DISABLE TRIGGER update_collection_entries_updated_at;
+UPDATE collection_entries SET password_changed_at = COALESCE(updated_at, created_at, NOW());
+ALTER TABLE collection_entries ENABLE TRIGGER update_collection_entries_updated_at;
+ALTER TABLE collection_entries ALTER COLUMN password_changed_at SET DEFAULT NOW();
+ALTER TABLE collection_entries ALTER COLUMN password_changed_at SET NOT NULL;
+
+-- Политика смены паролей коллекции действует для записей без собственной политики
+ALTER TABLE collections
+    ADD COLUMN IF NOT EXISTS rotation_days INTEGER CHECK (rotation_days > 0);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE collections DROP COLUMN IF EXISTS rotation_days;
+
+ALTER TABLE collection_entries
+    DROP COLUMN IF EXISTS rotation_days,
+    DROP COLUMN IF EXISTS password_changed_at;
+
+ALTER TABLE data_entries
+    DROP COLUMN IF EXISTS rotation_days,
+    DROP COLUMN IF EXISTS password_changed_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Токены доступа, привязанные к клиентскому сертификату mTLS. Такой токен
+-- не имеет значения для заголовка Authorization: его предъявляют сертификатом,
+-- отпечаток которого (SHA-256 от DER) сохраняется вместо хеша значения
+ALTER TABLE access_tokens ALTER COLUMN token_hash DROP NOT NULL;
+ALTER TABLE access_tokens
+    ADD COLUMN IF NOT EXISTS certificate_fingerprint BYTEA UNIQUE;
+ALTER TABLE access_tokens
+    ADD CONSTRAINT access_tokens_credential_check
+    CHECK (token_hash IS NOT NULL OR certificate_fingerprint IS NOT NULL);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM access_tokens WHERE token_hash IS NULL;
+ALTER TABLE access_tokens DROP CONSTRAINT IF EXISTS access_tokens_credential_check;
+ALTER TABLE access_tokens DROP COLUMN IF EXISTS certificate_fingerprint;
+ALTER TABLE access_tokens ALTER COLUMN token_hash SET NOT NULL;
+
+-- +goose StatementEnd
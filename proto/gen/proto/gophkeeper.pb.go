@@ -31,16 +31,28 @@ const (
 	DataType_DATA_TYPE_TEXT        DataType = 2
 	DataType_DATA_TYPE_BINARY      DataType = 3
 	DataType_DATA_TYPE_CARD        DataType = 4
+	DataType_DATA_TYPE_OTP         DataType = 5
+	DataType_DATA_TYPE_SSH_KEY     DataType = 6
+	DataType_DATA_TYPE_API_KEY     DataType = 7
+	DataType_DATA_TYPE_IDENTITY    DataType = 8
+	DataType_DATA_TYPE_WIFI        DataType = 9
+	DataType_DATA_TYPE_SECURE_NOTE DataType = 10
 )
 
 // Enum value maps for DataType.
 var (
 	DataType_name = map[int32]string{
-		0: "DATA_TYPE_UNSPECIFIED",
-		1: "DATA_TYPE_CREDENTIALS",
-		2: "DATA_TYPE_TEXT",
-		3: "DATA_TYPE_BINARY",
-		4: "DATA_TYPE_CARD",
+		0:  "DATA_TYPE_UNSPECIFIED",
+		1:  "DATA_TYPE_CREDENTIALS",
+		2:  "DATA_TYPE_TEXT",
+		3:  "DATA_TYPE_BINARY",
+		4:  "DATA_TYPE_CARD",
+		5:  "DATA_TYPE_OTP",
+		6:  "DATA_TYPE_SSH_KEY",
+		7:  "DATA_TYPE_API_KEY",
+		8:  "DATA_TYPE_IDENTITY",
+		9:  "DATA_TYPE_WIFI",
+		10: "DATA_TYPE_SECURE_NOTE",
 	}
 	DataType_value = map[string]int32{
 		"DATA_TYPE_UNSPECIFIED": 0,
@@ -48,6 +60,12 @@ var (
 		"DATA_TYPE_TEXT":        2,
 		"DATA_TYPE_BINARY":      3,
 		"DATA_TYPE_CARD":        4,
+		"DATA_TYPE_OTP":         5,
+		"DATA_TYPE_SSH_KEY":     6,
+		"DATA_TYPE_API_KEY":     7,
+		"DATA_TYPE_IDENTITY":    8,
+		"DATA_TYPE_WIFI":        9,
+		"DATA_TYPE_SECURE_NOTE": 10,
 	}
 )
 
@@ -78,11 +96,174 @@ func (DataType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{0}
 }
 
+// Права получателя общей записи
+type SharePermission int32
+
+const (
+	SharePermission_SHARE_PERMISSION_UNSPECIFIED SharePermission = 0
+	SharePermission_SHARE_PERMISSION_READ        SharePermission = 1
+	SharePermission_SHARE_PERMISSION_WRITE       SharePermission = 2
+)
+
+// Enum value maps for SharePermission.
+var (
+	SharePermission_name = map[int32]string{
+		0: "SHARE_PERMISSION_UNSPECIFIED",
+		1: "SHARE_PERMISSION_READ",
+		2: "SHARE_PERMISSION_WRITE",
+	}
+	SharePermission_value = map[string]int32{
+		"SHARE_PERMISSION_UNSPECIFIED": 0,
+		"SHARE_PERMISSION_READ":        1,
+		"SHARE_PERMISSION_WRITE":       2,
+	}
+)
+
+func (x SharePermission) Enum() *SharePermission {
+	p := new(SharePermission)
+	*p = x
+	return p
+}
+
+func (x SharePermission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SharePermission) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_gophkeeper_proto_enumTypes[1].Descriptor()
+}
+
+func (SharePermission) Type() protoreflect.EnumType {
+	return &file_proto_gophkeeper_proto_enumTypes[1]
+}
+
+func (x SharePermission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SharePermission.Descriptor instead.
+func (SharePermission) EnumDescriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{1}
+}
+
+// Роль участника организации
+type EmergencyAccessStatus int32
+
+const (
+	EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_UNSPECIFIED EmergencyAccessStatus = 0
+	EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_IDLE        EmergencyAccessStatus = 1
+	EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_REQUESTED   EmergencyAccessStatus = 2
+	EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_APPROVED    EmergencyAccessStatus = 3
+)
+
+// Enum value maps for EmergencyAccessStatus.
+var (
+	EmergencyAccessStatus_name = map[int32]string{
+		0: "EMERGENCY_ACCESS_STATUS_UNSPECIFIED",
+		1: "EMERGENCY_ACCESS_STATUS_IDLE",
+		2: "EMERGENCY_ACCESS_STATUS_REQUESTED",
+		3: "EMERGENCY_ACCESS_STATUS_APPROVED",
+	}
+	EmergencyAccessStatus_value = map[string]int32{
+		"EMERGENCY_ACCESS_STATUS_UNSPECIFIED": 0,
+		"EMERGENCY_ACCESS_STATUS_IDLE":        1,
+		"EMERGENCY_ACCESS_STATUS_REQUESTED":   2,
+		"EMERGENCY_ACCESS_STATUS_APPROVED":    3,
+	}
+)
+
+func (x EmergencyAccessStatus) Enum() *EmergencyAccessStatus {
+	p := new(EmergencyAccessStatus)
+	*p = x
+	return p
+}
+
+func (x EmergencyAccessStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmergencyAccessStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_gophkeeper_proto_enumTypes[2].Descriptor()
+}
+
+func (EmergencyAccessStatus) Type() protoreflect.EnumType {
+	return &file_proto_gophkeeper_proto_enumTypes[2]
+}
+
+func (x EmergencyAccessStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmergencyAccessStatus.Descriptor instead.
+func (EmergencyAccessStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{2}
+}
+
+type OrganizationRole int32
+
+const (
+	OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED OrganizationRole = 0
+	OrganizationRole_ORGANIZATION_ROLE_OWNER       OrganizationRole = 1
+	OrganizationRole_ORGANIZATION_ROLE_ADMIN       OrganizationRole = 2
+	OrganizationRole_ORGANIZATION_ROLE_EDITOR      OrganizationRole = 3
+	OrganizationRole_ORGANIZATION_ROLE_VIEWER      OrganizationRole = 4
+)
+
+// Enum value maps for OrganizationRole.
+var (
+	OrganizationRole_name = map[int32]string{
+		0: "ORGANIZATION_ROLE_UNSPECIFIED",
+		1: "ORGANIZATION_ROLE_OWNER",
+		2: "ORGANIZATION_ROLE_ADMIN",
+		3: "ORGANIZATION_ROLE_EDITOR",
+		4: "ORGANIZATION_ROLE_VIEWER",
+	}
+	OrganizationRole_value = map[string]int32{
+		"ORGANIZATION_ROLE_UNSPECIFIED": 0,
+		"ORGANIZATION_ROLE_OWNER":       1,
+		"ORGANIZATION_ROLE_ADMIN":       2,
+		"ORGANIZATION_ROLE_EDITOR":      3,
+		"ORGANIZATION_ROLE_VIEWER":      4,
+	}
+)
+
+func (x OrganizationRole) Enum() *OrganizationRole {
+	p := new(OrganizationRole)
+	*p = x
+	return p
+}
+
+func (x OrganizationRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrganizationRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_gophkeeper_proto_enumTypes[3].Descriptor()
+}
+
+func (OrganizationRole) Type() protoreflect.EnumType {
+	return &file_proto_gophkeeper_proto_enumTypes[3]
+}
+
+func (x OrganizationRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrganizationRole.Descriptor instead.
+func (OrganizationRole) EnumDescriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{3}
+}
+
 // Запрос регистрации
 type RegisterRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Пароль (не передается при регистрации с верификатором SRP)
+	Password        string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	WrappedVaultKey []byte `protobuf:"bytes,3,opt,name=wrapped_vault_key,json=wrappedVaultKey,proto3" json:"wrapped_vault_key,omitempty"`
+	// Соль и верификатор SRP-6a, вычисленные на клиенте
+	SrpSalt       []byte `protobuf:"bytes,4,opt,name=srp_salt,json=srpSalt,proto3" json:"srp_salt,omitempty"`
+	SrpVerifier   []byte `protobuf:"bytes,5,opt,name=srp_verifier,json=srpVerifier,proto3" json:"srp_verifier,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -131,6 +312,27 @@ func (x *RegisterRequest) GetPassword() string {
 	return ""
 }
 
+func (x *RegisterRequest) GetWrappedVaultKey() []byte {
+	if x != nil {
+		return x.WrappedVaultKey
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetSrpSalt() []byte {
+	if x != nil {
+		return x.SrpSalt
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetSrpVerifier() []byte {
+	if x != nil {
+		return x.SrpVerifier
+	}
+	return nil
+}
+
 // Запрос аутентификации
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -184,28 +386,30 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
-// Запрос обновления токена
-type RefreshTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Запрос начала входа по SRP-6a
+type BeginSRPLoginRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Эфемерный открытый ключ клиента A
+	ClientPublicKey []byte `protobuf:"bytes,2,opt,name=client_public_key,json=clientPublicKey,proto3" json:"client_public_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
+func (x *BeginSRPLoginRequest) Reset() {
+	*x = BeginSRPLoginRequest{}
 	mi := &file_proto_gophkeeper_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *BeginSRPLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*BeginSRPLoginRequest) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *BeginSRPLoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_gophkeeper_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -217,42 +421,49 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BeginSRPLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginSRPLoginRequest) Descriptor() ([]byte, []int) {
 	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *RefreshTokenRequest) GetToken() string {
+func (x *BeginSRPLoginRequest) GetUsername() string {
 	if x != nil {
-		return x.Token
+		return x.Username
 	}
 	return ""
 }
 
-// Ответ аутентификации
-type AuthResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+func (x *BeginSRPLoginRequest) GetClientPublicKey() []byte {
+	if x != nil {
+		return x.ClientPublicKey
+	}
+	return nil
+}
+
+// Запрос завершения входа по SRP-6a
+type FinishSRPLoginRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	LoginId string                 `protobuf:"bytes,1,opt,name=login_id,json=loginId,proto3" json:"login_id,omitempty"`
+	// Доказательство клиента M1
+	ClientProof   []byte `protobuf:"bytes,2,opt,name=client_proof,json=clientProof,proto3" json:"client_proof,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuthResponse) Reset() {
-	*x = AuthResponse{}
+func (x *FinishSRPLoginRequest) Reset() {
+	*x = FinishSRPLoginRequest{}
 	mi := &file_proto_gophkeeper_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuthResponse) String() string {
+func (x *FinishSRPLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuthResponse) ProtoMessage() {}
+func (*FinishSRPLoginRequest) ProtoMessage() {}
 
-func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+func (x *FinishSRPLoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_gophkeeper_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -264,57 +475,47 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
-func (*AuthResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FinishSRPLoginRequest.ProtoReflect.Descriptor instead.
+func (*FinishSRPLoginRequest) Descriptor() ([]byte, []int) {
 	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *AuthResponse) GetToken() string {
+func (x *FinishSRPLoginRequest) GetLoginId() string {
 	if x != nil {
-		return x.Token
+		return x.LoginId
 	}
 	return ""
 }
 
-func (x *AuthResponse) GetExpiresAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiresAt
-	}
-	return nil
-}
-
-func (x *AuthResponse) GetUser() *User {
+func (x *FinishSRPLoginRequest) GetClientProof() []byte {
 	if x != nil {
-		return x.User
+		return x.ClientProof
 	}
 	return nil
 }
 
-// Пользователь
-type User struct {
+// Запрос обновления токена
+type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
 	mi := &file_proto_gophkeeper_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *RefreshTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*RefreshTokenRequest) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_gophkeeper_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -326,66 +527,98 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
 	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *User) GetId() string {
+func (x *RefreshTokenRequest) GetToken() string {
 	if x != nil {
-		return x.Id
+		return x.Token
 	}
 	return ""
 }
 
-func (x *User) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+// Запрос сохранения ключа хранилища
+type SetVaultKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ключ хранилища, зашифрованный на клиенте ключом из пароля
+	WrappedVaultKey []byte `protobuf:"bytes,1,opt,name=wrapped_vault_key,json=wrappedVaultKey,proto3" json:"wrapped_vault_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SetVaultKeyRequest) Reset() {
+	*x = SetVaultKeyRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVaultKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVaultKeyRequest) ProtoMessage() {}
+
+func (x *SetVaultKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[5]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+// Deprecated: Use SetVaultKeyRequest.ProtoReflect.Descriptor instead.
+func (*SetVaultKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetVaultKeyRequest) GetWrappedVaultKey() []byte {
 	if x != nil {
-		return x.UpdatedAt
+		return x.WrappedVaultKey
 	}
 	return nil
 }
 
-// Запрос создания данных
-type CreateDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          DataType               `protobuf:"varint,1,opt,name=type,proto3,enum=gophkeeper.DataType" json:"type,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	EncryptedData []byte                 `protobuf:"bytes,4,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
-	Metadata      string                 `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateDataRequest) Reset() {
-	*x = CreateDataRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[5]
+// Запрос смены пароля
+type ChangePasswordRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OldPassword string                 `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	// Ключ хранилища, перешифрованный новым мастер-паролем (необязательно)
+	WrappedVaultKey []byte `protobuf:"bytes,3,opt,name=wrapped_vault_key,json=wrappedVaultKey,proto3" json:"wrapped_vault_key,omitempty"`
+	// Новые соль и верификатор SRP-6a. Для аккаунтов с SRP заменяют пароли,
+	// для аккаунтов с паролем переводят их на вход по SRP.
+	SrpSalt     []byte `protobuf:"bytes,4,opt,name=srp_salt,json=srpSalt,proto3" json:"srp_salt,omitempty"`
+	SrpVerifier []byte `protobuf:"bytes,5,opt,name=srp_verifier,json=srpVerifier,proto3" json:"srp_verifier,omitempty"`
+	// Свежее доказательство SRP текущего пароля, как в DeleteAccountRequest;
+	// обязательно для аккаунтов с SRP
+	SrpLoginId     string `protobuf:"bytes,6,opt,name=srp_login_id,json=srpLoginId,proto3" json:"srp_login_id,omitempty"`
+	SrpClientProof []byte `protobuf:"bytes,7,opt,name=srp_client_proof,json=srpClientProof,proto3" json:"srp_client_proof,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateDataRequest) String() string {
+func (x *ChangePasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateDataRequest) ProtoMessage() {}
+func (*ChangePasswordRequest) ProtoMessage() {}
 
-func (x *CreateDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[5]
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,69 +629,5369 @@ func (x *CreateDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateDataRequest.ProtoReflect.Descriptor instead.
-func (*CreateDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateDataRequest) GetType() DataType {
+func (x *ChangePasswordRequest) GetOldPassword() string {
 	if x != nil {
-		return x.Type
+		return x.OldPassword
 	}
-	return DataType_DATA_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *CreateDataRequest) GetName() string {
+func (x *ChangePasswordRequest) GetNewPassword() string {
 	if x != nil {
-		return x.Name
+		return x.NewPassword
 	}
 	return ""
 }
 
-func (x *CreateDataRequest) GetDescription() string {
+func (x *ChangePasswordRequest) GetWrappedVaultKey() []byte {
 	if x != nil {
-		return x.Description
+		return x.WrappedVaultKey
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateDataRequest) GetEncryptedData() []byte {
+func (x *ChangePasswordRequest) GetSrpSalt() []byte {
 	if x != nil {
-		return x.EncryptedData
+		return x.SrpSalt
 	}
 	return nil
 }
 
-func (x *CreateDataRequest) GetMetadata() string {
+func (x *ChangePasswordRequest) GetSrpVerifier() []byte {
 	if x != nil {
-		return x.Metadata
+		return x.SrpVerifier
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetSrpLoginId() string {
+	if x != nil {
+		return x.SrpLoginId
 	}
 	return ""
 }
 
-// Запрос получения данных
-type GetDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ChangePasswordRequest) GetSrpClientProof() []byte {
+	if x != nil {
+		return x.SrpClientProof
+	}
+	return nil
 }
 
-func (x *GetDataRequest) Reset() {
-	*x = GetDataRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[6]
+// Запрос удаления аккаунта
+type DeleteAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пароль аккаунтов без SRP
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	// Свежее доказательство SRP: рукопожатие начинается BeginSRPLogin,
+	// доказательство клиента передается вместо FinishSRPLogin
+	SrpLoginId     string `protobuf:"bytes,2,opt,name=srp_login_id,json=srpLoginId,proto3" json:"srp_login_id,omitempty"`
+	SrpClientProof []byte `protobuf:"bytes,3,opt,name=srp_client_proof,json=srpClientProof,proto3" json:"srp_client_proof,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDataRequest) String() string {
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) GetSrpLoginId() string {
+	if x != nil {
+		return x.SrpLoginId
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) GetSrpClientProof() []byte {
+	if x != nil {
+		return x.SrpClientProof
+	}
+	return nil
+}
+
+// Запрос экспорта аккаунта
+type ExportAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пароль аккаунтов без SRP
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	// Свежее доказательство SRP, как в DeleteAccountRequest
+	SrpLoginId     string `protobuf:"bytes,2,opt,name=srp_login_id,json=srpLoginId,proto3" json:"srp_login_id,omitempty"`
+	SrpClientProof []byte `protobuf:"bytes,3,opt,name=srp_client_proof,json=srpClientProof,proto3" json:"srp_client_proof,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportAccountRequest) Reset() {
+	*x = ExportAccountRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAccountRequest) ProtoMessage() {}
+
+func (x *ExportAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAccountRequest.ProtoReflect.Descriptor instead.
+func (*ExportAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExportAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ExportAccountRequest) GetSrpLoginId() string {
+	if x != nil {
+		return x.SrpLoginId
+	}
+	return ""
+}
+
+func (x *ExportAccountRequest) GetSrpClientProof() []byte {
+	if x != nil {
+		return x.SrpClientProof
+	}
+	return nil
+}
+
+// Запрос создания персонального токена доступа
+type CreateAccessTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Токен только для чтения
+	ReadOnly bool `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// Разрешенные типы данных (пусто - все типы)
+	DataTypes []DataType             `protobuf:"varint,3,rep,packed,name=data_types,json=dataTypes,proto3,enum=gophkeeper.DataType" json:"data_types,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Клиентский сертификат mTLS (PEM): токен предъявляется этим сертификатом
+	// вместо значения в заголовке authorization
+	ClientCertificate []byte `protobuf:"bytes,5,opt,name=client_certificate,json=clientCertificate,proto3" json:"client_certificate,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenRequest) Reset() {
+	*x = CreateAccessTokenRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreateAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateAccessTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccessTokenRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *CreateAccessTokenRequest) GetDataTypes() []DataType {
+	if x != nil {
+		return x.DataTypes
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateAccessTokenRequest) GetClientCertificate() []byte {
+	if x != nil {
+		return x.ClientCertificate
+	}
+	return nil
+}
+
+// Запрос списка персональных токенов доступа
+type ListAccessTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensRequest) Reset() {
+	*x = ListAccessTokensRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensRequest) ProtoMessage() {}
+
+func (x *ListAccessTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessTokensRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{10}
+}
+
+// Запрос отзыва персонального токена доступа
+type RevokeAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenRequest) Reset() {
+	*x = RevokeAccessTokenRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenRequest) ProtoMessage() {}
+
+func (x *RevokeAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokeAccessTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Ответ аутентификации
+type AuthResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	User            *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	WrappedVaultKey []byte                 `protobuf:"bytes,4,opt,name=wrapped_vault_key,json=wrappedVaultKey,proto3" json:"wrapped_vault_key,omitempty"`
+	// Закрытый ключ X25519 для общих записей, зашифрованный ключом хранилища
+	WrappedPrivateKey []byte `protobuf:"bytes,5,opt,name=wrapped_private_key,json=wrappedPrivateKey,proto3" json:"wrapped_private_key,omitempty"`
+	// Аккаунт вошел по паролю и должен перейти на SRP, задается только после проверки пароля
+	SrpUpgradeRequired bool `protobuf:"varint,6,opt,name=srp_upgrade_required,json=srpUpgradeRequired,proto3" json:"srp_upgrade_required,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AuthResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetWrappedVaultKey() []byte {
+	if x != nil {
+		return x.WrappedVaultKey
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetWrappedPrivateKey() []byte {
+	if x != nil {
+		return x.WrappedPrivateKey
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetSrpUpgradeRequired() bool {
+	if x != nil {
+		return x.SrpUpgradeRequired
+	}
+	return false
+}
+
+// Ответ начала входа по SRP-6a
+type BeginSRPLoginResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	LoginId string                 `protobuf:"bytes,1,opt,name=login_id,json=loginId,proto3" json:"login_id,omitempty"`
+	Salt    []byte                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Эфемерный открытый ключ сервера B
+	ServerPublicKey []byte `protobuf:"bytes,3,opt,name=server_public_key,json=serverPublicKey,proto3" json:"server_public_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BeginSRPLoginResponse) Reset() {
+	*x = BeginSRPLoginResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginSRPLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginSRPLoginResponse) ProtoMessage() {}
+
+func (x *BeginSRPLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginSRPLoginResponse.ProtoReflect.Descriptor instead.
+func (*BeginSRPLoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BeginSRPLoginResponse) GetLoginId() string {
+	if x != nil {
+		return x.LoginId
+	}
+	return ""
+}
+
+func (x *BeginSRPLoginResponse) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *BeginSRPLoginResponse) GetServerPublicKey() []byte {
+	if x != nil {
+		return x.ServerPublicKey
+	}
+	return nil
+}
+
+// Ответ завершения входа по SRP-6a
+type FinishSRPLoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Auth  *AuthResponse          `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	// Доказательство сервера M2
+	ServerProof   []byte `protobuf:"bytes,2,opt,name=server_proof,json=serverProof,proto3" json:"server_proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishSRPLoginResponse) Reset() {
+	*x = FinishSRPLoginResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishSRPLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishSRPLoginResponse) ProtoMessage() {}
+
+func (x *FinishSRPLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishSRPLoginResponse.ProtoReflect.Descriptor instead.
+func (*FinishSRPLoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FinishSRPLoginResponse) GetAuth() *AuthResponse {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *FinishSRPLoginResponse) GetServerProof() []byte {
+	if x != nil {
+		return x.ServerProof
+	}
+	return nil
+}
+
+// Пользователь
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос создания данных
+type CreateDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          DataType               `protobuf:"varint,1,opt,name=type,proto3,enum=gophkeeper.DataType" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	EncryptedData []byte                 `protobuf:"bytes,4,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Период смены пароля в днях, 0 - без политики
+	RotationDays  int32 `protobuf:"varint,6,opt,name=rotation_days,json=rotationDays,proto3" json:"rotation_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateDataRequest) Reset() {
+	*x = CreateDataRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDataRequest) ProtoMessage() {}
+
+func (x *CreateDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDataRequest.ProtoReflect.Descriptor instead.
+func (*CreateDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateDataRequest) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *CreateDataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateDataRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateDataRequest) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *CreateDataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *CreateDataRequest) GetRotationDays() int32 {
+	if x != nil {
+		return x.RotationDays
+	}
+	return 0
+}
+
+// Запрос получения данных
+type GetDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDataRequest) Reset() {
+	*x = GetDataRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*GetDataRequest) ProtoMessage() {}
 
-func (x *GetDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[6]
+func (x *GetDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDataRequest.ProtoReflect.Descriptor instead.
+func (*GetDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetDataRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос списка данных
+type ListDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          *DataType              `protobuf:"varint,1,opt,name=type,proto3,enum=gophkeeper.DataType,oneof" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDataRequest) Reset() {
+	*x = ListDataRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDataRequest) ProtoMessage() {}
+
+func (x *ListDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDataRequest.ProtoReflect.Descriptor instead.
+func (*ListDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDataRequest) GetType() DataType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *ListDataRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDataRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Запрос обновления данных
+type UpdateDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	EncryptedData []byte                 `protobuf:"bytes,4,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	// Период смены пароля в днях, заменяет политику записи; 0 снимает ее.
+	// Если не задан, политика записи сохраняется
+	RotationDays *int32 `protobuf:"varint,7,opt,name=rotation_days,json=rotationDays,proto3,oneof" json:"rotation_days,omitempty"`
+	// Пароль записи изменен, сервер обновляет время смены пароля
+	PasswordChanged bool `protobuf:"varint,8,opt,name=password_changed,json=passwordChanged,proto3" json:"password_changed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateDataRequest) Reset() {
+	*x = UpdateDataRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDataRequest) ProtoMessage() {}
+
+func (x *UpdateDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateDataRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateDataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateDataRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateDataRequest) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *UpdateDataRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *UpdateDataRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateDataRequest) GetRotationDays() int32 {
+	if x != nil && x.RotationDays != nil {
+		return *x.RotationDays
+	}
+	return 0
+}
+
+func (x *UpdateDataRequest) GetPasswordChanged() bool {
+	if x != nil {
+		return x.PasswordChanged
+	}
+	return false
+}
+
+// Запрос записей, пароли которых пора сменить
+type ListDueForRotationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Включить записи, срок смены пароля которых наступит в течение стольких дней
+	WithinDays    int32 `protobuf:"varint,1,opt,name=within_days,json=withinDays,proto3" json:"within_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDueForRotationRequest) Reset() {
+	*x = ListDueForRotationRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDueForRotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDueForRotationRequest) ProtoMessage() {}
+
+func (x *ListDueForRotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDueForRotationRequest.ProtoReflect.Descriptor instead.
+func (*ListDueForRotationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDueForRotationRequest) GetWithinDays() int32 {
+	if x != nil {
+		return x.WithinDays
+	}
+	return 0
+}
+
+// Запрос удаления данных
+type DeleteDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDataRequest) Reset() {
+	*x = DeleteDataRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDataRequest) ProtoMessage() {}
+
+func (x *DeleteDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteDataRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос синхронизации
+type SyncDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastSyncTime  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncDataRequest) Reset() {
+	*x = SyncDataRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncDataRequest) ProtoMessage() {}
+
+func (x *SyncDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncDataRequest.ProtoReflect.Descriptor instead.
+func (*SyncDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SyncDataRequest) GetLastSyncTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return nil
+}
+
+// Запрос публикации открытого ключа
+type SetPublicKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Открытый ключ X25519
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Закрытый ключ, зашифрованный ключом хранилища на стороне клиента
+	WrappedPrivateKey []byte `protobuf:"bytes,2,opt,name=wrapped_private_key,json=wrappedPrivateKey,proto3" json:"wrapped_private_key,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetPublicKeyRequest) Reset() {
+	*x = SetPublicKeyRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPublicKeyRequest) ProtoMessage() {}
+
+func (x *SetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*SetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetPublicKeyRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SetPublicKeyRequest) GetWrappedPrivateKey() []byte {
+	if x != nil {
+		return x.WrappedPrivateKey
+	}
+	return nil
+}
+
+// Запрос открытого ключа пользователя
+type GetPublicKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyRequest) Reset() {
+	*x = GetPublicKeyRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyRequest) ProtoMessage() {}
+
+func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPublicKeyRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// Запрос предоставления доступа к записи
+type ShareEntryRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EntryId           string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	RecipientUsername string                 `protobuf:"bytes,2,opt,name=recipient_username,json=recipientUsername,proto3" json:"recipient_username,omitempty"`
+	// Ключ записи, зашифрованный открытым ключом получателя
+	WrappedKey    []byte          `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	Permission    SharePermission `protobuf:"varint,4,opt,name=permission,proto3,enum=gophkeeper.SharePermission" json:"permission,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareEntryRequest) Reset() {
+	*x = ShareEntryRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareEntryRequest) ProtoMessage() {}
+
+func (x *ShareEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareEntryRequest.ProtoReflect.Descriptor instead.
+func (*ShareEntryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ShareEntryRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *ShareEntryRequest) GetRecipientUsername() string {
+	if x != nil {
+		return x.RecipientUsername
+	}
+	return ""
+}
+
+func (x *ShareEntryRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *ShareEntryRequest) GetPermission() SharePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return SharePermission_SHARE_PERMISSION_UNSPECIFIED
+}
+
+// Запрос списка получателей записи
+type ListEntrySharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntrySharesRequest) Reset() {
+	*x = ListEntrySharesRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntrySharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntrySharesRequest) ProtoMessage() {}
+
+func (x *ListEntrySharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntrySharesRequest.ProtoReflect.Descriptor instead.
+func (*ListEntrySharesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListEntrySharesRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+// Запрос отзыва доступа к записи
+type RevokeShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareRequest) Reset() {
+	*x = RevokeShareRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareRequest) ProtoMessage() {}
+
+func (x *RevokeShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RevokeShareRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос списка записей, к которым предоставлен доступ
+type ListSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{28}
+}
+
+// Запрос добавления вложения к записи
+type AddAttachmentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EntryId string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Тип содержимого в открытом виде, необязателен. Клиент GophKeeper его
+	// не передает, чтобы сервер не узнавал тип файла
+	MimeType string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Ключ вложения, зашифрованный на клиенте ключом хранилища (собственная запись)
+	// или ключом общей записи
+	WrappedKey []byte `protobuf:"bytes,4,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	// Содержимое, зашифрованное ключом вложения
+	EncryptedData []byte `protobuf:"bytes,5,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAttachmentRequest) Reset() {
+	*x = AddAttachmentRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAttachmentRequest) ProtoMessage() {}
+
+func (x *AddAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*AddAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddAttachmentRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *AddAttachmentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddAttachmentRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *AddAttachmentRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *AddAttachmentRequest) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+// Запрос списка вложений записи
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAttachmentsRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+// Запрос получения вложения
+type GetAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentRequest) Reset() {
+	*x = GetAttachmentRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentRequest) ProtoMessage() {}
+
+func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetAttachmentRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *GetAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос удаления вложения
+type RemoveAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveAttachmentRequest) Reset() {
+	*x = RemoveAttachmentRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAttachmentRequest) ProtoMessage() {}
+
+func (x *RemoveAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RemoveAttachmentRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *RemoveAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос создания организации
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Запрос списка организаций пользователя
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{34}
+}
+
+// Запрос списка участников организации
+type ListOrganizationMembersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMembersRequest) Reset() {
+	*x = ListOrganizationMembersRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMembersRequest) ProtoMessage() {}
+
+func (x *ListOrganizationMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMembersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListOrganizationMembersRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+// Запрос приглашения в организацию
+type InviteMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role           OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.OrganizationRole" json:"role,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InviteMemberRequest) Reset() {
+	*x = InviteMemberRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberRequest) ProtoMessage() {}
+
+func (x *InviteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *InviteMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetRole() OrganizationRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+}
+
+// Запрос списка приглашений текущего пользователя
+type ListInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsRequest) Reset() {
+	*x = ListInvitationsRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsRequest) ProtoMessage() {}
+
+func (x *ListInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{37}
+}
+
+// Запрос принятия приглашения
+type AcceptInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationRequest) Reset() {
+	*x = AcceptInvitationRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AcceptInvitationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос отклонения приглашения
+type DeclineInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineInvitationRequest) Reset() {
+	*x = DeclineInvitationRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineInvitationRequest) ProtoMessage() {}
+
+func (x *DeclineInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineInvitationRequest.ProtoReflect.Descriptor instead.
+func (*DeclineInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeclineInvitationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос изменения роли участника
+type UpdateMemberRoleRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role           OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.OrganizationRole" json:"role,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateMemberRoleRequest) Reset() {
+	*x = UpdateMemberRoleRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberRoleRequest) ProtoMessage() {}
+
+func (x *UpdateMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateMemberRoleRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *UpdateMemberRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateMemberRoleRequest) GetRole() OrganizationRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+}
+
+// Запрос исключения участника из организации
+type RemoveMemberRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RemoveMemberRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Запрос создания коллекции
+type CreateCollectionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Ключ коллекции, зашифрованный открытым ключом создателя
+	WrappedKey []byte `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	// Период смены паролей записей коллекции в днях, 0 - без политики
+	RotationDays  int32 `protobuf:"varint,4,opt,name=rotation_days,json=rotationDays,proto3" json:"rotation_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateCollectionRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *CreateCollectionRequest) GetRotationDays() int32 {
+	if x != nil {
+		return x.RotationDays
+	}
+	return 0
+}
+
+// Запрос изменения политики смены паролей коллекции
+type SetCollectionRotationPolicyRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Период смены паролей в днях, 0 снимает политику
+	RotationDays  int32 `protobuf:"varint,2,opt,name=rotation_days,json=rotationDays,proto3" json:"rotation_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCollectionRotationPolicyRequest) Reset() {
+	*x = SetCollectionRotationPolicyRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCollectionRotationPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCollectionRotationPolicyRequest) ProtoMessage() {}
+
+func (x *SetCollectionRotationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCollectionRotationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetCollectionRotationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetCollectionRotationPolicyRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *SetCollectionRotationPolicyRequest) GetRotationDays() int32 {
+	if x != nil {
+		return x.RotationDays
+	}
+	return 0
+}
+
+// Запрос списка коллекций организации
+type ListCollectionsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganizationId string                 `protobuf:"bytes,1,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListCollectionsRequest) Reset() {
+	*x = ListCollectionsRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsRequest) ProtoMessage() {}
+
+func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListCollectionsRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
+}
+
+// Запрос передачи ключа коллекции участнику
+type GrantCollectionKeyRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Ключ коллекции, зашифрованный открытым ключом участника
+	WrappedKey []byte `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	// Версия ключа, должна совпадать с текущей версией коллекции
+	KeyVersion    int32 `protobuf:"varint,4,opt,name=key_version,json=keyVersion,proto3" json:"key_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantCollectionKeyRequest) Reset() {
+	*x = GrantCollectionKeyRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantCollectionKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantCollectionKeyRequest) ProtoMessage() {}
+
+func (x *GrantCollectionKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantCollectionKeyRequest.ProtoReflect.Descriptor instead.
+func (*GrantCollectionKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GrantCollectionKeyRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *GrantCollectionKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantCollectionKeyRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *GrantCollectionKeyRequest) GetKeyVersion() int32 {
+	if x != nil {
+		return x.KeyVersion
+	}
+	return 0
+}
+
+// Запрос смены ключа коллекции
+type RotateCollectionKeyRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Новая версия ключа, на единицу больше текущей
+	KeyVersion int32 `protobuf:"varint,2,opt,name=key_version,json=keyVersion,proto3" json:"key_version,omitempty"`
+	// Новый ключ, зашифрованный для каждого участника организации
+	Keys []*CollectionKey `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Все записи коллекции, перешифрованные новым ключом
+	Entries       []*CollectionEntryUpdate `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateCollectionKeyRequest) Reset() {
+	*x = RotateCollectionKeyRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateCollectionKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateCollectionKeyRequest) ProtoMessage() {}
+
+func (x *RotateCollectionKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateCollectionKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateCollectionKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RotateCollectionKeyRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *RotateCollectionKeyRequest) GetKeyVersion() int32 {
+	if x != nil {
+		return x.KeyVersion
+	}
+	return 0
+}
+
+func (x *RotateCollectionKeyRequest) GetKeys() []*CollectionKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *RotateCollectionKeyRequest) GetEntries() []*CollectionEntryUpdate {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Запрос создания записи в коллекции
+type CreateCollectionEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Type          DataType               `protobuf:"varint,2,opt,name=type,proto3,enum=gophkeeper.DataType" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	EncryptedData []byte                 `protobuf:"bytes,5,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Период смены пароля в днях, 0 - действует политика коллекции
+	RotationDays  int32 `protobuf:"varint,7,opt,name=rotation_days,json=rotationDays,proto3" json:"rotation_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionEntryRequest) Reset() {
+	*x = CreateCollectionEntryRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionEntryRequest) ProtoMessage() {}
+
+func (x *CreateCollectionEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionEntryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateCollectionEntryRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *CreateCollectionEntryRequest) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *CreateCollectionEntryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCollectionEntryRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCollectionEntryRequest) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *CreateCollectionEntryRequest) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *CreateCollectionEntryRequest) GetRotationDays() int32 {
+	if x != nil {
+		return x.RotationDays
+	}
+	return 0
+}
+
+// Запрос списка записей коллекции
+type ListCollectionEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionEntriesRequest) Reset() {
+	*x = ListCollectionEntriesRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionEntriesRequest) ProtoMessage() {}
+
+func (x *ListCollectionEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListCollectionEntriesRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+// Запрос создания одноразовой ссылки на секрет
+type CreateSecretLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Секрет, зашифрованный ключом из фрагмента ссылки
+	EncryptedData []byte `protobuf:"bytes,1,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	// Количество просмотров до уничтожения (по умолчанию 1)
+	MaxViews      int32                  `protobuf:"varint,2,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSecretLinkRequest) Reset() {
+	*x = CreateSecretLinkRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecretLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretLinkRequest) ProtoMessage() {}
+
+func (x *CreateSecretLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateSecretLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateSecretLinkRequest) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *CreateSecretLinkRequest) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+func (x *CreateSecretLinkRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Запрос назначения контакта для экстренного доступа
+type AddEmergencyContactRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// Период ожидания в днях, в течение которого владелец может отклонить запрос
+	WaitDays int32 `protobuf:"varint,2,opt,name=wait_days,json=waitDays,proto3" json:"wait_days,omitempty"`
+	// Ключ хранилища, зашифрованный открытым ключом контакта
+	WrappedKey    []byte `protobuf:"bytes,3,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddEmergencyContactRequest) Reset() {
+	*x = AddEmergencyContactRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEmergencyContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEmergencyContactRequest) ProtoMessage() {}
+
+func (x *AddEmergencyContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEmergencyContactRequest.ProtoReflect.Descriptor instead.
+func (*AddEmergencyContactRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AddEmergencyContactRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AddEmergencyContactRequest) GetWaitDays() int32 {
+	if x != nil {
+		return x.WaitDays
+	}
+	return 0
+}
+
+func (x *AddEmergencyContactRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+// Запрос списка экстренных доступов
+type ListEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmergencyAccessRequest) Reset() {
+	*x = ListEmergencyAccessRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *ListEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*ListEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{51}
+}
+
+// Запрос удаления экстренного доступа
+type RemoveEmergencyContactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveEmergencyContactRequest) Reset() {
+	*x = RemoveEmergencyContactRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveEmergencyContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveEmergencyContactRequest) ProtoMessage() {}
+
+func (x *RemoveEmergencyContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveEmergencyContactRequest.ProtoReflect.Descriptor instead.
+func (*RemoveEmergencyContactRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RemoveEmergencyContactRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос экстренного доступа
+type RequestEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmergencyAccessRequest) Reset() {
+	*x = RequestEmergencyAccessRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *RequestEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*RequestEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RequestEmergencyAccessRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос одобрения экстренного доступа
+type ApproveEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveEmergencyAccessRequest) Reset() {
+	*x = ApproveEmergencyAccessRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *ApproveEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*ApproveEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ApproveEmergencyAccessRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос отклонения экстренного доступа
+type DenyEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DenyEmergencyAccessRequest) Reset() {
+	*x = DenyEmergencyAccessRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DenyEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DenyEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *DenyEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DenyEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*DenyEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DenyEmergencyAccessRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос хранилища владельца
+type GetEmergencyVaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyVaultRequest) Reset() {
+	*x = GetEmergencyVaultRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyVaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyVaultRequest) ProtoMessage() {}
+
+func (x *GetEmergencyVaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyVaultRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencyVaultRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetEmergencyVaultRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос журнала экстренного доступа
+type ListEmergencyAccessEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmergencyAccessEventsRequest) Reset() {
+	*x = ListEmergencyAccessEventsRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmergencyAccessEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmergencyAccessEventsRequest) ProtoMessage() {}
+
+func (x *ListEmergencyAccessEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmergencyAccessEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEmergencyAccessEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListEmergencyAccessEventsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Запрос уведомлений
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{58}
+}
+
+// Запрос генерации OTP
+type GenerateOTPRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Secret string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Параметры TOTP, пустые значения означают SHA1, 6 цифр и 30 секунд
+	Algorithm     string `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Digits        int32  `protobuf:"varint,3,opt,name=digits,proto3" json:"digits,omitempty"`
+	Period        int32  `protobuf:"varint,4,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateOTPRequest) Reset() {
+	*x = GenerateOTPRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateOTPRequest) ProtoMessage() {}
+
+func (x *GenerateOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateOTPRequest.ProtoReflect.Descriptor instead.
+func (*GenerateOTPRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GenerateOTPRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *GenerateOTPRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *GenerateOTPRequest) GetDigits() int32 {
+	if x != nil {
+		return x.Digits
+	}
+	return 0
+}
+
+func (x *GenerateOTPRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+// Запрос создания OTP секрета
+type CreateOTPSecretRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Issuer      string                 `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	AccountName string                 `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	// Параметры TOTP, пустые значения означают SHA1, 6 цифр и 30 секунд
+	Algorithm     string `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Digits        int32  `protobuf:"varint,4,opt,name=digits,proto3" json:"digits,omitempty"`
+	Period        int32  `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOTPSecretRequest) Reset() {
+	*x = CreateOTPSecretRequest{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOTPSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOTPSecretRequest) ProtoMessage() {}
+
+func (x *CreateOTPSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOTPSecretRequest.ProtoReflect.Descriptor instead.
+func (*CreateOTPSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateOTPSecretRequest) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *CreateOTPSecretRequest) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *CreateOTPSecretRequest) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *CreateOTPSecretRequest) GetDigits() int32 {
+	if x != nil {
+		return x.Digits
+	}
+	return 0
+}
+
+func (x *CreateOTPSecretRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+// Ответ записи данных
+type DataEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataEntry     *DataEntry             `protobuf:"bytes,1,opt,name=data_entry,json=dataEntry,proto3" json:"data_entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataEntryResponse) Reset() {
+	*x = DataEntryResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataEntryResponse) ProtoMessage() {}
+
+func (x *DataEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataEntryResponse.ProtoReflect.Descriptor instead.
+func (*DataEntryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DataEntryResponse) GetDataEntry() *DataEntry {
+	if x != nil {
+		return x.DataEntry
+	}
+	return nil
+}
+
+// Ответ списка данных
+type ListDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataEntries   []*DataEntry           `protobuf:"bytes,1,rep,name=data_entries,json=dataEntries,proto3" json:"data_entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDataResponse) Reset() {
+	*x = ListDataResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDataResponse) ProtoMessage() {}
+
+func (x *ListDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDataResponse.ProtoReflect.Descriptor instead.
+func (*ListDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListDataResponse) GetDataEntries() []*DataEntry {
+	if x != nil {
+		return x.DataEntries
+	}
+	return nil
+}
+
+func (x *ListDataResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Ответ удаления данных
+type DeleteDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDataResponse) Reset() {
+	*x = DeleteDataResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDataResponse) ProtoMessage() {}
+
+func (x *DeleteDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteDataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ удаления аккаунта
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DeleteAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ экспорта аккаунта
+type ExportAccountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Архив, зашифрованный ключом, производным от пароля пользователя
+	Archive    []byte                 `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	ExportedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	// Данные аккаунтов с SRP: сервер не знает пароль, архив шифрует клиент
+	Export        []byte `protobuf:"bytes,3,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAccountResponse) Reset() {
+	*x = ExportAccountResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAccountResponse) ProtoMessage() {}
+
+func (x *ExportAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAccountResponse.ProtoReflect.Descriptor instead.
+func (*ExportAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ExportAccountResponse) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *ExportAccountResponse) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *ExportAccountResponse) GetExport() []byte {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+// Ответ создания персонального токена доступа
+type CreateAccessTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Значение токена, показывается только один раз.
+	// Пусто для токенов, привязанных к клиентскому сертификату
+	Token         string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	AccessToken   *AccessToken `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessTokenResponse) Reset() {
+	*x = CreateAccessTokenResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessTokenResponse) ProtoMessage() {}
+
+func (x *CreateAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CreateAccessTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateAccessTokenResponse) GetAccessToken() *AccessToken {
+	if x != nil {
+		return x.AccessToken
+	}
+	return nil
+}
+
+// Ответ списка персональных токенов доступа
+type ListAccessTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessTokens  []*AccessToken         `protobuf:"bytes,1,rep,name=access_tokens,json=accessTokens,proto3" json:"access_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessTokensResponse) Reset() {
+	*x = ListAccessTokensResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessTokensResponse) ProtoMessage() {}
+
+func (x *ListAccessTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessTokensResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListAccessTokensResponse) GetAccessTokens() []*AccessToken {
+	if x != nil {
+		return x.AccessTokens
+	}
+	return nil
+}
+
+// Ответ отзыва персонального токена доступа
+type RevokeAccessTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAccessTokenResponse) Reset() {
+	*x = RevokeAccessTokenResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessTokenResponse) ProtoMessage() {}
+
+func (x *RevokeAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *RevokeAccessTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ синхронизации
+type SyncDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataEntries   []*DataEntry           `protobuf:"bytes,1,rep,name=data_entries,json=dataEntries,proto3" json:"data_entries,omitempty"`
+	DeletedIds    []string               `protobuf:"bytes,2,rep,name=deleted_ids,json=deletedIds,proto3" json:"deleted_ids,omitempty"`
+	LastSyncTime  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncDataResponse) Reset() {
+	*x = SyncDataResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncDataResponse) ProtoMessage() {}
+
+func (x *SyncDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncDataResponse.ProtoReflect.Descriptor instead.
+func (*SyncDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SyncDataResponse) GetDataEntries() []*DataEntry {
+	if x != nil {
+		return x.DataEntries
+	}
+	return nil
+}
+
+func (x *SyncDataResponse) GetDeletedIds() []string {
+	if x != nil {
+		return x.DeletedIds
+	}
+	return nil
+}
+
+func (x *SyncDataResponse) GetLastSyncTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return nil
+}
+
+// Ответ сохранения ключа хранилища
+type SetVaultKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVaultKeyResponse) Reset() {
+	*x = SetVaultKeyResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVaultKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVaultKeyResponse) ProtoMessage() {}
+
+func (x *SetVaultKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVaultKeyResponse.ProtoReflect.Descriptor instead.
+func (*SetVaultKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SetVaultKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ публикации открытого ключа
+type SetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPublicKeyResponse) Reset() {
+	*x = SetPublicKeyResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPublicKeyResponse) ProtoMessage() {}
+
+func (x *SetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*SetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SetPublicKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ с открытым ключом пользователя
+type GetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyResponse) Reset() {
+	*x = GetPublicKeyResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyResponse) ProtoMessage() {}
+
+func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetPublicKeyResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPublicKeyResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetPublicKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// Ответ предоставления доступа к записи
+type ShareEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *EntryShare            `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareEntryResponse) Reset() {
+	*x = ShareEntryResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareEntryResponse) ProtoMessage() {}
+
+func (x *ShareEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareEntryResponse.ProtoReflect.Descriptor instead.
+func (*ShareEntryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ShareEntryResponse) GetShare() *EntryShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+// Ответ списка получателей записи
+type ListEntrySharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*EntryShare          `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntrySharesResponse) Reset() {
+	*x = ListEntrySharesResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntrySharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntrySharesResponse) ProtoMessage() {}
+
+func (x *ListEntrySharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntrySharesResponse.ProtoReflect.Descriptor instead.
+func (*ListEntrySharesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListEntrySharesResponse) GetShares() []*EntryShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+// Ответ отзыва доступа к записи
+type RevokeShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareResponse) Reset() {
+	*x = RevokeShareResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareResponse) ProtoMessage() {}
+
+func (x *RevokeShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RevokeShareResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ с вложением
+type AttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentResponse) Reset() {
+	*x = AttachmentResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentResponse) ProtoMessage() {}
+
+func (x *AttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentResponse.ProtoReflect.Descriptor instead.
+func (*AttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *AttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+// Ответ со списком вложений записи
+type ListAttachmentsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Attachments []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// Суммарный размер вложений записи
+	TotalSize     int64 `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *ListAttachmentsResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// Ответ получения вложения
+type GetAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	EncryptedData []byte                 `protobuf:"bytes,2,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentResponse) Reset() {
+	*x = GetAttachmentResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentResponse) ProtoMessage() {}
+
+func (x *GetAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*GetAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *GetAttachmentResponse) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+// Ответ удаления вложения
+type RemoveAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveAttachmentResponse) Reset() {
+	*x = RemoveAttachmentResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAttachmentResponse) ProtoMessage() {}
+
+func (x *RemoveAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*RemoveAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RemoveAttachmentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ с организацией
+type OrganizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganizationResponse) Reset() {
+	*x = OrganizationResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganizationResponse) ProtoMessage() {}
+
+func (x *OrganizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganizationResponse.ProtoReflect.Descriptor instead.
+func (*OrganizationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *OrganizationResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+// Ответ списка организаций
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+// Ответ списка участников организации
+type ListOrganizationMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrganizationMember  `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationMembersResponse) Reset() {
+	*x = ListOrganizationMembersResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationMembersResponse) ProtoMessage() {}
+
+func (x *ListOrganizationMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationMembersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ListOrganizationMembersResponse) GetMembers() []*OrganizationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Ответ с приглашением
+type InvitationResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Invitation    *OrganizationInvitation `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvitationResponse) Reset() {
+	*x = InvitationResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitationResponse) ProtoMessage() {}
+
+func (x *InvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitationResponse.ProtoReflect.Descriptor instead.
+func (*InvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *InvitationResponse) GetInvitation() *OrganizationInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// Ответ списка приглашений
+type ListInvitationsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Invitations   []*OrganizationInvitation `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsResponse) Reset() {
+	*x = ListInvitationsResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsResponse) ProtoMessage() {}
+
+func (x *ListInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ListInvitationsResponse) GetInvitations() []*OrganizationInvitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+// Ответ отклонения приглашения
+type DeclineInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineInvitationResponse) Reset() {
+	*x = DeclineInvitationResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineInvitationResponse) ProtoMessage() {}
+
+func (x *DeclineInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineInvitationResponse.ProtoReflect.Descriptor instead.
+func (*DeclineInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *DeclineInvitationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ изменения роли участника
+type UpdateMemberRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *OrganizationMember    `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemberRoleResponse) Reset() {
+	*x = UpdateMemberRoleResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberRoleResponse) ProtoMessage() {}
+
+func (x *UpdateMemberRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberRoleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRoleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *UpdateMemberRoleResponse) GetMember() *OrganizationMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+// Ответ исключения участника
+type RemoveMemberResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Коллекции, ключи которых нужно сменить
+	RotationRequiredCollectionIds []string `protobuf:"bytes,1,rep,name=rotation_required_collection_ids,json=rotationRequiredCollectionIds,proto3" json:"rotation_required_collection_ids,omitempty"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *RemoveMemberResponse) GetRotationRequiredCollectionIds() []string {
+	if x != nil {
+		return x.RotationRequiredCollectionIds
+	}
+	return nil
+}
+
+// Ответ с коллекцией
+type CollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    *Collection            `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionResponse) Reset() {
+	*x = CollectionResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionResponse) ProtoMessage() {}
+
+func (x *CollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionResponse.ProtoReflect.Descriptor instead.
+func (*CollectionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CollectionResponse) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+// Ответ списка коллекций
+type ListCollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsResponse) ProtoMessage() {}
+
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+// Ответ передачи ключа коллекции
+type GrantCollectionKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantCollectionKeyResponse) Reset() {
+	*x = GrantCollectionKeyResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantCollectionKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantCollectionKeyResponse) ProtoMessage() {}
+
+func (x *GrantCollectionKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantCollectionKeyResponse.ProtoReflect.Descriptor instead.
+func (*GrantCollectionKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GrantCollectionKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ создания одноразовой ссылки на секрет
+type CreateSecretLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *SecretLink            `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSecretLinkResponse) Reset() {
+	*x = CreateSecretLinkResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSecretLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretLinkResponse) ProtoMessage() {}
+
+func (x *CreateSecretLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateSecretLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *CreateSecretLinkResponse) GetLink() *SecretLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// Ответ с экстренным доступом
+type EmergencyAccessResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EmergencyAccess *EmergencyAccess       `protobuf:"bytes,1,opt,name=emergency_access,json=emergencyAccess,proto3" json:"emergency_access,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EmergencyAccessResponse) Reset() {
+	*x = EmergencyAccessResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyAccessResponse) ProtoMessage() {}
+
+func (x *EmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*EmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *EmergencyAccessResponse) GetEmergencyAccess() *EmergencyAccess {
+	if x != nil {
+		return x.EmergencyAccess
+	}
+	return nil
+}
+
+// Ответ списка экстренных доступов
+type ListEmergencyAccessResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Контакты, назначенные пользователем
+	Granted []*EmergencyAccess `protobuf:"bytes,1,rep,name=granted,proto3" json:"granted,omitempty"`
+	// Доступы, в которых пользователь назначен контактом
+	Trusted       []*EmergencyAccess `protobuf:"bytes,2,rep,name=trusted,proto3" json:"trusted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmergencyAccessResponse) Reset() {
+	*x = ListEmergencyAccessResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *ListEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*ListEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ListEmergencyAccessResponse) GetGranted() []*EmergencyAccess {
+	if x != nil {
+		return x.Granted
+	}
+	return nil
+}
+
+func (x *ListEmergencyAccessResponse) GetTrusted() []*EmergencyAccess {
+	if x != nil {
+		return x.Trusted
+	}
+	return nil
+}
+
+// Ответ удаления экстренного доступа
+type RemoveEmergencyContactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveEmergencyContactResponse) Reset() {
+	*x = RemoveEmergencyContactResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveEmergencyContactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveEmergencyContactResponse) ProtoMessage() {}
+
+func (x *RemoveEmergencyContactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveEmergencyContactResponse.ProtoReflect.Descriptor instead.
+func (*RemoveEmergencyContactResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RemoveEmergencyContactResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Ответ с хранилищем владельца
+type GetEmergencyVaultResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EmergencyAccess *EmergencyAccess       `protobuf:"bytes,1,opt,name=emergency_access,json=emergencyAccess,proto3" json:"emergency_access,omitempty"`
+	// Ключ хранилища владельца, зашифрованный открытым ключом контакта
+	WrappedKey    []byte       `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	DataEntries   []*DataEntry `protobuf:"bytes,3,rep,name=data_entries,json=dataEntries,proto3" json:"data_entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyVaultResponse) Reset() {
+	*x = GetEmergencyVaultResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyVaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyVaultResponse) ProtoMessage() {}
+
+func (x *GetEmergencyVaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyVaultResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencyVaultResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetEmergencyVaultResponse) GetEmergencyAccess() *EmergencyAccess {
+	if x != nil {
+		return x.EmergencyAccess
+	}
+	return nil
+}
+
+func (x *GetEmergencyVaultResponse) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *GetEmergencyVaultResponse) GetDataEntries() []*DataEntry {
+	if x != nil {
+		return x.DataEntries
+	}
+	return nil
+}
+
+// Ответ журнала экстренного доступа
+type ListEmergencyAccessEventsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Events        []*EmergencyAccessEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEmergencyAccessEventsResponse) Reset() {
+	*x = ListEmergencyAccessEventsResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEmergencyAccessEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEmergencyAccessEventsResponse) ProtoMessage() {}
+
+func (x *ListEmergencyAccessEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEmergencyAccessEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEmergencyAccessEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ListEmergencyAccessEventsResponse) GetEvents() []*EmergencyAccessEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Ответ списка уведомлений
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+// Ответ генерации OTP
+type GenerateOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	TimeRemaining int32                  `protobuf:"varint,3,opt,name=time_remaining,json=timeRemaining,proto3" json:"time_remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateOTPResponse) Reset() {
+	*x = GenerateOTPResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateOTPResponse) ProtoMessage() {}
+
+func (x *GenerateOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateOTPResponse.ProtoReflect.Descriptor instead.
+func (*GenerateOTPResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *GenerateOTPResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GenerateOTPResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *GenerateOTPResponse) GetTimeRemaining() int32 {
+	if x != nil {
+		return x.TimeRemaining
+	}
+	return 0
+}
+
+// Ответ создания OTP секрета
+type CreateOTPSecretResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Secret      string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	QrCodeUrl   string                 `protobuf:"bytes,2,opt,name=qr_code_url,json=qrCodeUrl,proto3" json:"qr_code_url,omitempty"`
+	BackupCodes []string               `protobuf:"bytes,3,rep,name=backup_codes,json=backupCodes,proto3" json:"backup_codes,omitempty"`
+	// QR код с otpauth URI в формате PNG
+	QrCodePng     []byte `protobuf:"bytes,4,opt,name=qr_code_png,json=qrCodePng,proto3" json:"qr_code_png,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOTPSecretResponse) Reset() {
+	*x = CreateOTPSecretResponse{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOTPSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOTPSecretResponse) ProtoMessage() {}
+
+func (x *CreateOTPSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOTPSecretResponse.ProtoReflect.Descriptor instead.
+func (*CreateOTPSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *CreateOTPSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateOTPSecretResponse) GetQrCodeUrl() string {
+	if x != nil {
+		return x.QrCodeUrl
+	}
+	return ""
+}
+
+func (x *CreateOTPSecretResponse) GetBackupCodes() []string {
+	if x != nil {
+		return x.BackupCodes
+	}
+	return nil
+}
+
+func (x *CreateOTPSecretResponse) GetQrCodePng() []byte {
+	if x != nil {
+		return x.QrCodePng
+	}
+	return nil
+}
+
+// Запись данных
+type DataEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          DataType               `protobuf:"varint,2,opt,name=type,proto3,enum=gophkeeper.DataType" json:"type,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	EncryptedData []byte                 `protobuf:"bytes,5,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	Metadata      string                 `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// Заполняется для записей, к которым пользователю предоставлен доступ
+	Share *EntryShare `protobuf:"bytes,10,opt,name=share,proto3" json:"share,omitempty"`
+	// Заполняется для записей коллекции организации
+	CollectionId string `protobuf:"bytes,11,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Время последней смены пароля
+	PasswordChangedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=password_changed_at,json=passwordChangedAt,proto3" json:"password_changed_at,omitempty"`
+	// Период смены пароля записи в днях, 0 - политика записи не задана
+	RotationDays int32 `protobuf:"varint,13,opt,name=rotation_days,json=rotationDays,proto3" json:"rotation_days,omitempty"`
+	// Срок смены пароля по политике записи или коллекции, не задан без политики
+	RotationDueAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=rotation_due_at,json=rotationDueAt,proto3" json:"rotation_due_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataEntry) Reset() {
+	*x = DataEntry{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataEntry) ProtoMessage() {}
+
+func (x *DataEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataEntry.ProtoReflect.Descriptor instead.
+func (*DataEntry) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *DataEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DataEntry) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *DataEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DataEntry) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
+	}
+	return nil
+}
+
+func (x *DataEntry) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *DataEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DataEntry) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *DataEntry) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DataEntry) GetShare() *EntryShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *DataEntry) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *DataEntry) GetPasswordChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PasswordChangedAt
+	}
+	return nil
+}
+
+func (x *DataEntry) GetRotationDays() int32 {
+	if x != nil {
+		return x.RotationDays
+	}
+	return 0
+}
+
+func (x *DataEntry) GetRotationDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RotationDueAt
+	}
+	return nil
+}
+
+// Доступ к записи, предоставленный другому пользователю
+type EntryShare struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EntryId           string                 `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	OwnerUsername     string                 `protobuf:"bytes,3,opt,name=owner_username,json=ownerUsername,proto3" json:"owner_username,omitempty"`
+	RecipientUsername string                 `protobuf:"bytes,4,opt,name=recipient_username,json=recipientUsername,proto3" json:"recipient_username,omitempty"`
+	Permission        SharePermission        `protobuf:"varint,5,opt,name=permission,proto3,enum=gophkeeper.SharePermission" json:"permission,omitempty"`
+	// Ключ записи, зашифрованный открытым ключом получателя
+	WrappedKey    []byte                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryShare) Reset() {
+	*x = EntryShare{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryShare) ProtoMessage() {}
+
+func (x *EntryShare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryShare.ProtoReflect.Descriptor instead.
+func (*EntryShare) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *EntryShare) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EntryShare) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *EntryShare) GetOwnerUsername() string {
+	if x != nil {
+		return x.OwnerUsername
+	}
+	return ""
+}
+
+func (x *EntryShare) GetRecipientUsername() string {
+	if x != nil {
+		return x.RecipientUsername
+	}
+	return ""
+}
+
+func (x *EntryShare) GetPermission() SharePermission {
+	if x != nil {
+		return x.Permission
+	}
+	return SharePermission_SHARE_PERMISSION_UNSPECIFIED
+}
+
+func (x *EntryShare) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *EntryShare) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Персональный токен доступа
+type AccessToken struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReadOnly   bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	DataTypes  []DataType             `protobuf:"varint,4,rep,packed,name=data_types,json=dataTypes,proto3,enum=gophkeeper.DataType" json:"data_types,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	// Отпечаток SHA-256 привязанного клиентского сертификата (hex)
+	CertificateFingerprint string `protobuf:"bytes,8,opt,name=certificate_fingerprint,json=certificateFingerprint,proto3" json:"certificate_fingerprint,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AccessToken) Reset() {
+	*x = AccessToken{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessToken) ProtoMessage() {}
+
+func (x *AccessToken) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessToken.ProtoReflect.Descriptor instead.
+func (*AccessToken) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *AccessToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AccessToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessToken) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *AccessToken) GetDataTypes() []DataType {
+	if x != nil {
+		return x.DataTypes
+	}
+	return nil
+}
+
+func (x *AccessToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AccessToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AccessToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *AccessToken) GetCertificateFingerprint() string {
+	if x != nil {
+		return x.CertificateFingerprint
+	}
+	return ""
+}
+
+// Организация
+type Organization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Роль текущего пользователя
+	Role          OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.OrganizationRole" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,43 +6002,65 @@ func (x *GetDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDataRequest.ProtoReflect.Descriptor instead.
-func (*GetDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *GetDataRequest) GetId() string {
+func (x *Organization) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-// Запрос списка данных
-type ListDataRequest struct {
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() OrganizationRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
+}
+
+func (x *Organization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Участник организации
+type OrganizationMember struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          *DataType              `protobuf:"varint,1,opt,name=type,proto3,enum=gophkeeper.DataType,oneof" json:"type,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          OrganizationRole       `protobuf:"varint,3,opt,name=role,proto3,enum=gophkeeper.OrganizationRole" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDataRequest) Reset() {
-	*x = ListDataRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[7]
+func (x *OrganizationMember) Reset() {
+	*x = OrganizationMember{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDataRequest) String() string {
+func (x *OrganizationMember) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDataRequest) ProtoMessage() {}
+func (*OrganizationMember) ProtoMessage() {}
 
-func (x *ListDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[7]
+func (x *OrganizationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,60 +6071,69 @@ func (x *ListDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDataRequest.ProtoReflect.Descriptor instead.
-func (*ListDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use OrganizationMember.ProtoReflect.Descriptor instead.
+func (*OrganizationMember) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *ListDataRequest) GetType() DataType {
-	if x != nil && x.Type != nil {
-		return *x.Type
+func (x *OrganizationMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
-	return DataType_DATA_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *ListDataRequest) GetLimit() int32 {
+func (x *OrganizationMember) GetUsername() string {
 	if x != nil {
-		return x.Limit
+		return x.Username
 	}
-	return 0
+	return ""
 }
 
-func (x *ListDataRequest) GetOffset() int32 {
+func (x *OrganizationMember) GetRole() OrganizationRole {
 	if x != nil {
-		return x.Offset
+		return x.Role
 	}
-	return 0
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
 }
 
-// Запрос обновления данных
-type UpdateDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	EncryptedData []byte                 `protobuf:"bytes,4,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
-	Metadata      string                 `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *OrganizationMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *UpdateDataRequest) Reset() {
-	*x = UpdateDataRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[8]
+// Приглашение в организацию
+type OrganizationInvitation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId   string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	OrganizationName string                 `protobuf:"bytes,3,opt,name=organization_name,json=organizationName,proto3" json:"organization_name,omitempty"`
+	Username         string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	InvitedBy        string                 `protobuf:"bytes,5,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"`
+	Role             OrganizationRole       `protobuf:"varint,6,opt,name=role,proto3,enum=gophkeeper.OrganizationRole" json:"role,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrganizationInvitation) Reset() {
+	*x = OrganizationInvitation{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDataRequest) String() string {
+func (x *OrganizationInvitation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDataRequest) ProtoMessage() {}
+func (*OrganizationInvitation) ProtoMessage() {}
 
-func (x *UpdateDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[8]
+func (x *OrganizationInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,121 +6144,101 @@ func (x *UpdateDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDataRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use OrganizationInvitation.ProtoReflect.Descriptor instead.
+func (*OrganizationInvitation) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *UpdateDataRequest) GetId() string {
+func (x *OrganizationInvitation) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateDataRequest) GetName() string {
+func (x *OrganizationInvitation) GetOrganizationId() string {
 	if x != nil {
-		return x.Name
+		return x.OrganizationId
 	}
 	return ""
 }
 
-func (x *UpdateDataRequest) GetDescription() string {
+func (x *OrganizationInvitation) GetOrganizationName() string {
 	if x != nil {
-		return x.Description
+		return x.OrganizationName
 	}
 	return ""
 }
 
-func (x *UpdateDataRequest) GetEncryptedData() []byte {
+func (x *OrganizationInvitation) GetUsername() string {
 	if x != nil {
-		return x.EncryptedData
+		return x.Username
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateDataRequest) GetMetadata() string {
+func (x *OrganizationInvitation) GetInvitedBy() string {
 	if x != nil {
-		return x.Metadata
+		return x.InvitedBy
 	}
 	return ""
 }
 
-func (x *UpdateDataRequest) GetVersion() int64 {
+func (x *OrganizationInvitation) GetRole() OrganizationRole {
 	if x != nil {
-		return x.Version
+		return x.Role
 	}
-	return 0
-}
-
-// Запрос удаления данных
-type DeleteDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DeleteDataRequest) Reset() {
-	*x = DeleteDataRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *DeleteDataRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED
 }
 
-func (*DeleteDataRequest) ProtoMessage() {}
-
-func (x *DeleteDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[9]
+func (x *OrganizationInvitation) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CreatedAt
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteDataRequest.ProtoReflect.Descriptor instead.
-func (*DeleteDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{9}
+	return nil
 }
 
-func (x *DeleteDataRequest) GetId() string {
+func (x *OrganizationInvitation) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.ExpiresAt
 	}
-	return ""
+	return nil
 }
 
-// Запрос синхронизации
-type SyncDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	LastSyncTime  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
+// Коллекция записей организации
+type Collection struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrganizationId string                 `protobuf:"bytes,2,opt,name=organization_id,json=organizationId,proto3" json:"organization_id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	KeyVersion     int32                  `protobuf:"varint,4,opt,name=key_version,json=keyVersion,proto3" json:"key_version,omitempty"`
+	// Участник был исключен, ключ коллекции нужно сменить
+	RotationRequired bool `protobuf:"varint,5,opt,name=rotation_required,json=rotationRequired,proto3" json:"rotation_required,omitempty"`
+	// Ключ коллекции, зашифрованный открытым ключом текущего пользователя.
+	// Пустой, если ключ пользователю еще не передан.
+	WrappedKey []byte                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Период смены паролей записей коллекции в днях, 0 - политика не задана
+	RotationDays  int32 `protobuf:"varint,8,opt,name=rotation_days,json=rotationDays,proto3" json:"rotation_days,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncDataRequest) Reset() {
-	*x = SyncDataRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[10]
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncDataRequest) String() string {
+func (x *Collection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncDataRequest) ProtoMessage() {}
+func (*Collection) ProtoMessage() {}
 
-func (x *SyncDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[10]
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,87 +6249,91 @@ func (x *SyncDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncDataRequest.ProtoReflect.Descriptor instead.
-func (*SyncDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *SyncDataRequest) GetLastSyncTime() *timestamppb.Timestamp {
+func (x *Collection) GetId() string {
 	if x != nil {
-		return x.LastSyncTime
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-// Запрос генерации OTP
-type GenerateOTPRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Collection) GetOrganizationId() string {
+	if x != nil {
+		return x.OrganizationId
+	}
+	return ""
 }
 
-func (x *GenerateOTPRequest) Reset() {
-	*x = GenerateOTPRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *GenerateOTPRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Collection) GetKeyVersion() int32 {
+	if x != nil {
+		return x.KeyVersion
+	}
+	return 0
 }
 
-func (*GenerateOTPRequest) ProtoMessage() {}
+func (x *Collection) GetRotationRequired() bool {
+	if x != nil {
+		return x.RotationRequired
+	}
+	return false
+}
 
-func (x *GenerateOTPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[11]
+func (x *Collection) GetWrappedKey() []byte {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.WrappedKey
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GenerateOTPRequest.ProtoReflect.Descriptor instead.
-func (*GenerateOTPRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{11}
+func (x *Collection) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *GenerateOTPRequest) GetSecret() string {
+func (x *Collection) GetRotationDays() int32 {
 	if x != nil {
-		return x.Secret
+		return x.RotationDays
 	}
-	return ""
+	return 0
 }
 
-// Запрос создания OTP секрета
-type CreateOTPSecretRequest struct {
+// Ключ коллекции, зашифрованный для участника
+type CollectionKey struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Issuer        string                 `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
-	AccountName   string                 `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOTPSecretRequest) Reset() {
-	*x = CreateOTPSecretRequest{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[12]
+func (x *CollectionKey) Reset() {
+	*x = CollectionKey{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOTPSecretRequest) String() string {
+func (x *CollectionKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOTPSecretRequest) ProtoMessage() {}
+func (*CollectionKey) ProtoMessage() {}
 
-func (x *CreateOTPSecretRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[12]
+func (x *CollectionKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,48 +6344,51 @@ func (x *CreateOTPSecretRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOTPSecretRequest.ProtoReflect.Descriptor instead.
-func (*CreateOTPSecretRequest) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use CollectionKey.ProtoReflect.Descriptor instead.
+func (*CollectionKey) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *CreateOTPSecretRequest) GetIssuer() string {
+func (x *CollectionKey) GetUserId() string {
 	if x != nil {
-		return x.Issuer
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *CreateOTPSecretRequest) GetAccountName() string {
+func (x *CollectionKey) GetWrappedKey() []byte {
 	if x != nil {
-		return x.AccountName
+		return x.WrappedKey
 	}
-	return ""
+	return nil
 }
 
-// Ответ записи данных
-type DataEntryResponse struct {
+// Запись коллекции, перешифрованная новым ключом
+type CollectionEntryUpdate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DataEntry     *DataEntry             `protobuf:"bytes,1,opt,name=data_entry,json=dataEntry,proto3" json:"data_entry,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EncryptedData []byte                 `protobuf:"bytes,2,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
+	// Текущая версия записи для защиты от одновременного изменения
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DataEntryResponse) Reset() {
-	*x = DataEntryResponse{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[13]
+func (x *CollectionEntryUpdate) Reset() {
+	*x = CollectionEntryUpdate{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DataEntryResponse) String() string {
+func (x *CollectionEntryUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataEntryResponse) ProtoMessage() {}
+func (*CollectionEntryUpdate) ProtoMessage() {}
 
-func (x *DataEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[13]
+func (x *CollectionEntryUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -848,42 +6399,60 @@ func (x *DataEntryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataEntryResponse.ProtoReflect.Descriptor instead.
-func (*DataEntryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use CollectionEntryUpdate.ProtoReflect.Descriptor instead.
+func (*CollectionEntryUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *DataEntryResponse) GetDataEntry() *DataEntry {
+func (x *CollectionEntryUpdate) GetId() string {
 	if x != nil {
-		return x.DataEntry
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CollectionEntryUpdate) GetEncryptedData() []byte {
+	if x != nil {
+		return x.EncryptedData
 	}
 	return nil
 }
 
-// Ответ списка данных
-type ListDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DataEntries   []*DataEntry           `protobuf:"bytes,1,rep,name=data_entries,json=dataEntries,proto3" json:"data_entries,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+func (x *CollectionEntryUpdate) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Одноразовая ссылка на секрет
+type SecretLink struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Путь публичной страницы; ключ расшифровки добавляется клиентом во фрагмент
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	MaxViews      int32                  `protobuf:"varint,3,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDataResponse) Reset() {
-	*x = ListDataResponse{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[14]
+func (x *SecretLink) Reset() {
+	*x = SecretLink{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDataResponse) String() string {
+func (x *SecretLink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDataResponse) ProtoMessage() {}
+func (*SecretLink) ProtoMessage() {}
 
-func (x *ListDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[14]
+func (x *SecretLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,48 +6463,80 @@ func (x *ListDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDataResponse.ProtoReflect.Descriptor instead.
-func (*ListDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SecretLink.ProtoReflect.Descriptor instead.
+func (*SecretLink) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *ListDataResponse) GetDataEntries() []*DataEntry {
+func (x *SecretLink) GetId() string {
 	if x != nil {
-		return x.DataEntries
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ListDataResponse) GetTotal() int32 {
+func (x *SecretLink) GetPath() string {
 	if x != nil {
-		return x.Total
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SecretLink) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
 	}
 	return 0
 }
 
-// Ответ удаления данных
-type DeleteDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+func (x *SecretLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SecretLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Экстренный доступ контакта к хранилищу владельца
+type EmergencyAccess struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GrantorId       string                 `protobuf:"bytes,2,opt,name=grantor_id,json=grantorId,proto3" json:"grantor_id,omitempty"`
+	GrantorUsername string                 `protobuf:"bytes,3,opt,name=grantor_username,json=grantorUsername,proto3" json:"grantor_username,omitempty"`
+	GranteeId       string                 `protobuf:"bytes,4,opt,name=grantee_id,json=granteeId,proto3" json:"grantee_id,omitempty"`
+	GranteeUsername string                 `protobuf:"bytes,5,opt,name=grantee_username,json=granteeUsername,proto3" json:"grantee_username,omitempty"`
+	WaitDays        int32                  `protobuf:"varint,6,opt,name=wait_days,json=waitDays,proto3" json:"wait_days,omitempty"`
+	Status          EmergencyAccessStatus  `protobuf:"varint,7,opt,name=status,proto3,enum=gophkeeper.EmergencyAccessStatus" json:"status,omitempty"`
+	RequestedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// Момент автоматического предоставления доступа, если владелец не отклонит запрос
+	AvailableAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=available_at,json=availableAt,proto3" json:"available_at,omitempty"`
+	ApprovedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteDataResponse) Reset() {
-	*x = DeleteDataResponse{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[15]
+func (x *EmergencyAccess) Reset() {
+	*x = EmergencyAccess{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteDataResponse) String() string {
+func (x *EmergencyAccess) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteDataResponse) ProtoMessage() {}
+func (*EmergencyAccess) ProtoMessage() {}
 
-func (x *DeleteDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[15]
+func (x *EmergencyAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,104 +6547,116 @@ func (x *DeleteDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteDataResponse.ProtoReflect.Descriptor instead.
-func (*DeleteDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use EmergencyAccess.ProtoReflect.Descriptor instead.
+func (*EmergencyAccess) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *DeleteDataResponse) GetSuccess() bool {
+func (x *EmergencyAccess) GetId() string {
 	if x != nil {
-		return x.Success
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-// Ответ синхронизации
-type SyncDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DataEntries   []*DataEntry           `protobuf:"bytes,1,rep,name=data_entries,json=dataEntries,proto3" json:"data_entries,omitempty"`
-	DeletedIds    []string               `protobuf:"bytes,2,rep,name=deleted_ids,json=deletedIds,proto3" json:"deleted_ids,omitempty"`
-	LastSyncTime  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_sync_time,json=lastSyncTime,proto3" json:"last_sync_time,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *EmergencyAccess) GetGrantorId() string {
+	if x != nil {
+		return x.GrantorId
+	}
+	return ""
 }
 
-func (x *SyncDataResponse) Reset() {
-	*x = SyncDataResponse{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *EmergencyAccess) GetGrantorUsername() string {
+	if x != nil {
+		return x.GrantorUsername
+	}
+	return ""
 }
 
-func (x *SyncDataResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *EmergencyAccess) GetGranteeId() string {
+	if x != nil {
+		return x.GranteeId
+	}
+	return ""
 }
 
-func (*SyncDataResponse) ProtoMessage() {}
+func (x *EmergencyAccess) GetGranteeUsername() string {
+	if x != nil {
+		return x.GranteeUsername
+	}
+	return ""
+}
 
-func (x *SyncDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[16]
+func (x *EmergencyAccess) GetWaitDays() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.WaitDays
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SyncDataResponse.ProtoReflect.Descriptor instead.
-func (*SyncDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{16}
+func (x *EmergencyAccess) GetStatus() EmergencyAccessStatus {
+	if x != nil {
+		return x.Status
+	}
+	return EmergencyAccessStatus_EMERGENCY_ACCESS_STATUS_UNSPECIFIED
 }
 
-func (x *SyncDataResponse) GetDataEntries() []*DataEntry {
+func (x *EmergencyAccess) GetRequestedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.DataEntries
+		return x.RequestedAt
 	}
 	return nil
 }
 
-func (x *SyncDataResponse) GetDeletedIds() []string {
+func (x *EmergencyAccess) GetAvailableAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.DeletedIds
+		return x.AvailableAt
 	}
 	return nil
 }
 
-func (x *SyncDataResponse) GetLastSyncTime() *timestamppb.Timestamp {
+func (x *EmergencyAccess) GetApprovedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.LastSyncTime
+		return x.ApprovedAt
 	}
 	return nil
 }
 
-// Ответ генерации OTP
-type GenerateOTPResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	TimeRemaining int32                  `protobuf:"varint,3,opt,name=time_remaining,json=timeRemaining,proto3" json:"time_remaining,omitempty"`
+func (x *EmergencyAccess) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Событие журнала экстренного доступа
+type EmergencyAccessEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EmergencyAccessId string                 `protobuf:"bytes,2,opt,name=emergency_access_id,json=emergencyAccessId,proto3" json:"emergency_access_id,omitempty"`
+	// Пусто для событий, выполненных сервером автоматически
+	ActorUsername string                 `protobuf:"bytes,3,opt,name=actor_username,json=actorUsername,proto3" json:"actor_username,omitempty"`
+	Event         string                 `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateOTPResponse) Reset() {
-	*x = GenerateOTPResponse{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[17]
+func (x *EmergencyAccessEvent) Reset() {
+	*x = EmergencyAccessEvent{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateOTPResponse) String() string {
+func (x *EmergencyAccessEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateOTPResponse) ProtoMessage() {}
+func (*EmergencyAccessEvent) ProtoMessage() {}
 
-func (x *GenerateOTPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[17]
+func (x *EmergencyAccessEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,57 +6667,71 @@ func (x *GenerateOTPResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateOTPResponse.ProtoReflect.Descriptor instead.
-func (*GenerateOTPResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use EmergencyAccessEvent.ProtoReflect.Descriptor instead.
+func (*EmergencyAccessEvent) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *GenerateOTPResponse) GetCode() string {
+func (x *EmergencyAccessEvent) GetId() string {
 	if x != nil {
-		return x.Code
+		return x.Id
 	}
 	return ""
 }
 
-func (x *GenerateOTPResponse) GetExpiresAt() *timestamppb.Timestamp {
+func (x *EmergencyAccessEvent) GetEmergencyAccessId() string {
 	if x != nil {
-		return x.ExpiresAt
+		return x.EmergencyAccessId
 	}
-	return nil
+	return ""
 }
 
-func (x *GenerateOTPResponse) GetTimeRemaining() int32 {
+func (x *EmergencyAccessEvent) GetActorUsername() string {
 	if x != nil {
-		return x.TimeRemaining
+		return x.ActorUsername
 	}
-	return 0
+	return ""
 }
 
-// Ответ создания OTP секрета
-type CreateOTPSecretResponse struct {
+func (x *EmergencyAccessEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *EmergencyAccessEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Уведомление пользователя
+type Notification struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
-	QrCodeUrl     string                 `protobuf:"bytes,2,opt,name=qr_code_url,json=qrCodeUrl,proto3" json:"qr_code_url,omitempty"`
-	BackupCodes   []string               `protobuf:"bytes,3,rep,name=backup_codes,json=backupCodes,proto3" json:"backup_codes,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOTPSecretResponse) Reset() {
-	*x = CreateOTPSecretResponse{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[18]
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOTPSecretResponse) String() string {
+func (x *Notification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOTPSecretResponse) ProtoMessage() {}
+func (*Notification) ProtoMessage() {}
 
-func (x *CreateOTPSecretResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[18]
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,63 +6742,63 @@ func (x *CreateOTPSecretResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOTPSecretResponse.ProtoReflect.Descriptor instead.
-func (*CreateOTPSecretResponse) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *CreateOTPSecretResponse) GetSecret() string {
+func (x *Notification) GetId() string {
 	if x != nil {
-		return x.Secret
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateOTPSecretResponse) GetQrCodeUrl() string {
+func (x *Notification) GetMessage() string {
 	if x != nil {
-		return x.QrCodeUrl
+		return x.Message
 	}
 	return ""
 }
 
-func (x *CreateOTPSecretResponse) GetBackupCodes() []string {
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.BackupCodes
+		return x.CreatedAt
 	}
 	return nil
 }
 
-// Запись данных
-type DataEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type          DataType               `protobuf:"varint,2,opt,name=type,proto3,enum=gophkeeper.DataType" json:"type,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	EncryptedData []byte                 `protobuf:"bytes,5,opt,name=encrypted_data,json=encryptedData,proto3" json:"encrypted_data,omitempty"`
-	Metadata      string                 `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+// Вложение записи
+type Attachment struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EntryId  string                 `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	MimeType string                 `protobuf:"bytes,4,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Размер зашифрованного содержимого в байтах
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// Ключ вложения, зашифрованный на клиенте ключом хранилища или ключом общей записи
+	WrappedKey    []byte                 `protobuf:"bytes,6,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Version       int64                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DataEntry) Reset() {
-	*x = DataEntry{}
-	mi := &file_proto_gophkeeper_proto_msgTypes[19]
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_proto_gophkeeper_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DataEntry) String() string {
+func (x *Attachment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataEntry) ProtoMessage() {}
+func (*Attachment) ProtoMessage() {}
 
-func (x *DataEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_gophkeeper_proto_msgTypes[19]
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gophkeeper_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,129 +6809,292 @@ func (x *DataEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataEntry.ProtoReflect.Descriptor instead.
-func (*DataEntry) Descriptor() ([]byte, []int) {
-	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_proto_gophkeeper_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *DataEntry) GetId() string {
+func (x *Attachment) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *DataEntry) GetType() DataType {
+func (x *Attachment) GetEntryId() string {
 	if x != nil {
-		return x.Type
+		return x.EntryId
 	}
-	return DataType_DATA_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *DataEntry) GetName() string {
+func (x *Attachment) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *DataEntry) GetDescription() string {
+func (x *Attachment) GetMimeType() string {
 	if x != nil {
-		return x.Description
+		return x.MimeType
 	}
 	return ""
 }
 
-func (x *DataEntry) GetEncryptedData() []byte {
-	if x != nil {
-		return x.EncryptedData
-	}
-	return nil
-}
-
-func (x *DataEntry) GetMetadata() string {
+func (x *Attachment) GetSize() int64 {
 	if x != nil {
-		return x.Metadata
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *DataEntry) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Attachment) GetWrappedKey() []byte {
 	if x != nil {
-		return x.CreatedAt
+		return x.WrappedKey
 	}
 	return nil
 }
 
-func (x *DataEntry) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *DataEntry) GetVersion() int64 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
-}
-
 var File_proto_gophkeeper_proto protoreflect.FileDescriptor
 
 const file_proto_gophkeeper_proto_rawDesc = "" +
 	"\n" +
 	"\x16proto/gophkeeper.proto\x12\n" +
-	"gophkeeper\x1a\x1fgoogle/protobuf/timestamp.proto\"I\n" +
+	"gophkeeper\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
 	"\x0fRegisterRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"F\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12*\n" +
+	"\x11wrapped_vault_key\x18\x03 \x01(\fR\x0fwrappedVaultKey\x12\x19\n" +
+	"\bsrp_salt\x18\x04 \x01(\fR\asrpSalt\x12!\n" +
+	"\fsrp_verifier\x18\x05 \x01(\fR\vsrpVerifier\"F\n" +
 	"\fLoginRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"+\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"^\n" +
+	"\x14BeginSRPLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12*\n" +
+	"\x11client_public_key\x18\x02 \x01(\fR\x0fclientPublicKey\"U\n" +
+	"\x15FinishSRPLoginRequest\x12\x19\n" +
+	"\blogin_id\x18\x01 \x01(\tR\aloginId\x12!\n" +
+	"\fclient_proof\x18\x02 \x01(\fR\vclientProof\"+\n" +
 	"\x13RefreshTokenRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\x85\x01\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"@\n" +
+	"\x12SetVaultKeyRequest\x12*\n" +
+	"\x11wrapped_vault_key\x18\x01 \x01(\fR\x0fwrappedVaultKey\"\x93\x02\n" +
+	"\x15ChangePasswordRequest\x12!\n" +
+	"\fold_password\x18\x01 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\x12*\n" +
+	"\x11wrapped_vault_key\x18\x03 \x01(\fR\x0fwrappedVaultKey\x12\x19\n" +
+	"\bsrp_salt\x18\x04 \x01(\fR\asrpSalt\x12!\n" +
+	"\fsrp_verifier\x18\x05 \x01(\fR\vsrpVerifier\x12 \n" +
+	"\fsrp_login_id\x18\x06 \x01(\tR\n" +
+	"srpLoginId\x12(\n" +
+	"\x10srp_client_proof\x18\a \x01(\fR\x0esrpClientProof\"~\n" +
+	"\x14DeleteAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\x12 \n" +
+	"\fsrp_login_id\x18\x02 \x01(\tR\n" +
+	"srpLoginId\x12(\n" +
+	"\x10srp_client_proof\x18\x03 \x01(\fR\x0esrpClientProof\"~\n" +
+	"\x14ExportAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\x12 \n" +
+	"\fsrp_login_id\x18\x02 \x01(\tR\n" +
+	"srpLoginId\x12(\n" +
+	"\x10srp_client_proof\x18\x03 \x01(\fR\x0esrpClientProof\"\xea\x01\n" +
+	"\x18CreateAccessTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tread_only\x18\x02 \x01(\bR\breadOnly\x123\n" +
+	"\n" +
+	"data_types\x18\x03 \x03(\x0e2\x14.gophkeeper.DataTypeR\tdataTypes\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12-\n" +
+	"\x12client_certificate\x18\x05 \x01(\fR\x11clientCertificate\"\x19\n" +
+	"\x17ListAccessTokensRequest\"*\n" +
+	"\x18RevokeAccessTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x93\x02\n" +
 	"\fAuthResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
 	"\n" +
 	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12$\n" +
-	"\x04user\x18\x03 \x01(\v2\x10.gophkeeper.UserR\x04user\"\xa8\x01\n" +
+	"\x04user\x18\x03 \x01(\v2\x10.gophkeeper.UserR\x04user\x12*\n" +
+	"\x11wrapped_vault_key\x18\x04 \x01(\fR\x0fwrappedVaultKey\x12.\n" +
+	"\x13wrapped_private_key\x18\x05 \x01(\fR\x11wrappedPrivateKey\x120\n" +
+	"\x14srp_upgrade_required\x18\x06 \x01(\bR\x12srpUpgradeRequired\"r\n" +
+	"\x15BeginSRPLoginResponse\x12\x19\n" +
+	"\blogin_id\x18\x01 \x01(\tR\aloginId\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\fR\x04salt\x12*\n" +
+	"\x11server_public_key\x18\x03 \x01(\fR\x0fserverPublicKey\"i\n" +
+	"\x16FinishSRPLoginResponse\x12,\n" +
+	"\x04auth\x18\x01 \x01(\v2\x18.gophkeeper.AuthResponseR\x04auth\x12!\n" +
+	"\fserver_proof\x18\x02 \x01(\fR\vserverProof\"\xa8\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x129\n" +
 	"\n" +
 	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb6\x01\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdb\x01\n" +
 	"\x11CreateDataRequest\x12(\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x14.gophkeeper.DataTypeR\x04type\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
 	"\x0eencrypted_data\x18\x04 \x01(\fR\rencryptedData\x12\x1a\n" +
-	"\bmetadata\x18\x05 \x01(\tR\bmetadata\" \n" +
+	"\bmetadata\x18\x05 \x01(\tR\bmetadata\x12#\n" +
+	"\rrotation_days\x18\x06 \x01(\x05R\frotationDays\" \n" +
 	"\x0eGetDataRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"w\n" +
 	"\x0fListDataRequest\x12-\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x14.gophkeeper.DataTypeH\x00R\x04type\x88\x01\x01\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x05R\x06offsetB\a\n" +
-	"\x05_type\"\xb6\x01\n" +
+	"\x05_type\"\x9d\x02\n" +
 	"\x11UpdateDataRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
 	"\x0eencrypted_data\x18\x04 \x01(\fR\rencryptedData\x12\x1a\n" +
 	"\bmetadata\x18\x05 \x01(\tR\bmetadata\x12\x18\n" +
-	"\aversion\x18\x06 \x01(\x03R\aversion\"#\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12(\n" +
+	"\rrotation_days\x18\a \x01(\x05H\x00R\frotationDays\x88\x01\x01\x12)\n" +
+	"\x10password_changed\x18\b \x01(\bR\x0fpasswordChangedB\x10\n" +
+	"\x0e_rotation_days\"<\n" +
+	"\x19ListDueForRotationRequest\x12\x1f\n" +
+	"\vwithin_days\x18\x01 \x01(\x05R\n" +
+	"withinDays\"#\n" +
 	"\x11DeleteDataRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"S\n" +
 	"\x0fSyncDataRequest\x12@\n" +
-	"\x0elast_sync_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\",\n" +
+	"\x0elast_sync_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\"d\n" +
+	"\x13SetPublicKeyRequest\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12.\n" +
+	"\x13wrapped_private_key\x18\x02 \x01(\fR\x11wrappedPrivateKey\"1\n" +
+	"\x13GetPublicKeyRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\xbb\x01\n" +
+	"\x11ShareEntryRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12-\n" +
+	"\x12recipient_username\x18\x02 \x01(\tR\x11recipientUsername\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\fR\n" +
+	"wrappedKey\x12;\n" +
+	"\n" +
+	"permission\x18\x04 \x01(\x0e2\x1b.gophkeeper.SharePermissionR\n" +
+	"permission\"3\n" +
+	"\x16ListEntrySharesRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\"$\n" +
+	"\x12RevokeShareRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x19\n" +
+	"\x17ListSharedWithMeRequest\"\xaa\x01\n" +
+	"\x14AddAttachmentRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x1f\n" +
+	"\vwrapped_key\x18\x04 \x01(\fR\n" +
+	"wrappedKey\x12%\n" +
+	"\x0eencrypted_data\x18\x05 \x01(\fR\rencryptedData\"3\n" +
+	"\x16ListAttachmentsRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\"A\n" +
+	"\x14GetAttachmentRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"D\n" +
+	"\x17RemoveAttachmentRequest\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"/\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1a\n" +
+	"\x18ListOrganizationsRequest\"I\n" +
+	"\x1eListOrganizationMembersRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"\x8c\x01\n" +
+	"\x13InviteMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x120\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1c.gophkeeper.OrganizationRoleR\x04role\"\x18\n" +
+	"\x16ListInvitationsRequest\")\n" +
+	"\x17AcceptInvitationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x18DeclineInvitationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8d\x01\n" +
+	"\x17UpdateMemberRoleRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x120\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1c.gophkeeper.OrganizationRoleR\x04role\"W\n" +
+	"\x13RemoveMemberRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x9c\x01\n" +
+	"\x17CreateCollectionRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\fR\n" +
+	"wrappedKey\x12#\n" +
+	"\rrotation_days\x18\x04 \x01(\x05R\frotationDays\"n\n" +
+	"\"SetCollectionRotationPolicyRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12#\n" +
+	"\rrotation_days\x18\x02 \x01(\x05R\frotationDays\"A\n" +
+	"\x16ListCollectionsRequest\x12'\n" +
+	"\x0forganization_id\x18\x01 \x01(\tR\x0eorganizationId\"\x9b\x01\n" +
+	"\x19GrantCollectionKeyRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\fR\n" +
+	"wrappedKey\x12\x1f\n" +
+	"\vkey_version\x18\x04 \x01(\x05R\n" +
+	"keyVersion\"\xce\x01\n" +
+	"\x1aRotateCollectionKeyRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x1f\n" +
+	"\vkey_version\x18\x02 \x01(\x05R\n" +
+	"keyVersion\x12-\n" +
+	"\x04keys\x18\x03 \x03(\v2\x19.gophkeeper.CollectionKeyR\x04keys\x12;\n" +
+	"\aentries\x18\x04 \x03(\v2!.gophkeeper.CollectionEntryUpdateR\aentries\"\x8b\x02\n" +
+	"\x1cCreateCollectionEntryRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12(\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x14.gophkeeper.DataTypeR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12%\n" +
+	"\x0eencrypted_data\x18\x05 \x01(\fR\rencryptedData\x12\x1a\n" +
+	"\bmetadata\x18\x06 \x01(\tR\bmetadata\x12#\n" +
+	"\rrotation_days\x18\a \x01(\x05R\frotationDays\"C\n" +
+	"\x1cListCollectionEntriesRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\"\x98\x01\n" +
+	"\x17CreateSecretLinkRequest\x12%\n" +
+	"\x0eencrypted_data\x18\x01 \x01(\fR\rencryptedData\x12\x1b\n" +
+	"\tmax_views\x18\x02 \x01(\x05R\bmaxViews\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"v\n" +
+	"\x1aAddEmergencyContactRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1b\n" +
+	"\twait_days\x18\x02 \x01(\x05R\bwaitDays\x12\x1f\n" +
+	"\vwrapped_key\x18\x03 \x01(\fR\n" +
+	"wrappedKey\"\x1c\n" +
+	"\x1aListEmergencyAccessRequest\"/\n" +
+	"\x1dRemoveEmergencyContactRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x1dRequestEmergencyAccessRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x1dApproveEmergencyAccessRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x1aDenyEmergencyAccessRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"*\n" +
+	"\x18GetEmergencyVaultRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	" ListEmergencyAccessEventsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18ListNotificationsRequest\"z\n" +
 	"\x12GenerateOTPRequest\x12\x16\n" +
-	"\x06secret\x18\x01 \x01(\tR\x06secret\"S\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x16\n" +
+	"\x06digits\x18\x03 \x01(\x05R\x06digits\x12\x16\n" +
+	"\x06period\x18\x04 \x01(\x05R\x06period\"\xa1\x01\n" +
 	"\x16CreateOTPSecretRequest\x12\x16\n" +
 	"\x06issuer\x18\x01 \x01(\tR\x06issuer\x12!\n" +
-	"\faccount_name\x18\x02 \x01(\tR\vaccountName\"I\n" +
+	"\faccount_name\x18\x02 \x01(\tR\vaccountName\x12\x1c\n" +
+	"\talgorithm\x18\x03 \x01(\tR\talgorithm\x12\x16\n" +
+	"\x06digits\x18\x04 \x01(\x05R\x06digits\x12\x16\n" +
+	"\x06period\x18\x05 \x01(\x05R\x06period\"I\n" +
 	"\x11DataEntryResponse\x124\n" +
 	"\n" +
 	"data_entry\x18\x01 \x01(\v2\x15.gophkeeper.DataEntryR\tdataEntry\"b\n" +
@@ -1312,21 +7102,110 @@ const file_proto_gophkeeper_proto_rawDesc = "" +
 	"\fdata_entries\x18\x01 \x03(\v2\x15.gophkeeper.DataEntryR\vdataEntries\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\".\n" +
 	"\x12DeleteDataResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"1\n" +
+	"\x15DeleteAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x86\x01\n" +
+	"\x15ExportAccountResponse\x12\x18\n" +
+	"\aarchive\x18\x01 \x01(\fR\aarchive\x12;\n" +
+	"\vexported_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\x12\x16\n" +
+	"\x06export\x18\x03 \x01(\fR\x06export\"m\n" +
+	"\x19CreateAccessTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12:\n" +
+	"\faccess_token\x18\x02 \x01(\v2\x17.gophkeeper.AccessTokenR\vaccessToken\"X\n" +
+	"\x18ListAccessTokensResponse\x12<\n" +
+	"\raccess_tokens\x18\x01 \x03(\v2\x17.gophkeeper.AccessTokenR\faccessTokens\"5\n" +
+	"\x19RevokeAccessTokenResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xaf\x01\n" +
 	"\x10SyncDataResponse\x128\n" +
 	"\fdata_entries\x18\x01 \x03(\v2\x15.gophkeeper.DataEntryR\vdataEntries\x12\x1f\n" +
 	"\vdeleted_ids\x18\x02 \x03(\tR\n" +
 	"deletedIds\x12@\n" +
-	"\x0elast_sync_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\"\x8b\x01\n" +
+	"\x0elast_sync_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\flastSyncTime\"/\n" +
+	"\x13SetVaultKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"0\n" +
+	"\x14SetPublicKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"j\n" +
+	"\x14GetPublicKeyResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fR\tpublicKey\"B\n" +
+	"\x12ShareEntryResponse\x12,\n" +
+	"\x05share\x18\x01 \x01(\v2\x16.gophkeeper.EntryShareR\x05share\"I\n" +
+	"\x17ListEntrySharesResponse\x12.\n" +
+	"\x06shares\x18\x01 \x03(\v2\x16.gophkeeper.EntryShareR\x06shares\"/\n" +
+	"\x13RevokeShareResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"L\n" +
+	"\x12AttachmentResponse\x126\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x16.gophkeeper.AttachmentR\n" +
+	"attachment\"r\n" +
+	"\x17ListAttachmentsResponse\x128\n" +
+	"\vattachments\x18\x01 \x03(\v2\x16.gophkeeper.AttachmentR\vattachments\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x02 \x01(\x03R\ttotalSize\"v\n" +
+	"\x15GetAttachmentResponse\x126\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x16.gophkeeper.AttachmentR\n" +
+	"attachment\x12%\n" +
+	"\x0eencrypted_data\x18\x02 \x01(\fR\rencryptedData\"4\n" +
+	"\x18RemoveAttachmentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"T\n" +
+	"\x14OrganizationResponse\x12<\n" +
+	"\forganization\x18\x01 \x01(\v2\x18.gophkeeper.OrganizationR\forganization\"[\n" +
+	"\x19ListOrganizationsResponse\x12>\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x18.gophkeeper.OrganizationR\rorganizations\"[\n" +
+	"\x1fListOrganizationMembersResponse\x128\n" +
+	"\amembers\x18\x01 \x03(\v2\x1e.gophkeeper.OrganizationMemberR\amembers\"X\n" +
+	"\x12InvitationResponse\x12B\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2\".gophkeeper.OrganizationInvitationR\n" +
+	"invitation\"_\n" +
+	"\x17ListInvitationsResponse\x12D\n" +
+	"\vinvitations\x18\x01 \x03(\v2\".gophkeeper.OrganizationInvitationR\vinvitations\"5\n" +
+	"\x19DeclineInvitationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"R\n" +
+	"\x18UpdateMemberRoleResponse\x126\n" +
+	"\x06member\x18\x01 \x01(\v2\x1e.gophkeeper.OrganizationMemberR\x06member\"_\n" +
+	"\x14RemoveMemberResponse\x12G\n" +
+	" rotation_required_collection_ids\x18\x01 \x03(\tR\x1drotationRequiredCollectionIds\"L\n" +
+	"\x12CollectionResponse\x126\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\v2\x16.gophkeeper.CollectionR\n" +
+	"collection\"S\n" +
+	"\x17ListCollectionsResponse\x128\n" +
+	"\vcollections\x18\x01 \x03(\v2\x16.gophkeeper.CollectionR\vcollections\"6\n" +
+	"\x1aGrantCollectionKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"F\n" +
+	"\x18CreateSecretLinkResponse\x12*\n" +
+	"\x04link\x18\x01 \x01(\v2\x16.gophkeeper.SecretLinkR\x04link\"a\n" +
+	"\x17EmergencyAccessResponse\x12F\n" +
+	"\x10emergency_access\x18\x01 \x01(\v2\x1b.gophkeeper.EmergencyAccessR\x0femergencyAccess\"\x8b\x01\n" +
+	"\x1bListEmergencyAccessResponse\x125\n" +
+	"\agranted\x18\x01 \x03(\v2\x1b.gophkeeper.EmergencyAccessR\agranted\x125\n" +
+	"\atrusted\x18\x02 \x03(\v2\x1b.gophkeeper.EmergencyAccessR\atrusted\":\n" +
+	"\x1eRemoveEmergencyContactResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xbe\x01\n" +
+	"\x19GetEmergencyVaultResponse\x12F\n" +
+	"\x10emergency_access\x18\x01 \x01(\v2\x1b.gophkeeper.EmergencyAccessR\x0femergencyAccess\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\x128\n" +
+	"\fdata_entries\x18\x03 \x03(\v2\x15.gophkeeper.DataEntryR\vdataEntries\"]\n" +
+	"!ListEmergencyAccessEventsResponse\x128\n" +
+	"\x06events\x18\x01 \x03(\v2 .gophkeeper.EmergencyAccessEventR\x06events\"[\n" +
+	"\x19ListNotificationsResponse\x12>\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x18.gophkeeper.NotificationR\rnotifications\"\x8b\x01\n" +
 	"\x13GenerateOTPResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x129\n" +
 	"\n" +
 	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12%\n" +
-	"\x0etime_remaining\x18\x03 \x01(\x05R\rtimeRemaining\"t\n" +
+	"\x0etime_remaining\x18\x03 \x01(\x05R\rtimeRemaining\"\x94\x01\n" +
 	"\x17CreateOTPSecretResponse\x12\x16\n" +
 	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1e\n" +
 	"\vqr_code_url\x18\x02 \x01(\tR\tqrCodeUrl\x12!\n" +
-	"\fbackup_codes\x18\x03 \x03(\tR\vbackupCodes\"\xce\x02\n" +
+	"\fbackup_codes\x18\x03 \x03(\tR\vbackupCodes\x12\x1e\n" +
+	"\vqr_code_png\x18\x04 \x01(\fR\tqrCodePng\"\xd6\x04\n" +
 	"\tDataEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x14.gophkeeper.DataTypeR\x04type\x12\x12\n" +
@@ -1338,18 +7217,175 @@ const file_proto_gophkeeper_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
-	"\aversion\x18\t \x01(\x03R\aversion*~\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\x12,\n" +
+	"\x05share\x18\n" +
+	" \x01(\v2\x16.gophkeeper.EntryShareR\x05share\x12#\n" +
+	"\rcollection_id\x18\v \x01(\tR\fcollectionId\x12J\n" +
+	"\x13password_changed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x11passwordChangedAt\x12#\n" +
+	"\rrotation_days\x18\r \x01(\x05R\frotationDays\x12B\n" +
+	"\x0frotation_due_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\rrotationDueAt\"\xa6\x02\n" +
+	"\n" +
+	"EntryShare\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\x12%\n" +
+	"\x0eowner_username\x18\x03 \x01(\tR\rownerUsername\x12-\n" +
+	"\x12recipient_username\x18\x04 \x01(\tR\x11recipientUsername\x12;\n" +
+	"\n" +
+	"permission\x18\x05 \x01(\x0e2\x1b.gophkeeper.SharePermissionR\n" +
+	"permission\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\fR\n" +
+	"wrappedKey\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf0\x02\n" +
+	"\vAccessToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\x123\n" +
+	"\n" +
+	"data_types\x18\x04 \x03(\x0e2\x14.gophkeeper.DataTypeR\tdataTypes\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x127\n" +
+	"\x17certificate_fingerprint\x18\b \x01(\tR\x16certificateFingerprint\"\x9f\x01\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1c.gophkeeper.OrganizationRoleR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb6\x01\n" +
+	"\x12OrganizationMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x120\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1c.gophkeeper.OrganizationRoleR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xe1\x02\n" +
+	"\x16OrganizationInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12+\n" +
+	"\x11organization_name\x18\x03 \x01(\tR\x10organizationName\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x05 \x01(\tR\tinvitedBy\x120\n" +
+	"\x04role\x18\x06 \x01(\x0e2\x1c.gophkeeper.OrganizationRoleR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xa8\x02\n" +
+	"\n" +
+	"Collection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0forganization_id\x18\x02 \x01(\tR\x0eorganizationId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vkey_version\x18\x04 \x01(\x05R\n" +
+	"keyVersion\x12+\n" +
+	"\x11rotation_required\x18\x05 \x01(\bR\x10rotationRequired\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\fR\n" +
+	"wrappedKey\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rrotation_days\x18\b \x01(\x05R\frotationDays\"I\n" +
+	"\rCollectionKey\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\"h\n" +
+	"\x15CollectionEntryUpdate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eencrypted_data\x18\x02 \x01(\fR\rencryptedData\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"\xc3\x01\n" +
+	"\n" +
+	"SecretLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\tmax_views\x18\x03 \x01(\x05R\bmaxViews\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x83\x04\n" +
+	"\x0fEmergencyAccess\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"grantor_id\x18\x02 \x01(\tR\tgrantorId\x12)\n" +
+	"\x10grantor_username\x18\x03 \x01(\tR\x0fgrantorUsername\x12\x1d\n" +
+	"\n" +
+	"grantee_id\x18\x04 \x01(\tR\tgranteeId\x12)\n" +
+	"\x10grantee_username\x18\x05 \x01(\tR\x0fgranteeUsername\x12\x1b\n" +
+	"\twait_days\x18\x06 \x01(\x05R\bwaitDays\x129\n" +
+	"\x06status\x18\a \x01(\x0e2!.gophkeeper.EmergencyAccessStatusR\x06status\x12=\n" +
+	"\frequested_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12=\n" +
+	"\favailable_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vavailableAt\x12;\n" +
+	"\vapproved_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"approvedAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xce\x01\n" +
+	"\x14EmergencyAccessEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x13emergency_access_id\x18\x02 \x01(\tR\x11emergencyAccessId\x12%\n" +
+	"\x0eactor_username\x18\x03 \x01(\tR\ractorUsername\x12\x14\n" +
+	"\x05event\x18\x04 \x01(\tR\x05event\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"s\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd8\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmime_type\x18\x04 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1f\n" +
+	"\vwrapped_key\x18\x06 \x01(\fR\n" +
+	"wrappedKey\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*\x86\x02\n" +
 	"\bDataType\x12\x19\n" +
 	"\x15DATA_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15DATA_TYPE_CREDENTIALS\x10\x01\x12\x12\n" +
 	"\x0eDATA_TYPE_TEXT\x10\x02\x12\x14\n" +
 	"\x10DATA_TYPE_BINARY\x10\x03\x12\x12\n" +
-	"\x0eDATA_TYPE_CARD\x10\x042\xbc\x06\n" +
+	"\x0eDATA_TYPE_CARD\x10\x04\x12\x11\n" +
+	"\rDATA_TYPE_OTP\x10\x05\x12\x15\n" +
+	"\x11DATA_TYPE_SSH_KEY\x10\x06\x12\x15\n" +
+	"\x11DATA_TYPE_API_KEY\x10\a\x12\x16\n" +
+	"\x12DATA_TYPE_IDENTITY\x10\b\x12\x12\n" +
+	"\x0eDATA_TYPE_WIFI\x10\t\x12\x19\n" +
+	"\x15DATA_TYPE_SECURE_NOTE\x10\n" +
+	"*j\n" +
+	"\x0fSharePermission\x12 \n" +
+	"\x1cSHARE_PERMISSION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15SHARE_PERMISSION_READ\x10\x01\x12\x1a\n" +
+	"\x16SHARE_PERMISSION_WRITE\x10\x02*\xaf\x01\n" +
+	"\x15EmergencyAccessStatus\x12'\n" +
+	"#EMERGENCY_ACCESS_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cEMERGENCY_ACCESS_STATUS_IDLE\x10\x01\x12%\n" +
+	"!EMERGENCY_ACCESS_STATUS_REQUESTED\x10\x02\x12$\n" +
+	" EMERGENCY_ACCESS_STATUS_APPROVED\x10\x03*\xab\x01\n" +
+	"\x10OrganizationRole\x12!\n" +
+	"\x1dORGANIZATION_ROLE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ORGANIZATION_ROLE_OWNER\x10\x01\x12\x1b\n" +
+	"\x17ORGANIZATION_ROLE_ADMIN\x10\x02\x12\x1c\n" +
+	"\x18ORGANIZATION_ROLE_EDITOR\x10\x03\x12\x1c\n" +
+	"\x18ORGANIZATION_ROLE_VIEWER\x10\x042\x9c(\n" +
 	"\n" +
 	"GophKeeper\x12A\n" +
 	"\bRegister\x12\x1b.gophkeeper.RegisterRequest\x1a\x18.gophkeeper.AuthResponse\x12;\n" +
-	"\x05Login\x12\x18.gophkeeper.LoginRequest\x1a\x18.gophkeeper.AuthResponse\x12I\n" +
-	"\fRefreshToken\x12\x1f.gophkeeper.RefreshTokenRequest\x1a\x18.gophkeeper.AuthResponse\x12J\n" +
+	"\x05Login\x12\x18.gophkeeper.LoginRequest\x1a\x18.gophkeeper.AuthResponse\x12T\n" +
+	"\rBeginSRPLogin\x12 .gophkeeper.BeginSRPLoginRequest\x1a!.gophkeeper.BeginSRPLoginResponse\x12W\n" +
+	"\x0eFinishSRPLogin\x12!.gophkeeper.FinishSRPLoginRequest\x1a\".gophkeeper.FinishSRPLoginResponse\x12I\n" +
+	"\fRefreshToken\x12\x1f.gophkeeper.RefreshTokenRequest\x1a\x18.gophkeeper.AuthResponse\x12M\n" +
+	"\x0eChangePassword\x12!.gophkeeper.ChangePasswordRequest\x1a\x18.gophkeeper.AuthResponse\x12N\n" +
+	"\vSetVaultKey\x12\x1e.gophkeeper.SetVaultKeyRequest\x1a\x1f.gophkeeper.SetVaultKeyResponse\x12T\n" +
+	"\rDeleteAccount\x12 .gophkeeper.DeleteAccountRequest\x1a!.gophkeeper.DeleteAccountResponse\x12T\n" +
+	"\rExportAccount\x12 .gophkeeper.ExportAccountRequest\x1a!.gophkeeper.ExportAccountResponse\x12`\n" +
+	"\x11CreateAccessToken\x12$.gophkeeper.CreateAccessTokenRequest\x1a%.gophkeeper.CreateAccessTokenResponse\x12]\n" +
+	"\x10ListAccessTokens\x12#.gophkeeper.ListAccessTokensRequest\x1a$.gophkeeper.ListAccessTokensResponse\x12`\n" +
+	"\x11RevokeAccessToken\x12$.gophkeeper.RevokeAccessTokenRequest\x1a%.gophkeeper.RevokeAccessTokenResponse\x12J\n" +
 	"\n" +
 	"CreateData\x12\x1d.gophkeeper.CreateDataRequest\x1a\x1d.gophkeeper.DataEntryResponse\x12D\n" +
 	"\aGetData\x12\x1a.gophkeeper.GetDataRequest\x1a\x1d.gophkeeper.DataEntryResponse\x12E\n" +
@@ -1357,8 +7393,46 @@ const file_proto_gophkeeper_proto_rawDesc = "" +
 	"\n" +
 	"UpdateData\x12\x1d.gophkeeper.UpdateDataRequest\x1a\x1d.gophkeeper.DataEntryResponse\x12K\n" +
 	"\n" +
-	"DeleteData\x12\x1d.gophkeeper.DeleteDataRequest\x1a\x1e.gophkeeper.DeleteDataResponse\x12E\n" +
-	"\bSyncData\x12\x1b.gophkeeper.SyncDataRequest\x1a\x1c.gophkeeper.SyncDataResponse\x12N\n" +
+	"DeleteData\x12\x1d.gophkeeper.DeleteDataRequest\x1a\x1e.gophkeeper.DeleteDataResponse\x12Y\n" +
+	"\x12ListDueForRotation\x12%.gophkeeper.ListDueForRotationRequest\x1a\x1c.gophkeeper.ListDataResponse\x12E\n" +
+	"\bSyncData\x12\x1b.gophkeeper.SyncDataRequest\x1a\x1c.gophkeeper.SyncDataResponse\x12Q\n" +
+	"\fSetPublicKey\x12\x1f.gophkeeper.SetPublicKeyRequest\x1a .gophkeeper.SetPublicKeyResponse\x12Q\n" +
+	"\fGetPublicKey\x12\x1f.gophkeeper.GetPublicKeyRequest\x1a .gophkeeper.GetPublicKeyResponse\x12K\n" +
+	"\n" +
+	"ShareEntry\x12\x1d.gophkeeper.ShareEntryRequest\x1a\x1e.gophkeeper.ShareEntryResponse\x12Z\n" +
+	"\x0fListEntryShares\x12\".gophkeeper.ListEntrySharesRequest\x1a#.gophkeeper.ListEntrySharesResponse\x12N\n" +
+	"\vRevokeShare\x12\x1e.gophkeeper.RevokeShareRequest\x1a\x1f.gophkeeper.RevokeShareResponse\x12U\n" +
+	"\x10ListSharedWithMe\x12#.gophkeeper.ListSharedWithMeRequest\x1a\x1c.gophkeeper.ListDataResponse\x12]\n" +
+	"\x12CreateOrganization\x12%.gophkeeper.CreateOrganizationRequest\x1a .gophkeeper.OrganizationResponse\x12`\n" +
+	"\x11ListOrganizations\x12$.gophkeeper.ListOrganizationsRequest\x1a%.gophkeeper.ListOrganizationsResponse\x12r\n" +
+	"\x17ListOrganizationMembers\x12*.gophkeeper.ListOrganizationMembersRequest\x1a+.gophkeeper.ListOrganizationMembersResponse\x12O\n" +
+	"\fInviteMember\x12\x1f.gophkeeper.InviteMemberRequest\x1a\x1e.gophkeeper.InvitationResponse\x12Z\n" +
+	"\x0fListInvitations\x12\".gophkeeper.ListInvitationsRequest\x1a#.gophkeeper.ListInvitationsResponse\x12Y\n" +
+	"\x10AcceptInvitation\x12#.gophkeeper.AcceptInvitationRequest\x1a .gophkeeper.OrganizationResponse\x12`\n" +
+	"\x11DeclineInvitation\x12$.gophkeeper.DeclineInvitationRequest\x1a%.gophkeeper.DeclineInvitationResponse\x12]\n" +
+	"\x10UpdateMemberRole\x12#.gophkeeper.UpdateMemberRoleRequest\x1a$.gophkeeper.UpdateMemberRoleResponse\x12Q\n" +
+	"\fRemoveMember\x12\x1f.gophkeeper.RemoveMemberRequest\x1a .gophkeeper.RemoveMemberResponse\x12W\n" +
+	"\x10CreateCollection\x12#.gophkeeper.CreateCollectionRequest\x1a\x1e.gophkeeper.CollectionResponse\x12Z\n" +
+	"\x0fListCollections\x12\".gophkeeper.ListCollectionsRequest\x1a#.gophkeeper.ListCollectionsResponse\x12c\n" +
+	"\x12GrantCollectionKey\x12%.gophkeeper.GrantCollectionKeyRequest\x1a&.gophkeeper.GrantCollectionKeyResponse\x12]\n" +
+	"\x13RotateCollectionKey\x12&.gophkeeper.RotateCollectionKeyRequest\x1a\x1e.gophkeeper.CollectionResponse\x12m\n" +
+	"\x1bSetCollectionRotationPolicy\x12..gophkeeper.SetCollectionRotationPolicyRequest\x1a\x1e.gophkeeper.CollectionResponse\x12`\n" +
+	"\x15CreateCollectionEntry\x12(.gophkeeper.CreateCollectionEntryRequest\x1a\x1d.gophkeeper.DataEntryResponse\x12_\n" +
+	"\x15ListCollectionEntries\x12(.gophkeeper.ListCollectionEntriesRequest\x1a\x1c.gophkeeper.ListDataResponse\x12Q\n" +
+	"\rAddAttachment\x12 .gophkeeper.AddAttachmentRequest\x1a\x1e.gophkeeper.AttachmentResponse\x12Z\n" +
+	"\x0fListAttachments\x12\".gophkeeper.ListAttachmentsRequest\x1a#.gophkeeper.ListAttachmentsResponse\x12T\n" +
+	"\rGetAttachment\x12 .gophkeeper.GetAttachmentRequest\x1a!.gophkeeper.GetAttachmentResponse\x12]\n" +
+	"\x10RemoveAttachment\x12#.gophkeeper.RemoveAttachmentRequest\x1a$.gophkeeper.RemoveAttachmentResponse\x12]\n" +
+	"\x10CreateSecretLink\x12#.gophkeeper.CreateSecretLinkRequest\x1a$.gophkeeper.CreateSecretLinkResponse\x12b\n" +
+	"\x13AddEmergencyContact\x12&.gophkeeper.AddEmergencyContactRequest\x1a#.gophkeeper.EmergencyAccessResponse\x12f\n" +
+	"\x13ListEmergencyAccess\x12&.gophkeeper.ListEmergencyAccessRequest\x1a'.gophkeeper.ListEmergencyAccessResponse\x12o\n" +
+	"\x16RemoveEmergencyContact\x12).gophkeeper.RemoveEmergencyContactRequest\x1a*.gophkeeper.RemoveEmergencyContactResponse\x12h\n" +
+	"\x16RequestEmergencyAccess\x12).gophkeeper.RequestEmergencyAccessRequest\x1a#.gophkeeper.EmergencyAccessResponse\x12h\n" +
+	"\x16ApproveEmergencyAccess\x12).gophkeeper.ApproveEmergencyAccessRequest\x1a#.gophkeeper.EmergencyAccessResponse\x12b\n" +
+	"\x13DenyEmergencyAccess\x12&.gophkeeper.DenyEmergencyAccessRequest\x1a#.gophkeeper.EmergencyAccessResponse\x12`\n" +
+	"\x11GetEmergencyVault\x12$.gophkeeper.GetEmergencyVaultRequest\x1a%.gophkeeper.GetEmergencyVaultResponse\x12x\n" +
+	"\x19ListEmergencyAccessEvents\x12,.gophkeeper.ListEmergencyAccessEventsRequest\x1a-.gophkeeper.ListEmergencyAccessEventsResponse\x12`\n" +
+	"\x11ListNotifications\x12$.gophkeeper.ListNotificationsRequest\x1a%.gophkeeper.ListNotificationsResponse\x12N\n" +
 	"\vGenerateOTP\x12\x1e.gophkeeper.GenerateOTPRequest\x1a\x1f.gophkeeper.GenerateOTPResponse\x12Z\n" +
 	"\x0fCreateOTPSecret\x12\".gophkeeper.CreateOTPSecretRequest\x1a#.gophkeeper.CreateOTPSecretResponseB\aZ\x05./genb\x06proto3"
 
@@ -1374,75 +7448,325 @@ func file_proto_gophkeeper_proto_rawDescGZIP() []byte {
 	return file_proto_gophkeeper_proto_rawDescData
 }
 
-var file_proto_gophkeeper_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_gophkeeper_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_gophkeeper_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_gophkeeper_proto_msgTypes = make([]protoimpl.MessageInfo, 114)
 var file_proto_gophkeeper_proto_goTypes = []any{
-	(DataType)(0),                   // 0: gophkeeper.DataType
-	(*RegisterRequest)(nil),         // 1: gophkeeper.RegisterRequest
-	(*LoginRequest)(nil),            // 2: gophkeeper.LoginRequest
-	(*RefreshTokenRequest)(nil),     // 3: gophkeeper.RefreshTokenRequest
-	(*AuthResponse)(nil),            // 4: gophkeeper.AuthResponse
-	(*User)(nil),                    // 5: gophkeeper.User
-	(*CreateDataRequest)(nil),       // 6: gophkeeper.CreateDataRequest
-	(*GetDataRequest)(nil),          // 7: gophkeeper.GetDataRequest
-	(*ListDataRequest)(nil),         // 8: gophkeeper.ListDataRequest
-	(*UpdateDataRequest)(nil),       // 9: gophkeeper.UpdateDataRequest
-	(*DeleteDataRequest)(nil),       // 10: gophkeeper.DeleteDataRequest
-	(*SyncDataRequest)(nil),         // 11: gophkeeper.SyncDataRequest
-	(*GenerateOTPRequest)(nil),      // 12: gophkeeper.GenerateOTPRequest
-	(*CreateOTPSecretRequest)(nil),  // 13: gophkeeper.CreateOTPSecretRequest
-	(*DataEntryResponse)(nil),       // 14: gophkeeper.DataEntryResponse
-	(*ListDataResponse)(nil),        // 15: gophkeeper.ListDataResponse
-	(*DeleteDataResponse)(nil),      // 16: gophkeeper.DeleteDataResponse
-	(*SyncDataResponse)(nil),        // 17: gophkeeper.SyncDataResponse
-	(*GenerateOTPResponse)(nil),     // 18: gophkeeper.GenerateOTPResponse
-	(*CreateOTPSecretResponse)(nil), // 19: gophkeeper.CreateOTPSecretResponse
-	(*DataEntry)(nil),               // 20: gophkeeper.DataEntry
-	(*timestamppb.Timestamp)(nil),   // 21: google.protobuf.Timestamp
+	(DataType)(0),                              // 0: gophkeeper.DataType
+	(SharePermission)(0),                       // 1: gophkeeper.SharePermission
+	(EmergencyAccessStatus)(0),                 // 2: gophkeeper.EmergencyAccessStatus
+	(OrganizationRole)(0),                      // 3: gophkeeper.OrganizationRole
+	(*RegisterRequest)(nil),                    // 4: gophkeeper.RegisterRequest
+	(*LoginRequest)(nil),                       // 5: gophkeeper.LoginRequest
+	(*BeginSRPLoginRequest)(nil),               // 6: gophkeeper.BeginSRPLoginRequest
+	(*FinishSRPLoginRequest)(nil),              // 7: gophkeeper.FinishSRPLoginRequest
+	(*RefreshTokenRequest)(nil),                // 8: gophkeeper.RefreshTokenRequest
+	(*SetVaultKeyRequest)(nil),                 // 9: gophkeeper.SetVaultKeyRequest
+	(*ChangePasswordRequest)(nil),              // 10: gophkeeper.ChangePasswordRequest
+	(*DeleteAccountRequest)(nil),               // 11: gophkeeper.DeleteAccountRequest
+	(*ExportAccountRequest)(nil),               // 12: gophkeeper.ExportAccountRequest
+	(*CreateAccessTokenRequest)(nil),           // 13: gophkeeper.CreateAccessTokenRequest
+	(*ListAccessTokensRequest)(nil),            // 14: gophkeeper.ListAccessTokensRequest
+	(*RevokeAccessTokenRequest)(nil),           // 15: gophkeeper.RevokeAccessTokenRequest
+	(*AuthResponse)(nil),                       // 16: gophkeeper.AuthResponse
+	(*BeginSRPLoginResponse)(nil),              // 17: gophkeeper.BeginSRPLoginResponse
+	(*FinishSRPLoginResponse)(nil),             // 18: gophkeeper.FinishSRPLoginResponse
+	(*User)(nil),                               // 19: gophkeeper.User
+	(*CreateDataRequest)(nil),                  // 20: gophkeeper.CreateDataRequest
+	(*GetDataRequest)(nil),                     // 21: gophkeeper.GetDataRequest
+	(*ListDataRequest)(nil),                    // 22: gophkeeper.ListDataRequest
+	(*UpdateDataRequest)(nil),                  // 23: gophkeeper.UpdateDataRequest
+	(*ListDueForRotationRequest)(nil),          // 24: gophkeeper.ListDueForRotationRequest
+	(*DeleteDataRequest)(nil),                  // 25: gophkeeper.DeleteDataRequest
+	(*SyncDataRequest)(nil),                    // 26: gophkeeper.SyncDataRequest
+	(*SetPublicKeyRequest)(nil),                // 27: gophkeeper.SetPublicKeyRequest
+	(*GetPublicKeyRequest)(nil),                // 28: gophkeeper.GetPublicKeyRequest
+	(*ShareEntryRequest)(nil),                  // 29: gophkeeper.ShareEntryRequest
+	(*ListEntrySharesRequest)(nil),             // 30: gophkeeper.ListEntrySharesRequest
+	(*RevokeShareRequest)(nil),                 // 31: gophkeeper.RevokeShareRequest
+	(*ListSharedWithMeRequest)(nil),            // 32: gophkeeper.ListSharedWithMeRequest
+	(*AddAttachmentRequest)(nil),               // 33: gophkeeper.AddAttachmentRequest
+	(*ListAttachmentsRequest)(nil),             // 34: gophkeeper.ListAttachmentsRequest
+	(*GetAttachmentRequest)(nil),               // 35: gophkeeper.GetAttachmentRequest
+	(*RemoveAttachmentRequest)(nil),            // 36: gophkeeper.RemoveAttachmentRequest
+	(*CreateOrganizationRequest)(nil),          // 37: gophkeeper.CreateOrganizationRequest
+	(*ListOrganizationsRequest)(nil),           // 38: gophkeeper.ListOrganizationsRequest
+	(*ListOrganizationMembersRequest)(nil),     // 39: gophkeeper.ListOrganizationMembersRequest
+	(*InviteMemberRequest)(nil),                // 40: gophkeeper.InviteMemberRequest
+	(*ListInvitationsRequest)(nil),             // 41: gophkeeper.ListInvitationsRequest
+	(*AcceptInvitationRequest)(nil),            // 42: gophkeeper.AcceptInvitationRequest
+	(*DeclineInvitationRequest)(nil),           // 43: gophkeeper.DeclineInvitationRequest
+	(*UpdateMemberRoleRequest)(nil),            // 44: gophkeeper.UpdateMemberRoleRequest
+	(*RemoveMemberRequest)(nil),                // 45: gophkeeper.RemoveMemberRequest
+	(*CreateCollectionRequest)(nil),            // 46: gophkeeper.CreateCollectionRequest
+	(*SetCollectionRotationPolicyRequest)(nil), // 47: gophkeeper.SetCollectionRotationPolicyRequest
+	(*ListCollectionsRequest)(nil),             // 48: gophkeeper.ListCollectionsRequest
+	(*GrantCollectionKeyRequest)(nil),          // 49: gophkeeper.GrantCollectionKeyRequest
+	(*RotateCollectionKeyRequest)(nil),         // 50: gophkeeper.RotateCollectionKeyRequest
+	(*CreateCollectionEntryRequest)(nil),       // 51: gophkeeper.CreateCollectionEntryRequest
+	(*ListCollectionEntriesRequest)(nil),       // 52: gophkeeper.ListCollectionEntriesRequest
+	(*CreateSecretLinkRequest)(nil),            // 53: gophkeeper.CreateSecretLinkRequest
+	(*AddEmergencyContactRequest)(nil),         // 54: gophkeeper.AddEmergencyContactRequest
+	(*ListEmergencyAccessRequest)(nil),         // 55: gophkeeper.ListEmergencyAccessRequest
+	(*RemoveEmergencyContactRequest)(nil),      // 56: gophkeeper.RemoveEmergencyContactRequest
+	(*RequestEmergencyAccessRequest)(nil),      // 57: gophkeeper.RequestEmergencyAccessRequest
+	(*ApproveEmergencyAccessRequest)(nil),      // 58: gophkeeper.ApproveEmergencyAccessRequest
+	(*DenyEmergencyAccessRequest)(nil),         // 59: gophkeeper.DenyEmergencyAccessRequest
+	(*GetEmergencyVaultRequest)(nil),           // 60: gophkeeper.GetEmergencyVaultRequest
+	(*ListEmergencyAccessEventsRequest)(nil),   // 61: gophkeeper.ListEmergencyAccessEventsRequest
+	(*ListNotificationsRequest)(nil),           // 62: gophkeeper.ListNotificationsRequest
+	(*GenerateOTPRequest)(nil),                 // 63: gophkeeper.GenerateOTPRequest
+	(*CreateOTPSecretRequest)(nil),             // 64: gophkeeper.CreateOTPSecretRequest
+	(*DataEntryResponse)(nil),                  // 65: gophkeeper.DataEntryResponse
+	(*ListDataResponse)(nil),                   // 66: gophkeeper.ListDataResponse
+	(*DeleteDataResponse)(nil),                 // 67: gophkeeper.DeleteDataResponse
+	(*DeleteAccountResponse)(nil),              // 68: gophkeeper.DeleteAccountResponse
+	(*ExportAccountResponse)(nil),              // 69: gophkeeper.ExportAccountResponse
+	(*CreateAccessTokenResponse)(nil),          // 70: gophkeeper.CreateAccessTokenResponse
+	(*ListAccessTokensResponse)(nil),           // 71: gophkeeper.ListAccessTokensResponse
+	(*RevokeAccessTokenResponse)(nil),          // 72: gophkeeper.RevokeAccessTokenResponse
+	(*SyncDataResponse)(nil),                   // 73: gophkeeper.SyncDataResponse
+	(*SetVaultKeyResponse)(nil),                // 74: gophkeeper.SetVaultKeyResponse
+	(*SetPublicKeyResponse)(nil),               // 75: gophkeeper.SetPublicKeyResponse
+	(*GetPublicKeyResponse)(nil),               // 76: gophkeeper.GetPublicKeyResponse
+	(*ShareEntryResponse)(nil),                 // 77: gophkeeper.ShareEntryResponse
+	(*ListEntrySharesResponse)(nil),            // 78: gophkeeper.ListEntrySharesResponse
+	(*RevokeShareResponse)(nil),                // 79: gophkeeper.RevokeShareResponse
+	(*AttachmentResponse)(nil),                 // 80: gophkeeper.AttachmentResponse
+	(*ListAttachmentsResponse)(nil),            // 81: gophkeeper.ListAttachmentsResponse
+	(*GetAttachmentResponse)(nil),              // 82: gophkeeper.GetAttachmentResponse
+	(*RemoveAttachmentResponse)(nil),           // 83: gophkeeper.RemoveAttachmentResponse
+	(*OrganizationResponse)(nil),               // 84: gophkeeper.OrganizationResponse
+	(*ListOrganizationsResponse)(nil),          // 85: gophkeeper.ListOrganizationsResponse
+	(*ListOrganizationMembersResponse)(nil),    // 86: gophkeeper.ListOrganizationMembersResponse
+	(*InvitationResponse)(nil),                 // 87: gophkeeper.InvitationResponse
+	(*ListInvitationsResponse)(nil),            // 88: gophkeeper.ListInvitationsResponse
+	(*DeclineInvitationResponse)(nil),          // 89: gophkeeper.DeclineInvitationResponse
+	(*UpdateMemberRoleResponse)(nil),           // 90: gophkeeper.UpdateMemberRoleResponse
+	(*RemoveMemberResponse)(nil),               // 91: gophkeeper.RemoveMemberResponse
+	(*CollectionResponse)(nil),                 // 92: gophkeeper.CollectionResponse
+	(*ListCollectionsResponse)(nil),            // 93: gophkeeper.ListCollectionsResponse
+	(*GrantCollectionKeyResponse)(nil),         // 94: gophkeeper.GrantCollectionKeyResponse
+	(*CreateSecretLinkResponse)(nil),           // 95: gophkeeper.CreateSecretLinkResponse
+	(*EmergencyAccessResponse)(nil),            // 96: gophkeeper.EmergencyAccessResponse
+	(*ListEmergencyAccessResponse)(nil),        // 97: gophkeeper.ListEmergencyAccessResponse
+	(*RemoveEmergencyContactResponse)(nil),     // 98: gophkeeper.RemoveEmergencyContactResponse
+	(*GetEmergencyVaultResponse)(nil),          // 99: gophkeeper.GetEmergencyVaultResponse
+	(*ListEmergencyAccessEventsResponse)(nil),  // 100: gophkeeper.ListEmergencyAccessEventsResponse
+	(*ListNotificationsResponse)(nil),          // 101: gophkeeper.ListNotificationsResponse
+	(*GenerateOTPResponse)(nil),                // 102: gophkeeper.GenerateOTPResponse
+	(*CreateOTPSecretResponse)(nil),            // 103: gophkeeper.CreateOTPSecretResponse
+	(*DataEntry)(nil),                          // 104: gophkeeper.DataEntry
+	(*EntryShare)(nil),                         // 105: gophkeeper.EntryShare
+	(*AccessToken)(nil),                        // 106: gophkeeper.AccessToken
+	(*Organization)(nil),                       // 107: gophkeeper.Organization
+	(*OrganizationMember)(nil),                 // 108: gophkeeper.OrganizationMember
+	(*OrganizationInvitation)(nil),             // 109: gophkeeper.OrganizationInvitation
+	(*Collection)(nil),                         // 110: gophkeeper.Collection
+	(*CollectionKey)(nil),                      // 111: gophkeeper.CollectionKey
+	(*CollectionEntryUpdate)(nil),              // 112: gophkeeper.CollectionEntryUpdate
+	(*SecretLink)(nil),                         // 113: gophkeeper.SecretLink
+	(*EmergencyAccess)(nil),                    // 114: gophkeeper.EmergencyAccess
+	(*EmergencyAccessEvent)(nil),               // 115: gophkeeper.EmergencyAccessEvent
+	(*Notification)(nil),                       // 116: gophkeeper.Notification
+	(*Attachment)(nil),                         // 117: gophkeeper.Attachment
+	(*timestamppb.Timestamp)(nil),              // 118: google.protobuf.Timestamp
 }
 var file_proto_gophkeeper_proto_depIdxs = []int32{
-	21, // 0: gophkeeper.AuthResponse.expires_at:type_name -> google.protobuf.Timestamp
-	5,  // 1: gophkeeper.AuthResponse.user:type_name -> gophkeeper.User
-	21, // 2: gophkeeper.User.created_at:type_name -> google.protobuf.Timestamp
-	21, // 3: gophkeeper.User.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 4: gophkeeper.CreateDataRequest.type:type_name -> gophkeeper.DataType
-	0,  // 5: gophkeeper.ListDataRequest.type:type_name -> gophkeeper.DataType
-	21, // 6: gophkeeper.SyncDataRequest.last_sync_time:type_name -> google.protobuf.Timestamp
-	20, // 7: gophkeeper.DataEntryResponse.data_entry:type_name -> gophkeeper.DataEntry
-	20, // 8: gophkeeper.ListDataResponse.data_entries:type_name -> gophkeeper.DataEntry
-	20, // 9: gophkeeper.SyncDataResponse.data_entries:type_name -> gophkeeper.DataEntry
-	21, // 10: gophkeeper.SyncDataResponse.last_sync_time:type_name -> google.protobuf.Timestamp
-	21, // 11: gophkeeper.GenerateOTPResponse.expires_at:type_name -> google.protobuf.Timestamp
-	0,  // 12: gophkeeper.DataEntry.type:type_name -> gophkeeper.DataType
-	21, // 13: gophkeeper.DataEntry.created_at:type_name -> google.protobuf.Timestamp
-	21, // 14: gophkeeper.DataEntry.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 15: gophkeeper.GophKeeper.Register:input_type -> gophkeeper.RegisterRequest
-	2,  // 16: gophkeeper.GophKeeper.Login:input_type -> gophkeeper.LoginRequest
-	3,  // 17: gophkeeper.GophKeeper.RefreshToken:input_type -> gophkeeper.RefreshTokenRequest
-	6,  // 18: gophkeeper.GophKeeper.CreateData:input_type -> gophkeeper.CreateDataRequest
-	7,  // 19: gophkeeper.GophKeeper.GetData:input_type -> gophkeeper.GetDataRequest
-	8,  // 20: gophkeeper.GophKeeper.ListData:input_type -> gophkeeper.ListDataRequest
-	9,  // 21: gophkeeper.GophKeeper.UpdateData:input_type -> gophkeeper.UpdateDataRequest
-	10, // 22: gophkeeper.GophKeeper.DeleteData:input_type -> gophkeeper.DeleteDataRequest
-	11, // 23: gophkeeper.GophKeeper.SyncData:input_type -> gophkeeper.SyncDataRequest
-	12, // 24: gophkeeper.GophKeeper.GenerateOTP:input_type -> gophkeeper.GenerateOTPRequest
-	13, // 25: gophkeeper.GophKeeper.CreateOTPSecret:input_type -> gophkeeper.CreateOTPSecretRequest
-	4,  // 26: gophkeeper.GophKeeper.Register:output_type -> gophkeeper.AuthResponse
-	4,  // 27: gophkeeper.GophKeeper.Login:output_type -> gophkeeper.AuthResponse
-	4,  // 28: gophkeeper.GophKeeper.RefreshToken:output_type -> gophkeeper.AuthResponse
-	14, // 29: gophkeeper.GophKeeper.CreateData:output_type -> gophkeeper.DataEntryResponse
-	14, // 30: gophkeeper.GophKeeper.GetData:output_type -> gophkeeper.DataEntryResponse
-	15, // 31: gophkeeper.GophKeeper.ListData:output_type -> gophkeeper.ListDataResponse
-	14, // 32: gophkeeper.GophKeeper.UpdateData:output_type -> gophkeeper.DataEntryResponse
-	16, // 33: gophkeeper.GophKeeper.DeleteData:output_type -> gophkeeper.DeleteDataResponse
-	17, // 34: gophkeeper.GophKeeper.SyncData:output_type -> gophkeeper.SyncDataResponse
-	18, // 35: gophkeeper.GophKeeper.GenerateOTP:output_type -> gophkeeper.GenerateOTPResponse
-	19, // 36: gophkeeper.GophKeeper.CreateOTPSecret:output_type -> gophkeeper.CreateOTPSecretResponse
-	26, // [26:37] is the sub-list for method output_type
-	15, // [15:26] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	0,   // 0: gophkeeper.CreateAccessTokenRequest.data_types:type_name -> gophkeeper.DataType
+	118, // 1: gophkeeper.CreateAccessTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	118, // 2: gophkeeper.AuthResponse.expires_at:type_name -> google.protobuf.Timestamp
+	19,  // 3: gophkeeper.AuthResponse.user:type_name -> gophkeeper.User
+	16,  // 4: gophkeeper.FinishSRPLoginResponse.auth:type_name -> gophkeeper.AuthResponse
+	118, // 5: gophkeeper.User.created_at:type_name -> google.protobuf.Timestamp
+	118, // 6: gophkeeper.User.updated_at:type_name -> google.protobuf.Timestamp
+	0,   // 7: gophkeeper.CreateDataRequest.type:type_name -> gophkeeper.DataType
+	0,   // 8: gophkeeper.ListDataRequest.type:type_name -> gophkeeper.DataType
+	118, // 9: gophkeeper.SyncDataRequest.last_sync_time:type_name -> google.protobuf.Timestamp
+	1,   // 10: gophkeeper.ShareEntryRequest.permission:type_name -> gophkeeper.SharePermission
+	3,   // 11: gophkeeper.InviteMemberRequest.role:type_name -> gophkeeper.OrganizationRole
+	3,   // 12: gophkeeper.UpdateMemberRoleRequest.role:type_name -> gophkeeper.OrganizationRole
+	111, // 13: gophkeeper.RotateCollectionKeyRequest.keys:type_name -> gophkeeper.CollectionKey
+	112, // 14: gophkeeper.RotateCollectionKeyRequest.entries:type_name -> gophkeeper.CollectionEntryUpdate
+	0,   // 15: gophkeeper.CreateCollectionEntryRequest.type:type_name -> gophkeeper.DataType
+	118, // 16: gophkeeper.CreateSecretLinkRequest.expires_at:type_name -> google.protobuf.Timestamp
+	104, // 17: gophkeeper.DataEntryResponse.data_entry:type_name -> gophkeeper.DataEntry
+	104, // 18: gophkeeper.ListDataResponse.data_entries:type_name -> gophkeeper.DataEntry
+	118, // 19: gophkeeper.ExportAccountResponse.exported_at:type_name -> google.protobuf.Timestamp
+	106, // 20: gophkeeper.CreateAccessTokenResponse.access_token:type_name -> gophkeeper.AccessToken
+	106, // 21: gophkeeper.ListAccessTokensResponse.access_tokens:type_name -> gophkeeper.AccessToken
+	104, // 22: gophkeeper.SyncDataResponse.data_entries:type_name -> gophkeeper.DataEntry
+	118, // 23: gophkeeper.SyncDataResponse.last_sync_time:type_name -> google.protobuf.Timestamp
+	105, // 24: gophkeeper.ShareEntryResponse.share:type_name -> gophkeeper.EntryShare
+	105, // 25: gophkeeper.ListEntrySharesResponse.shares:type_name -> gophkeeper.EntryShare
+	117, // 26: gophkeeper.AttachmentResponse.attachment:type_name -> gophkeeper.Attachment
+	117, // 27: gophkeeper.ListAttachmentsResponse.attachments:type_name -> gophkeeper.Attachment
+	117, // 28: gophkeeper.GetAttachmentResponse.attachment:type_name -> gophkeeper.Attachment
+	107, // 29: gophkeeper.OrganizationResponse.organization:type_name -> gophkeeper.Organization
+	107, // 30: gophkeeper.ListOrganizationsResponse.organizations:type_name -> gophkeeper.Organization
+	108, // 31: gophkeeper.ListOrganizationMembersResponse.members:type_name -> gophkeeper.OrganizationMember
+	109, // 32: gophkeeper.InvitationResponse.invitation:type_name -> gophkeeper.OrganizationInvitation
+	109, // 33: gophkeeper.ListInvitationsResponse.invitations:type_name -> gophkeeper.OrganizationInvitation
+	108, // 34: gophkeeper.UpdateMemberRoleResponse.member:type_name -> gophkeeper.OrganizationMember
+	110, // 35: gophkeeper.CollectionResponse.collection:type_name -> gophkeeper.Collection
+	110, // 36: gophkeeper.ListCollectionsResponse.collections:type_name -> gophkeeper.Collection
+	113, // 37: gophkeeper.CreateSecretLinkResponse.link:type_name -> gophkeeper.SecretLink
+	114, // 38: gophkeeper.EmergencyAccessResponse.emergency_access:type_name -> gophkeeper.EmergencyAccess
+	114, // 39: gophkeeper.ListEmergencyAccessResponse.granted:type_name -> gophkeeper.EmergencyAccess
+	114, // 40: gophkeeper.ListEmergencyAccessResponse.trusted:type_name -> gophkeeper.EmergencyAccess
+	114, // 41: gophkeeper.GetEmergencyVaultResponse.emergency_access:type_name -> gophkeeper.EmergencyAccess
+	104, // 42: gophkeeper.GetEmergencyVaultResponse.data_entries:type_name -> gophkeeper.DataEntry
+	115, // 43: gophkeeper.ListEmergencyAccessEventsResponse.events:type_name -> gophkeeper.EmergencyAccessEvent
+	116, // 44: gophkeeper.ListNotificationsResponse.notifications:type_name -> gophkeeper.Notification
+	118, // 45: gophkeeper.GenerateOTPResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,   // 46: gophkeeper.DataEntry.type:type_name -> gophkeeper.DataType
+	118, // 47: gophkeeper.DataEntry.created_at:type_name -> google.protobuf.Timestamp
+	118, // 48: gophkeeper.DataEntry.updated_at:type_name -> google.protobuf.Timestamp
+	105, // 49: gophkeeper.DataEntry.share:type_name -> gophkeeper.EntryShare
+	118, // 50: gophkeeper.DataEntry.password_changed_at:type_name -> google.protobuf.Timestamp
+	118, // 51: gophkeeper.DataEntry.rotation_due_at:type_name -> google.protobuf.Timestamp
+	1,   // 52: gophkeeper.EntryShare.permission:type_name -> gophkeeper.SharePermission
+	118, // 53: gophkeeper.EntryShare.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 54: gophkeeper.AccessToken.data_types:type_name -> gophkeeper.DataType
+	118, // 55: gophkeeper.AccessToken.created_at:type_name -> google.protobuf.Timestamp
+	118, // 56: gophkeeper.AccessToken.expires_at:type_name -> google.protobuf.Timestamp
+	118, // 57: gophkeeper.AccessToken.last_used_at:type_name -> google.protobuf.Timestamp
+	3,   // 58: gophkeeper.Organization.role:type_name -> gophkeeper.OrganizationRole
+	118, // 59: gophkeeper.Organization.created_at:type_name -> google.protobuf.Timestamp
+	3,   // 60: gophkeeper.OrganizationMember.role:type_name -> gophkeeper.OrganizationRole
+	118, // 61: gophkeeper.OrganizationMember.created_at:type_name -> google.protobuf.Timestamp
+	3,   // 62: gophkeeper.OrganizationInvitation.role:type_name -> gophkeeper.OrganizationRole
+	118, // 63: gophkeeper.OrganizationInvitation.created_at:type_name -> google.protobuf.Timestamp
+	118, // 64: gophkeeper.OrganizationInvitation.expires_at:type_name -> google.protobuf.Timestamp
+	118, // 65: gophkeeper.Collection.created_at:type_name -> google.protobuf.Timestamp
+	118, // 66: gophkeeper.SecretLink.expires_at:type_name -> google.protobuf.Timestamp
+	118, // 67: gophkeeper.SecretLink.created_at:type_name -> google.protobuf.Timestamp
+	2,   // 68: gophkeeper.EmergencyAccess.status:type_name -> gophkeeper.EmergencyAccessStatus
+	118, // 69: gophkeeper.EmergencyAccess.requested_at:type_name -> google.protobuf.Timestamp
+	118, // 70: gophkeeper.EmergencyAccess.available_at:type_name -> google.protobuf.Timestamp
+	118, // 71: gophkeeper.EmergencyAccess.approved_at:type_name -> google.protobuf.Timestamp
+	118, // 72: gophkeeper.EmergencyAccess.created_at:type_name -> google.protobuf.Timestamp
+	118, // 73: gophkeeper.EmergencyAccessEvent.created_at:type_name -> google.protobuf.Timestamp
+	118, // 74: gophkeeper.Notification.created_at:type_name -> google.protobuf.Timestamp
+	118, // 75: gophkeeper.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	4,   // 76: gophkeeper.GophKeeper.Register:input_type -> gophkeeper.RegisterRequest
+	5,   // 77: gophkeeper.GophKeeper.Login:input_type -> gophkeeper.LoginRequest
+	6,   // 78: gophkeeper.GophKeeper.BeginSRPLogin:input_type -> gophkeeper.BeginSRPLoginRequest
+	7,   // 79: gophkeeper.GophKeeper.FinishSRPLogin:input_type -> gophkeeper.FinishSRPLoginRequest
+	8,   // 80: gophkeeper.GophKeeper.RefreshToken:input_type -> gophkeeper.RefreshTokenRequest
+	10,  // 81: gophkeeper.GophKeeper.ChangePassword:input_type -> gophkeeper.ChangePasswordRequest
+	9,   // 82: gophkeeper.GophKeeper.SetVaultKey:input_type -> gophkeeper.SetVaultKeyRequest
+	11,  // 83: gophkeeper.GophKeeper.DeleteAccount:input_type -> gophkeeper.DeleteAccountRequest
+	12,  // 84: gophkeeper.GophKeeper.ExportAccount:input_type -> gophkeeper.ExportAccountRequest
+	13,  // 85: gophkeeper.GophKeeper.CreateAccessToken:input_type -> gophkeeper.CreateAccessTokenRequest
+	14,  // 86: gophkeeper.GophKeeper.ListAccessTokens:input_type -> gophkeeper.ListAccessTokensRequest
+	15,  // 87: gophkeeper.GophKeeper.RevokeAccessToken:input_type -> gophkeeper.RevokeAccessTokenRequest
+	20,  // 88: gophkeeper.GophKeeper.CreateData:input_type -> gophkeeper.CreateDataRequest
+	21,  // 89: gophkeeper.GophKeeper.GetData:input_type -> gophkeeper.GetDataRequest
+	22,  // 90: gophkeeper.GophKeeper.ListData:input_type -> gophkeeper.ListDataRequest
+	23,  // 91: gophkeeper.GophKeeper.UpdateData:input_type -> gophkeeper.UpdateDataRequest
+	25,  // 92: gophkeeper.GophKeeper.DeleteData:input_type -> gophkeeper.DeleteDataRequest
+	24,  // 93: gophkeeper.GophKeeper.ListDueForRotation:input_type -> gophkeeper.ListDueForRotationRequest
+	26,  // 94: gophkeeper.GophKeeper.SyncData:input_type -> gophkeeper.SyncDataRequest
+	27,  // 95: gophkeeper.GophKeeper.SetPublicKey:input_type -> gophkeeper.SetPublicKeyRequest
+	28,  // 96: gophkeeper.GophKeeper.GetPublicKey:input_type -> gophkeeper.GetPublicKeyRequest
+	29,  // 97: gophkeeper.GophKeeper.ShareEntry:input_type -> gophkeeper.ShareEntryRequest
+	30,  // 98: gophkeeper.GophKeeper.ListEntryShares:input_type -> gophkeeper.ListEntrySharesRequest
+	31,  // 99: gophkeeper.GophKeeper.RevokeShare:input_type -> gophkeeper.RevokeShareRequest
+	32,  // 100: gophkeeper.GophKeeper.ListSharedWithMe:input_type -> gophkeeper.ListSharedWithMeRequest
+	37,  // 101: gophkeeper.GophKeeper.CreateOrganization:input_type -> gophkeeper.CreateOrganizationRequest
+	38,  // 102: gophkeeper.GophKeeper.ListOrganizations:input_type -> gophkeeper.ListOrganizationsRequest
+	39,  // 103: gophkeeper.GophKeeper.ListOrganizationMembers:input_type -> gophkeeper.ListOrganizationMembersRequest
+	40,  // 104: gophkeeper.GophKeeper.InviteMember:input_type -> gophkeeper.InviteMemberRequest
+	41,  // 105: gophkeeper.GophKeeper.ListInvitations:input_type -> gophkeeper.ListInvitationsRequest
+	42,  // 106: gophkeeper.GophKeeper.AcceptInvitation:input_type -> gophkeeper.AcceptInvitationRequest
+	43,  // 107: gophkeeper.GophKeeper.DeclineInvitation:input_type -> gophkeeper.DeclineInvitationRequest
+	44,  // 108: gophkeeper.GophKeeper.UpdateMemberRole:input_type -> gophkeeper.UpdateMemberRoleRequest
+	45,  // 109: gophkeeper.GophKeeper.RemoveMember:input_type -> gophkeeper.RemoveMemberRequest
+	46,  // 110: gophkeeper.GophKeeper.CreateCollection:input_type -> gophkeeper.CreateCollectionRequest
+	48,  // 111: gophkeeper.GophKeeper.ListCollections:input_type -> gophkeeper.ListCollectionsRequest
+	49,  // 112: gophkeeper.GophKeeper.GrantCollectionKey:input_type -> gophkeeper.GrantCollectionKeyRequest
+	50,  // 113: gophkeeper.GophKeeper.RotateCollectionKey:input_type -> gophkeeper.RotateCollectionKeyRequest
+	47,  // 114: gophkeeper.GophKeeper.SetCollectionRotationPolicy:input_type -> gophkeeper.SetCollectionRotationPolicyRequest
+	51,  // 115: gophkeeper.GophKeeper.CreateCollectionEntry:input_type -> gophkeeper.CreateCollectionEntryRequest
+	52,  // 116: gophkeeper.GophKeeper.ListCollectionEntries:input_type -> gophkeeper.ListCollectionEntriesRequest
+	33,  // 117: gophkeeper.GophKeeper.AddAttachment:input_type -> gophkeeper.AddAttachmentRequest
+	34,  // 118: gophkeeper.GophKeeper.ListAttachments:input_type -> gophkeeper.ListAttachmentsRequest
+	35,  // 119: gophkeeper.GophKeeper.GetAttachment:input_type -> gophkeeper.GetAttachmentRequest
+	36,  // 120: gophkeeper.GophKeeper.RemoveAttachment:input_type -> gophkeeper.RemoveAttachmentRequest
+	53,  // 121: gophkeeper.GophKeeper.CreateSecretLink:input_type -> gophkeeper.CreateSecretLinkRequest
+	54,  // 122: gophkeeper.GophKeeper.AddEmergencyContact:input_type -> gophkeeper.AddEmergencyContactRequest
+	55,  // 123: gophkeeper.GophKeeper.ListEmergencyAccess:input_type -> gophkeeper.ListEmergencyAccessRequest
+	56,  // 124: gophkeeper.GophKeeper.RemoveEmergencyContact:input_type -> gophkeeper.RemoveEmergencyContactRequest
+	57,  // 125: gophkeeper.GophKeeper.RequestEmergencyAccess:input_type -> gophkeeper.RequestEmergencyAccessRequest
+	58,  // 126: gophkeeper.GophKeeper.ApproveEmergencyAccess:input_type -> gophkeeper.ApproveEmergencyAccessRequest
+	59,  // 127: gophkeeper.GophKeeper.DenyEmergencyAccess:input_type -> gophkeeper.DenyEmergencyAccessRequest
+	60,  // 128: gophkeeper.GophKeeper.GetEmergencyVault:input_type -> gophkeeper.GetEmergencyVaultRequest
+	61,  // 129: gophkeeper.GophKeeper.ListEmergencyAccessEvents:input_type -> gophkeeper.ListEmergencyAccessEventsRequest
+	62,  // 130: gophkeeper.GophKeeper.ListNotifications:input_type -> gophkeeper.ListNotificationsRequest
+	63,  // 131: gophkeeper.GophKeeper.GenerateOTP:input_type -> gophkeeper.GenerateOTPRequest
+	64,  // 132: gophkeeper.GophKeeper.CreateOTPSecret:input_type -> gophkeeper.CreateOTPSecretRequest
+	16,  // 133: gophkeeper.GophKeeper.Register:output_type -> gophkeeper.AuthResponse
+	16,  // 134: gophkeeper.GophKeeper.Login:output_type -> gophkeeper.AuthResponse
+	17,  // 135: gophkeeper.GophKeeper.BeginSRPLogin:output_type -> gophkeeper.BeginSRPLoginResponse
+	18,  // 136: gophkeeper.GophKeeper.FinishSRPLogin:output_type -> gophkeeper.FinishSRPLoginResponse
+	16,  // 137: gophkeeper.GophKeeper.RefreshToken:output_type -> gophkeeper.AuthResponse
+	16,  // 138: gophkeeper.GophKeeper.ChangePassword:output_type -> gophkeeper.AuthResponse
+	74,  // 139: gophkeeper.GophKeeper.SetVaultKey:output_type -> gophkeeper.SetVaultKeyResponse
+	68,  // 140: gophkeeper.GophKeeper.DeleteAccount:output_type -> gophkeeper.DeleteAccountResponse
+	69,  // 141: gophkeeper.GophKeeper.ExportAccount:output_type -> gophkeeper.ExportAccountResponse
+	70,  // 142: gophkeeper.GophKeeper.CreateAccessToken:output_type -> gophkeeper.CreateAccessTokenResponse
+	71,  // 143: gophkeeper.GophKeeper.ListAccessTokens:output_type -> gophkeeper.ListAccessTokensResponse
+	72,  // 144: gophkeeper.GophKeeper.RevokeAccessToken:output_type -> gophkeeper.RevokeAccessTokenResponse
+	65,  // 145: gophkeeper.GophKeeper.CreateData:output_type -> gophkeeper.DataEntryResponse
+	65,  // 146: gophkeeper.GophKeeper.GetData:output_type -> gophkeeper.DataEntryResponse
+	66,  // 147: gophkeeper.GophKeeper.ListData:output_type -> gophkeeper.ListDataResponse
+	65,  // 148: gophkeeper.GophKeeper.UpdateData:output_type -> gophkeeper.DataEntryResponse
+	67,  // 149: gophkeeper.GophKeeper.DeleteData:output_type -> gophkeeper.DeleteDataResponse
+	66,  // 150: gophkeeper.GophKeeper.ListDueForRotation:output_type -> gophkeeper.ListDataResponse
+	73,  // 151: gophkeeper.GophKeeper.SyncData:output_type -> gophkeeper.SyncDataResponse
+	75,  // 152: gophkeeper.GophKeeper.SetPublicKey:output_type -> gophkeeper.SetPublicKeyResponse
+	76,  // 153: gophkeeper.GophKeeper.GetPublicKey:output_type -> gophkeeper.GetPublicKeyResponse
+	77,  // 154: gophkeeper.GophKeeper.ShareEntry:output_type -> gophkeeper.ShareEntryResponse
+	78,  // 155: gophkeeper.GophKeeper.ListEntryShares:output_type -> gophkeeper.ListEntrySharesResponse
+	79,  // 156: gophkeeper.GophKeeper.RevokeShare:output_type -> gophkeeper.RevokeShareResponse
+	66,  // 157: gophkeeper.GophKeeper.ListSharedWithMe:output_type -> gophkeeper.ListDataResponse
+	84,  // 158: gophkeeper.GophKeeper.CreateOrganization:output_type -> gophkeeper.OrganizationResponse
+	85,  // 159: gophkeeper.GophKeeper.ListOrganizations:output_type -> gophkeeper.ListOrganizationsResponse
+	86,  // 160: gophkeeper.GophKeeper.ListOrganizationMembers:output_type -> gophkeeper.ListOrganizationMembersResponse
+	87,  // 161: gophkeeper.GophKeeper.InviteMember:output_type -> gophkeeper.InvitationResponse
+	88,  // 162: gophkeeper.GophKeeper.ListInvitations:output_type -> gophkeeper.ListInvitationsResponse
+	84,  // 163: gophkeeper.GophKeeper.AcceptInvitation:output_type -> gophkeeper.OrganizationResponse
+	89,  // 164: gophkeeper.GophKeeper.DeclineInvitation:output_type -> gophkeeper.DeclineInvitationResponse
+	90,  // 165: gophkeeper.GophKeeper.UpdateMemberRole:output_type -> gophkeeper.UpdateMemberRoleResponse
+	91,  // 166: gophkeeper.GophKeeper.RemoveMember:output_type -> gophkeeper.RemoveMemberResponse
+	92,  // 167: gophkeeper.GophKeeper.CreateCollection:output_type -> gophkeeper.CollectionResponse
+	93,  // 168: gophkeeper.GophKeeper.ListCollections:output_type -> gophkeeper.ListCollectionsResponse
+	94,  // 169: gophkeeper.GophKeeper.GrantCollectionKey:output_type -> gophkeeper.GrantCollectionKeyResponse
+	92,  // 170: gophkeeper.GophKeeper.RotateCollectionKey:output_type -> gophkeeper.CollectionResponse
+	92,  // 171: gophkeeper.GophKeeper.SetCollectionRotationPolicy:output_type -> gophkeeper.CollectionResponse
+	65,  // 172: gophkeeper.GophKeeper.CreateCollectionEntry:output_type -> gophkeeper.DataEntryResponse
+	66,  // 173: gophkeeper.GophKeeper.ListCollectionEntries:output_type -> gophkeeper.ListDataResponse
+	80,  // 174: gophkeeper.GophKeeper.AddAttachment:output_type -> gophkeeper.AttachmentResponse
+	81,  // 175: gophkeeper.GophKeeper.ListAttachments:output_type -> gophkeeper.ListAttachmentsResponse
+	82,  // 176: gophkeeper.GophKeeper.GetAttachment:output_type -> gophkeeper.GetAttachmentResponse
+	83,  // 177: gophkeeper.GophKeeper.RemoveAttachment:output_type -> gophkeeper.RemoveAttachmentResponse
+	95,  // 178: gophkeeper.GophKeeper.CreateSecretLink:output_type -> gophkeeper.CreateSecretLinkResponse
+	96,  // 179: gophkeeper.GophKeeper.AddEmergencyContact:output_type -> gophkeeper.EmergencyAccessResponse
+	97,  // 180: gophkeeper.GophKeeper.ListEmergencyAccess:output_type -> gophkeeper.ListEmergencyAccessResponse
+	98,  // 181: gophkeeper.GophKeeper.RemoveEmergencyContact:output_type -> gophkeeper.RemoveEmergencyContactResponse
+	96,  // 182: gophkeeper.GophKeeper.RequestEmergencyAccess:output_type -> gophkeeper.EmergencyAccessResponse
+	96,  // 183: gophkeeper.GophKeeper.ApproveEmergencyAccess:output_type -> gophkeeper.EmergencyAccessResponse
+	96,  // 184: gophkeeper.GophKeeper.DenyEmergencyAccess:output_type -> gophkeeper.EmergencyAccessResponse
+	99,  // 185: gophkeeper.GophKeeper.GetEmergencyVault:output_type -> gophkeeper.GetEmergencyVaultResponse
+	100, // 186: gophkeeper.GophKeeper.ListEmergencyAccessEvents:output_type -> gophkeeper.ListEmergencyAccessEventsResponse
+	101, // 187: gophkeeper.GophKeeper.ListNotifications:output_type -> gophkeeper.ListNotificationsResponse
+	102, // 188: gophkeeper.GophKeeper.GenerateOTP:output_type -> gophkeeper.GenerateOTPResponse
+	103, // 189: gophkeeper.GophKeeper.CreateOTPSecret:output_type -> gophkeeper.CreateOTPSecretResponse
+	133, // [133:190] is the sub-list for method output_type
+	76,  // [76:133] is the sub-list for method input_type
+	76,  // [76:76] is the sub-list for extension type_name
+	76,  // [76:76] is the sub-list for extension extendee
+	0,   // [0:76] is the sub-list for field type_name
 }
 
 func init() { file_proto_gophkeeper_proto_init() }
@@ -1450,14 +7774,15 @@ func file_proto_gophkeeper_proto_init() {
 	if File_proto_gophkeeper_proto != nil {
 		return
 	}
-	file_proto_gophkeeper_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_gophkeeper_proto_msgTypes[18].OneofWrappers = []any{}
+	file_proto_gophkeeper_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_gophkeeper_proto_rawDesc), len(file_proto_gophkeeper_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   20,
+			NumEnums:      4,
+			NumMessages:   114,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
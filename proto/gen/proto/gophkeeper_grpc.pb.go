@@ -19,17 +19,63 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GophKeeper_Register_FullMethodName        = "/gophkeeper.GophKeeper/Register"
-	GophKeeper_Login_FullMethodName           = "/gophkeeper.GophKeeper/Login"
-	GophKeeper_RefreshToken_FullMethodName    = "/gophkeeper.GophKeeper/RefreshToken"
-	GophKeeper_CreateData_FullMethodName      = "/gophkeeper.GophKeeper/CreateData"
-	GophKeeper_GetData_FullMethodName         = "/gophkeeper.GophKeeper/GetData"
-	GophKeeper_ListData_FullMethodName        = "/gophkeeper.GophKeeper/ListData"
-	GophKeeper_UpdateData_FullMethodName      = "/gophkeeper.GophKeeper/UpdateData"
-	GophKeeper_DeleteData_FullMethodName      = "/gophkeeper.GophKeeper/DeleteData"
-	GophKeeper_SyncData_FullMethodName        = "/gophkeeper.GophKeeper/SyncData"
-	GophKeeper_GenerateOTP_FullMethodName     = "/gophkeeper.GophKeeper/GenerateOTP"
-	GophKeeper_CreateOTPSecret_FullMethodName = "/gophkeeper.GophKeeper/CreateOTPSecret"
+	GophKeeper_Register_FullMethodName                    = "/gophkeeper.GophKeeper/Register"
+	GophKeeper_Login_FullMethodName                       = "/gophkeeper.GophKeeper/Login"
+	GophKeeper_BeginSRPLogin_FullMethodName               = "/gophkeeper.GophKeeper/BeginSRPLogin"
+	GophKeeper_FinishSRPLogin_FullMethodName              = "/gophkeeper.GophKeeper/FinishSRPLogin"
+	GophKeeper_RefreshToken_FullMethodName                = "/gophkeeper.GophKeeper/RefreshToken"
+	GophKeeper_ChangePassword_FullMethodName              = "/gophkeeper.GophKeeper/ChangePassword"
+	GophKeeper_SetVaultKey_FullMethodName                 = "/gophkeeper.GophKeeper/SetVaultKey"
+	GophKeeper_DeleteAccount_FullMethodName               = "/gophkeeper.GophKeeper/DeleteAccount"
+	GophKeeper_ExportAccount_FullMethodName               = "/gophkeeper.GophKeeper/ExportAccount"
+	GophKeeper_CreateAccessToken_FullMethodName           = "/gophkeeper.GophKeeper/CreateAccessToken"
+	GophKeeper_ListAccessTokens_FullMethodName            = "/gophkeeper.GophKeeper/ListAccessTokens"
+	GophKeeper_RevokeAccessToken_FullMethodName           = "/gophkeeper.GophKeeper/RevokeAccessToken"
+	GophKeeper_CreateData_FullMethodName                  = "/gophkeeper.GophKeeper/CreateData"
+	GophKeeper_GetData_FullMethodName                     = "/gophkeeper.GophKeeper/GetData"
+	GophKeeper_ListData_FullMethodName                    = "/gophkeeper.GophKeeper/ListData"
+	GophKeeper_UpdateData_FullMethodName                  = "/gophkeeper.GophKeeper/UpdateData"
+	GophKeeper_DeleteData_FullMethodName                  = "/gophkeeper.GophKeeper/DeleteData"
+	GophKeeper_ListDueForRotation_FullMethodName          = "/gophkeeper.GophKeeper/ListDueForRotation"
+	GophKeeper_SyncData_FullMethodName                    = "/gophkeeper.GophKeeper/SyncData"
+	GophKeeper_SetPublicKey_FullMethodName                = "/gophkeeper.GophKeeper/SetPublicKey"
+	GophKeeper_GetPublicKey_FullMethodName                = "/gophkeeper.GophKeeper/GetPublicKey"
+	GophKeeper_ShareEntry_FullMethodName                  = "/gophkeeper.GophKeeper/ShareEntry"
+	GophKeeper_ListEntryShares_FullMethodName             = "/gophkeeper.GophKeeper/ListEntryShares"
+	GophKeeper_RevokeShare_FullMethodName                 = "/gophkeeper.GophKeeper/RevokeShare"
+	GophKeeper_ListSharedWithMe_FullMethodName            = "/gophkeeper.GophKeeper/ListSharedWithMe"
+	GophKeeper_CreateOrganization_FullMethodName          = "/gophkeeper.GophKeeper/CreateOrganization"
+	GophKeeper_ListOrganizations_FullMethodName           = "/gophkeeper.GophKeeper/ListOrganizations"
+	GophKeeper_ListOrganizationMembers_FullMethodName     = "/gophkeeper.GophKeeper/ListOrganizationMembers"
+	GophKeeper_InviteMember_FullMethodName                = "/gophkeeper.GophKeeper/InviteMember"
+	GophKeeper_ListInvitations_FullMethodName             = "/gophkeeper.GophKeeper/ListInvitations"
+	GophKeeper_AcceptInvitation_FullMethodName            = "/gophkeeper.GophKeeper/AcceptInvitation"
+	GophKeeper_DeclineInvitation_FullMethodName           = "/gophkeeper.GophKeeper/DeclineInvitation"
+	GophKeeper_UpdateMemberRole_FullMethodName            = "/gophkeeper.GophKeeper/UpdateMemberRole"
+	GophKeeper_RemoveMember_FullMethodName                = "/gophkeeper.GophKeeper/RemoveMember"
+	GophKeeper_CreateCollection_FullMethodName            = "/gophkeeper.GophKeeper/CreateCollection"
+	GophKeeper_ListCollections_FullMethodName             = "/gophkeeper.GophKeeper/ListCollections"
+	GophKeeper_GrantCollectionKey_FullMethodName          = "/gophkeeper.GophKeeper/GrantCollectionKey"
+	GophKeeper_RotateCollectionKey_FullMethodName         = "/gophkeeper.GophKeeper/RotateCollectionKey"
+	GophKeeper_SetCollectionRotationPolicy_FullMethodName = "/gophkeeper.GophKeeper/SetCollectionRotationPolicy"
+	GophKeeper_CreateCollectionEntry_FullMethodName       = "/gophkeeper.GophKeeper/CreateCollectionEntry"
+	GophKeeper_ListCollectionEntries_FullMethodName       = "/gophkeeper.GophKeeper/ListCollectionEntries"
+	GophKeeper_AddAttachment_FullMethodName               = "/gophkeeper.GophKeeper/AddAttachment"
+	GophKeeper_ListAttachments_FullMethodName             = "/gophkeeper.GophKeeper/ListAttachments"
+	GophKeeper_GetAttachment_FullMethodName               = "/gophkeeper.GophKeeper/GetAttachment"
+	GophKeeper_RemoveAttachment_FullMethodName            = "/gophkeeper.GophKeeper/RemoveAttachment"
+	GophKeeper_CreateSecretLink_FullMethodName            = "/gophkeeper.GophKeeper/CreateSecretLink"
+	GophKeeper_AddEmergencyContact_FullMethodName         = "/gophkeeper.GophKeeper/AddEmergencyContact"
+	GophKeeper_ListEmergencyAccess_FullMethodName         = "/gophkeeper.GophKeeper/ListEmergencyAccess"
+	GophKeeper_RemoveEmergencyContact_FullMethodName      = "/gophkeeper.GophKeeper/RemoveEmergencyContact"
+	GophKeeper_RequestEmergencyAccess_FullMethodName      = "/gophkeeper.GophKeeper/RequestEmergencyAccess"
+	GophKeeper_ApproveEmergencyAccess_FullMethodName      = "/gophkeeper.GophKeeper/ApproveEmergencyAccess"
+	GophKeeper_DenyEmergencyAccess_FullMethodName         = "/gophkeeper.GophKeeper/DenyEmergencyAccess"
+	GophKeeper_GetEmergencyVault_FullMethodName           = "/gophkeeper.GophKeeper/GetEmergencyVault"
+	GophKeeper_ListEmergencyAccessEvents_FullMethodName   = "/gophkeeper.GophKeeper/ListEmergencyAccessEvents"
+	GophKeeper_ListNotifications_FullMethodName           = "/gophkeeper.GophKeeper/ListNotifications"
+	GophKeeper_GenerateOTP_FullMethodName                 = "/gophkeeper.GophKeeper/GenerateOTP"
+	GophKeeper_CreateOTPSecret_FullMethodName             = "/gophkeeper.GophKeeper/CreateOTPSecret"
 )
 
 // GophKeeperClient is the client API for GophKeeper service.
@@ -42,8 +88,26 @@ type GophKeeperClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	// Аутентификация пользователя
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// Начало входа по протоколу SRP-6a: сервер возвращает соль и открытый ключ B
+	BeginSRPLogin(ctx context.Context, in *BeginSRPLoginRequest, opts ...grpc.CallOption) (*BeginSRPLoginResponse, error)
+	// Завершение входа по SRP-6a: проверка доказательства клиента
+	FinishSRPLogin(ctx context.Context, in *FinishSRPLoginRequest, opts ...grpc.CallOption) (*FinishSRPLoginResponse, error)
 	// Обновление токена
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// Смена пароля пользователя
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// Сохранение ключа хранилища аккаунта, созданного без него
+	SetVaultKey(ctx context.Context, in *SetVaultKeyRequest, opts ...grpc.CallOption) (*SetVaultKeyResponse, error)
+	// Удаление аккаунта со всеми данными
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	// Экспорт всех данных аккаунта в зашифрованный архив
+	ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*ExportAccountResponse, error)
+	// Создание персонального токена доступа
+	CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*CreateAccessTokenResponse, error)
+	// Получение списка персональных токенов доступа
+	ListAccessTokens(ctx context.Context, in *ListAccessTokensRequest, opts ...grpc.CallOption) (*ListAccessTokensResponse, error)
+	// Отзыв персонального токена доступа
+	RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenRequest, opts ...grpc.CallOption) (*RevokeAccessTokenResponse, error)
 	// Создание записи данных
 	CreateData(ctx context.Context, in *CreateDataRequest, opts ...grpc.CallOption) (*DataEntryResponse, error)
 	// Получение записи данных
@@ -54,8 +118,82 @@ type GophKeeperClient interface {
 	UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*DataEntryResponse, error)
 	// Удаление записи данных
 	DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*DeleteDataResponse, error)
+	// Получение записей, пароли которых пора сменить по политике
+	ListDueForRotation(ctx context.Context, in *ListDueForRotationRequest, opts ...grpc.CallOption) (*ListDataResponse, error)
 	// Синхронизация данных
 	SyncData(ctx context.Context, in *SyncDataRequest, opts ...grpc.CallOption) (*SyncDataResponse, error)
+	// Публикация открытого ключа для получения общих записей
+	SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*SetPublicKeyResponse, error)
+	// Получение открытого ключа пользователя
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	// Предоставление доступа к записи другому пользователю
+	ShareEntry(ctx context.Context, in *ShareEntryRequest, opts ...grpc.CallOption) (*ShareEntryResponse, error)
+	// Получение списка получателей записи
+	ListEntryShares(ctx context.Context, in *ListEntrySharesRequest, opts ...grpc.CallOption) (*ListEntrySharesResponse, error)
+	// Отзыв доступа к записи (владельцем или получателем)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error)
+	// Получение записей, к которым пользователю предоставлен доступ
+	ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListDataResponse, error)
+	// Создание организации (создатель становится владельцем)
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*OrganizationResponse, error)
+	// Получение организаций пользователя
+	ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	// Получение участников организации
+	ListOrganizationMembers(ctx context.Context, in *ListOrganizationMembersRequest, opts ...grpc.CallOption) (*ListOrganizationMembersResponse, error)
+	// Приглашение пользователя в организацию
+	InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InvitationResponse, error)
+	// Получение приглашений текущего пользователя
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
+	// Принятие приглашения в организацию
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*OrganizationResponse, error)
+	// Отклонение или отзыв приглашения
+	DeclineInvitation(ctx context.Context, in *DeclineInvitationRequest, opts ...grpc.CallOption) (*DeclineInvitationResponse, error)
+	// Изменение роли участника организации
+	UpdateMemberRole(ctx context.Context, in *UpdateMemberRoleRequest, opts ...grpc.CallOption) (*UpdateMemberRoleResponse, error)
+	// Исключение участника из организации (требует смены ключей коллекций)
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+	// Создание коллекции организации
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	// Получение коллекций организации
+	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+	// Передача ключа коллекции участнику организации
+	GrantCollectionKey(ctx context.Context, in *GrantCollectionKeyRequest, opts ...grpc.CallOption) (*GrantCollectionKeyResponse, error)
+	// Смена ключа коллекции с перешифрованием записей
+	RotateCollectionKey(ctx context.Context, in *RotateCollectionKeyRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	// Изменение политики смены паролей коллекции
+	SetCollectionRotationPolicy(ctx context.Context, in *SetCollectionRotationPolicyRequest, opts ...grpc.CallOption) (*CollectionResponse, error)
+	// Создание записи в коллекции
+	CreateCollectionEntry(ctx context.Context, in *CreateCollectionEntryRequest, opts ...grpc.CallOption) (*DataEntryResponse, error)
+	// Получение записей коллекции
+	ListCollectionEntries(ctx context.Context, in *ListCollectionEntriesRequest, opts ...grpc.CallOption) (*ListDataResponse, error)
+	// Добавление вложения к записи
+	AddAttachment(ctx context.Context, in *AddAttachmentRequest, opts ...grpc.CallOption) (*AttachmentResponse, error)
+	// Получение списка вложений записи без содержимого
+	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	// Получение вложения записи с зашифрованным содержимым
+	GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*GetAttachmentResponse, error)
+	// Удаление вложения записи
+	RemoveAttachment(ctx context.Context, in *RemoveAttachmentRequest, opts ...grpc.CallOption) (*RemoveAttachmentResponse, error)
+	// Создание одноразовой ссылки на секрет
+	CreateSecretLink(ctx context.Context, in *CreateSecretLinkRequest, opts ...grpc.CallOption) (*CreateSecretLinkResponse, error)
+	// Назначение контакта для экстренного доступа
+	AddEmergencyContact(ctx context.Context, in *AddEmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error)
+	// Получение назначенных контактов и доступов, предоставленных пользователю
+	ListEmergencyAccess(ctx context.Context, in *ListEmergencyAccessRequest, opts ...grpc.CallOption) (*ListEmergencyAccessResponse, error)
+	// Удаление экстренного доступа владельцем или контактом
+	RemoveEmergencyContact(ctx context.Context, in *RemoveEmergencyContactRequest, opts ...grpc.CallOption) (*RemoveEmergencyContactResponse, error)
+	// Запрос экстренного доступа контактом
+	RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error)
+	// Досрочное одобрение запроса владельцем
+	ApproveEmergencyAccess(ctx context.Context, in *ApproveEmergencyAccessRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error)
+	// Отклонение запроса или отзыв предоставленного доступа владельцем
+	DenyEmergencyAccess(ctx context.Context, in *DenyEmergencyAccessRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error)
+	// Получение хранилища владельца после одобрения или истечения ожидания
+	GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error)
+	// Журнал действий с экстренным доступом
+	ListEmergencyAccessEvents(ctx context.Context, in *ListEmergencyAccessEventsRequest, opts ...grpc.CallOption) (*ListEmergencyAccessEventsResponse, error)
+	// Получение уведомлений пользователя
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
 	// Генерация OTP кода
 	GenerateOTP(ctx context.Context, in *GenerateOTPRequest, opts ...grpc.CallOption) (*GenerateOTPResponse, error)
 	// Создание OTP секрета
@@ -90,6 +228,26 @@ func (c *gophKeeperClient) Login(ctx context.Context, in *LoginRequest, opts ...
 	return out, nil
 }
 
+func (c *gophKeeperClient) BeginSRPLogin(ctx context.Context, in *BeginSRPLoginRequest, opts ...grpc.CallOption) (*BeginSRPLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginSRPLoginResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_BeginSRPLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) FinishSRPLogin(ctx context.Context, in *FinishSRPLoginRequest, opts ...grpc.CallOption) (*FinishSRPLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishSRPLoginResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_FinishSRPLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AuthResponse)
@@ -100,6 +258,76 @@ func (c *gophKeeperClient) RefreshToken(ctx context.Context, in *RefreshTokenReq
 	return out, nil
 }
 
+func (c *gophKeeperClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) SetVaultKey(ctx context.Context, in *SetVaultKeyRequest, opts ...grpc.CallOption) (*SetVaultKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetVaultKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_SetVaultKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*ExportAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportAccountResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ExportAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*CreateAccessTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAccessTokenResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListAccessTokens(ctx context.Context, in *ListAccessTokensRequest, opts ...grpc.CallOption) (*ListAccessTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessTokensResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListAccessTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenRequest, opts ...grpc.CallOption) (*RevokeAccessTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAccessTokenResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RevokeAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperClient) CreateData(ctx context.Context, in *CreateDataRequest, opts ...grpc.CallOption) (*DataEntryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DataEntryResponse)
@@ -150,6 +378,16 @@ func (c *gophKeeperClient) DeleteData(ctx context.Context, in *DeleteDataRequest
 	return out, nil
 }
 
+func (c *gophKeeperClient) ListDueForRotation(ctx context.Context, in *ListDueForRotationRequest, opts ...grpc.CallOption) (*ListDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDataResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListDueForRotation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperClient) SyncData(ctx context.Context, in *SyncDataRequest, opts ...grpc.CallOption) (*SyncDataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SyncDataResponse)
@@ -160,276 +398,1694 @@ func (c *gophKeeperClient) SyncData(ctx context.Context, in *SyncDataRequest, op
 	return out, nil
 }
 
-func (c *gophKeeperClient) GenerateOTP(ctx context.Context, in *GenerateOTPRequest, opts ...grpc.CallOption) (*GenerateOTPResponse, error) {
+func (c *gophKeeperClient) SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*SetPublicKeyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GenerateOTPResponse)
-	err := c.cc.Invoke(ctx, GophKeeper_GenerateOTP_FullMethodName, in, out, cOpts...)
+	out := new(SetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_SetPublicKey_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gophKeeperClient) CreateOTPSecret(ctx context.Context, in *CreateOTPSecretRequest, opts ...grpc.CallOption) (*CreateOTPSecretResponse, error) {
+func (c *gophKeeperClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateOTPSecretResponse)
-	err := c.cc.Invoke(ctx, GophKeeper_CreateOTPSecret_FullMethodName, in, out, cOpts...)
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetPublicKey_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// GophKeeperServer is the server API for GophKeeper service.
-// All implementations must embed UnimplementedGophKeeperServer
-// for forward compatibility.
-//
-// Сервис для управления паролями
-type GophKeeperServer interface {
-	// Регистрация пользователя
-	Register(context.Context, *RegisterRequest) (*AuthResponse, error)
-	// Аутентификация пользователя
-	Login(context.Context, *LoginRequest) (*AuthResponse, error)
-	// Обновление токена
-	RefreshToken(context.Context, *RefreshTokenRequest) (*AuthResponse, error)
-	// Создание записи данных
-	CreateData(context.Context, *CreateDataRequest) (*DataEntryResponse, error)
-	// Получение записи данных
-	GetData(context.Context, *GetDataRequest) (*DataEntryResponse, error)
-	// Получение списка записей данных
-	ListData(context.Context, *ListDataRequest) (*ListDataResponse, error)
-	// Обновление записи данных
-	UpdateData(context.Context, *UpdateDataRequest) (*DataEntryResponse, error)
-	// Удаление записи данных
-	DeleteData(context.Context, *DeleteDataRequest) (*DeleteDataResponse, error)
-	// Синхронизация данных
-	SyncData(context.Context, *SyncDataRequest) (*SyncDataResponse, error)
-	// Генерация OTP кода
-	GenerateOTP(context.Context, *GenerateOTPRequest) (*GenerateOTPResponse, error)
-	// Создание OTP секрета
-	CreateOTPSecret(context.Context, *CreateOTPSecretRequest) (*CreateOTPSecretResponse, error)
-	mustEmbedUnimplementedGophKeeperServer()
+func (c *gophKeeperClient) ShareEntry(ctx context.Context, in *ShareEntryRequest, opts ...grpc.CallOption) (*ShareEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareEntryResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ShareEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedGophKeeperServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedGophKeeperServer struct{}
+func (c *gophKeeperClient) ListEntryShares(ctx context.Context, in *ListEntrySharesRequest, opts ...grpc.CallOption) (*ListEntrySharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEntrySharesResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListEntryShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedGophKeeperServer) Register(context.Context, *RegisterRequest) (*AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+func (c *gophKeeperClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RevokeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGophKeeperServer) Login(context.Context, *LoginRequest) (*AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+
+func (c *gophKeeperClient) ListSharedWithMe(ctx context.Context, in *ListSharedWithMeRequest, opts ...grpc.CallOption) (*ListDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDataResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListSharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGophKeeperServer) RefreshToken(context.Context, *RefreshTokenRequest) (*AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+
+func (c *gophKeeperClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*OrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGophKeeperServer) CreateData(context.Context, *CreateDataRequest) (*DataEntryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateData not implemented")
+
+func (c *gophKeeperClient) ListOrganizations(ctx context.Context, in *ListOrganizationsRequest, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListOrganizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGophKeeperServer) GetData(context.Context, *GetDataRequest) (*DataEntryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetData not implemented")
+
+func (c *gophKeeperClient) ListOrganizationMembers(ctx context.Context, in *ListOrganizationMembersRequest, opts ...grpc.CallOption) (*ListOrganizationMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationMembersResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListOrganizationMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedGophKeeperServer) ListData(context.Context, *ListDataRequest) (*ListDataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListData not implemented")
+
+func (c *gophKeeperClient) InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvitationResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_InviteMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitationsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*OrganizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrganizationResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_AcceptInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DeclineInvitation(ctx context.Context, in *DeclineInvitationRequest, opts ...grpc.CallOption) (*DeclineInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeclineInvitationResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_DeclineInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) UpdateMemberRole(ctx context.Context, in *UpdateMemberRoleRequest, opts ...grpc.CallOption) (*UpdateMemberRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMemberRoleResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_UpdateMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GrantCollectionKey(ctx context.Context, in *GrantCollectionKeyRequest, opts ...grpc.CallOption) (*GrantCollectionKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantCollectionKeyResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GrantCollectionKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RotateCollectionKey(ctx context.Context, in *RotateCollectionKeyRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RotateCollectionKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) SetCollectionRotationPolicy(ctx context.Context, in *SetCollectionRotationPolicyRequest, opts ...grpc.CallOption) (*CollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_SetCollectionRotationPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateCollectionEntry(ctx context.Context, in *CreateCollectionEntryRequest, opts ...grpc.CallOption) (*DataEntryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DataEntryResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateCollectionEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListCollectionEntries(ctx context.Context, in *ListCollectionEntriesRequest, opts ...grpc.CallOption) (*ListDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDataResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListCollectionEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) AddAttachment(ctx context.Context, in *AddAttachmentRequest, opts ...grpc.CallOption) (*AttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_AddAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*GetAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttachmentResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RemoveAttachment(ctx context.Context, in *RemoveAttachmentRequest, opts ...grpc.CallOption) (*RemoveAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveAttachmentResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RemoveAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateSecretLink(ctx context.Context, in *CreateSecretLinkRequest, opts ...grpc.CallOption) (*CreateSecretLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSecretLinkResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateSecretLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) AddEmergencyContact(ctx context.Context, in *AddEmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_AddEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListEmergencyAccess(ctx context.Context, in *ListEmergencyAccessRequest, opts ...grpc.CallOption) (*ListEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RemoveEmergencyContact(ctx context.Context, in *RemoveEmergencyContactRequest, opts ...grpc.CallOption) (*RemoveEmergencyContactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveEmergencyContactResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RemoveEmergencyContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_RequestEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ApproveEmergencyAccess(ctx context.Context, in *ApproveEmergencyAccessRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ApproveEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) DenyEmergencyAccess(ctx context.Context, in *DenyEmergencyAccessRequest, opts ...grpc.CallOption) (*EmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_DenyEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmergencyVaultResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GetEmergencyVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListEmergencyAccessEvents(ctx context.Context, in *ListEmergencyAccessEventsRequest, opts ...grpc.CallOption) (*ListEmergencyAccessEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyAccessEventsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListEmergencyAccessEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) GenerateOTP(ctx context.Context, in *GenerateOTPRequest, opts ...grpc.CallOption) (*GenerateOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateOTPResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_GenerateOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperClient) CreateOTPSecret(ctx context.Context, in *CreateOTPSecretRequest, opts ...grpc.CallOption) (*CreateOTPSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOTPSecretResponse)
+	err := c.cc.Invoke(ctx, GophKeeper_CreateOTPSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GophKeeperServer is the server API for GophKeeper service.
+// All implementations must embed UnimplementedGophKeeperServer
+// for forward compatibility.
+//
+// Сервис для управления паролями
+type GophKeeperServer interface {
+	// Регистрация пользователя
+	Register(context.Context, *RegisterRequest) (*AuthResponse, error)
+	// Аутентификация пользователя
+	Login(context.Context, *LoginRequest) (*AuthResponse, error)
+	// Начало входа по протоколу SRP-6a: сервер возвращает соль и открытый ключ B
+	BeginSRPLogin(context.Context, *BeginSRPLoginRequest) (*BeginSRPLoginResponse, error)
+	// Завершение входа по SRP-6a: проверка доказательства клиента
+	FinishSRPLogin(context.Context, *FinishSRPLoginRequest) (*FinishSRPLoginResponse, error)
+	// Обновление токена
+	RefreshToken(context.Context, *RefreshTokenRequest) (*AuthResponse, error)
+	// Смена пароля пользователя
+	ChangePassword(context.Context, *ChangePasswordRequest) (*AuthResponse, error)
+	// Сохранение ключа хранилища аккаунта, созданного без него
+	SetVaultKey(context.Context, *SetVaultKeyRequest) (*SetVaultKeyResponse, error)
+	// Удаление аккаунта со всеми данными
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	// Экспорт всех данных аккаунта в зашифрованный архив
+	ExportAccount(context.Context, *ExportAccountRequest) (*ExportAccountResponse, error)
+	// Создание персонального токена доступа
+	CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*CreateAccessTokenResponse, error)
+	// Получение списка персональных токенов доступа
+	ListAccessTokens(context.Context, *ListAccessTokensRequest) (*ListAccessTokensResponse, error)
+	// Отзыв персонального токена доступа
+	RevokeAccessToken(context.Context, *RevokeAccessTokenRequest) (*RevokeAccessTokenResponse, error)
+	// Создание записи данных
+	CreateData(context.Context, *CreateDataRequest) (*DataEntryResponse, error)
+	// Получение записи данных
+	GetData(context.Context, *GetDataRequest) (*DataEntryResponse, error)
+	// Получение списка записей данных
+	ListData(context.Context, *ListDataRequest) (*ListDataResponse, error)
+	// Обновление записи данных
+	UpdateData(context.Context, *UpdateDataRequest) (*DataEntryResponse, error)
+	// Удаление записи данных
+	DeleteData(context.Context, *DeleteDataRequest) (*DeleteDataResponse, error)
+	// Получение записей, пароли которых пора сменить по политике
+	ListDueForRotation(context.Context, *ListDueForRotationRequest) (*ListDataResponse, error)
+	// Синхронизация данных
+	SyncData(context.Context, *SyncDataRequest) (*SyncDataResponse, error)
+	// Публикация открытого ключа для получения общих записей
+	SetPublicKey(context.Context, *SetPublicKeyRequest) (*SetPublicKeyResponse, error)
+	// Получение открытого ключа пользователя
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	// Предоставление доступа к записи другому пользователю
+	ShareEntry(context.Context, *ShareEntryRequest) (*ShareEntryResponse, error)
+	// Получение списка получателей записи
+	ListEntryShares(context.Context, *ListEntrySharesRequest) (*ListEntrySharesResponse, error)
+	// Отзыв доступа к записи (владельцем или получателем)
+	RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error)
+	// Получение записей, к которым пользователю предоставлен доступ
+	ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListDataResponse, error)
+	// Создание организации (создатель становится владельцем)
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*OrganizationResponse, error)
+	// Получение организаций пользователя
+	ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error)
+	// Получение участников организации
+	ListOrganizationMembers(context.Context, *ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error)
+	// Приглашение пользователя в организацию
+	InviteMember(context.Context, *InviteMemberRequest) (*InvitationResponse, error)
+	// Получение приглашений текущего пользователя
+	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
+	// Принятие приглашения в организацию
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*OrganizationResponse, error)
+	// Отклонение или отзыв приглашения
+	DeclineInvitation(context.Context, *DeclineInvitationRequest) (*DeclineInvitationResponse, error)
+	// Изменение роли участника организации
+	UpdateMemberRole(context.Context, *UpdateMemberRoleRequest) (*UpdateMemberRoleResponse, error)
+	// Исключение участника из организации (требует смены ключей коллекций)
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	// Создание коллекции организации
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CollectionResponse, error)
+	// Получение коллекций организации
+	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error)
+	// Передача ключа коллекции участнику организации
+	GrantCollectionKey(context.Context, *GrantCollectionKeyRequest) (*GrantCollectionKeyResponse, error)
+	// Смена ключа коллекции с перешифрованием записей
+	RotateCollectionKey(context.Context, *RotateCollectionKeyRequest) (*CollectionResponse, error)
+	// Изменение политики смены паролей коллекции
+	SetCollectionRotationPolicy(context.Context, *SetCollectionRotationPolicyRequest) (*CollectionResponse, error)
+	// Создание записи в коллекции
+	CreateCollectionEntry(context.Context, *CreateCollectionEntryRequest) (*DataEntryResponse, error)
+	// Получение записей коллекции
+	ListCollectionEntries(context.Context, *ListCollectionEntriesRequest) (*ListDataResponse, error)
+	// Добавление вложения к записи
+	AddAttachment(context.Context, *AddAttachmentRequest) (*AttachmentResponse, error)
+	// Получение списка вложений записи без содержимого
+	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
+	// Получение вложения записи с зашифрованным содержимым
+	GetAttachment(context.Context, *GetAttachmentRequest) (*GetAttachmentResponse, error)
+	// Удаление вложения записи
+	RemoveAttachment(context.Context, *RemoveAttachmentRequest) (*RemoveAttachmentResponse, error)
+	// Создание одноразовой ссылки на секрет
+	CreateSecretLink(context.Context, *CreateSecretLinkRequest) (*CreateSecretLinkResponse, error)
+	// Назначение контакта для экстренного доступа
+	AddEmergencyContact(context.Context, *AddEmergencyContactRequest) (*EmergencyAccessResponse, error)
+	// Получение назначенных контактов и доступов, предоставленных пользователю
+	ListEmergencyAccess(context.Context, *ListEmergencyAccessRequest) (*ListEmergencyAccessResponse, error)
+	// Удаление экстренного доступа владельцем или контактом
+	RemoveEmergencyContact(context.Context, *RemoveEmergencyContactRequest) (*RemoveEmergencyContactResponse, error)
+	// Запрос экстренного доступа контактом
+	RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*EmergencyAccessResponse, error)
+	// Досрочное одобрение запроса владельцем
+	ApproveEmergencyAccess(context.Context, *ApproveEmergencyAccessRequest) (*EmergencyAccessResponse, error)
+	// Отклонение запроса или отзыв предоставленного доступа владельцем
+	DenyEmergencyAccess(context.Context, *DenyEmergencyAccessRequest) (*EmergencyAccessResponse, error)
+	// Получение хранилища владельца после одобрения или истечения ожидания
+	GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error)
+	// Журнал действий с экстренным доступом
+	ListEmergencyAccessEvents(context.Context, *ListEmergencyAccessEventsRequest) (*ListEmergencyAccessEventsResponse, error)
+	// Получение уведомлений пользователя
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	// Генерация OTP кода
+	GenerateOTP(context.Context, *GenerateOTPRequest) (*GenerateOTPResponse, error)
+	// Создание OTP секрета
+	CreateOTPSecret(context.Context, *CreateOTPSecretRequest) (*CreateOTPSecretResponse, error)
+	mustEmbedUnimplementedGophKeeperServer()
+}
+
+// UnimplementedGophKeeperServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGophKeeperServer struct{}
+
+func (UnimplementedGophKeeperServer) Register(context.Context, *RegisterRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedGophKeeperServer) Login(context.Context, *LoginRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedGophKeeperServer) BeginSRPLogin(context.Context, *BeginSRPLoginRequest) (*BeginSRPLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginSRPLogin not implemented")
+}
+func (UnimplementedGophKeeperServer) FinishSRPLogin(context.Context, *FinishSRPLoginRequest) (*FinishSRPLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishSRPLogin not implemented")
+}
+func (UnimplementedGophKeeperServer) RefreshToken(context.Context, *RefreshTokenRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedGophKeeperServer) ChangePassword(context.Context, *ChangePasswordRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedGophKeeperServer) SetVaultKey(context.Context, *SetVaultKeyRequest) (*SetVaultKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultKey not implemented")
+}
+func (UnimplementedGophKeeperServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedGophKeeperServer) ExportAccount(context.Context, *ExportAccountRequest) (*ExportAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAccount not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*CreateAccessTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessToken not implemented")
+}
+func (UnimplementedGophKeeperServer) ListAccessTokens(context.Context, *ListAccessTokensRequest) (*ListAccessTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessTokens not implemented")
+}
+func (UnimplementedGophKeeperServer) RevokeAccessToken(context.Context, *RevokeAccessTokenRequest) (*RevokeAccessTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccessToken not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateData(context.Context, *CreateDataRequest) (*DataEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateData not implemented")
+}
+func (UnimplementedGophKeeperServer) GetData(context.Context, *GetDataRequest) (*DataEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetData not implemented")
+}
+func (UnimplementedGophKeeperServer) ListData(context.Context, *ListDataRequest) (*ListDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListData not implemented")
+}
+func (UnimplementedGophKeeperServer) UpdateData(context.Context, *UpdateDataRequest) (*DataEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
+}
+func (UnimplementedGophKeeperServer) DeleteData(context.Context, *DeleteDataRequest) (*DeleteDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteData not implemented")
+}
+func (UnimplementedGophKeeperServer) ListDueForRotation(context.Context, *ListDueForRotationRequest) (*ListDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDueForRotation not implemented")
+}
+func (UnimplementedGophKeeperServer) SyncData(context.Context, *SyncDataRequest) (*SyncDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncData not implemented")
+}
+func (UnimplementedGophKeeperServer) SetPublicKey(context.Context, *SetPublicKeyRequest) (*SetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPublicKey not implemented")
+}
+func (UnimplementedGophKeeperServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedGophKeeperServer) ShareEntry(context.Context, *ShareEntryRequest) (*ShareEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareEntry not implemented")
+}
+func (UnimplementedGophKeeperServer) ListEntryShares(context.Context, *ListEntrySharesRequest) (*ListEntrySharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntryShares not implemented")
+}
+func (UnimplementedGophKeeperServer) RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedGophKeeperServer) ListSharedWithMe(context.Context, *ListSharedWithMeRequest) (*ListDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*OrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedGophKeeperServer) ListOrganizations(context.Context, *ListOrganizationsRequest) (*ListOrganizationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizations not implemented")
+}
+func (UnimplementedGophKeeperServer) ListOrganizationMembers(context.Context, *ListOrganizationMembersRequest) (*ListOrganizationMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizationMembers not implemented")
+}
+func (UnimplementedGophKeeperServer) InviteMember(context.Context, *InviteMemberRequest) (*InvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteMember not implemented")
+}
+func (UnimplementedGophKeeperServer) ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedGophKeeperServer) AcceptInvitation(context.Context, *AcceptInvitationRequest) (*OrganizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (UnimplementedGophKeeperServer) DeclineInvitation(context.Context, *DeclineInvitationRequest) (*DeclineInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineInvitation not implemented")
+}
+func (UnimplementedGophKeeperServer) UpdateMemberRole(context.Context, *UpdateMemberRoleRequest) (*UpdateMemberRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMemberRole not implemented")
+}
+func (UnimplementedGophKeeperServer) RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedGophKeeperServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedGophKeeperServer) GrantCollectionKey(context.Context, *GrantCollectionKeyRequest) (*GrantCollectionKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantCollectionKey not implemented")
+}
+func (UnimplementedGophKeeperServer) RotateCollectionKey(context.Context, *RotateCollectionKeyRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateCollectionKey not implemented")
+}
+func (UnimplementedGophKeeperServer) SetCollectionRotationPolicy(context.Context, *SetCollectionRotationPolicyRequest) (*CollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCollectionRotationPolicy not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateCollectionEntry(context.Context, *CreateCollectionEntryRequest) (*DataEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollectionEntry not implemented")
+}
+func (UnimplementedGophKeeperServer) ListCollectionEntries(context.Context, *ListCollectionEntriesRequest) (*ListDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollectionEntries not implemented")
+}
+func (UnimplementedGophKeeperServer) AddAttachment(context.Context, *AddAttachmentRequest) (*AttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAttachment not implemented")
+}
+func (UnimplementedGophKeeperServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedGophKeeperServer) GetAttachment(context.Context, *GetAttachmentRequest) (*GetAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedGophKeeperServer) RemoveAttachment(context.Context, *RemoveAttachmentRequest) (*RemoveAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAttachment not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateSecretLink(context.Context, *CreateSecretLinkRequest) (*CreateSecretLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecretLink not implemented")
+}
+func (UnimplementedGophKeeperServer) AddEmergencyContact(context.Context, *AddEmergencyContactRequest) (*EmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEmergencyContact not implemented")
+}
+func (UnimplementedGophKeeperServer) ListEmergencyAccess(context.Context, *ListEmergencyAccessRequest) (*ListEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyAccess not implemented")
+}
+func (UnimplementedGophKeeperServer) RemoveEmergencyContact(context.Context, *RemoveEmergencyContactRequest) (*RemoveEmergencyContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveEmergencyContact not implemented")
+}
+func (UnimplementedGophKeeperServer) RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*EmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergencyAccess not implemented")
+}
+func (UnimplementedGophKeeperServer) ApproveEmergencyAccess(context.Context, *ApproveEmergencyAccessRequest) (*EmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveEmergencyAccess not implemented")
+}
+func (UnimplementedGophKeeperServer) DenyEmergencyAccess(context.Context, *DenyEmergencyAccessRequest) (*EmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyEmergencyAccess not implemented")
+}
+func (UnimplementedGophKeeperServer) GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyVault not implemented")
+}
+func (UnimplementedGophKeeperServer) ListEmergencyAccessEvents(context.Context, *ListEmergencyAccessEventsRequest) (*ListEmergencyAccessEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyAccessEvents not implemented")
+}
+func (UnimplementedGophKeeperServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedGophKeeperServer) GenerateOTP(context.Context, *GenerateOTPRequest) (*GenerateOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateOTP not implemented")
+}
+func (UnimplementedGophKeeperServer) CreateOTPSecret(context.Context, *CreateOTPSecretRequest) (*CreateOTPSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOTPSecret not implemented")
+}
+func (UnimplementedGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {}
+func (UnimplementedGophKeeperServer) testEmbeddedByValue()                    {}
+
+// UnsafeGophKeeperServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GophKeeperServer will
+// result in compilation errors.
+type UnsafeGophKeeperServer interface {
+	mustEmbedUnimplementedGophKeeperServer()
+}
+
+func RegisterGophKeeperServer(s grpc.ServiceRegistrar, srv GophKeeperServer) {
+	// If the following call pancis, it indicates UnimplementedGophKeeperServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GophKeeper_ServiceDesc, srv)
+}
+
+func _GophKeeper_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_BeginSRPLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginSRPLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).BeginSRPLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_BeginSRPLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).BeginSRPLogin(ctx, req.(*BeginSRPLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_FinishSRPLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishSRPLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).FinishSRPLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_FinishSRPLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).FinishSRPLogin(ctx, req.(*FinishSRPLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_SetVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVaultKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SetVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SetVaultKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SetVaultKey(ctx, req.(*SetVaultKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ExportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ExportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ExportAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ExportAccount(ctx, req.(*ExportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateAccessToken(ctx, req.(*CreateAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListAccessTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListAccessTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListAccessTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListAccessTokens(ctx, req.(*ListAccessTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RevokeAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RevokeAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RevokeAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RevokeAccessToken(ctx, req.(*RevokeAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateData(ctx, req.(*CreateDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetData(ctx, req.(*GetDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListData(ctx, req.(*ListDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).UpdateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_UpdateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).UpdateData(ctx, req.(*UpdateDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DeleteData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeleteData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeleteData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeleteData(ctx, req.(*DeleteDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListDueForRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDueForRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListDueForRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListDueForRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListDueForRotation(ctx, req.(*ListDueForRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_SyncData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SyncData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SyncData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SyncData(ctx, req.(*SyncDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_SetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SetPublicKey(ctx, req.(*SetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ShareEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ShareEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ShareEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ShareEntry(ctx, req.(*ShareEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListEntryShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntrySharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListEntryShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListEntryShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListEntryShares(ctx, req.(*ListEntrySharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RevokeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListSharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListSharedWithMe(ctx, req.(*ListSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListOrganizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListOrganizations(ctx, req.(*ListOrganizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListOrganizationMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrganizationMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListOrganizationMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListOrganizationMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListOrganizationMembers(ctx, req.(*ListOrganizationMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_InviteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).InviteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_InviteMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).InviteMember(ctx, req.(*InviteMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListInvitations(ctx, req.(*ListInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_AcceptInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_DeclineInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclineInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).DeclineInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_DeclineInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).DeclineInvitation(ctx, req.(*DeclineInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_UpdateMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).UpdateMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_UpdateMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).UpdateMemberRole(ctx, req.(*UpdateMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListCollections(ctx, req.(*ListCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_GrantCollectionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantCollectionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GrantCollectionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GrantCollectionKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GrantCollectionKey(ctx, req.(*GrantCollectionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_RotateCollectionKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateCollectionKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RotateCollectionKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RotateCollectionKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RotateCollectionKey(ctx, req.(*RotateCollectionKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGophKeeperServer) UpdateData(context.Context, *UpdateDataRequest) (*DataEntryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
+
+func _GophKeeper_SetCollectionRotationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCollectionRotationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).SetCollectionRotationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_SetCollectionRotationPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).SetCollectionRotationPolicy(ctx, req.(*SetCollectionRotationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGophKeeperServer) DeleteData(context.Context, *DeleteDataRequest) (*DeleteDataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteData not implemented")
+
+func _GophKeeper_CreateCollectionEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).CreateCollectionEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_CreateCollectionEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).CreateCollectionEntry(ctx, req.(*CreateCollectionEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGophKeeperServer) SyncData(context.Context, *SyncDataRequest) (*SyncDataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SyncData not implemented")
+
+func _GophKeeper_ListCollectionEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollectionEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListCollectionEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListCollectionEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListCollectionEntries(ctx, req.(*ListCollectionEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGophKeeperServer) GenerateOTP(context.Context, *GenerateOTPRequest) (*GenerateOTPResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GenerateOTP not implemented")
+
+func _GophKeeper_AddAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).AddAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_AddAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).AddAttachment(ctx, req.(*AddAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGophKeeperServer) CreateOTPSecret(context.Context, *CreateOTPSecretRequest) (*CreateOTPSecretResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateOTPSecret not implemented")
+
+func _GophKeeper_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListAttachments(ctx, req.(*ListAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedGophKeeperServer) mustEmbedUnimplementedGophKeeperServer() {}
-func (UnimplementedGophKeeperServer) testEmbeddedByValue()                    {}
 
-// UnsafeGophKeeperServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to GophKeeperServer will
-// result in compilation errors.
-type UnsafeGophKeeperServer interface {
-	mustEmbedUnimplementedGophKeeperServer()
+func _GophKeeper_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).GetAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_GetAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).GetAttachment(ctx, req.(*GetAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterGophKeeperServer(s grpc.ServiceRegistrar, srv GophKeeperServer) {
-	// If the following call pancis, it indicates UnimplementedGophKeeperServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _GophKeeper_RemoveAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&GophKeeper_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(GophKeeperServer).RemoveAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_RemoveAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).RemoveAttachment(ctx, req.(*RemoveAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterRequest)
+func _GophKeeper_CreateSecretLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretLinkRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).Register(ctx, in)
+		return srv.(GophKeeperServer).CreateSecretLink(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_Register_FullMethodName,
+		FullMethod: GophKeeper_CreateSecretLink_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).Register(ctx, req.(*RegisterRequest))
+		return srv.(GophKeeperServer).CreateSecretLink(ctx, req.(*CreateSecretLinkRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _GophKeeper_AddEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEmergencyContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).Login(ctx, in)
+		return srv.(GophKeeperServer).AddEmergencyContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_Login_FullMethodName,
+		FullMethod: GophKeeper_AddEmergencyContact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).Login(ctx, req.(*LoginRequest))
+		return srv.(GophKeeperServer).AddEmergencyContact(ctx, req.(*AddEmergencyContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RefreshTokenRequest)
+func _GophKeeper_ListEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyAccessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).RefreshToken(ctx, in)
+		return srv.(GophKeeperServer).ListEmergencyAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_RefreshToken_FullMethodName,
+		FullMethod: GophKeeper_ListEmergencyAccess_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+		return srv.(GophKeeperServer).ListEmergencyAccess(ctx, req.(*ListEmergencyAccessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_CreateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDataRequest)
+func _GophKeeper_RemoveEmergencyContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveEmergencyContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).CreateData(ctx, in)
+		return srv.(GophKeeperServer).RemoveEmergencyContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_CreateData_FullMethodName,
+		FullMethod: GophKeeper_RemoveEmergencyContact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).CreateData(ctx, req.(*CreateDataRequest))
+		return srv.(GophKeeperServer).RemoveEmergencyContact(ctx, req.(*RemoveEmergencyContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_GetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDataRequest)
+func _GophKeeper_RequestEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmergencyAccessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).GetData(ctx, in)
+		return srv.(GophKeeperServer).RequestEmergencyAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_GetData_FullMethodName,
+		FullMethod: GophKeeper_RequestEmergencyAccess_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).GetData(ctx, req.(*GetDataRequest))
+		return srv.(GophKeeperServer).RequestEmergencyAccess(ctx, req.(*RequestEmergencyAccessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_ListData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDataRequest)
+func _GophKeeper_ApproveEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveEmergencyAccessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).ListData(ctx, in)
+		return srv.(GophKeeperServer).ApproveEmergencyAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_ListData_FullMethodName,
+		FullMethod: GophKeeper_ApproveEmergencyAccess_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).ListData(ctx, req.(*ListDataRequest))
+		return srv.(GophKeeperServer).ApproveEmergencyAccess(ctx, req.(*ApproveEmergencyAccessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDataRequest)
+func _GophKeeper_DenyEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyEmergencyAccessRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).UpdateData(ctx, in)
+		return srv.(GophKeeperServer).DenyEmergencyAccess(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_UpdateData_FullMethodName,
+		FullMethod: GophKeeper_DenyEmergencyAccess_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).UpdateData(ctx, req.(*UpdateDataRequest))
+		return srv.(GophKeeperServer).DenyEmergencyAccess(ctx, req.(*DenyEmergencyAccessRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_DeleteData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDataRequest)
+func _GophKeeper_GetEmergencyVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyVaultRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).DeleteData(ctx, in)
+		return srv.(GophKeeperServer).GetEmergencyVault(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_DeleteData_FullMethodName,
+		FullMethod: GophKeeper_GetEmergencyVault_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).DeleteData(ctx, req.(*DeleteDataRequest))
+		return srv.(GophKeeperServer).GetEmergencyVault(ctx, req.(*GetEmergencyVaultRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeper_SyncData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SyncDataRequest)
+func _GophKeeper_ListEmergencyAccessEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEmergencyAccessEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GophKeeperServer).SyncData(ctx, in)
+		return srv.(GophKeeperServer).ListEmergencyAccessEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GophKeeper_SyncData_FullMethodName,
+		FullMethod: GophKeeper_ListEmergencyAccessEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServer).SyncData(ctx, req.(*SyncDataRequest))
+		return srv.(GophKeeperServer).ListEmergencyAccessEvents(ctx, req.(*ListEmergencyAccessEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeper_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeper_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -485,10 +2141,46 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _GophKeeper_Login_Handler,
 		},
+		{
+			MethodName: "BeginSRPLogin",
+			Handler:    _GophKeeper_BeginSRPLogin_Handler,
+		},
+		{
+			MethodName: "FinishSRPLogin",
+			Handler:    _GophKeeper_FinishSRPLogin_Handler,
+		},
 		{
 			MethodName: "RefreshToken",
 			Handler:    _GophKeeper_RefreshToken_Handler,
 		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _GophKeeper_ChangePassword_Handler,
+		},
+		{
+			MethodName: "SetVaultKey",
+			Handler:    _GophKeeper_SetVaultKey_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _GophKeeper_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "ExportAccount",
+			Handler:    _GophKeeper_ExportAccount_Handler,
+		},
+		{
+			MethodName: "CreateAccessToken",
+			Handler:    _GophKeeper_CreateAccessToken_Handler,
+		},
+		{
+			MethodName: "ListAccessTokens",
+			Handler:    _GophKeeper_ListAccessTokens_Handler,
+		},
+		{
+			MethodName: "RevokeAccessToken",
+			Handler:    _GophKeeper_RevokeAccessToken_Handler,
+		},
 		{
 			MethodName: "CreateData",
 			Handler:    _GophKeeper_CreateData_Handler,
@@ -509,10 +2201,158 @@ var GophKeeper_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteData",
 			Handler:    _GophKeeper_DeleteData_Handler,
 		},
+		{
+			MethodName: "ListDueForRotation",
+			Handler:    _GophKeeper_ListDueForRotation_Handler,
+		},
 		{
 			MethodName: "SyncData",
 			Handler:    _GophKeeper_SyncData_Handler,
 		},
+		{
+			MethodName: "SetPublicKey",
+			Handler:    _GophKeeper_SetPublicKey_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _GophKeeper_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "ShareEntry",
+			Handler:    _GophKeeper_ShareEntry_Handler,
+		},
+		{
+			MethodName: "ListEntryShares",
+			Handler:    _GophKeeper_ListEntryShares_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _GophKeeper_RevokeShare_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _GophKeeper_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _GophKeeper_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "ListOrganizations",
+			Handler:    _GophKeeper_ListOrganizations_Handler,
+		},
+		{
+			MethodName: "ListOrganizationMembers",
+			Handler:    _GophKeeper_ListOrganizationMembers_Handler,
+		},
+		{
+			MethodName: "InviteMember",
+			Handler:    _GophKeeper_InviteMember_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _GophKeeper_ListInvitations_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _GophKeeper_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "DeclineInvitation",
+			Handler:    _GophKeeper_DeclineInvitation_Handler,
+		},
+		{
+			MethodName: "UpdateMemberRole",
+			Handler:    _GophKeeper_UpdateMemberRole_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _GophKeeper_RemoveMember_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _GophKeeper_CreateCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _GophKeeper_ListCollections_Handler,
+		},
+		{
+			MethodName: "GrantCollectionKey",
+			Handler:    _GophKeeper_GrantCollectionKey_Handler,
+		},
+		{
+			MethodName: "RotateCollectionKey",
+			Handler:    _GophKeeper_RotateCollectionKey_Handler,
+		},
+		{
+			MethodName: "SetCollectionRotationPolicy",
+			Handler:    _GophKeeper_SetCollectionRotationPolicy_Handler,
+		},
+		{
+			MethodName: "CreateCollectionEntry",
+			Handler:    _GophKeeper_CreateCollectionEntry_Handler,
+		},
+		{
+			MethodName: "ListCollectionEntries",
+			Handler:    _GophKeeper_ListCollectionEntries_Handler,
+		},
+		{
+			MethodName: "AddAttachment",
+			Handler:    _GophKeeper_AddAttachment_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _GophKeeper_ListAttachments_Handler,
+		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _GophKeeper_GetAttachment_Handler,
+		},
+		{
+			MethodName: "RemoveAttachment",
+			Handler:    _GophKeeper_RemoveAttachment_Handler,
+		},
+		{
+			MethodName: "CreateSecretLink",
+			Handler:    _GophKeeper_CreateSecretLink_Handler,
+		},
+		{
+			MethodName: "AddEmergencyContact",
+			Handler:    _GophKeeper_AddEmergencyContact_Handler,
+		},
+		{
+			MethodName: "ListEmergencyAccess",
+			Handler:    _GophKeeper_ListEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RemoveEmergencyContact",
+			Handler:    _GophKeeper_RemoveEmergencyContact_Handler,
+		},
+		{
+			MethodName: "RequestEmergencyAccess",
+			Handler:    _GophKeeper_RequestEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "ApproveEmergencyAccess",
+			Handler:    _GophKeeper_ApproveEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "DenyEmergencyAccess",
+			Handler:    _GophKeeper_DenyEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyVault",
+			Handler:    _GophKeeper_GetEmergencyVault_Handler,
+		},
+		{
+			MethodName: "ListEmergencyAccessEvents",
+			Handler:    _GophKeeper_ListEmergencyAccessEvents_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _GophKeeper_ListNotifications_Handler,
+		},
 		{
 			MethodName: "GenerateOTP",
 			Handler:    _GophKeeper_GenerateOTP_Handler,